@@ -2,10 +2,14 @@ package cli
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"reflect"
+	"sort"
 	"strings"
 
 	blaxel "github.com/blaxel-ai/sdk-go"
@@ -27,6 +31,8 @@ type ResourceOperationResult struct {
 	ErrorMsg       string
 	CallbackSecret string
 	MetadataURL    string
+	// RateLimited is set when the platform rejected the operation with 429 Too Many Requests
+	RateLimited bool
 }
 
 type ApplyResult struct {
@@ -40,7 +46,11 @@ type ApplyOption func(*applyOptions)
 
 // applyOptions holds all possible options for Apply
 type applyOptions struct {
-	recursive bool
+	recursive       bool
+	continueOnError bool
+	failFast        bool
+	force           bool
+	dryRun          bool
 }
 
 // WithRecursive sets the recursive option
@@ -50,11 +60,47 @@ func WithRecursive(recursive bool) ApplyOption {
 	}
 }
 
+// WithContinueOnError applies every resource even after a failure, reporting
+// files that cannot be parsed and unknown kinds as failed resources
+func WithContinueOnError(continueOnError bool) ApplyOption {
+	return func(o *applyOptions) {
+		o.continueOnError = continueOnError
+	}
+}
+
+// WithFailFast stops applying at the first resource that fails
+func WithFailFast(failFast bool) ApplyOption {
+	return func(o *applyOptions) {
+		o.failFast = failFast
+	}
+}
+
+// WithForce updates resources even when they changed since the version
+// recorded in the manifest's metadata.updatedAt
+func WithForce(force bool) ApplyOption {
+	return func(o *applyOptions) {
+		o.force = force
+	}
+}
+
+// WithDryRun reports the resources that would be applied without changing them
+func WithDryRun(dryRun bool) ApplyOption {
+	return func(o *applyOptions) {
+		o.dryRun = dryRun
+	}
+}
+
 func ApplyCmd() *cobra.Command {
 	var filePath string
 	var recursive bool
 	var envFiles []string
 	var commandSecrets []string
+	var continueOnError bool
+	var failFast bool
+	var force bool
+	var prune bool
+	var dryRun bool
+	var selectors []string
 	cmd := &cobra.Command{
 		Use:   "apply",
 		Short: "Apply a configuration to a resource by file",
@@ -77,7 +123,43 @@ For deploying code changes to agents/jobs, use 'bl deploy'.
 For managing resource configuration, use 'bl apply'.
 
 The command respects environment variables and secrets, which can be injected
-via -e flag for .env files or -s flag for command-line secrets.`,
+via -e flag for .env files or -s flag for command-line secrets.
+
+By default apply applies every resource even when some fail. With
+--continue-on-error it also reports files that cannot be parsed and unknown
+kinds as failures, and prints a summary of all failures at the end. With
+--fail-fast it stops at the first resource that fails instead. In all cases
+the command exits with a non-zero code if any resource failed.
+
+Pass -f - to read the manifests from stdin, for example from a script or the
+file written by 'bl deploy --output-manifest'. Documents are separated by ---
+like in files and --recursive is ignored. Parsing errors give the number of the
+bad document. Secrets referenced with $secrets cannot be prompted for, set them
+with -s or -e.
+
+A manifest saved with 'bl get <type> <name> -o yaml' keeps metadata.updatedAt.
+Before updating such a resource, apply checks that it was not changed since
+that time, so it does not overwrite the changes of someone else. If it was,
+the resource fails with a conflict listing the fields where the deployed
+resource differs from the manifest. Fetch it again to merge the changes, or
+pass --force to overwrite them. Manifests without metadata.updatedAt are
+applied without this check.
+
+With a label selector (-l), apply then lists the resources of the workspace
+carrying these labels that are not in the manifests, and --prune deletes them,
+like 'kubectl apply --prune'. With --prune the selector must hold at least one
+key=value or key term, so only the resources managed by the manifests can be
+deleted: a selector made only of key!=value terms is rejected. Nothing is
+pruned when a resource failed to apply. Use --dryrun to see what would be
+applied and pruned without changing anything.
+
+Resources are applied in the order of the files and documents, unless their
+metadata says otherwise: a resource is applied after those listed in
+metadata.dependsOn (Kind/name or name, as a list or comma separated) and after
+those with a lower metadata.order (0 when not set). Dependencies that are not
+in the manifests are expected to exist already. A dependency cycle is an
+error. 'bl deploy' follows the same rules for the .blaxel directory, where it
+also waits for a dependency to be deployed before applying its dependents.`,
 		Example: `  # Apply a single resource
   bl apply -f agent.yaml
 
@@ -87,12 +169,33 @@ via -e flag for .env files or -s flag for command-line secrets.`,
   # Apply with environment variable substitution
   bl apply -f deployment.yaml -e .env.production
 
+  # Apply everything and report all failures at the end
+  bl apply -f ./resources/ -R --continue-on-error
+
   # Apply from stdin (useful for CI/CD)
   cat config.yaml | bl apply -f -
 
+  # Apply manifests generated by a script
+  ./generate-resources.sh | bl apply -f - -s API_KEY=xxx
+
   # Apply with secrets
   bl apply -f config.yaml -s API_KEY=xxx -s DB_PASSWORD=yyy
 
+  # Edit a deployed agent, failing if someone changed it meanwhile
+  bl get agent my-agent -o yaml > agent.yaml
+  bl apply -f agent.yaml
+
+  # Overwrite the changes made since the manifest was saved
+  bl apply -f agent.yaml --force
+
+  # Apply an agent once the model it uses is applied
+  # (metadata.dependsOn: [Model/my-model] in agent.yaml)
+  bl apply -f ./resources/ -R
+
+  # Make a directory the source of truth of the resources labeled managed-by=me
+  bl apply -R -f manifests/ -l managed-by=me --prune --dryrun
+  bl apply -R -f manifests/ -l managed-by=me --prune
+
   # Example YAML structure for an agent:
   # apiVersion: blaxel.ai/v1alpha1
   # kind: Agent
@@ -149,24 +252,46 @@ via -e flag for .env files or -s flag for command-line secrets.`,
 		Run: func(cmd *cobra.Command, args []string) {
 			core.LoadCommandSecrets(commandSecrets)
 			core.ReadSecrets("", envFiles)
-			applyResults, err := Apply(filePath, WithRecursive(recursive))
+			if filePath == "-" && recursive {
+				core.StrictWarning("Apply", "--recursive is ignored when reading from stdin")
+			}
+			selector, err := core.ParseLabelSelector(selectors)
+			if err == nil && failFast && continueOnError {
+				err = &core.ConfigError{Err: fmt.Errorf("--fail-fast and --continue-on-error cannot be used together")}
+			}
+			if err == nil && prune {
+				err = validatePruneSelector(selector)
+			}
+			if err != nil {
+				core.PrintError("Apply", err)
+				core.ExitWithError(err)
+			}
+			applyResults, err := Apply(filePath, WithRecursive(recursive), WithContinueOnError(continueOnError), WithFailFast(failFast), WithForce(force), WithDryRun(dryRun))
 			if err != nil {
 				core.PrintError("Apply", err)
 				core.ExitWithError(err)
 			}
 
-			// Check if any resources failed
-			hasFailures := false
-			for _, result := range applyResults {
-				if result.Result.Status == "failed" {
-					hasFailures = true
-					break
+			if !selector.Empty() {
+				if hasFailedApplyResult(applyResults) {
+					core.PrintWarning("Some resources failed to apply, skipping prune")
+				} else {
+					candidates, err := pruneCandidates(selector, applyResults)
+					if err != nil {
+						core.PrintError("Apply", err)
+						core.ExitWithError(err)
+					}
+					applyResults = append(applyResults, pruneResources(candidates, prune, dryRun)...)
 				}
 			}
 
+			hasFailures := hasFailedApplyResult(applyResults)
+
 			outputFmt := core.GetOutputFormat()
 			if outputFmt == "json" || outputFmt == "yaml" {
 				printApplyStructuredOutput(applyResults, outputFmt, !hasFailures)
+			} else if (continueOnError && hasFailures) || !selector.Empty() || dryRun {
+				printApplySummary(applyResults)
 			}
 
 			if hasFailures {
@@ -179,6 +304,13 @@ via -e flag for .env files or -s flag for command-line secrets.`,
 	cmd.Flags().BoolVarP(&recursive, "recursive", "R", false, "Process the directory used in -f, --filename recursively. Useful when you want to manage related manifests organized within the same directory.")
 	cmd.Flags().StringSliceVarP(&envFiles, "env-file", "e", []string{".env"}, "Environment file to load")
 	cmd.Flags().StringSliceVarP(&commandSecrets, "secrets", "s", []string{}, "Secrets to deploy")
+	cmd.Flags().BoolVar(&continueOnError, "continue-on-error", false, "Apply all resources even if some fail, then print a summary of the failures")
+	cmd.Flags().BoolVar(&failFast, "fail-fast", false, "Stop at the first resource that fails to apply")
+	cmd.Flags().BoolVar(&force, "force", false, "Update resources even if they changed since the metadata.updatedAt of the manifest")
+	cmd.Flags().StringSliceVarP(&selectors, "selector", "l", nil, "List the resources whose labels match (key=value, key!=value or key) but are not in the manifests")
+	cmd.Flags().BoolVar(&prune, "prune", false, "Delete the resources matching --selector that are not in the manifests")
+	cmd.Flags().BoolVar(&dryRun, "dryrun", false, "Print what would be applied and pruned without changing anything")
+	core.AliasFlags(cmd, map[string]string{"dry-run": "dryrun"})
 	err := cmd.MarkFlagRequired("filename")
 	if err != nil {
 		core.PrintError("Apply", err)
@@ -188,7 +320,11 @@ via -e flag for .env files or -s flag for command-line secrets.`,
 	return cmd
 }
 
-func ApplyResources(results []core.Result) ([]ApplyResult, error) {
+func ApplyResources(results []core.Result, opts ...ApplyOption) ([]ApplyResult, error) {
+	options := &applyOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
 	applyResults := []ApplyResult{}
 	resources := core.GetResources()
 
@@ -218,6 +354,20 @@ func ApplyResources(results []core.Result) ([]ApplyResult, error) {
 					}
 				}
 
+				if options.dryRun {
+					core.Print(fmt.Sprintf("Resource %s:%s would be applied (dry run)\n", resource.Kind, name))
+					applyResults = append(applyResults, ApplyResult{Kind: resource.Kind, Name: name, Result: ResourceOperationResult{Status: "dry-run"}})
+					continue
+				}
+
+				if !options.force {
+					if conflict := checkApplyConflict(resource, result, name); conflict != nil {
+						core.Print(fmt.Sprintf("Resource %s:%s error: %s\n", resource.Kind, name, conflict.ErrorMsg))
+						applyResults = append(applyResults, ApplyResult{Kind: resource.Kind, Name: name, Result: *conflict})
+						continue
+					}
+				}
+
 				var resultOp *ResourceOperationResult
 				if resource.Kind == "Sandbox" || resource.Kind == "Application" {
 					resultOp = PostThenPutFn(resource, result.Kind, name, result, parentName, metadata)
@@ -248,19 +398,216 @@ func Apply(filePath string, opts ...ApplyOption) ([]ApplyResult, error) {
 		opt(options)
 	}
 
-	results, err := core.GetResults("apply", filePath, options.recursive)
+	if !options.continueOnError {
+		results, err := core.GetResults("apply", filePath, options.recursive)
+		if err != nil {
+			return nil, fmt.Errorf("error getting results: %w", err)
+		}
+		if results, err = core.SortResultsForApply(results); err != nil {
+			return nil, &core.ConfigError{Err: err}
+		}
+		if options.failFast {
+			return applyUntilFailure(results, opts...)
+		}
+		applyResults, err := ApplyResources(results, opts...)
+		if err != nil {
+			return nil, fmt.Errorf("error applying resources: %w", err)
+		}
+		return applyResults, nil
+	}
+
+	results, fileErrors, err := core.GetResultsWithFileErrors("apply", filePath, options.recursive)
 	if err != nil {
 		return nil, fmt.Errorf("error getting results: %w", err)
 	}
+	if results, err = core.SortResultsForApply(results); err != nil {
+		return nil, &core.ConfigError{Err: err}
+	}
+
+	applyResults := []ApplyResult{}
+	for _, fileError := range fileErrors {
+		core.Print(fmt.Sprintf("File %s error: %v\n", fileError.Path, fileError.Err))
+		applyResults = append(applyResults, ApplyResult{
+			Kind:   "File",
+			Name:   fileError.Path,
+			Result: ResourceOperationResult{Status: "failed", ErrorMsg: fileError.Err.Error()},
+		})
+	}
+	for _, result := range unknownKindResults(results) {
+		core.Print(fmt.Sprintf("Resource %s:%s error: %s\n", result.Kind, result.Name, result.Result.ErrorMsg))
+		applyResults = append(applyResults, result)
+	}
 
-	applyResults, err := ApplyResources(results)
+	resourceResults, err := ApplyResources(results, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("error applying resources: %w", err)
 	}
 
+	return append(applyResults, resourceResults...), nil
+}
+
+// applyUntilFailure applies resources in order and stops at the first one that fails
+func applyUntilFailure(results []core.Result, opts ...ApplyOption) ([]ApplyResult, error) {
+	applyResults := []ApplyResult{}
+	for i, result := range results {
+		resourceResults, err := ApplyResources([]core.Result{result}, opts...)
+		if err != nil {
+			return nil, fmt.Errorf("error applying resources: %w", err)
+		}
+		applyResults = append(applyResults, resourceResults...)
+		if remaining := len(results) - i - 1; hasFailedApplyResult(resourceResults) && remaining > 0 {
+			core.Print(fmt.Sprintf("Stopped at the first failure, %d remaining resource(s) not applied (remove --fail-fast to apply them anyway)\n", remaining))
+			break
+		}
+	}
 	return applyResults, nil
 }
 
+// checkApplyConflict returns a failed result when the manifest records the
+// metadata.updatedAt of the version it was made from and the deployed
+// resource changed since. The API has no conditional update, so the check
+// runs right before the update.
+func checkApplyConflict(resource *core.Resource, result core.Result, name string) *ResourceOperationResult {
+	metadata, _ := result.Metadata.(map[string]interface{})
+	expected, _ := metadata["updatedAt"].(string)
+	if expected == "" || resource.Get == nil || resource.ParentField != "" {
+		return nil
+	}
+
+	live, err := GetExec(resource, name)
+	var apiErr *blaxel.Error
+	if errors.As(err, &apiErr) && apiErr.StatusCode == 404 {
+		return nil
+	}
+	if err != nil {
+		return &ResourceOperationResult{
+			Status:   "failed",
+			ErrorMsg: fmt.Sprintf("cannot check for conflicts: %s", extractErrorMessage(err)),
+		}
+	}
+	liveMap, _ := live.(map[string]interface{})
+	liveMetadata, _ := liveMap["metadata"].(map[string]interface{})
+	updatedAt, _ := liveMetadata["updatedAt"].(string)
+	if updatedAt == "" || updatedAt == expected {
+		return nil
+	}
+
+	msg := fmt.Sprintf("conflict: changed at %s", updatedAt)
+	if updatedBy, _ := liveMetadata["updatedBy"].(string); updatedBy != "" {
+		msg += fmt.Sprintf(" by %s", updatedBy)
+	}
+	msg += fmt.Sprintf(", after the version of the manifest (%s)", expected)
+	if desired, err := normalizeSpec(result.Spec); err == nil {
+		if fields := differingFields(pruneToDesired(liveMap["spec"], desired), desired, "spec"); len(fields) > 0 {
+			msg += fmt.Sprintf("; fields that differ: %s", strings.Join(fields, ", "))
+		}
+	}
+	msg += fmt.Sprintf(". Run 'bl get %s %s -o yaml' to fetch it again, or use --force to overwrite it", resource.Singular, name)
+	return &ResourceOperationResult{Status: "failed", ErrorMsg: msg}
+}
+
+// differingFields returns the sorted paths under prefix where current and
+// desired hold different values
+func differingFields(current, desired interface{}, prefix string) []string {
+	currentMap, currentOk := current.(map[string]interface{})
+	desiredMap, desiredOk := desired.(map[string]interface{})
+	if !currentOk || !desiredOk {
+		if reflect.DeepEqual(current, desired) {
+			return nil
+		}
+		return []string{prefix}
+	}
+	keys := map[string]bool{}
+	for key := range currentMap {
+		keys[key] = true
+	}
+	for key := range desiredMap {
+		keys[key] = true
+	}
+	fields := []string{}
+	for key := range keys {
+		fields = append(fields, differingFields(currentMap[key], desiredMap[key], prefix+"."+key)...)
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// unknownKindResults returns a failed result for each document whose kind cannot be applied
+func unknownKindResults(results []core.Result) []ApplyResult {
+	known := map[string]bool{}
+	for _, resource := range core.GetResources() {
+		known[resource.Kind] = true
+	}
+	applyResults := []ApplyResult{}
+	for _, result := range results {
+		if known[result.Kind] {
+			continue
+		}
+		name := ""
+		if metadata, ok := result.Metadata.(map[string]interface{}); ok {
+			name, _ = metadata["name"].(string)
+		}
+		applyResults = append(applyResults, ApplyResult{
+			Kind: result.Kind,
+			Name: name,
+			Result: ResourceOperationResult{
+				Status:   "failed",
+				ErrorMsg: fmt.Sprintf("unknown kind %q", result.Kind),
+			},
+		})
+	}
+	return applyResults
+}
+
+func hasFailedApplyResult(results []ApplyResult) bool {
+	for _, result := range results {
+		if result.Result.Status == "failed" {
+			return true
+		}
+	}
+	return false
+}
+
+// applySummaryLabels names the statuses of apply results in the summary, in order
+var applySummaryLabels = []struct{ status, label string }{
+	{"created", "created"},
+	{"configured", "configured"},
+	{"skipped", "skipped"},
+	{"dry-run", "to apply"},
+	{"pruned", "pruned"},
+	{"prunable", "to prune"},
+	{"failed", "failed"},
+}
+
+// applySummary counts the results by status, like "2 created, 1 pruned"
+func applySummary(results []ApplyResult) string {
+	counts := map[string]int{}
+	for _, r := range results {
+		counts[r.Result.Status]++
+	}
+	parts := []string{}
+	for _, l := range applySummaryLabels {
+		if counts[l.status] > 0 || l.status == "failed" {
+			parts = append(parts, fmt.Sprintf("%d %s", counts[l.status], l.label))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// printApplySummary prints the number of resources by status followed by each failure
+func printApplySummary(results []ApplyResult) {
+	failed := []ApplyResult{}
+	for _, r := range results {
+		if r.Result.Status == "failed" {
+			failed = append(failed, r)
+		}
+	}
+	core.Print(fmt.Sprintf("\nSummary: %s\n", applySummary(results)))
+	for _, r := range failed {
+		core.Print(fmt.Sprintf("  - %s:%s: %s\n", r.Kind, r.Name, r.Result.ErrorMsg))
+	}
+}
+
 func printApplyStructuredOutput(results []ApplyResult, outputFmt string, success bool) {
 	type applyResourceResult struct {
 		Kind   string `json:"kind"`
@@ -272,6 +619,7 @@ func printApplyStructuredOutput(results []ApplyResult, outputFmt string, success
 	type applyOutput struct {
 		Applied []applyResourceResult `json:"applied"`
 		Failed  []applyResourceResult `json:"failed"`
+		Pruned  []applyResourceResult `json:"pruned,omitempty"`
 		Success bool                  `json:"success"`
 	}
 
@@ -287,10 +635,13 @@ func printApplyStructuredOutput(results []ApplyResult, outputFmt string, success
 			Name:   r.Name,
 			Action: r.Result.Status,
 		}
-		if r.Result.Status == "failed" {
+		switch r.Result.Status {
+		case "failed":
 			entry.Error = r.Result.ErrorMsg
 			output.Failed = append(output.Failed, entry)
-		} else {
+		case "pruned", "prunable":
+			output.Pruned = append(output.Pruned, entry)
+		default:
 			output.Applied = append(output.Applied, entry)
 		}
 	}
@@ -363,6 +714,12 @@ func handleResourceOperation(resource *core.Resource, name string, resourceObjec
 	// re-inject them via WithJSONSet which patches the serialized JSON body.
 	opts = append(opts, preserveExtraRuntimeFields(resourceJson)...)
 
+	// Creates carry an idempotency key so that a retried POST for the same
+	// manifest is recognized server-side instead of creating a duplicate.
+	if operation == "post" {
+		opts = append(opts, option.WithHeader(idempotencyKeyHeader, idempotencyKey(resource.Kind, name, resourceJson)))
+	}
+
 	// Get function signature information
 	funcType := fn.Type()
 
@@ -455,6 +812,21 @@ func handleResourceOperation(resource *core.Resource, name string, resourceObjec
 	return result, nil
 }
 
+// idempotencyKeyHeader is the request header carrying the create idempotency key
+const idempotencyKeyHeader = "Idempotency-Key"
+
+// idempotencyKey returns a deterministic key for a create operation, derived
+// from the resource kind, its name and the content of the manifest.
+func idempotencyKey(kind string, name string, resourceJson []byte) string {
+	h := sha256.New()
+	h.Write([]byte(kind))
+	h.Write([]byte{0})
+	h.Write([]byte(name))
+	h.Write([]byte{0})
+	h.Write(resourceJson)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
 // setBodyFieldsFromJSON sets fields in dst directly from the original YAML JSON
 // This ensures we only send fields that were actually present in the YAML,
 // not Go's default values for missing fields
@@ -695,8 +1067,9 @@ func PostThenPutFn(resource *core.Resource, resourceName string, name string, re
 		errorMsg := extractErrorMessage(err)
 		core.Print(fmt.Sprintf("%s%s\n", formattedError, errorMsg))
 		return &ResourceOperationResult{
-			Status:   "failed",
-			ErrorMsg: errorMsg,
+			Status:      "failed",
+			ErrorMsg:    errorMsg,
+			RateLimited: isRateLimitedError(err),
 		}
 	}
 	if opResult == nil {
@@ -717,6 +1090,12 @@ func PostThenPutFn(resource *core.Resource, resourceName string, name string, re
 }
 
 func PutFn(resource *core.Resource, resourceName string, name string, resourceObject interface{}, parentName string, metadata map[string]interface{}) *ResourceOperationResult {
+	return putFn(resource, resourceName, name, resourceObject, parentName, metadata, true)
+}
+
+// putFn updates the resource. When allowCreate is true, a 404/405 response
+// falls back to creating it with PostFn.
+func putFn(resource *core.Resource, resourceName string, name string, resourceObject interface{}, parentName string, metadata map[string]interface{}, allowCreate bool) *ResourceOperationResult {
 	if resource.Kind == "IntegrationConnection" {
 		client := core.GetClient()
 		_, err := client.Integrations.Connections.Get(context.Background(), name)
@@ -746,7 +1125,7 @@ func PutFn(resource *core.Resource, resourceName string, name string, resourceOb
 	if err != nil {
 		// Check if it's a 404 or 405 error - need to create
 		var apiErr *blaxel.Error
-		if ok := isBlaxelError(err, &apiErr); ok {
+		if ok := isBlaxelError(err, &apiErr); ok && allowCreate {
 			if apiErr.StatusCode == 404 || apiErr.StatusCode == 405 {
 				return PostFn(resource, resourceName, name, resourceObject, parentName, metadata)
 			}
@@ -754,8 +1133,9 @@ func PutFn(resource *core.Resource, resourceName string, name string, resourceOb
 		errorMsg := extractErrorMessage(err)
 		core.Print(fmt.Sprintf("%s%s\n", formattedError, errorMsg))
 		return &ResourceOperationResult{
-			Status:   "failed",
-			ErrorMsg: errorMsg,
+			Status:      "failed",
+			ErrorMsg:    errorMsg,
+			RateLimited: isRateLimitedError(err),
 		}
 	}
 	if opResult == nil {
@@ -793,11 +1173,18 @@ func PostFn(resource *core.Resource, resourceName string, name string, resourceO
 	formattedError := fmt.Sprintf("Resource %s:%s error: ", resourceName, name)
 	opResult, err := handleResourceOperation(resource, name, resourceObject, "post", parentName, metadata)
 	if err != nil {
+		// The API does not honor the idempotency key everywhere: a 409 means an
+		// earlier attempt already created the resource, so update it instead.
+		var apiErr *blaxel.Error
+		if ok := isBlaxelError(err, &apiErr); ok && apiErr.StatusCode == 409 && resource.Put != nil {
+			return putFn(resource, resourceName, name, resourceObject, parentName, metadata, false)
+		}
 		errorMsg := extractErrorMessage(err)
 		core.Print(fmt.Sprintf("%s%s\n", formattedError, errorMsg))
 		return &ResourceOperationResult{
-			Status:   "failed",
-			ErrorMsg: errorMsg,
+			Status:      "failed",
+			ErrorMsg:    errorMsg,
+			RateLimited: isRateLimitedError(err),
 		}
 	}
 	if opResult == nil {
@@ -843,6 +1230,12 @@ func isBlaxelError(err error, apiErr **blaxel.Error) bool {
 	return false
 }
 
+// isRateLimitedError reports whether err is a 429 Too Many Requests API error
+func isRateLimitedError(err error) bool {
+	var apiErr *blaxel.Error
+	return isBlaxelError(err, &apiErr) && apiErr.StatusCode == http.StatusTooManyRequests
+}
+
 // extractErrorMessage extracts a user-friendly error message from an error.
 // If the error is a blaxel API error, it parses the JSON response to get the
 // human-readable message. It checks for both "message" and "error" fields since
@@ -0,0 +1,114 @@
+package cli
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/blaxel-ai/toolkit/cli/core"
+)
+
+// prunableResources returns the resources apply --prune may delete: top-level
+// kinds that can be listed and deleted by name
+func prunableResources() []*core.Resource {
+	prunable := []*core.Resource{}
+	for _, resource := range core.GetResources() {
+		if resource.ParentField != "" || resource.Delete == nil || resource.Kind == "Image" {
+			continue
+		}
+		if resource.List == nil && !(resource.Paginated && resource.APIPath != "") {
+			continue
+		}
+		prunable = append(prunable, resource)
+	}
+	return prunable
+}
+
+// listAll returns every resource of a kind, fetching all pages when the
+// listing is paginated
+func listAll(resource *core.Resource) ([]interface{}, error) {
+	if resource.Paginated && resource.APIPath != "" {
+		return core.ListAllPaginated(resource)
+	}
+	return ListExec(resource)
+}
+
+// validatePruneSelector checks that the selector given with --prune only
+// matches labeled resources: with only key!=value terms it would also match
+// every resource of the workspace without the label.
+func validatePruneSelector(selector core.LabelSelector) error {
+	if selector.Empty() {
+		return &core.ConfigError{Err: fmt.Errorf("--prune requires a label selector (-l) to limit the resources it may delete")}
+	}
+	if !selector.RequiresLabel() {
+		return &core.ConfigError{Err: fmt.Errorf("--prune requires a key=value or key term in the label selector (-l), key!=value alone also matches the resources without labels")}
+	}
+	return nil
+}
+
+// pruneCandidate is a resource of the workspace missing from the manifests
+type pruneCandidate struct {
+	resource *core.Resource
+	name     string
+}
+
+// pruneCandidates returns the resources of the workspace matching selector
+// that are not part of applied, sorted by kind and name
+func pruneCandidates(selector core.LabelSelector, applied []ApplyResult) ([]pruneCandidate, error) {
+	inManifests := map[string]bool{}
+	for _, r := range applied {
+		inManifests[r.Kind+"/"+r.Name] = true
+	}
+
+	candidates := []pruneCandidate{}
+	for _, resource := range prunableResources() {
+		items, err := listAll(resource)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list %s: %w", resource.Plural, err)
+		}
+		for _, item := range selector.Filter(items) {
+			name := itemName(item)
+			if name == "" || inManifests[resource.Kind+"/"+name] {
+				continue
+			}
+			candidates = append(candidates, pruneCandidate{resource: resource, name: name})
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].resource.Kind != candidates[j].resource.Kind {
+			return candidates[i].resource.Kind < candidates[j].resource.Kind
+		}
+		return candidates[i].name < candidates[j].name
+	})
+	return candidates, nil
+}
+
+// itemName returns metadata.name of a listed resource
+func itemName(item interface{}) string {
+	itemMap, _ := item.(map[string]interface{})
+	metadata, _ := itemMap["metadata"].(map[string]interface{})
+	name, _ := metadata["name"].(string)
+	return name
+}
+
+// pruneResources deletes the candidates when prune is set and it is not a dry
+// run, otherwise it only lists them. It returns one result per candidate.
+func pruneResources(candidates []pruneCandidate, prune bool, dryRun bool) []ApplyResult {
+	results := make([]ApplyResult, 0, len(candidates))
+	for _, candidate := range candidates {
+		kind, name := candidate.resource.Kind, candidate.name
+		result := ResourceOperationResult{Status: "prunable"}
+		switch {
+		case !prune:
+			core.Print(fmt.Sprintf("Resource %s:%s is not in the manifests (use --prune to delete it)\n", kind, name))
+		case dryRun:
+			core.Print(fmt.Sprintf("Resource %s:%s would be pruned (dry run)\n", kind, name))
+		default:
+			result.Status = "pruned"
+			if err := DeleteFn(candidate.resource, name); err != nil {
+				result = ResourceOperationResult{Status: "failed", ErrorMsg: fmt.Sprintf("prune: %s", extractErrorMessage(err))}
+			}
+		}
+		results = append(results, ApplyResult{Kind: kind, Name: name, Result: result})
+	}
+	return results
+}
@@ -0,0 +1,73 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/blaxel-ai/toolkit/cli/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPruneResourcesWithoutDeleting(t *testing.T) {
+	agent := &core.Resource{Kind: "Agent"}
+	candidates := []pruneCandidate{{resource: agent, name: "old-agent"}}
+
+	for _, tc := range []struct {
+		name   string
+		prune  bool
+		dryRun bool
+	}{
+		{"listed only", false, false},
+		{"dry run", true, true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			results := pruneResources(candidates, tc.prune, tc.dryRun)
+			assert.Equal(t, []ApplyResult{{Kind: "Agent", Name: "old-agent", Result: ResourceOperationResult{Status: "prunable"}}}, results)
+		})
+	}
+}
+
+func TestValidatePruneSelector(t *testing.T) {
+	for _, tc := range []struct {
+		selectors []string
+		valid     bool
+	}{
+		{nil, false},
+		{[]string{"env!=prod"}, false},
+		{[]string{"env!=prod,tier!=gold"}, false},
+		{[]string{"managed-by=me"}, true},
+		{[]string{"managed-by"}, true},
+		{[]string{"managed-by=me,env!=prod"}, true},
+	} {
+		selector, err := core.ParseLabelSelector(tc.selectors)
+		assert.NoError(t, err)
+		err = validatePruneSelector(selector)
+		if tc.valid {
+			assert.NoError(t, err, "selectors %v", tc.selectors)
+			continue
+		}
+		var configErr *core.ConfigError
+		assert.ErrorAs(t, err, &configErr, "selectors %v", tc.selectors)
+	}
+}
+
+func TestApplySummary(t *testing.T) {
+	results := []ApplyResult{
+		{Kind: "Agent", Name: "a", Result: ResourceOperationResult{Status: "created"}},
+		{Kind: "Agent", Name: "b", Result: ResourceOperationResult{Status: "configured"}},
+		{Kind: "Agent", Name: "c", Result: ResourceOperationResult{Status: "configured"}},
+		{Kind: "Function", Name: "d", Result: ResourceOperationResult{Status: "pruned"}},
+	}
+	assert.Equal(t, "1 created, 2 configured, 1 pruned, 0 failed", applySummary(results))
+
+	results = []ApplyResult{
+		{Kind: "Agent", Name: "a", Result: ResourceOperationResult{Status: "dry-run"}},
+		{Kind: "Agent", Name: "b", Result: ResourceOperationResult{Status: "prunable"}},
+		{Kind: "Agent", Name: "c", Result: ResourceOperationResult{Status: "failed"}},
+	}
+	assert.Equal(t, "1 to apply, 1 to prune, 1 failed", applySummary(results))
+}
+
+func TestItemName(t *testing.T) {
+	assert.Equal(t, "my-agent", itemName(map[string]interface{}{"metadata": map[string]interface{}{"name": "my-agent"}}))
+	assert.Equal(t, "", itemName("not a resource"))
+}
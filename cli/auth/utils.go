@@ -6,6 +6,7 @@ import (
 
 	blaxel "github.com/blaxel-ai/sdk-go"
 	"github.com/blaxel-ai/sdk-go/option"
+	"github.com/blaxel-ai/toolkit/cli/core"
 )
 
 // WorkspaceClient interface for workspace lookups (allows mocking)
@@ -54,7 +55,7 @@ func BuildClientOptions(workspace string, credentials blaxel.Credentials) []opti
 		opts = append(opts, option.WithClientCredentials(credentials.ClientCredentials))
 	}
 
-	return opts
+	return append(opts, core.RequestLogOptions()...)
 }
 
 // ValidateWorkspace validates workspace credentials
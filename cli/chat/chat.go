@@ -7,6 +7,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/blaxel-ai/toolkit/cli/core"
 	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/textarea"
 	"github.com/charmbracelet/bubbles/viewport"
@@ -362,7 +363,7 @@ func (m *ChatModel) View() string {
 	}
 
 	style := lipgloss.NewStyle().
-		BorderStyle(lipgloss.RoundedBorder()).
+		BorderStyle(core.Border(lipgloss.RoundedBorder())).
 		BorderForeground(lipgloss.Color("214")). // Changed from 202 to match
 		Padding(0)
 
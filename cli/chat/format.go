@@ -9,6 +9,7 @@ import (
 	_ "image/jpeg"
 	_ "image/png"
 
+	"github.com/blaxel-ai/toolkit/cli/core"
 	"github.com/charmbracelet/glamour"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/qeesung/image2ascii/convert"
@@ -22,7 +23,11 @@ func FormatMarkdown(text string) string {
 }
 
 func FormatText(text string) string {
-	text, err := glamour.Render(text, "dark")
+	style := "dark"
+	if core.ColorDisabled() {
+		style = "ascii"
+	}
+	text, err := glamour.Render(text, style)
 	if err != nil {
 		return text
 	}
@@ -47,7 +52,7 @@ func FormatMarkdownImage(text string) string {
 
 		imageStyle := lipgloss.NewStyle().
 			Foreground(lipgloss.Color("99")).
-			Border(lipgloss.NormalBorder()).
+			Border(core.Border(lipgloss.NormalBorder())).
 			BorderForeground(lipgloss.Color("99")).
 			Padding(1)
 
@@ -3,6 +3,7 @@ package chat
 import (
 	"time"
 
+	"github.com/blaxel-ai/toolkit/cli/core"
 	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/lipgloss"
 )
@@ -16,10 +17,10 @@ func (m *ChatModel) getSpinnerStyle() lipgloss.Style {
 
 func (m *ChatModel) initializeSpinner() spinner.Model {
 	sp := spinner.New()
-	sp.Spinner = spinner.Spinner{
+	sp.Spinner = core.Spinner(spinner.Spinner{
 		Frames: []string{"🌑", "🌒", "🌓", "🌔", "🌕", "🌖", "🌗", "🌘"},
 		FPS:    100 * time.Millisecond,
-	}
+	})
 	sp.Style = lipgloss.NewStyle().
 		Foreground(lipgloss.Color("240")).
 		PaddingLeft(2)
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/blaxel-ai/toolkit/cli/core"
 	"github.com/charmbracelet/bubbles/viewport"
 	"github.com/charmbracelet/lipgloss"
 )
@@ -12,7 +13,7 @@ func (m *ChatModel) createWelcomeMessage(width int) string {
 	headerStyle := lipgloss.NewStyle().
 		Foreground(lipgloss.Color("214")).
 		Bold(true).
-		BorderStyle(lipgloss.NormalBorder()).
+		BorderStyle(core.Border(lipgloss.NormalBorder())).
 		BorderBottom(true).
 		BorderForeground(lipgloss.Color("214")).
 		Width(width).
@@ -80,6 +80,18 @@ func TestApplyCmd(t *testing.T) {
 
 	rFlag := cmd.Flags().Lookup("recursive")
 	assert.NotNil(t, rFlag)
+
+	continueFlag := cmd.Flags().Lookup("continue-on-error")
+	assert.NotNil(t, continueFlag)
+	assert.Equal(t, "false", continueFlag.DefValue)
+
+	failFastFlag := cmd.Flags().Lookup("fail-fast")
+	assert.NotNil(t, failFastFlag)
+	assert.Equal(t, "false", failFastFlag.DefValue)
+
+	forceFlag := cmd.Flags().Lookup("force")
+	assert.NotNil(t, forceFlag)
+	assert.Equal(t, "false", forceFlag.DefValue)
 }
 
 func TestApplyOptionWithRecursive(t *testing.T) {
@@ -96,6 +108,50 @@ func TestApplyOptionWithRecursive(t *testing.T) {
 	assert.False(t, opts.recursive)
 }
 
+func TestApplyOptionWithFailFast(t *testing.T) {
+	opts := &applyOptions{}
+
+	option := WithFailFast(true)
+	option(opts)
+
+	assert.True(t, opts.failFast)
+}
+
+func TestApplyOptionWithForce(t *testing.T) {
+	opts := &applyOptions{}
+	WithForce(true)(opts)
+	assert.True(t, opts.force)
+}
+
+func TestCheckApplyConflictWithoutUpdatedAt(t *testing.T) {
+	resource := &core.Resource{Kind: "Agent", Singular: "agent", Get: func() {}}
+	result := core.Result{
+		Kind:     "Agent",
+		Metadata: map[string]interface{}{"name": "my-agent"},
+		Spec:     map[string]interface{}{"enabled": true},
+	}
+
+	// Without the version of the manifest there is nothing to compare to,
+	// so the deployed resource is not even fetched
+	assert.Nil(t, checkApplyConflict(resource, result, "my-agent"))
+}
+
+func TestDifferingFields(t *testing.T) {
+	current := map[string]interface{}{
+		"enabled": true,
+		"runtime": map[string]interface{}{"memory": 2048.0, "image": "agent/a:1"},
+		"envs":    []interface{}{"A"},
+	}
+	desired := map[string]interface{}{
+		"enabled": true,
+		"runtime": map[string]interface{}{"memory": 4096.0, "image": "agent/a:1", "generation": "mk3"},
+		"envs":    []interface{}{"A", "B"},
+	}
+
+	assert.Equal(t, []string{"spec.envs", "spec.runtime.generation", "spec.runtime.memory"}, differingFields(current, desired, "spec"))
+	assert.Empty(t, differingFields(desired, desired, "spec"))
+}
+
 func TestApplyResultStruct(t *testing.T) {
 	result := ApplyResult{
 		Kind: "Agent",
@@ -381,6 +437,51 @@ func TestApplyWithInvalidFile(t *testing.T) {
 	assert.Nil(t, result)
 }
 
+func TestApplyContinueOnErrorCollectsFailures(t *testing.T) {
+	tempDir := t.TempDir()
+
+	invalid := "kind: Agent\nmetadata: [unclosed\n"
+	unknown := `apiVersion: blaxel.ai/v1alpha1
+kind: Unicorn
+metadata:
+  name: sparkles
+`
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "invalid.yaml"), []byte(invalid), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "unknown.yaml"), []byte(unknown), 0644))
+
+	results, err := Apply(tempDir, WithContinueOnError(true))
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+
+	assert.Equal(t, "File", results[0].Kind)
+	assert.Equal(t, filepath.Join(tempDir, "invalid.yaml"), results[0].Name)
+	assert.Equal(t, "failed", results[0].Result.Status)
+	assert.Contains(t, results[0].Result.ErrorMsg, "error decoding YAML")
+
+	assert.Equal(t, "Unicorn", results[1].Kind)
+	assert.Equal(t, "sparkles", results[1].Name)
+	assert.Equal(t, "failed", results[1].Result.Status)
+	assert.Contains(t, results[1].Result.ErrorMsg, "unknown kind")
+	assert.True(t, hasFailedApplyResult(results))
+
+	// Without the option, bad files are skipped as before
+	results, err = Apply(tempDir)
+	require.NoError(t, err)
+	assert.Empty(t, results)
+}
+
+func TestHasFailedApplyResult(t *testing.T) {
+	assert.False(t, hasFailedApplyResult(nil))
+	assert.False(t, hasFailedApplyResult([]ApplyResult{
+		{Kind: "Agent", Name: "a", Result: ResourceOperationResult{Status: "configured"}},
+		{Kind: "IntegrationConnection", Name: "b", Result: ResourceOperationResult{Status: "skipped"}},
+	}))
+	assert.True(t, hasFailedApplyResult([]ApplyResult{
+		{Kind: "Agent", Name: "a", Result: ResourceOperationResult{Status: "configured"}},
+		{Kind: "Function", Name: "b", Result: ResourceOperationResult{Status: "failed"}},
+	}))
+}
+
 func TestApplyWithDirectoryParsing(t *testing.T) {
 	// Create a temporary directory
 	tempDir, err := os.MkdirTemp("", "apply_dir_test")
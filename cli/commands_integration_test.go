@@ -778,3 +778,98 @@ DEBUG = "true"
 	assert.NotNil(t, config.Env)
 	assert.Len(t, config.Env, 3)
 }
+
+// TestApplyRepeatedManifestIntegration tests that applying the same manifest
+// twice neither errors nor creates a duplicate resource
+func TestApplyRepeatedManifestIntegration(t *testing.T) {
+	created := map[string]bool{}
+	seenKeys := map[string]bool{}
+	postCount := 0
+
+	writeAgent := func(w http.ResponseWriter, name string) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"metadata": map[string]interface{}{"name": name},
+		})
+	}
+
+	handlers := map[string]http.HandlerFunc{
+		"PUT /agents/": func(w http.ResponseWriter, r *http.Request) {
+			name := strings.TrimPrefix(r.URL.Path, "/agents/")
+			if !created[name] {
+				w.WriteHeader(http.StatusNotFound)
+				_ = json.NewEncoder(w).Encode(map[string]string{"error": "not found"})
+				return
+			}
+			writeAgent(w, name)
+		},
+		"POST /agents": func(w http.ResponseWriter, r *http.Request) {
+			postCount++
+			var body map[string]interface{}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			name := body["metadata"].(map[string]interface{})["name"].(string)
+			key := r.Header.Get("Idempotency-Key")
+			assert.NotEmpty(t, key)
+			if seenKeys[key] {
+				writeAgent(w, name)
+				return
+			}
+			if created[name] {
+				w.WriteHeader(http.StatusConflict)
+				_ = json.NewEncoder(w).Encode(map[string]string{"error": "already exists"})
+				return
+			}
+			seenKeys[key] = true
+			created[name] = true
+			writeAgent(w, name)
+		},
+	}
+
+	server := setupTestServer(t, handlers)
+	defer server.Close()
+	setupTestClient(t, server.URL)
+	core.RegisterResourceOperations(context.Background())
+
+	manifest := []core.Result{{
+		ApiVersion: "blaxel.ai/v1alpha1",
+		Kind:       "Agent",
+		Metadata:   map[string]interface{}{"name": "my-agent"},
+		Spec:       map[string]interface{}{},
+	}}
+
+	for i := 0; i < 2; i++ {
+		results, err := ApplyResources(manifest)
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.NotEqual(t, "failed", results[0].Result.Status)
+	}
+	assert.Len(t, created, 1)
+	assert.Equal(t, 1, postCount)
+
+	// A retried create with the same content reuses the same key
+	var agentResource *core.Resource
+	for _, resource := range core.GetResources() {
+		if resource.Kind == "Agent" {
+			agentResource = resource
+		}
+	}
+	require.NotNil(t, agentResource)
+	metadata := manifest[0].Metadata.(map[string]interface{})
+	result := PostFn(agentResource, "Agent", "my-agent", manifest[0], "", metadata)
+	require.NotNil(t, result)
+	assert.Equal(t, "created", result.Status)
+	assert.Len(t, created, 1)
+
+	// A create the server cannot match by key falls back to an update
+	delete(seenKeys, idempotencyKey("Agent", "my-agent", mustMarshal(t, manifest[0])))
+	result = PostFn(agentResource, "Agent", "my-agent", manifest[0], "", metadata)
+	require.NotNil(t, result)
+	assert.Equal(t, "configured", result.Status)
+	assert.Len(t, created, 1)
+}
+
+func mustMarshal(t *testing.T, v interface{}) []byte {
+	data, err := json.Marshal(v)
+	require.NoError(t, err)
+	return data
+}
@@ -2,20 +2,98 @@ package cli
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
 	blaxel "github.com/blaxel-ai/sdk-go"
 	"github.com/blaxel-ai/sdk-go/option"
+	"github.com/blaxel-ai/toolkit/cli/core"
 	"github.com/spf13/cobra"
 )
 
 // completionTimeout is the maximum time to wait for API calls during completion
 const completionTimeout = 3 * time.Second
 
+// defaultCompletionLimit is how many resources completions list when
+// BL_COMPLETION_LIMIT is not set
+const defaultCompletionLimit = 20
+
+// completionLimit returns how many resources completions list, the most
+// recent first, from BL_COMPLETION_LIMIT, where 0 lists them all
+func completionLimit() int {
+	limit, err := strconv.Atoi(os.Getenv("BL_COMPLETION_LIMIT"))
+	if err != nil || limit < 0 {
+		return defaultCompletionLimit
+	}
+	return limit
+}
+
+// limitCompletions keeps the first completionLimit items, sorted most recent
+// first, and returns how many were left out
+func limitCompletions[T any](items []T) ([]T, int) {
+	limit := completionLimit()
+	if limit == 0 || len(items) <= limit {
+		return items, 0
+	}
+	return items[:limit], len(items) - limit
+}
+
+// appendCompletionLimitHint tells, as active help, that hidden resources were
+// left out of the completions
+func appendCompletionLimitHint(completions []string, hidden int) []string {
+	if hidden == 0 {
+		return completions
+	}
+	return cobra.AppendActiveHelp(completions, fmt.Sprintf("%d older not shown, type more characters or raise BL_COMPLETION_LIMIT (0 for all)", hidden))
+}
+
+// completionFuzzyEnabled reports whether resource names also match a
+// fragment from their middle, as enabled by BL_COMPLETION_FUZZY=1
+func completionFuzzyEnabled() bool {
+	enabled, _ := strconv.ParseBool(os.Getenv("BL_COMPLETION_FUZZY"))
+	return enabled
+}
+
+// completionMatch scores how well name matches what was typed, the higher the
+// better, and 0 when it does not match: 4 for a prefix, then with fuzzy
+// matching 3 for a fragment starting a word of the name, 2 for any fragment
+// and 1 for characters appearing in order, like "mpa" for "my-payments-agent".
+func completionMatch(name, toComplete string) int {
+	if strings.HasPrefix(name, toComplete) {
+		return 4
+	}
+	if !completionFuzzyEnabled() {
+		return 0
+	}
+	name, toComplete = strings.ToLower(name), strings.ToLower(toComplete)
+	if strings.HasPrefix(name, toComplete) {
+		return 4
+	}
+	for i := 1; i < len(name); i++ {
+		if strings.ContainsRune("-_./", rune(name[i-1])) && strings.HasPrefix(name[i:], toComplete) {
+			return 3
+		}
+	}
+	if strings.Contains(name, toComplete) {
+		return 2
+	}
+	rest := toComplete
+	for _, r := range name {
+		if rest != "" && strings.HasPrefix(rest, string(r)) {
+			rest = rest[len(string(r)):]
+		}
+	}
+	if rest == "" {
+		return 1
+	}
+	return 0
+}
+
 // completionContext returns a context with a timeout for completion API calls
 func completionContext() (context.Context, context.CancelFunc) {
 	return context.WithTimeout(context.Background(), completionTimeout)
@@ -40,10 +118,17 @@ func getWorkspaceFromFlags() string {
 	return ""
 }
 
+// completionLoginHintPrinted prevents the login hint from being printed once per
+// completion function when a shell completion runs several of them.
+var completionLoginHintPrinted bool
+
 // getClientForCompletion returns a client configured for the workspace specified in flags,
 // or the default client if no workspace flag is set.
-// Uses NewClientFromCredentials which handles token refresh properly.
+// An access token close to expiry is refreshed first and saved back to the config, so
+// completions keep working. When that fails, a one-line hint to log in again is printed
+// to stderr and nil is returned.
 // Also initializes the environment based on the workspace config (dev/prod).
+// Its responses are cached for a few seconds, see completionCacheMiddleware.
 func getClientForCompletion() *blaxel.Client {
 	workspace := getWorkspaceFromFlags()
 	if workspace == "" {
@@ -59,18 +144,32 @@ func getClientForCompletion() *blaxel.Client {
 	// Initialize environment for this workspace (sets correct URLs for dev/prod)
 	blaxel.InitializeEnvironment(workspace)
 
-	// Load credentials for the workspace
-	credentials, err := blaxel.LoadCredentials(workspace)
-	if err != nil || !credentials.IsValid() {
+	// Load credentials for the workspace, refreshing the access token if needed
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	credentials, err := core.FreshCredentials(ctx, workspace)
+	if err != nil {
+		if errors.Is(err, core.ErrSessionExpired) && !completionLoginHintPrinted {
+			completionLoginHintPrinted = true
+			fmt.Fprintf(os.Stderr, "bl: session for workspace %s expired, run 'bl login %s' to get completions again\n", workspace, workspace)
+		}
+		return nil
+	}
+	if !credentials.IsValid() {
 		return nil
 	}
 
-	// Use NewClientFromCredentials which handles token refresh properly
 	// GetBaseURL() now returns the correct URL based on the workspace's environment
-	client := blaxel.NewClientFromCredentials(credentials,
+	opts := []option.RequestOption{
 		option.WithWorkspace(workspace),
 		option.WithBaseURL(blaxel.GetBaseURL()),
-	)
+	}
+	if completionCacheEnabled() {
+		opts = append(opts, option.WithMiddleware(completionCacheMiddleware(workspace)))
+	}
+	// Traced behind the cache, so only requests reaching the API are recorded
+	opts = append(opts, core.HTTPTraceOptions()...)
+	client := blaxel.NewClientFromCredentials(credentials, opts...)
 	return &client
 }
 
@@ -132,12 +231,13 @@ func CompleteSandboxNames(cmd *cobra.Command, args []string, toComplete string)
 		name      string
 		desc      string
 		timestamp time.Time
+		score     int
 	}
 	var filtered []resourceWithTime
 
 	for _, sbx := range sandboxes.Data {
 		if sbx.Metadata.Name != "" {
-			if toComplete == "" || strings.HasPrefix(sbx.Metadata.Name, toComplete) {
+			if score := completionMatch(sbx.Metadata.Name, toComplete); score > 0 {
 				var descParts []string
 				var ts time.Time
 				if sbx.Metadata.CreatedAt != "" {
@@ -150,20 +250,21 @@ func CompleteSandboxNames(cmd *cobra.Command, args []string, toComplete string)
 					descParts = append(descParts, string(sbx.Status))
 				}
 				desc := strings.Join(descParts, " ")
-				filtered = append(filtered, resourceWithTime{name: sbx.Metadata.Name, desc: desc, timestamp: ts})
+				filtered = append(filtered, resourceWithTime{name: sbx.Metadata.Name, desc: desc, timestamp: ts, score: score})
 			}
 		}
 	}
 
-	// Sort by timestamp descending (most recent first)
+	// Sort by match quality, then by timestamp descending (most recent first)
 	sort.Slice(filtered, func(i, j int) bool {
+		if filtered[i].score != filtered[j].score {
+			return filtered[i].score > filtered[j].score
+		}
 		return filtered[i].timestamp.After(filtered[j].timestamp)
 	})
 
-	// Limit to 20 most recent
-	if len(filtered) > 20 {
-		filtered = filtered[:20]
-	}
+	// Limit to the most recent ones to avoid cluttered display
+	filtered, hidden := limitCompletions(filtered)
 
 	// Build completion strings with rank
 	var completions []string
@@ -176,6 +277,7 @@ func CompleteSandboxNames(cmd *cobra.Command, args []string, toComplete string)
 		}
 	}
 
+	completions = appendCompletionLimitHint(completions, hidden)
 	return completions, cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveKeepOrder
 }
 
@@ -235,10 +337,8 @@ func CompleteSandboxProcessNames(sandboxName string, toComplete string) ([]strin
 		return filtered[i].timestamp.After(filtered[j].timestamp)
 	})
 
-	// Limit to 20 most recent to avoid cluttered display
-	if len(filtered) > 20 {
-		filtered = filtered[:20]
-	}
+	// Limit to the most recent ones to avoid cluttered display
+	filtered, hidden := limitCompletions(filtered)
 
 	// Build completion strings with rank number to show order even if shell sorts alphabetically
 	var completions []string
@@ -251,6 +351,7 @@ func CompleteSandboxProcessNames(sandboxName string, toComplete string) ([]strin
 		}
 	}
 
+	completions = appendCompletionLimitHint(completions, hidden)
 	return completions, cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveKeepOrder
 }
 
@@ -347,12 +448,13 @@ func CompleteJobNames(cmd *cobra.Command, args []string, toComplete string) ([]s
 		name      string
 		desc      string
 		timestamp time.Time
+		score     int
 	}
 	var filtered []resourceWithTime
 
 	for _, job := range jobs.Data {
 		if job.Metadata.Name != "" {
-			if toComplete == "" || strings.HasPrefix(job.Metadata.Name, toComplete) {
+			if score := completionMatch(job.Metadata.Name, toComplete); score > 0 {
 				var descParts []string
 				var ts time.Time
 				if job.Metadata.CreatedAt != "" {
@@ -365,20 +467,21 @@ func CompleteJobNames(cmd *cobra.Command, args []string, toComplete string) ([]s
 					descParts = append(descParts, string(job.Status))
 				}
 				desc := strings.Join(descParts, " ")
-				filtered = append(filtered, resourceWithTime{name: job.Metadata.Name, desc: desc, timestamp: ts})
+				filtered = append(filtered, resourceWithTime{name: job.Metadata.Name, desc: desc, timestamp: ts, score: score})
 			}
 		}
 	}
 
-	// Sort by timestamp descending (most recent first)
+	// Sort by match quality, then by timestamp descending (most recent first)
 	sort.Slice(filtered, func(i, j int) bool {
+		if filtered[i].score != filtered[j].score {
+			return filtered[i].score > filtered[j].score
+		}
 		return filtered[i].timestamp.After(filtered[j].timestamp)
 	})
 
-	// Limit to 20 most recent
-	if len(filtered) > 20 {
-		filtered = filtered[:20]
-	}
+	// Limit to the most recent ones to avoid cluttered display
+	filtered, hidden := limitCompletions(filtered)
 
 	// Build completion strings with rank
 	var completions []string
@@ -391,6 +494,7 @@ func CompleteJobNames(cmd *cobra.Command, args []string, toComplete string) ([]s
 		}
 	}
 
+	completions = appendCompletionLimitHint(completions, hidden)
 	return completions, cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveKeepOrder
 }
 
@@ -453,22 +557,26 @@ func CompleteJobExecutionIDs(jobName string, toComplete string) ([]string, cobra
 		return filtered[i].timestamp.After(filtered[j].timestamp)
 	})
 
-	// Limit to 20 most recent to avoid cluttered display
-	if len(filtered) > 20 {
-		filtered = filtered[:20]
-	}
+	// Limit to the most recent ones to avoid cluttered display
+	filtered, hidden := limitCompletions(filtered)
 
 	// Build completion strings with rank number to show order even if shell sorts alphabetically
 	var completions []string
 	width := len(fmt.Sprintf("%d", len(filtered))) // Calculate padding width
 	for i, e := range filtered {
-		if e.desc != "" {
-			completions = append(completions, e.id+"\t"+fmt.Sprintf("#%0*d %s", width, i+1, e.desc))
+		desc := e.desc
+		// Flag the most recent one, usually the execution just started by bl run job
+		if i == 0 && toComplete == "" {
+			desc = strings.TrimSpace("latest " + desc)
+		}
+		if desc != "" {
+			completions = append(completions, e.id+"\t"+fmt.Sprintf("#%0*d %s", width, i+1, desc))
 		} else {
 			completions = append(completions, e.id)
 		}
 	}
 
+	completions = appendCompletionLimitHint(completions, hidden)
 	return completions, cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveKeepOrder
 }
 
@@ -562,12 +670,13 @@ func CompleteAgentNames(cmd *cobra.Command, args []string, toComplete string) ([
 		name      string
 		desc      string
 		timestamp time.Time
+		score     int
 	}
 	var filtered []resourceWithTime
 
 	for _, agent := range agents.Data {
 		if agent.Metadata.Name != "" {
-			if toComplete == "" || strings.HasPrefix(agent.Metadata.Name, toComplete) {
+			if score := completionMatch(agent.Metadata.Name, toComplete); score > 0 {
 				var descParts []string
 				var ts time.Time
 				if agent.Metadata.CreatedAt != "" {
@@ -580,20 +689,21 @@ func CompleteAgentNames(cmd *cobra.Command, args []string, toComplete string) ([
 					descParts = append(descParts, string(agent.Status))
 				}
 				desc := strings.Join(descParts, " ")
-				filtered = append(filtered, resourceWithTime{name: agent.Metadata.Name, desc: desc, timestamp: ts})
+				filtered = append(filtered, resourceWithTime{name: agent.Metadata.Name, desc: desc, timestamp: ts, score: score})
 			}
 		}
 	}
 
-	// Sort by timestamp descending (most recent first)
+	// Sort by match quality, then by timestamp descending (most recent first)
 	sort.Slice(filtered, func(i, j int) bool {
+		if filtered[i].score != filtered[j].score {
+			return filtered[i].score > filtered[j].score
+		}
 		return filtered[i].timestamp.After(filtered[j].timestamp)
 	})
 
-	// Limit to 20 most recent
-	if len(filtered) > 20 {
-		filtered = filtered[:20]
-	}
+	// Limit to the most recent ones to avoid cluttered display
+	filtered, hidden := limitCompletions(filtered)
 
 	// Build completion strings with rank
 	var completions []string
@@ -606,6 +716,7 @@ func CompleteAgentNames(cmd *cobra.Command, args []string, toComplete string) ([
 		}
 	}
 
+	completions = appendCompletionLimitHint(completions, hidden)
 	return completions, cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveKeepOrder
 }
 
@@ -627,12 +738,13 @@ func CompleteFunctionNames(cmd *cobra.Command, args []string, toComplete string)
 		name      string
 		desc      string
 		timestamp time.Time
+		score     int
 	}
 	var filtered []resourceWithTime
 
 	for _, fn := range functions.Data {
 		if fn.Metadata.Name != "" {
-			if toComplete == "" || strings.HasPrefix(fn.Metadata.Name, toComplete) {
+			if score := completionMatch(fn.Metadata.Name, toComplete); score > 0 {
 				var descParts []string
 				var ts time.Time
 				if fn.Metadata.CreatedAt != "" {
@@ -645,20 +757,21 @@ func CompleteFunctionNames(cmd *cobra.Command, args []string, toComplete string)
 					descParts = append(descParts, string(fn.Status))
 				}
 				desc := strings.Join(descParts, " ")
-				filtered = append(filtered, resourceWithTime{name: fn.Metadata.Name, desc: desc, timestamp: ts})
+				filtered = append(filtered, resourceWithTime{name: fn.Metadata.Name, desc: desc, timestamp: ts, score: score})
 			}
 		}
 	}
 
-	// Sort by timestamp descending (most recent first)
+	// Sort by match quality, then by timestamp descending (most recent first)
 	sort.Slice(filtered, func(i, j int) bool {
+		if filtered[i].score != filtered[j].score {
+			return filtered[i].score > filtered[j].score
+		}
 		return filtered[i].timestamp.After(filtered[j].timestamp)
 	})
 
-	// Limit to 20 most recent
-	if len(filtered) > 20 {
-		filtered = filtered[:20]
-	}
+	// Limit to the most recent ones to avoid cluttered display
+	filtered, hidden := limitCompletions(filtered)
 
 	// Build completion strings with rank
 	var completions []string
@@ -671,6 +784,7 @@ func CompleteFunctionNames(cmd *cobra.Command, args []string, toComplete string)
 		}
 	}
 
+	completions = appendCompletionLimitHint(completions, hidden)
 	return completions, cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveKeepOrder
 }
 
@@ -692,12 +806,13 @@ func CompleteModelNames(cmd *cobra.Command, args []string, toComplete string) ([
 		name      string
 		desc      string
 		timestamp time.Time
+		score     int
 	}
 	var filtered []resourceWithTime
 
 	for _, model := range models.Data {
 		if model.Metadata.Name != "" {
-			if toComplete == "" || strings.HasPrefix(model.Metadata.Name, toComplete) {
+			if score := completionMatch(model.Metadata.Name, toComplete); score > 0 {
 				var descParts []string
 				var ts time.Time
 				if model.Metadata.CreatedAt != "" {
@@ -710,20 +825,21 @@ func CompleteModelNames(cmd *cobra.Command, args []string, toComplete string) ([
 					descParts = append(descParts, string(model.Status))
 				}
 				desc := strings.Join(descParts, " ")
-				filtered = append(filtered, resourceWithTime{name: model.Metadata.Name, desc: desc, timestamp: ts})
+				filtered = append(filtered, resourceWithTime{name: model.Metadata.Name, desc: desc, timestamp: ts, score: score})
 			}
 		}
 	}
 
-	// Sort by timestamp descending (most recent first)
+	// Sort by match quality, then by timestamp descending (most recent first)
 	sort.Slice(filtered, func(i, j int) bool {
+		if filtered[i].score != filtered[j].score {
+			return filtered[i].score > filtered[j].score
+		}
 		return filtered[i].timestamp.After(filtered[j].timestamp)
 	})
 
-	// Limit to 20 most recent
-	if len(filtered) > 20 {
-		filtered = filtered[:20]
-	}
+	// Limit to the most recent ones to avoid cluttered display
+	filtered, hidden := limitCompletions(filtered)
 
 	// Build completion strings with rank
 	var completions []string
@@ -736,6 +852,7 @@ func CompleteModelNames(cmd *cobra.Command, args []string, toComplete string) ([
 		}
 	}
 
+	completions = appendCompletionLimitHint(completions, hidden)
 	return completions, cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveKeepOrder
 }
 
@@ -757,12 +874,13 @@ func CompleteVolumeNames(cmd *cobra.Command, args []string, toComplete string) (
 		name      string
 		desc      string
 		timestamp time.Time
+		score     int
 	}
 	var filtered []resourceWithTime
 
 	for _, vol := range volumes.Data {
 		if vol.Metadata.Name != "" {
-			if toComplete == "" || strings.HasPrefix(vol.Metadata.Name, toComplete) {
+			if score := completionMatch(vol.Metadata.Name, toComplete); score > 0 {
 				var descParts []string
 				var ts time.Time
 				if vol.Metadata.CreatedAt != "" {
@@ -772,20 +890,21 @@ func CompleteVolumeNames(cmd *cobra.Command, args []string, toComplete string) (
 					}
 				}
 				desc := strings.Join(descParts, " ")
-				filtered = append(filtered, resourceWithTime{name: vol.Metadata.Name, desc: desc, timestamp: ts})
+				filtered = append(filtered, resourceWithTime{name: vol.Metadata.Name, desc: desc, timestamp: ts, score: score})
 			}
 		}
 	}
 
-	// Sort by timestamp descending (most recent first)
+	// Sort by match quality, then by timestamp descending (most recent first)
 	sort.Slice(filtered, func(i, j int) bool {
+		if filtered[i].score != filtered[j].score {
+			return filtered[i].score > filtered[j].score
+		}
 		return filtered[i].timestamp.After(filtered[j].timestamp)
 	})
 
-	// Limit to 20 most recent
-	if len(filtered) > 20 {
-		filtered = filtered[:20]
-	}
+	// Limit to the most recent ones to avoid cluttered display
+	filtered, hidden := limitCompletions(filtered)
 
 	// Build completion strings with rank
 	var completions []string
@@ -798,6 +917,7 @@ func CompleteVolumeNames(cmd *cobra.Command, args []string, toComplete string) (
 		}
 	}
 
+	completions = appendCompletionLimitHint(completions, hidden)
 	return completions, cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveKeepOrder
 }
 
@@ -819,12 +939,13 @@ func CompletePolicyNames(cmd *cobra.Command, args []string, toComplete string) (
 		name      string
 		desc      string
 		timestamp time.Time
+		score     int
 	}
 	var filtered []resourceWithTime
 
 	for _, pol := range policies.Data {
 		if pol.Metadata.Name != "" {
-			if toComplete == "" || strings.HasPrefix(pol.Metadata.Name, toComplete) {
+			if score := completionMatch(pol.Metadata.Name, toComplete); score > 0 {
 				var descParts []string
 				var ts time.Time
 				if pol.Metadata.CreatedAt != "" {
@@ -834,20 +955,21 @@ func CompletePolicyNames(cmd *cobra.Command, args []string, toComplete string) (
 					}
 				}
 				desc := strings.Join(descParts, " ")
-				filtered = append(filtered, resourceWithTime{name: pol.Metadata.Name, desc: desc, timestamp: ts})
+				filtered = append(filtered, resourceWithTime{name: pol.Metadata.Name, desc: desc, timestamp: ts, score: score})
 			}
 		}
 	}
 
-	// Sort by timestamp descending (most recent first)
+	// Sort by match quality, then by timestamp descending (most recent first)
 	sort.Slice(filtered, func(i, j int) bool {
+		if filtered[i].score != filtered[j].score {
+			return filtered[i].score > filtered[j].score
+		}
 		return filtered[i].timestamp.After(filtered[j].timestamp)
 	})
 
-	// Limit to 20 most recent
-	if len(filtered) > 20 {
-		filtered = filtered[:20]
-	}
+	// Limit to the most recent ones to avoid cluttered display
+	filtered, hidden := limitCompletions(filtered)
 
 	// Build completion strings with rank
 	var completions []string
@@ -860,6 +982,7 @@ func CompletePolicyNames(cmd *cobra.Command, args []string, toComplete string) (
 		}
 	}
 
+	completions = appendCompletionLimitHint(completions, hidden)
 	return completions, cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveKeepOrder
 }
 
@@ -1280,6 +1403,39 @@ func GetRunValidArgsFunction() func(cmd *cobra.Command, args []string, toComplet
 	}
 }
 
+// verifyResourceTypesWithDesc are the valid resource types for verify command with descriptions
+var verifyResourceTypesWithDesc = []struct {
+	name string
+	desc string
+}{
+	{"agent", "AI agent application"},
+	{"function", "MCP server / function"},
+	{"sandbox", "Isolated execution environment"},
+	{"job", "Batch processing task"},
+}
+
+// GetVerifyValidArgsFunction returns a ValidArgsFunction for the verify command
+// It handles completions for:
+// - resource types (first arg)
+// - resource names (second arg)
+func GetVerifyValidArgsFunction() func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			var completions []string
+			for _, rt := range verifyResourceTypesWithDesc {
+				if toComplete == "" || strings.HasPrefix(rt.name, toComplete) {
+					completions = append(completions, rt.name+"\t"+rt.desc)
+				}
+			}
+			return completions, cobra.ShellCompDirectiveNoFileComp
+		}
+		if len(args) == 1 {
+			return GetRunValidArgsFunction()(cmd, args, toComplete)
+		}
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
 // newResourceTypesWithDesc are the valid resource types for new command with descriptions
 var newResourceTypesWithDesc = []struct {
 	name string
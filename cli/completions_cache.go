@@ -0,0 +1,182 @@
+package cli
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/blaxel-ai/sdk-go/option"
+)
+
+// completionCacheTTL is how long a completion API response is reused without
+// asking the API again, long enough for a double Tab
+const completionCacheTTL = 10 * time.Second
+
+// completionCacheMaxStale is how long an expired response is still used when
+// the API cannot be reached in time
+const completionCacheMaxStale = 5 * time.Minute
+
+// completionCacheNow returns the current time, replaced in tests
+var completionCacheNow = time.Now
+
+// completionCacheEntry is a cached API response
+type completionCacheEntry struct {
+	StoredAt    time.Time `json:"stored_at"`
+	ContentType string    `json:"content_type"`
+	Body        []byte    `json:"body"`
+}
+
+// completionCacheEnabled reports whether completions may use the cache,
+// BL_NO_COMPLETION_CACHE=1 disables it to debug completions
+func completionCacheEnabled() bool {
+	disabled, _ := strconv.ParseBool(os.Getenv("BL_NO_COMPLETION_CACHE"))
+	return !disabled
+}
+
+// completionCachePath returns the file caching the response of a GET request
+// to url in workspace, or "" when there is no home directory
+func completionCachePath(workspace, url string) string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(workspace + " " + url))
+	return filepath.Join(home, ".blaxel", "cache", "completions", hex.EncodeToString(sum[:])+".json")
+}
+
+// completionCacheMiddleware serves the GET requests of completions from an
+// on-disk cache for completionCacheTTL, so rapid tabbing does not call the API
+// every time. Once expired, an entry is refreshed, or used for up to
+// completionCacheMaxStale more if the API fails.
+func completionCacheMiddleware(workspace string) option.Middleware {
+	return func(req *http.Request, next option.MiddlewareNext) (*http.Response, error) {
+		if req.Method != http.MethodGet {
+			return next(req)
+		}
+		path := completionCachePath(workspace, req.URL.String())
+		if path == "" {
+			return next(req)
+		}
+
+		entry, cached := readCompletionCache(path)
+		age := completionCacheNow().Sub(entry.StoredAt)
+		if cached && age < completionCacheTTL {
+			return entry.response(req), nil
+		}
+
+		res, err := next(req)
+		if err != nil || res.StatusCode >= 500 {
+			if cached && age < completionCacheTTL+completionCacheMaxStale {
+				if res != nil {
+					_ = res.Body.Close()
+				}
+				return entry.response(req), nil
+			}
+			return res, err
+		}
+		if res.StatusCode != http.StatusOK {
+			return res, nil
+		}
+
+		body, err := io.ReadAll(res.Body)
+		_ = res.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		writeCompletionCache(path, completionCacheEntry{
+			StoredAt:    completionCacheNow(),
+			ContentType: res.Header.Get("Content-Type"),
+			Body:        body,
+		})
+		res.Body = io.NopCloser(bytes.NewReader(body))
+		return res, nil
+	}
+}
+
+// response rebuilds the HTTP response of a cache entry
+func (e completionCacheEntry) response(req *http.Request) *http.Response {
+	header := http.Header{}
+	if e.ContentType != "" {
+		header.Set("Content-Type", e.ContentType)
+	}
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(e.Body)),
+		ContentLength: int64(len(e.Body)),
+		Request:       req,
+	}
+}
+
+func readCompletionCache(path string) (completionCacheEntry, bool) {
+	var entry completionCacheEntry
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return entry, false
+	}
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return entry, false
+	}
+	return entry, true
+}
+
+// writeCompletionCache stores an entry, errors are ignored since the cache is
+// only an optimization
+func writeCompletionCache(path string, entry completionCacheEntry) {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return
+	}
+	// Write then rename so a completion running in parallel never reads half a file
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return
+	}
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(tmp.Name())
+		return
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		_ = os.Remove(tmp.Name())
+	}
+	pruneCompletionCache(filepath.Dir(path))
+}
+
+// pruneCompletionCache removes the files of dir that are too old to be used
+// even when the API fails, so the cache does not grow with every workspace and
+// URL ever completed. Errors are ignored like for writes.
+func pruneCompletionCache(dir string) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return
+	}
+	cutoff := completionCacheNow().Add(-(completionCacheTTL + completionCacheMaxStale))
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil || !info.ModTime().Before(cutoff) {
+			continue
+		}
+		_ = os.Remove(filepath.Join(dir, entry.Name()))
+	}
+}
@@ -0,0 +1,125 @@
+package cli
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompletionCacheMiddleware(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	completionCacheNow = func() time.Time { return now }
+	t.Cleanup(func() { completionCacheNow = time.Now })
+
+	calls := 0
+	var failure error
+	next := func(req *http.Request) (*http.Response, error) {
+		calls++
+		if failure != nil {
+			return nil, failure
+		}
+		rec := httptest.NewRecorder()
+		rec.Header().Set("Content-Type", "application/json")
+		rec.WriteHeader(http.StatusOK)
+		_, _ = rec.WriteString(`[{"name": "agent-` + string(rune('0'+calls)) + `"}]`)
+		return rec.Result(), nil
+	}
+	get := func(workspace, url string) string {
+		req := httptest.NewRequest(http.MethodGet, url, nil)
+		res, err := completionCacheMiddleware(workspace)(req, next)
+		require.NoError(t, err)
+		defer func() { _ = res.Body.Close() }()
+		assert.Equal(t, "application/json", res.Header.Get("Content-Type"))
+		body, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		return string(body)
+	}
+
+	assert.Contains(t, get("ws", "https://api.blaxel.ai/v0/agents"), "agent-1")
+	assert.Contains(t, get("ws", "https://api.blaxel.ai/v0/agents"), "agent-1", "served from the cache")
+	assert.Equal(t, 1, calls)
+
+	// Keyed by workspace and URL
+	assert.Contains(t, get("other", "https://api.blaxel.ai/v0/agents"), "agent-2")
+	assert.Contains(t, get("ws", "https://api.blaxel.ai/v0/functions"), "agent-3")
+	assert.Equal(t, 3, calls)
+
+	// Refreshed once expired
+	now = now.Add(completionCacheTTL)
+	assert.Contains(t, get("ws", "https://api.blaxel.ai/v0/agents"), "agent-4")
+
+	// Expired entries are still used when the API fails
+	now = now.Add(completionCacheTTL)
+	failure = errors.New("context deadline exceeded")
+	assert.Contains(t, get("ws", "https://api.blaxel.ai/v0/agents"), "agent-4")
+
+	// But not forever
+	now = now.Add(completionCacheMaxStale)
+	req := httptest.NewRequest(http.MethodGet, "https://api.blaxel.ai/v0/agents", nil)
+	_, err := completionCacheMiddleware("ws")(req, next)
+	assert.ErrorIs(t, err, failure)
+}
+
+func TestCompletionCacheMiddlewareSkipsOtherRequests(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	calls := 0
+	status := http.StatusOK
+	next := func(req *http.Request) (*http.Response, error) {
+		calls++
+		rec := httptest.NewRecorder()
+		rec.WriteHeader(status)
+		return rec.Result(), nil
+	}
+	middleware := completionCacheMiddleware("ws")
+
+	for i := 0; i < 2; i++ {
+		_, err := middleware(httptest.NewRequest(http.MethodPost, "https://api.blaxel.ai/v0/agents", strings.NewReader("{}")), next)
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 2, calls, "only GET requests are cached")
+
+	status = http.StatusNotFound
+	for i := 0; i < 2; i++ {
+		res, err := middleware(httptest.NewRequest(http.MethodGet, "https://api.blaxel.ai/v0/agents/missing", nil), next)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, res.StatusCode)
+	}
+	assert.Equal(t, 4, calls, "errors are not cached")
+}
+
+func TestPruneCompletionCache(t *testing.T) {
+	dir := t.TempDir()
+	old := filepath.Join(dir, "old.json")
+	stale := filepath.Join(dir, "stale.json")
+	recent := filepath.Join(dir, "recent.json")
+	for _, path := range []string{old, stale, recent} {
+		require.NoError(t, os.WriteFile(path, []byte("{}"), 0600))
+	}
+	now := time.Now()
+	require.NoError(t, os.Chtimes(old, now.Add(-time.Hour), now.Add(-time.Hour)))
+	// Still usable when the API fails
+	require.NoError(t, os.Chtimes(stale, now.Add(-completionCacheMaxStale/2), now.Add(-completionCacheMaxStale/2)))
+
+	pruneCompletionCache(dir)
+
+	assert.NoFileExists(t, old)
+	assert.FileExists(t, stale)
+	assert.FileExists(t, recent)
+}
+
+func TestCompletionCacheEnabled(t *testing.T) {
+	t.Setenv("BL_NO_COMPLETION_CACHE", "")
+	assert.True(t, completionCacheEnabled())
+	t.Setenv("BL_NO_COMPLETION_CACHE", "1")
+	assert.False(t, completionCacheEnabled())
+}
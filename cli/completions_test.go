@@ -0,0 +1,58 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLimitCompletions(t *testing.T) {
+	items := make([]int, 25)
+	for i := range items {
+		items[i] = i
+	}
+
+	t.Setenv("BL_COMPLETION_LIMIT", "")
+	kept, hidden := limitCompletions(items)
+	assert.Len(t, kept, defaultCompletionLimit)
+	assert.Equal(t, 5, hidden)
+
+	t.Setenv("BL_COMPLETION_LIMIT", "10")
+	kept, hidden = limitCompletions(items)
+	assert.Equal(t, items[:10], kept)
+	assert.Equal(t, 15, hidden)
+
+	t.Setenv("BL_COMPLETION_LIMIT", "0")
+	kept, hidden = limitCompletions(items)
+	assert.Len(t, kept, 25)
+	assert.Equal(t, 0, hidden)
+
+	t.Setenv("BL_COMPLETION_LIMIT", "lots")
+	assert.Equal(t, defaultCompletionLimit, completionLimit())
+}
+
+func TestAppendCompletionLimitHint(t *testing.T) {
+	completions := []string{"a", "b"}
+	assert.Equal(t, completions, appendCompletionLimitHint(completions, 0))
+
+	withHint := appendCompletionLimitHint(completions, 3)
+	assert.Len(t, withHint, 3)
+	assert.Equal(t, cobra.AppendActiveHelp(nil, "3 older not shown, type more characters or raise BL_COMPLETION_LIMIT (0 for all)")[0], withHint[2])
+}
+
+func TestCompletionMatch(t *testing.T) {
+	t.Setenv("BL_COMPLETION_FUZZY", "")
+	assert.Equal(t, 4, completionMatch("my-payments-agent", ""))
+	assert.Equal(t, 4, completionMatch("my-payments-agent", "my-pay"))
+	assert.Equal(t, 0, completionMatch("my-payments-agent", "pay"), "prefix only by default")
+
+	t.Setenv("BL_COMPLETION_FUZZY", "1")
+	assert.Equal(t, 4, completionMatch("my-payments-agent", "My-Pay"))
+	assert.Equal(t, 3, completionMatch("my-payments-agent", "pay"))
+	assert.Equal(t, 3, completionMatch("my-payments-agent", "agent"))
+	assert.Equal(t, 2, completionMatch("my-payments-agent", "ments"))
+	assert.Equal(t, 1, completionMatch("my-payments-agent", "mpa"))
+	assert.Equal(t, 0, completionMatch("my-payments-agent", "billing"))
+	assert.Equal(t, 0, completionMatch("my-payments-agent", "apm"), "characters must appear in order")
+}
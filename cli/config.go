@@ -0,0 +1,211 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/blaxel-ai/toolkit/cli/core"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+func init() {
+	core.RegisterCommand("config", func() *cobra.Command {
+		return ConfigCmd()
+	})
+}
+
+func ConfigCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Inspect the blaxel.toml project configuration",
+		Long: `Inspect the blaxel.toml configuration of the current project.
+
+Values from an optional blaxel.local.toml, placed next to blaxel.toml,
+override the committed configuration. Use it for machine-specific settings
+(local ports, env) and keep it out of version control.`,
+	}
+
+	cmd.AddCommand(ConfigValidateCmd())
+	return cmd
+}
+
+// configValidateResult is the structured output of `bl config validate`
+type configValidateResult struct {
+	Valid          bool               `json:"valid" yaml:"valid"`
+	File           string             `json:"file" yaml:"file"`
+	LocalFile      string             `json:"localFile,omitempty" yaml:"localFile,omitempty"`
+	LocalOverrides []string           `json:"localOverrides" yaml:"localOverrides"`
+	Warning        string             `json:"warning,omitempty" yaml:"warning,omitempty"`
+	Issues         []core.ConfigIssue `json:"issues" yaml:"issues"`
+	Fixed          []core.ConfigIssue `json:"fixed,omitempty" yaml:"fixed,omitempty"`
+}
+
+func ConfigValidateCmd() *cobra.Command {
+	var folder string
+	var fix bool
+
+	cmd := &cobra.Command{
+		Use:   "validate",
+		Short: "Validate blaxel.toml and show local overrides",
+		Long: `Validate the blaxel.toml of the current project.
+
+Parses blaxel.toml, merges blaxel.local.toml on top of it when present,
+and reports any configuration error along with the keys whose values
+came from the local file.
+
+It also detects common mistakes: an unsupported type (the closest supported
+one is suggested), a volume-template without defaultSize, ports without a
+protocol, timeouts written as a bare number of seconds and a target in
+[build], which the build service does not support yet. Use --fix to rewrite
+blaxel.toml with the safe corrections applied.
+
+With --strict (or BL_STRICT=1), any detected mistake makes the command exit
+with a non-zero code, so CI can enforce a clean configuration.`,
+		Example: `  # Validate the configuration in the current directory
+  bl config validate
+
+  # Validate a project in another directory
+  bl config validate -d ./my-agent
+
+  # Apply safe corrections to blaxel.toml
+  bl config validate --fix
+
+  # Machine-readable result
+  bl config validate -o json
+
+  # Fail in CI on any detected mistake
+  bl config validate --strict`,
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			result, err := validateConfig(folder, fix)
+			if err != nil {
+				core.PrintError("Config validate", err)
+				core.ExitWithError(err)
+			}
+
+			outputFmt := core.GetOutputFormat()
+			switch outputFmt {
+			case "json":
+				data, _ := json.MarshalIndent(result, "", "  ")
+				fmt.Println(string(data))
+			case "yaml":
+				data, _ := yaml.Marshal(result)
+				fmt.Print(string(data))
+			default:
+				printConfigValidateResult(result)
+			}
+
+			if !result.Valid {
+				core.ExitWithError(fmt.Errorf("invalid configuration in %s", result.File))
+			}
+			if core.IsStrict() && len(result.Issues) > 0 {
+				err := core.StrictError(fmt.Sprintf("%d issue(s) found in %s", len(result.Issues), result.File))
+				core.PrintError("Config validate", err)
+				core.ExitWithError(err)
+			}
+		},
+	}
+
+	cmd.Flags().StringVarP(&folder, "directory", "d", "", "Directory containing blaxel.toml")
+	cmd.Flags().BoolVar(&fix, "fix", false, "Rewrite blaxel.toml applying safe corrections for detected mistakes")
+	return cmd
+}
+
+// validateConfig reads blaxel.toml (and blaxel.local.toml) from folder and
+// reports whether it parsed, which keys were overridden locally and which
+// common mistakes it contains. With fix, fixable mistakes are corrected in place.
+func validateConfig(folder string, fix bool) (configValidateResult, error) {
+	// core.ReadConfigToml resolves the folder relative to the working directory
+	if filepath.IsAbs(folder) {
+		if cwd, err := os.Getwd(); err == nil {
+			if rel, err := filepath.Rel(cwd, folder); err == nil {
+				folder = rel
+			}
+		}
+	}
+
+	file := filepath.Join(folder, "blaxel.toml")
+	if _, err := os.Stat(file); err != nil {
+		return configValidateResult{}, fmt.Errorf("no blaxel.toml found in %s", filepath.Clean(filepath.Join(".", folder)))
+	}
+
+	core.ResetConfig()
+	core.ClearBlaxelTomlWarning()
+	core.ReadConfigToml(folder, false)
+
+	result := configValidateResult{
+		Valid:          true,
+		File:           file,
+		LocalOverrides: []string{},
+		Warning:        core.GetBlaxelTomlWarning(),
+		Issues:         []core.ConfigIssue{},
+	}
+	if result.Warning != "" {
+		result.Valid = false
+		core.ClearBlaxelTomlWarning()
+	}
+
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return configValidateResult{}, fmt.Errorf("failed to read %s: %w", file, err)
+	}
+	issues := core.LintConfigToml(content)
+
+	// Only rewrite a file that parses, so a fix never hides a syntax error
+	if fix && result.Valid {
+		for _, issue := range issues {
+			if issue.Fix != "" {
+				result.Fixed = append(result.Fixed, issue)
+			}
+		}
+		if len(result.Fixed) > 0 {
+			content = core.FixConfigToml(content, issues)
+			if err := os.WriteFile(file, content, 0644); err != nil {
+				return configValidateResult{}, fmt.Errorf("failed to write %s: %w", file, err)
+			}
+			core.ResetConfig()
+			core.ReadConfigToml(folder, false)
+			issues = core.LintConfigToml(content)
+		}
+	}
+	result.Issues = append(result.Issues, issues...)
+
+	localFile := filepath.Join(folder, core.LocalConfigFileName)
+	if _, err := os.Stat(localFile); err == nil {
+		result.LocalFile = localFile
+		result.LocalOverrides = append(result.LocalOverrides, core.GetLocalConfigOverrides()...)
+	}
+	return result, nil
+}
+
+func printConfigValidateResult(result configValidateResult) {
+	if !result.Valid {
+		fmt.Println(result.Warning)
+		return
+	}
+	for _, issue := range result.Fixed {
+		core.PrintSuccess(fmt.Sprintf("Fixed %s (line %d): %s", issue.Key, issue.Line, issue.Fix))
+	}
+	for _, issue := range result.Issues {
+		message := fmt.Sprintf("%s (line %d): %s", issue.Key, issue.Line, issue.Message)
+		if issue.Fix != "" {
+			message += fmt.Sprintf(", %s (run with --fix to apply)", issue.Fix)
+		}
+		core.PrintWarning(message)
+	}
+	core.PrintSuccess(fmt.Sprintf("%s is valid", result.File))
+	if result.LocalFile == "" {
+		return
+	}
+	if len(result.LocalOverrides) == 0 {
+		core.PrintInfo(fmt.Sprintf("%s found but it does not override any value", result.LocalFile))
+		return
+	}
+	core.PrintInfo(fmt.Sprintf("Values overridden by %s:", result.LocalFile))
+	for _, key := range result.LocalOverrides {
+		fmt.Printf("  - %s\n", key)
+	}
+}
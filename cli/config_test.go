@@ -0,0 +1,62 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/blaxel-ai/toolkit/cli/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigCmd(t *testing.T) {
+	cmd := ConfigCmd()
+	assert.Equal(t, "config", cmd.Use)
+
+	validateCmd, _, err := cmd.Find([]string{"validate"})
+	require.NoError(t, err)
+	assert.Equal(t, "validate", validateCmd.Use)
+	assert.NotNil(t, validateCmd.Flags().Lookup("directory"))
+}
+
+func TestValidateConfigLocalOverrides(t *testing.T) {
+	defer core.ResetConfig()
+
+	tempDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "blaxel.toml"), []byte("name = \"my-agent\"\nport = 8080\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, core.LocalConfigFileName), []byte("port = 9090\n"), 0644))
+
+	result, err := validateConfig(tempDir, false)
+	require.NoError(t, err)
+	assert.True(t, result.Valid)
+	assert.Equal(t, core.LocalConfigFileName, filepath.Base(result.LocalFile))
+	assert.Equal(t, []string{"port"}, result.LocalOverrides)
+}
+
+func TestValidateConfigMissingFile(t *testing.T) {
+	_, err := validateConfig(t.TempDir(), false)
+	assert.Error(t, err)
+}
+
+func TestValidateConfigFix(t *testing.T) {
+	defer core.ResetConfig()
+
+	tempDir := t.TempDir()
+	file := filepath.Join(tempDir, "blaxel.toml")
+	require.NoError(t, os.WriteFile(file, []byte("type = \"agnet\"\n\n[runtime]\ntimeout = 60\n"), 0644))
+
+	result, err := validateConfig(tempDir, false)
+	require.NoError(t, err)
+	assert.True(t, result.Valid)
+	assert.Len(t, result.Issues, 2)
+
+	result, err = validateConfig(tempDir, true)
+	require.NoError(t, err)
+	assert.Len(t, result.Fixed, 2)
+	assert.Empty(t, result.Issues)
+
+	content, err := os.ReadFile(file)
+	require.NoError(t, err)
+	assert.Equal(t, "type = \"agent\"\n\n[runtime]\ntimeout = \"1m\"\n", string(content))
+}
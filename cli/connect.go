@@ -2,9 +2,13 @@ package cli
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"fmt"
+	"io"
 	"os"
 	"strings"
+	"time"
 
 	blaxel "github.com/blaxel-ai/sdk-go"
 	"github.com/blaxel-ai/toolkit/cli/connect"
@@ -31,6 +35,8 @@ func ConnectCmd() *cobra.Command {
 }
 
 func ConnectSandboxCmd() *cobra.Command {
+	var execFile string
+
 	cmd := &cobra.Command{
 		Use:               "sandbox [sandbox-name]",
 		Aliases:           []string{"sb", "sbx"},
@@ -43,10 +49,21 @@ The terminal supports full ANSI colors, cursor movement, and interactive applica
 
 Press Ctrl+D to disconnect from the sandbox.
 
+To run a local script non-interactively instead, pass it with --exec-file
+or pipe it on stdin. The script is uploaded to a temporary path in the
+sandbox, executed there, and its output is streamed back. The command exits
+with the script's exit code, which makes it usable in CI.
+
 Examples:
   bl connect sandbox my-sandbox
   bl connect sb my-sandbox
-  bl connect sbx production-env`,
+  bl connect sbx production-env
+
+  # Run a local script in the sandbox
+  bl connect sandbox my-sandbox --exec-file ./setup.sh
+
+  # Pipe a script on stdin
+  cat setup.sh | bl connect sandbox my-sandbox`,
 		Args: cobra.ExactArgs(1),
 		Run: func(cmd *cobra.Command, args []string) {
 			sandboxName := args[0]
@@ -56,11 +73,19 @@ Examples:
 				ctx = context.Background()
 			}
 
-			// Check if stdin is a terminal
-			if !term.IsTerminal(int(os.Stdin.Fd())) {
-				err := fmt.Errorf("this command requires an interactive terminal")
-				core.PrintError("Connect", err)
-				core.ExitWithError(err)
+			// Non-interactive mode: run a script from --exec-file or piped stdin
+			if execFile != "" || !term.IsTerminal(int(os.Stdin.Fd())) {
+				script, err := readExecScript(execFile, os.Stdin)
+				if err != nil {
+					core.PrintError("Connect", err)
+					core.ExitWithError(err)
+				}
+				exitCode, err := runSandboxScript(ctx, sandboxName, script)
+				if err != nil {
+					core.PrintError("Connect", err)
+					core.ExitWithError(err)
+				}
+				core.Exit(exitCode)
 			}
 
 			// Get the current workspace
@@ -152,5 +177,90 @@ Examples:
 		},
 	}
 
+	cmd.Flags().StringVar(&execFile, "exec-file", "", "Run a local script in the sandbox non-interactively (use - for stdin)")
+
 	return cmd
 }
+
+// readExecScript reads the script to execute from path, or from stdin when
+// path is empty or "-".
+func readExecScript(path string, stdin io.Reader) (string, error) {
+	var content []byte
+	var err error
+	if path == "" || path == "-" {
+		content, err = io.ReadAll(stdin)
+	} else {
+		content, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read script: %w", err)
+	}
+	if strings.TrimSpace(string(content)) == "" {
+		return "", fmt.Errorf("script is empty")
+	}
+	return string(content), nil
+}
+
+// runSandboxScript uploads script to a temporary path in the sandbox, runs it,
+// streams its output and returns the remote exit code.
+func runSandboxScript(ctx context.Context, sandboxName string, script string) (int, error) {
+	client := core.GetClient()
+	sandboxInstance, err := client.Sandboxes.GetInstance(ctx, sandboxName)
+	if err != nil {
+		return 1, fmt.Errorf("failed to get sandbox instance '%s': %w", sandboxName, err)
+	}
+
+	suffix := make([]byte, 6)
+	_, _ = rand.Read(suffix)
+	processName := "bl-exec-" + hex.EncodeToString(suffix)
+	scriptPath := "/tmp/" + processName + ".sh"
+
+	if _, err := sandboxInstance.FS.Write(ctx, scriptPath, script); err != nil {
+		return 1, fmt.Errorf("failed to upload script to '%s': %w", scriptPath, err)
+	}
+
+	_, err = sandboxInstance.Process.New(ctx, blaxel.ProcessRequestParam{
+		Command: fmt.Sprintf("sh %s; status=$?; rm -f %s; exit $status", scriptPath, scriptPath),
+		Name:    blaxel.String(processName),
+	})
+	if err != nil {
+		return 1, fmt.Errorf("failed to execute script: %w", err)
+	}
+
+	stream := sandboxInstance.Process.StreamLogs(ctx, processName, blaxel.ProcessStreamOptions{
+		OnStdout: func(stdout string) {
+			fmt.Print(stdout)
+		},
+		OnStderr: func(stderr string) {
+			fmt.Fprint(os.Stderr, stderr)
+		},
+		OnError: func(err error) {
+			core.PrintWarning(fmt.Sprintf("Log stream error: %v", err))
+		},
+	})
+
+	process, err := sandboxInstance.Process.Wait(ctx, processName, 24*time.Hour, time.Second)
+	if err != nil {
+		stream.Close()
+		return 1, fmt.Errorf("failed waiting for script: %w", err)
+	}
+	// The stream ends on its own once the process has exited; give it a moment
+	// to flush the remaining output before closing it.
+	streamDone := make(chan struct{})
+	go func() {
+		stream.Wait()
+		close(streamDone)
+	}()
+	select {
+	case <-streamDone:
+	case <-time.After(5 * time.Second):
+		stream.Close()
+	}
+
+	exitCode := int(process.ExitCode)
+	if exitCode == 0 && process.Status != blaxel.ProcessResponseStatusCompleted {
+		exitCode = 1
+	}
+	core.PrintDiagnostic(fmt.Sprintf("Exit code: %d (%s)", exitCode, process.Status))
+	return exitCode, nil
+}
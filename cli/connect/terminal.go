@@ -10,6 +10,7 @@ import (
 	"strings"
 	"sync"
 
+	"github.com/blaxel-ai/toolkit/cli/core"
 	"github.com/gorilla/websocket"
 	"golang.org/x/term"
 )
@@ -161,7 +162,11 @@ func (t *TerminalClient) readLoop() {
 		case "output":
 			_, _ = os.Stdout.WriteString(msg.Data)
 		case "error":
-			_, _ = os.Stdout.WriteString("\r\n\x1b[31mError: " + msg.Data + "\x1b[0m\r\n")
+			if core.ColorDisabled() {
+				_, _ = os.Stdout.WriteString("\r\nError: " + msg.Data + "\r\n")
+			} else {
+				_, _ = os.Stdout.WriteString("\r\n\x1b[31mError: " + msg.Data + "\x1b[0m\r\n")
+			}
 		}
 	}
 }
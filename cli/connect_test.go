@@ -1,10 +1,14 @@
 package cli
 
 import (
+	"os"
+	"path/filepath"
 	"runtime"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestConnectCmd(t *testing.T) {
@@ -69,3 +73,36 @@ func TestConnectCmdHasSandboxSubcommand(t *testing.T) {
 
 	assert.NotNil(t, sandboxCmd, "Connect command should have sandbox subcommand")
 }
+
+func TestConnectSandboxCmdExecFileFlag(t *testing.T) {
+	cmd := ConnectSandboxCmd()
+	flag := cmd.Flags().Lookup("exec-file")
+	require.NotNil(t, flag)
+	assert.Equal(t, "", flag.DefValue)
+}
+
+func TestReadExecScript(t *testing.T) {
+	t.Run("from file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "script.sh")
+		require.NoError(t, os.WriteFile(path, []byte("echo hello\n"), 0644))
+		script, err := readExecScript(path, strings.NewReader(""))
+		require.NoError(t, err)
+		assert.Equal(t, "echo hello\n", script)
+	})
+
+	t.Run("from stdin", func(t *testing.T) {
+		script, err := readExecScript("-", strings.NewReader("ls -la\n"))
+		require.NoError(t, err)
+		assert.Equal(t, "ls -la\n", script)
+	})
+
+	t.Run("empty script", func(t *testing.T) {
+		_, err := readExecScript("", strings.NewReader("  \n"))
+		assert.Error(t, err)
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		_, err := readExecScript(filepath.Join(t.TempDir(), "missing.sh"), strings.NewReader(""))
+		assert.Error(t, err)
+	})
+}
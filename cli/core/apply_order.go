@@ -0,0 +1,169 @@
+package core
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ApplyDependencies returns, for each result, the indexes of the results that
+// must be applied before it: those listed in its metadata.dependsOn, as
+// Kind/name or name, and those with a lower metadata.order. Dependencies on
+// resources that are not part of results are ignored, they are expected to
+// exist already. A dependency cycle is an error.
+func ApplyDependencies(results []Result) ([][]int, error) {
+	byKey := map[string][]int{}
+	orders := make([]int, len(results))
+	for i, result := range results {
+		kind, name := resultKindName(result)
+		key := strings.ToLower(kind) + "/" + name
+		byKey[key] = append(byKey[key], i)
+		byKey[name] = append(byKey[name], i)
+
+		metadata, _ := result.Metadata.(map[string]interface{})
+		order, err := metadataOrder(metadata)
+		if err != nil {
+			return nil, fmt.Errorf("resource %s:%s: %w", kind, name, err)
+		}
+		orders[i] = order
+	}
+
+	deps := make([][]int, len(results))
+	for i, result := range results {
+		seen := map[int]bool{i: true}
+		add := func(j int) {
+			if !seen[j] {
+				seen[j] = true
+				deps[i] = append(deps[i], j)
+			}
+		}
+		metadata, _ := result.Metadata.(map[string]interface{})
+		for _, dep := range metadataDependsOn(metadata) {
+			key := dep
+			if kind, name, ok := strings.Cut(dep, "/"); ok {
+				key = strings.ToLower(kind) + "/" + name
+			}
+			for _, j := range byKey[key] {
+				add(j)
+			}
+		}
+		for j := range results {
+			if orders[j] < orders[i] {
+				add(j)
+			}
+		}
+	}
+
+	if _, err := topologicalOrder(results, deps); err != nil {
+		return nil, err
+	}
+	return deps, nil
+}
+
+// SortResultsForApply orders results so that each one comes after its
+// dependencies (see ApplyDependencies). Otherwise results keep their order.
+func SortResultsForApply(results []Result) ([]Result, error) {
+	deps, err := ApplyDependencies(results)
+	if err != nil {
+		return nil, err
+	}
+	order, err := topologicalOrder(results, deps)
+	if err != nil {
+		return nil, err
+	}
+	sorted := make([]Result, 0, len(results))
+	for _, i := range order {
+		sorted = append(sorted, results[i])
+	}
+	return sorted, nil
+}
+
+// topologicalOrder returns the indexes of results with each one after its
+// deps, picking the first one ready when there is a choice
+func topologicalOrder(results []Result, deps [][]int) ([]int, error) {
+	pending := make([]int, len(results))
+	dependents := make([][]int, len(results))
+	for i, d := range deps {
+		pending[i] = len(d)
+		for _, j := range d {
+			dependents[j] = append(dependents[j], i)
+		}
+	}
+
+	order := make([]int, 0, len(results))
+	done := make([]bool, len(results))
+	for len(order) < len(results) {
+		next := -1
+		for i := range results {
+			if !done[i] && pending[i] == 0 {
+				next = i
+				break
+			}
+		}
+		if next < 0 {
+			names := []string{}
+			for i, result := range results {
+				if !done[i] {
+					kind, name := resultKindName(result)
+					names = append(names, kind+"/"+name)
+				}
+			}
+			return nil, fmt.Errorf("dependency cycle between %s", strings.Join(names, ", "))
+		}
+		done[next] = true
+		order = append(order, next)
+		for _, i := range dependents[next] {
+			pending[i]--
+		}
+	}
+	return order, nil
+}
+
+// resultKindName returns the kind and metadata.name of a result
+func resultKindName(result Result) (string, string) {
+	metadata, _ := result.Metadata.(map[string]interface{})
+	name, _ := metadata["name"].(string)
+	return result.Kind, name
+}
+
+// metadataOrder returns metadata.order, 0 when it is not set
+func metadataOrder(metadata map[string]interface{}) (int, error) {
+	switch v := metadata["order"].(type) {
+	case nil:
+		return 0, nil
+	case int:
+		return v, nil
+	case int64:
+		return int(v), nil
+	case float64:
+		return int(v), nil
+	case string:
+		order, err := strconv.Atoi(v)
+		if err != nil {
+			return 0, fmt.Errorf("metadata.order must be a number, got %q", v)
+		}
+		return order, nil
+	default:
+		return 0, fmt.Errorf("metadata.order must be a number, got %v", v)
+	}
+}
+
+// metadataDependsOn returns metadata.dependsOn, a list or a comma separated string
+func metadataDependsOn(metadata map[string]interface{}) []string {
+	deps := []string{}
+	switch v := metadata["dependsOn"].(type) {
+	case string:
+		for _, dep := range strings.Split(v, ",") {
+			if dep = strings.TrimSpace(dep); dep != "" {
+				deps = append(deps, dep)
+			}
+		}
+	case []interface{}:
+		for _, dep := range v {
+			if s, ok := dep.(string); ok && strings.TrimSpace(s) != "" {
+				deps = append(deps, strings.TrimSpace(s))
+			}
+		}
+	}
+	return deps
+}
@@ -0,0 +1,79 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func orderResult(kind, name string, extra map[string]interface{}) Result {
+	metadata := map[string]interface{}{"name": name}
+	for k, v := range extra {
+		metadata[k] = v
+	}
+	return Result{Kind: kind, Metadata: metadata}
+}
+
+func resultNames(results []Result) []string {
+	names := []string{}
+	for _, r := range results {
+		_, name := resultKindName(r)
+		names = append(names, name)
+	}
+	return names
+}
+
+func TestSortResultsForApplyKeepsOrderWithoutMetadata(t *testing.T) {
+	results := []Result{orderResult("Agent", "a", nil), orderResult("Model", "b", nil), orderResult("Function", "c", nil)}
+	sorted, err := SortResultsForApply(results)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b", "c"}, resultNames(sorted))
+}
+
+func TestSortResultsForApplyDependsOn(t *testing.T) {
+	results := []Result{
+		orderResult("Agent", "agent", map[string]interface{}{"dependsOn": []interface{}{"Model/model", "tools"}}),
+		orderResult("Function", "tools", map[string]interface{}{"dependsOn": "model"}),
+		orderResult("Model", "model", nil),
+		orderResult("Policy", "unrelated", map[string]interface{}{"dependsOn": "Model/not-in-manifests"}),
+	}
+	sorted, err := SortResultsForApply(results)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"model", "tools", "agent", "unrelated"}, resultNames(sorted))
+}
+
+func TestSortResultsForApplyOrder(t *testing.T) {
+	results := []Result{
+		orderResult("Agent", "late", map[string]interface{}{"order": 10}),
+		orderResult("Agent", "default", nil),
+		orderResult("Model", "early", map[string]interface{}{"order": "-1"}),
+	}
+	sorted, err := SortResultsForApply(results)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"early", "default", "late"}, resultNames(sorted))
+}
+
+func TestApplyDependencies(t *testing.T) {
+	results := []Result{
+		orderResult("Agent", "agent", map[string]interface{}{"dependsOn": "model"}),
+		orderResult("Model", "model", nil),
+		orderResult("Agent", "other", nil),
+	}
+	deps, err := ApplyDependencies(results)
+	require.NoError(t, err)
+	assert.Equal(t, [][]int{{1}, nil, nil}, deps)
+}
+
+func TestSortResultsForApplyErrors(t *testing.T) {
+	_, err := SortResultsForApply([]Result{
+		orderResult("Agent", "a", map[string]interface{}{"dependsOn": "b"}),
+		orderResult("Agent", "b", map[string]interface{}{"dependsOn": "Agent/a"}),
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "dependency cycle between Agent/a, Agent/b")
+
+	_, err = SortResultsForApply([]Result{orderResult("Agent", "a", map[string]interface{}{"order": "first"})})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "metadata.order must be a number")
+}
@@ -66,6 +66,22 @@ func ResolveAuthSource(workspace string) AuthSource {
 	return AuthSource{}
 }
 
+// registerCredentialSecrets registers the credentials of workspace, from the
+// credentials file and the environment, as values to mask in the output
+func registerCredentialSecrets(workspace string) {
+	creds, _ := blaxel.LoadCredentials(workspace)
+	for _, value := range []string{
+		creds.APIKey,
+		creds.AccessToken,
+		creds.RefreshToken,
+		creds.ClientCredentials,
+		os.Getenv("BL_API_KEY"),
+		os.Getenv("BL_CLIENT_CREDENTIALS"),
+	} {
+		RegisterSecretValue(value)
+	}
+}
+
 // IsAuthError returns true when err looks like an authentication or
 // authorisation failure (HTTP 401/403).
 func IsAuthError(err error) bool {
@@ -88,8 +104,8 @@ func IsAuthError(err error) bool {
 // PrintAuthSourceHint prints a coloured hint about the authentication source
 // to stderr. Call this after printing an auth-related error so the user can
 // immediately see where the credentials came from.
-// The hint is printed at most once per session to avoid duplicate output
-// when both PrintError and ExitWithError are on the same code path.
+// The hint is printed at most once per session, even when several auth
+// errors are printed.
 func PrintAuthSourceHint() {
 	if authHintPrinted {
 		return
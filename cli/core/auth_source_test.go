@@ -145,3 +145,19 @@ func TestPrintAuthSourceHint_WithConfigSource(t *testing.T) {
 	// Should not panic — hint is shown for all auth modes, not just env vars.
 	PrintAuthSourceHint()
 }
+
+func TestPrintCommandError_AuthHint(t *testing.T) {
+	original := GetAuthSource()
+	defer SetAuthSource(original)
+
+	SetAuthSource(AuthSource{Method: "API key", Origin: "environment variable BL_API_KEY"})
+	PrintCommandError(fmt.Errorf("something went wrong"))
+	assert.False(t, authHintPrinted)
+
+	PrintCommandError(fmt.Errorf("401 Unauthorized"))
+	assert.True(t, authHintPrinted)
+
+	SetAuthSource(AuthSource{Method: "API key", Origin: "environment variable BL_API_KEY"})
+	PrintCommandError(&CommandError{Op: "Get", Err: fmt.Errorf("401 Unauthorized")})
+	assert.True(t, authHintPrinted)
+}
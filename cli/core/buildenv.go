@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 )
 
@@ -116,3 +117,35 @@ func MergeBuildEnvContent(tomlArgs map[string]string, envArgs map[string]string)
 
 	return []byte(strings.Join(lines, "\n") + "\n"), len(merged)
 }
+
+// ParseBuildArgs parses --build-arg KEY=VALUE values. The value may be empty
+// or contain '=', the key may not be empty or contain spaces.
+func ParseBuildArgs(values []string) (map[string]string, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+	args := make(map[string]string, len(values))
+	for _, value := range values {
+		key, val, ok := strings.Cut(value, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" || strings.ContainsAny(key, " \t") {
+			return nil, fmt.Errorf("invalid build arg %q (expected KEY=VALUE)", value)
+		}
+		args[key] = val
+	}
+	return args, nil
+}
+
+// SecretBuildArgs returns, sorted, the names of the build args that look like
+// secrets: a loaded secret (.env files and -s flags) or a name such as TOKEN or
+// API_KEY. Build args end up in the image metadata, so secrets do not belong there.
+func SecretBuildArgs(args map[string]string) []string {
+	names := []string{}
+	for name := range args {
+		if secretKeyPattern.MatchString(name) || LookupSecret(name) != "" {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
@@ -132,3 +132,33 @@ func TestMergeBuildEnvContentOnlyEnv(t *testing.T) {
 	assert.Equal(t, 1, count)
 	assert.Contains(t, string(result), "FOO=bar")
 }
+
+func TestParseBuildArgs(t *testing.T) {
+	args, err := ParseBuildArgs([]string{"FOO=bar", "EMPTY=", "TOKEN=abc=def"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"FOO": "bar", "EMPTY": "", "TOKEN": "abc=def"}, args)
+
+	args, err = ParseBuildArgs(nil)
+	require.NoError(t, err)
+	assert.Nil(t, args)
+
+	for _, invalid := range []string{"NOVALUE", "=value", "MY KEY=value"} {
+		_, err := ParseBuildArgs([]string{invalid})
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestSecretBuildArgs(t *testing.T) {
+	originalSecrets := secrets
+	defer func() { secrets = originalSecrets }()
+	secrets = Secrets{{Name: "DB_URL", Value: "postgres://x"}}
+
+	names := SecretBuildArgs(map[string]string{
+		"PYTHON_VERSION": "3.12",
+		"NPM_TOKEN":      "x",
+		"DB_URL":         "y",
+		"AWS_SECRET":     "z",
+	})
+	assert.Equal(t, []string{"AWS_SECRET", "DB_URL", "NPM_TOKEN"}, names)
+	assert.Empty(t, SecretBuildArgs(nil))
+}
@@ -0,0 +1,58 @@
+package core
+
+import (
+	"os"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/fatih/color"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/muesli/termenv"
+)
+
+// noColor is set by the --no-color flag, NO_COLOR disables colors as well
+var noColor bool
+
+// ColorDisabled reports whether output must be plain, as requested with
+// --no-color or by setting NO_COLOR to any value (https://no-color.org).
+func ColorDisabled() bool {
+	return noColor || os.Getenv("NO_COLOR") != ""
+}
+
+// applyColorSettings turns colors off in every library printing them when
+// they are disabled
+func applyColorSettings() {
+	if !ColorDisabled() {
+		return
+	}
+	color.NoColor = true
+	lipgloss.SetColorProfile(termenv.Ascii)
+	colorYellow, colorCyan, colorGreen, colorBold, colorReset = "", "", "", "", ""
+}
+
+// Spinner returns s, or a spinner made of ASCII characters when colors are
+// disabled, for terminals and logs that do not render the others
+func Spinner(s spinner.Spinner) spinner.Spinner {
+	if ColorDisabled() {
+		return spinner.Line
+	}
+	return s
+}
+
+// Border returns b, or a border made of ASCII characters when colors are
+// disabled
+func Border(b lipgloss.Border) lipgloss.Border {
+	if ColorDisabled() {
+		return lipgloss.ASCIIBorder()
+	}
+	return b
+}
+
+// TableStyle returns the style of boxed tables, drawn with ASCII characters
+// when colors are disabled
+func TableStyle() table.Style {
+	if ColorDisabled() {
+		return table.StyleDefault
+	}
+	return table.StyleLight
+}
@@ -0,0 +1,48 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/fatih/color"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestColorDisabled(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+	noColor = false
+	assert.False(t, ColorDisabled())
+	assert.Equal(t, spinner.Dot, Spinner(spinner.Dot))
+	assert.Equal(t, lipgloss.RoundedBorder(), Border(lipgloss.RoundedBorder()))
+	assert.Equal(t, table.StyleLight, TableStyle())
+
+	noColor = true
+	t.Cleanup(func() { noColor = false })
+	assert.True(t, ColorDisabled())
+
+	noColor = false
+	t.Setenv("NO_COLOR", "1")
+	assert.True(t, ColorDisabled())
+	assert.Equal(t, spinner.Line, Spinner(spinner.Dot))
+	assert.Equal(t, lipgloss.ASCIIBorder(), Border(lipgloss.RoundedBorder()))
+	assert.Equal(t, table.StyleDefault, TableStyle())
+}
+
+func TestApplyColorSettings(t *testing.T) {
+	previous, profile := color.NoColor, lipgloss.ColorProfile()
+	codes := []string{colorYellow, colorCyan, colorGreen, colorBold, colorReset}
+	t.Cleanup(func() {
+		color.NoColor = previous
+		lipgloss.SetColorProfile(profile)
+		colorYellow, colorCyan, colorGreen, colorBold, colorReset = codes[0], codes[1], codes[2], codes[3], codes[4]
+	})
+
+	t.Setenv("NO_COLOR", "1")
+	applyColorSettings()
+	assert.True(t, color.NoColor)
+	assert.Empty(t, colorReset)
+	assert.Equal(t, "done", color.New(color.FgGreen).Sprint("done"))
+	assert.Equal(t, "done", lipgloss.NewStyle().Foreground(lipgloss.Color("214")).Render("done"))
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
 )
@@ -48,7 +49,7 @@ func init() {
 
 func completionCmd() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "completion [bash|zsh|fish|powershell]",
+		Use:   "completion [bash|zsh|fish|powershell|nushell|elvish]",
 		Short: "Generate shell completion scripts",
 		Long: `Generate shell completion scripts for bl.
 To load completions:
@@ -83,20 +84,50 @@ PowerShell:
   # To load completions for each session, execute once:
   bl completion powershell > bl.ps1
   # and source this file from your PowerShell profile.
+
+Nushell:
+  bl completion nushell | save --force ($nu.default-config-dir | path join "bl-completion.nu")
+
+  # Then add to your config.nu:
+  source ($nu.default-config-dir | path join "bl-completion.nu")
+
+Elvish:
+  eval (bl completion elvish | slurp)
+
+  # To load completions for each session, execute once:
+  mkdir -p ~/.config/elvish/lib
+  bl completion elvish > ~/.config/elvish/lib/bl-completion.elv
+  # and add 'use bl-completion' to your rc.elv.
+
+Resource names are completed from the API. Responses are cached for 10
+seconds in ~/.blaxel/cache/completions so repeated Tabs are instant, and
+removed once they are a few minutes old. Set BL_NO_COMPLETION_CACHE=1 to
+always ask the API. Set BL_COMPLETION_FUZZY=1 to
+also complete names from a fragment, like pay for my-payments-agent, in bash,
+zsh and fish. Names starting with what was typed are still listed first.
+
+The 20 most recent resources are listed, set BL_COMPLETION_LIMIT to list more,
+or 0 to list them all.
 `,
 		DisableFlagsInUseLine: true,
-		ValidArgs:             []string{"bash", "zsh", "fish", "powershell"},
+		ValidArgs:             []string{"bash", "zsh", "fish", "powershell", "nushell", "elvish"},
 		Args:                  cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			switch args[0] {
 			case "bash":
 				return genBashCompletionWithShim(cmd.Root(), os.Stdout)
 			case "zsh":
-				return cmd.Root().GenZshCompletion(os.Stdout)
+				return genZshCompletionWithFuzzy(cmd.Root(), os.Stdout)
 			case "fish":
 				return cmd.Root().GenFishCompletion(os.Stdout, true)
 			case "powershell":
 				return cmd.Root().GenPowerShellCompletionWithDesc(os.Stdout)
+			case "nushell":
+				_, err := os.Stdout.WriteString(nushellCompletion)
+				return err
+			case "elvish":
+				_, err := os.Stdout.WriteString(elvishCompletion)
+				return err
 			default:
 				return fmt.Errorf("unsupported shell: %s", args[0])
 			}
@@ -120,6 +151,114 @@ func genBashCompletionWithShim(root *cobra.Command, out *os.File) error {
 	if _, err := out.WriteString(bashCompletionShim); err != nil {
 		return err
 	}
-	_, err := out.Write(buf.Bytes())
+	_, err := out.WriteString(allowFuzzyBashCompletion(buf.String()))
 	return err
 }
+
+// genZshCompletionWithFuzzy generates zsh completion that keeps the fuzzy
+// matches of resource names, see allowFuzzyZshCompletion.
+func genZshCompletionWithFuzzy(root *cobra.Command, out *os.File) error {
+	var buf bytes.Buffer
+	if err := root.GenZshCompletion(&buf); err != nil {
+		return err
+	}
+	_, err := out.WriteString(allowFuzzyZshCompletion(buf.String()))
+	return err
+}
+
+// fuzzyCompletionTest is true in the bash completion script when
+// BL_COMPLETION_FUZZY is set to a value strconv.ParseBool reads as true
+const fuzzyCompletionTest = `${BL_COMPLETION_FUZZY-} =~ ^(1|t|T|true|TRUE|True)$`
+
+// allowFuzzyBashCompletion stops bash from dropping the completions that do not
+// start with the current word when BL_COMPLETION_FUZZY is set: bl then also
+// returns names containing it, already filtered and ranked.
+func allowFuzzyBashCompletion(script string) string {
+	return strings.Replace(script,
+		`[[ $comp == "$cur"* ]] || continue`,
+		`[[ $comp == "$cur"* || `+fuzzyCompletionTest+` ]] || continue`, 1)
+}
+
+// allowFuzzyZshCompletion passes -U to compadd when BL_COMPLETION_FUZZY is set,
+// so zsh keeps the completions that do not start with the current word.
+func allowFuzzyZshCompletion(script string) string {
+	script = strings.Replace(script,
+		`        __bl_debug "Calling _describe"
+`,
+		`        __bl_debug "Calling _describe"
+        local fuzzy=""
+        case ${BL_COMPLETION_FUZZY-} in 1|t|T|true|TRUE|True) fuzzy="-U" ;; esac
+`, 1)
+	return strings.Replace(script,
+		`eval _describe $keepOrder "completions" completions $flagPrefix $noSpace;`,
+		`eval _describe $keepOrder "completions" completions $flagPrefix $noSpace $fuzzy;`, 1)
+}
+
+// nushellCompletion registers an external completer for bl in Nushell, which
+// cobra does not generate. It asks bl __complete for the candidates like the
+// other shells do, so resource names are completed too, and hands other
+// commands to the completer that was configured before.
+const nushellCompletion = `# nushell completion for bl
+
+let bl_completer = {|spans: list<string>|
+    let result = (do { ^bl __complete ...($spans | skip 1) } | complete)
+    if $result.exit_code != 0 { return null }
+    let lines = ($result.stdout | lines)
+    if ($lines | is-empty) { return null }
+    # The last line is the directive of cobra, like :4
+    let directive = ($lines | last | str replace ':' '' | into int)
+    let candidates = ($lines | drop 1 | where {|line| $line != "" and not ($line | str starts-with "_activeHelp_") } | each {|line|
+        let parts = ($line | split row "\t")
+        if ($parts | length) > 1 {
+            {value: $parts.0, description: $parts.1}
+        } else {
+            {value: $parts.0}
+        }
+    })
+    # Without ShellCompDirectiveNoFileComp (4), fall back to file names
+    if ($candidates | is-empty) and ($directive | bits and 4) == 0 { return null }
+    $candidates
+}
+
+let bl_previous_completer = $env.config.completions?.external?.completer?
+$env.config.completions.external.enable = true
+$env.config.completions.external.completer = {|spans: list<string>|
+    if ($spans.0 | path basename) == "bl" {
+        do $bl_completer $spans
+    } else if $bl_previous_completer != null {
+        do $bl_previous_completer $spans
+    }
+}
+`
+
+// elvishCompletion registers an argument completer for bl in Elvish, which
+// cobra does not generate. It asks bl __complete for the candidates like the
+// other shells do.
+const elvishCompletion = `# elvish completion for bl
+
+use str
+
+set edit:completion:arg-completer[bl] = {|@words|
+    var lines = []
+    try {
+        set lines = [(e:bl __complete (all $words[1..]) 2>/dev/null)]
+    } catch {
+        return
+    }
+    if (== (count $lines) 0) {
+        return
+    }
+    # The last line is the directive of cobra, like :4
+    for line $lines[..(- (count $lines) 1)] {
+        if (or (eq $line '') (str:has-prefix $line '_activeHelp_')) {
+            continue
+        }
+        var parts = [(str:split "\t" $line)]
+        if (> (count $parts) 1) {
+            edit:complex-candidate $parts[0] &display=$parts[0]' ('$parts[1]')'
+        } else {
+            put $parts[0]
+        }
+    }
+}
+`
@@ -0,0 +1,37 @@
+package core
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAllowFuzzyCompletion(t *testing.T) {
+	root := &cobra.Command{Use: "bl"}
+	root.AddCommand(&cobra.Command{Use: "get", Run: func(cmd *cobra.Command, args []string) {}})
+
+	var bash bytes.Buffer
+	require.NoError(t, root.GenBashCompletionV2(&bash, true))
+	patched := allowFuzzyBashCompletion(bash.String())
+	assert.NotEqual(t, bash.String(), patched, "the bash script of cobra changed, update allowFuzzyBashCompletion")
+	assert.Contains(t, patched, fuzzyCompletionTest)
+
+	var zsh bytes.Buffer
+	require.NoError(t, root.GenZshCompletion(&zsh))
+	patched = allowFuzzyZshCompletion(zsh.String())
+	assert.NotEqual(t, zsh.String(), patched, "the zsh script of cobra changed, update allowFuzzyZshCompletion")
+	assert.Contains(t, patched, `$noSpace $fuzzy`)
+}
+
+func TestNushellAndElvishCompletion(t *testing.T) {
+	cmd := completionCmd()
+	assert.Contains(t, cmd.ValidArgs, "nushell")
+	assert.Contains(t, cmd.ValidArgs, "elvish")
+
+	// Both ask bl for the candidates, so dynamic completions work
+	assert.Contains(t, nushellCompletion, "^bl "+cobra.ShellCompRequestCmd)
+	assert.Contains(t, elvishCompletion, "e:bl "+cobra.ShellCompRequestCmd)
+}
@@ -1,12 +1,16 @@
 package core
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
 	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/BurntSushi/toml"
@@ -38,6 +42,7 @@ type Resource struct {
 	Put         interface{}
 	Post        interface{}
 	Fields      []Field // ordered slice of fields - e.g., {Key: "STATUS", Value: "status"}
+	WideFields  []Field // extra fields appended to Fields with --output wide
 }
 
 var resources = []*Resource{
@@ -86,6 +91,10 @@ var resources = []*Resource{
 			{Key: "STATUS", Value: "status"},
 			{Key: "CREATED_AT", Value: "createdAt", Special: "date"},
 		},
+		WideFields: []Field{
+			{Key: "MEMORY", Value: "spec.runtime.memory"},
+			{Key: "PUBLIC", Value: "spec.public"},
+		},
 	},
 	{
 		Kind:      "Agent",
@@ -102,6 +111,10 @@ var resources = []*Resource{
 			{Key: "STATUS", Value: "status"},
 			{Key: "CREATED_AT", Value: "createdAt", Special: "date"},
 		},
+		WideFields: []Field{
+			{Key: "MEMORY", Value: "spec.runtime.memory"},
+			{Key: "PUBLIC", Value: "spec.public"},
+		},
 	},
 	{
 		Kind:     "IntegrationConnection",
@@ -131,6 +144,10 @@ var resources = []*Resource{
 			{Key: "STATUS", Value: "status"},
 			{Key: "CREATED_AT", Value: "createdAt", Special: "date"},
 		},
+		WideFields: []Field{
+			{Key: "MEMORY", Value: "spec.runtime.memory"},
+			{Key: "TTL", Value: "spec.runtime.ttl"},
+		},
 	},
 	{
 		Kind:      "Application",
@@ -147,6 +164,10 @@ var resources = []*Resource{
 			{Key: "STATUS", Value: "status"},
 			{Key: "CREATED_AT", Value: "createdAt", Special: "date"},
 		},
+		WideFields: []Field{
+			{Key: "REGION", Value: "spec.region"},
+			{Key: "PORT", Value: "spec.port"},
+		},
 	},
 	{
 		Kind:      "Job",
@@ -163,6 +184,10 @@ var resources = []*Resource{
 			{Key: "STATUS", Value: "status"},
 			{Key: "CREATED_AT", Value: "createdAt", Special: "date"},
 		},
+		WideFields: []Field{
+			{Key: "IMAGE", Value: "spec.runtime.image", Special: "image"},
+			{Key: "MEMORY", Value: "spec.runtime.memory"},
+		},
 	},
 	{
 		Kind:      "Volume",
@@ -269,6 +294,9 @@ type Package struct {
 	Path string `toml:"path"`
 	Port int    `toml:"port,omitempty"`
 	Type string `toml:"type,omitempty"`
+	// DependsOn are the files and directories shared with other packages, a
+	// change to them redeploys the package with bl deploy --only-changed
+	DependsOn []string `toml:"dependsOn,omitempty"`
 }
 
 // BuildConfig represents the [build] section of blaxel.toml
@@ -304,6 +332,9 @@ type Config struct {
 	Port         int                       `toml:"port,omitempty"`
 	Image        string                    `toml:"image,omitempty"`
 	Build        *BuildConfig              `toml:"build,omitempty"`
+
+	// Environments holds the [env.<name>] override sections, keyed by environment name
+	Environments map[string]map[string]interface{} `toml:"-"`
 }
 
 // blaxelTomlWarning stores any warning from parsing blaxel.toml
@@ -329,13 +360,42 @@ func readConfigToml(folder string, setDefaultType bool) {
 		return
 	}
 
-	err = toml.Unmarshal(content, &config)
+	undefinedConfigVars = nil
+	expanded, err := expandConfigContent(string(content))
+	if err != nil {
+		blaxelTomlWarning = buildBlaxelTomlWarning(err)
+		return
+	}
+
+	err = toml.Unmarshal([]byte(expanded), &config)
 	if err != nil {
 		// Store the warning for the caller to handle
 		blaxelTomlWarning = buildBlaxelTomlWarning(err)
 		return
 	}
 
+	if err := readConfigEnvironments(expanded); err != nil {
+		blaxelTomlWarning = buildBlaxelTomlWarning(err)
+		return
+	}
+
+	// Merge machine-specific overrides on top of the committed config
+	localConfigOverrides = nil
+	localContent, err := os.ReadFile(filepath.Join(cwd, folder, LocalConfigFileName))
+	if err == nil {
+		expandedLocal, err := expandConfigContent(string(localContent))
+		if err != nil {
+			blaxelTomlWarning = buildBlaxelTomlWarning(fmt.Errorf("%s: %w", LocalConfigFileName, err))
+			return
+		}
+		md, err := toml.Decode(expandedLocal, &config)
+		if err != nil {
+			blaxelTomlWarning = buildBlaxelTomlWarning(fmt.Errorf("%s: %w", LocalConfigFileName, err))
+			return
+		}
+		localConfigOverrides = localConfigKeys(md)
+	}
+
 	// Resolve variable interpolation in string fields
 	resolveConfigVars()
 
@@ -343,9 +403,26 @@ func readConfigToml(folder string, setDefaultType bool) {
 		config.Type = "agent"
 	}
 
-	if config.Workspace != "" {
+	if config.Workspace != "" && !workspaceFlagSet {
 		workspace = config.Workspace
 	}
+	AddBreadcrumb("config", "config loaded", map[string]interface{}{"type": config.Type, "name": config.Name})
+}
+
+// CheckConfigWorkspace returns an error when blaxel.toml pins a workspace
+// other than the current one (BL_WORKSPACE or the current context), unless
+// --workspace selects one of the two
+func CheckConfigWorkspace() error {
+	if config.Workspace == "" || contextWorkspace == "" || config.Workspace == contextWorkspace {
+		return nil
+	}
+	if !workspaceFlagSet {
+		return fmt.Errorf("blaxel.toml pins workspace '%s' but the current workspace is '%s'; pass --workspace %s or --workspace %s to choose one", config.Workspace, contextWorkspace, config.Workspace, contextWorkspace)
+	}
+	if workspace != config.Workspace && workspace != contextWorkspace {
+		return fmt.Errorf("--workspace %s matches neither the workspace of blaxel.toml ('%s') nor the current workspace ('%s')", workspace, config.Workspace, contextWorkspace)
+	}
+	return nil
 }
 
 // resolveConfigVars resolves variable interpolation patterns in Config string fields.
@@ -370,6 +447,197 @@ func resolveConfigVars() {
 	}
 }
 
+// undefinedConfigVars lists the variables referenced by the last blaxel.toml read that were not defined
+var undefinedConfigVars []string
+
+// UndefinedConfigVars returns the variables referenced in blaxel.toml or
+// blaxel.local.toml that were neither in the environment nor in the loaded
+// .env files. They were expanded to empty by the last ReadConfigToml.
+func UndefinedConfigVars() []string {
+	return undefinedConfigVars
+}
+
+// expandConfigContent expands the variable references of every string value of
+// the TOML document content with ExpandConfigVars. Documents without any $ are
+// returned as is so parse errors keep pointing at the right line.
+func expandConfigContent(content string) (string, error) {
+	if !strings.Contains(content, "$") {
+		return content, nil
+	}
+	var doc map[string]interface{}
+	if _, err := toml.Decode(content, &doc); err != nil {
+		return "", err
+	}
+	doc = expandConfigValue(doc).(map[string]interface{})
+	var buf bytes.Buffer
+	if err := toml.NewEncoder(&buf).Encode(doc); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// expandConfigValue expands the strings found in a decoded TOML value, recording undefined variables
+func expandConfigValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case string:
+		expanded, undefined := ExpandConfigVars(v)
+		for _, name := range undefined {
+			if !slices.Contains(undefinedConfigVars, name) {
+				undefinedConfigVars = append(undefinedConfigVars, name)
+			}
+		}
+		return expanded
+	case map[string]interface{}:
+		for key, item := range v {
+			v[key] = expandConfigValue(item)
+		}
+	case []map[string]interface{}:
+		for i, item := range v {
+			v[i] = expandConfigValue(item).(map[string]interface{})
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = expandConfigValue(item)
+		}
+	}
+	return value
+}
+
+// configEnvironment is the blaxel.toml environment section selected with --bl-env
+var configEnvironment string
+
+// SetConfigEnvironment selects the [env.<name>] section merged over the base
+// config by the next ReadConfigToml. An empty name keeps the base config.
+func SetConfigEnvironment(name string) {
+	configEnvironment = name
+}
+
+// readConfigEnvironments collects the [env.<name>] sections of content into
+// config.Environments and merges the selected one over the config. Environments
+// without a section fall back to the base config.
+func readConfigEnvironments(content string) error {
+	var raw struct {
+		Env map[string]interface{} `toml:"env"`
+	}
+	if _, err := toml.Decode(content, &raw); err != nil {
+		return err
+	}
+	config.Environments = nil
+	for name, value := range raw.Env {
+		if section, ok := value.(map[string]interface{}); ok {
+			if config.Environments == nil {
+				config.Environments = map[string]map[string]interface{}{}
+			}
+			config.Environments[name] = section
+		}
+	}
+
+	section, ok := config.Environments[configEnvironment]
+	if configEnvironment == "" || !ok {
+		return nil
+	}
+	var doc bytes.Buffer
+	if err := toml.NewEncoder(&doc).Encode(section); err != nil {
+		return fmt.Errorf("env.%s: %w", configEnvironment, err)
+	}
+	if _, err := toml.Decode(doc.String(), &config); err != nil {
+		return fmt.Errorf("env.%s: %w", configEnvironment, err)
+	}
+	return nil
+}
+
+// LocalConfigFileName is the optional, gitignored file whose values override blaxel.toml
+const LocalConfigFileName = "blaxel.local.toml"
+
+// localConfigOverrides lists the keys set by blaxel.local.toml during the last read
+var localConfigOverrides []string
+
+// localConfigKeys returns the dotted keys of all values (not tables) defined in a TOML document
+func localConfigKeys(md toml.MetaData) []string {
+	keys := []string{}
+	for _, key := range md.Keys() {
+		if md.Type(key...) == "Hash" {
+			continue
+		}
+		keys = append(keys, key.String())
+	}
+	return keys
+}
+
+// GetLocalConfigOverrides returns the keys whose values came from blaxel.local.toml
+func GetLocalConfigOverrides() []string {
+	return localConfigOverrides
+}
+
+var configSetKeyRe = regexp.MustCompile(`^[A-Za-z0-9_-]+(\.[A-Za-z0-9_-]+)*$`)
+
+// ApplyConfigSets merges key=value overrides (as given to `bl deploy --set`)
+// into the loaded config. Dotted keys navigate into tables such as runtime,
+// creating keys as needed. Values are coerced to integers, floats or booleans
+// when they parse as such and kept as strings otherwise.
+func ApplyConfigSets(sets []string) error {
+	if len(sets) == 0 {
+		return nil
+	}
+	parsed, err := parseConfigSets(sets)
+	if err != nil {
+		return err
+	}
+	var doc strings.Builder
+	for _, set := range parsed {
+		doc.WriteString(set.key + " = " + set.literal + "\n")
+	}
+	if _, err := toml.Decode(doc.String(), &config); err != nil {
+		return fmt.Errorf("invalid --set: %w", err)
+	}
+	return nil
+}
+
+// configSet is one --set override with its value as a TOML literal
+type configSet struct {
+	key     string
+	literal string
+}
+
+// parseConfigSets parses --set overrides. When a key is set more than once
+// the last value wins, like helm --set.
+func parseConfigSets(sets []string) ([]configSet, error) {
+	parsed := make([]configSet, 0, len(sets))
+	index := map[string]int{}
+	for _, set := range sets {
+		key, value, ok := strings.Cut(set, "=")
+		key = strings.TrimSpace(key)
+		if !ok || !configSetKeyRe.MatchString(key) {
+			return nil, fmt.Errorf("invalid --set %q: expected key=value, e.g. runtime.memory=4096", set)
+		}
+		if i, seen := index[key]; seen {
+			parsed[i].literal = configSetValue(value)
+			continue
+		}
+		index[key] = len(parsed)
+		parsed = append(parsed, configSet{key: key, literal: configSetValue(value)})
+	}
+	return parsed, nil
+}
+
+// configSetValue returns the TOML literal for a --set value
+func configSetValue(value string) string {
+	value = strings.TrimSpace(value)
+	if _, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return value
+	}
+	if _, err := strconv.ParseFloat(value, 64); err == nil && !strings.ContainsAny(value, "xXnN") {
+		return value
+	}
+	if value == "true" || value == "false" {
+		return value
+	}
+	if len(value) >= 2 && value[0] == '"' && value[len(value)-1] == '"' {
+		value = value[1 : len(value)-1]
+	}
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\t", `\t`).Replace(value) + `"`
+}
+
 // GetBlaxelTomlWarning returns any warning from parsing blaxel.toml
 func GetBlaxelTomlWarning() string {
 	return blaxelTomlWarning
@@ -0,0 +1,264 @@
+package core
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// supportedConfigTypes are the values accepted for the top-level `type` of blaxel.toml
+var supportedConfigTypes = []string{"agent", "function", "job", "sandbox", "application", "volume-template"}
+
+// configTypeAliases maps values users commonly write to the supported type they meant
+var configTypeAliases = map[string]string{
+	"mcp":       "function",
+	"mcps":      "function",
+	"fn":        "function",
+	"functions": "function",
+	"agents":    "agent",
+	"jobs":      "job",
+	"sbx":       "sandbox",
+	"sandboxes": "sandbox",
+	"app":       "application",
+}
+
+// defaultVolumeTemplateSize is the defaultSize (in MB) added by `--fix` to volume templates
+const defaultVolumeTemplateSize = 1024
+
+// ConfigIssue is a likely mistake found in blaxel.toml
+type ConfigIssue struct {
+	Key     string `json:"key" yaml:"key"`
+	Line    int    `json:"line" yaml:"line"`
+	Message string `json:"message" yaml:"message"`
+	// Fix describes the correction applied by FixConfigToml, empty when it must be fixed by hand
+	Fix string `json:"fix,omitempty" yaml:"fix,omitempty"`
+
+	// pos is the line index the fix edits, used to apply fixes bottom-up
+	pos   int
+	apply func(lines []string) []string
+}
+
+var (
+	tomlTableRe = regexp.MustCompile(`^\s*\[\[?\s*([^\]]+?)\s*\]\]?\s*(#.*)?$`)
+	tomlKeyRe   = regexp.MustCompile(`^(\s*)([A-Za-z0-9_-]+)\s*=\s*(.*?)\s*(#.*)?$`)
+)
+
+// LintConfigToml detects common blaxel.toml mistakes: unsupported types, volume
+// templates without a defaultSize, ports without a protocol, timeouts written
+// as bare integers and a [build] target, which is not supported yet.
+func LintConfigToml(content []byte) []ConfigIssue {
+	lines := strings.Split(string(content), "\n")
+	issues := []ConfigIssue{}
+
+	table := ""
+	typeLine := -1
+	resourceType := ""
+	hasDefaultSize := false
+
+	// Track the current [[...ports]] block to detect a missing protocol
+	portsStart, portsLast := -1, -1
+	portsHasProtocol := false
+	closePorts := func() {
+		if portsStart >= 0 && !portsHasProtocol {
+			insertAt := portsLast + 1
+			indent := leadingSpace(lines[portsLast])
+			issues = append(issues, ConfigIssue{
+				Key:     "runtime.ports.protocol",
+				Line:    portsStart + 1,
+				Message: "port is missing a protocol",
+				Fix:     `set protocol = "HTTP"`,
+				pos:     insertAt,
+				apply: func(lines []string) []string {
+					return insertLine(lines, insertAt, indent+`protocol = "HTTP"`)
+				},
+			})
+		}
+		portsStart, portsLast = -1, -1
+		portsHasProtocol = false
+	}
+
+	for i, line := range lines {
+		if m := tomlTableRe.FindStringSubmatch(line); m != nil {
+			closePorts()
+			table = m[1]
+			if strings.HasSuffix(table, "ports") && strings.HasPrefix(strings.TrimSpace(line), "[[") {
+				portsStart, portsLast = i, i
+			}
+			continue
+		}
+
+		m := tomlKeyRe.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		indent, key, value := m[1], m[2], m[3]
+		fullKey := key
+		if table != "" {
+			fullKey = table + "." + key
+		}
+		if portsStart >= 0 {
+			portsLast = i
+			if key == "protocol" {
+				portsHasProtocol = true
+			}
+		}
+
+		switch {
+		case table == "" && key == "type":
+			typeLine = i
+			resourceType = strings.Trim(value, `"'`)
+			if issue, ok := lintConfigType(resourceType, i, indent); ok {
+				issues = append(issues, issue)
+				if issue.Fix != "" {
+					resourceType = configTypeSuggestion(resourceType)
+				}
+			}
+		case table == "" && key == "defaultSize":
+			hasDefaultSize = true
+		case table == "build" && key == "target":
+			issues = append(issues, ConfigIssue{
+				Key:     fullKey,
+				Line:    i + 1,
+				Message: "build target is not supported yet: the build service has no option to select a stage, the last stage of the Dockerfile is built",
+			})
+		case key == "timeout":
+			seconds, err := strconv.Atoi(value)
+			if err != nil {
+				continue
+			}
+			duration := FormatDurationSeconds(seconds)
+			lineIdx := i
+			issues = append(issues, ConfigIssue{
+				Key:     fullKey,
+				Line:    i + 1,
+				Message: fmt.Sprintf("timeout %d is a bare number of seconds", seconds),
+				Fix:     fmt.Sprintf("use timeout = %q", duration),
+				pos:     lineIdx,
+				apply: func(lines []string) []string {
+					lines[lineIdx] = fmt.Sprintf(`%stimeout = %q`, indent, duration)
+					return lines
+				},
+			})
+		}
+	}
+	closePorts()
+
+	if IsVolumeTemplate(resourceType) && !hasDefaultSize && typeLine >= 0 {
+		insertAt := typeLine + 1
+		issues = append(issues, ConfigIssue{
+			Key:     "defaultSize",
+			Line:    typeLine + 1,
+			Message: "volume-template has no defaultSize",
+			Fix:     fmt.Sprintf("set defaultSize = %d", defaultVolumeTemplateSize),
+			pos:     insertAt,
+			apply: func(lines []string) []string {
+				return insertLine(lines, insertAt, fmt.Sprintf("defaultSize = %d", defaultVolumeTemplateSize))
+			},
+		})
+	}
+
+	return issues
+}
+
+// FixConfigToml applies the fixable issues to content and returns the corrected file
+func FixConfigToml(content []byte, issues []ConfigIssue) []byte {
+	lines := strings.Split(string(content), "\n")
+	// Apply from the bottom up so inserted lines do not shift pending fixes
+	sorted := slices.Clone(issues)
+	slices.SortStableFunc(sorted, func(a, b ConfigIssue) int { return b.pos - a.pos })
+	for _, issue := range sorted {
+		if issue.apply != nil {
+			lines = issue.apply(lines)
+		}
+	}
+	return []byte(strings.Join(lines, "\n"))
+}
+
+// lintConfigType reports an unsupported top-level type, suggesting the closest supported one
+func lintConfigType(value string, lineIdx int, indent string) (ConfigIssue, bool) {
+	if slices.Contains(supportedConfigTypes, value) || IsVolumeTemplate(value) {
+		return ConfigIssue{}, false
+	}
+	issue := ConfigIssue{
+		Key:     "type",
+		Line:    lineIdx + 1,
+		Message: fmt.Sprintf("unsupported type %q, expected one of: %s", value, strings.Join(supportedConfigTypes, ", ")),
+	}
+	suggestion := configTypeSuggestion(value)
+	if suggestion == "" {
+		return issue, true
+	}
+	if suggestion == "function" && strings.HasPrefix(strings.ToLower(value), "mcp") {
+		issue.Message = fmt.Sprintf("MCP servers are deployed with type = \"function\", not %q", value)
+	}
+	issue.Fix = fmt.Sprintf("use type = %q", suggestion)
+	issue.pos = lineIdx
+	issue.apply = func(lines []string) []string {
+		lines[lineIdx] = fmt.Sprintf(`%stype = %q`, indent, suggestion)
+		return lines
+	}
+	return issue, true
+}
+
+// configTypeSuggestion returns the supported type closest to value, or "" when none is close
+func configTypeSuggestion(value string) string {
+	v := strings.ToLower(strings.TrimSpace(value))
+	if alias, ok := configTypeAliases[v]; ok {
+		return alias
+	}
+	best, bestDistance := "", 3
+	for _, t := range supportedConfigTypes {
+		if d := levenshtein(v, t); d < bestDistance {
+			best, bestDistance = t, d
+		}
+	}
+	return best
+}
+
+// FormatDurationSeconds formats seconds with the largest unit that divides it
+// exactly, using the units accepted by ParseDurationToSeconds (e.g. 900 -> 15m)
+func FormatDurationSeconds(seconds int) string {
+	units := []struct {
+		suffix string
+		size   int
+	}{{"w", 7 * 86400}, {"d", 86400}, {"h", 3600}, {"m", 60}}
+	for _, u := range units {
+		if seconds > 0 && seconds%u.size == 0 {
+			return fmt.Sprintf("%d%s", seconds/u.size, u.suffix)
+		}
+	}
+	return fmt.Sprintf("%ds", seconds)
+}
+
+func levenshtein(a, b string) int {
+	prev := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur := make([]int, len(b)+1)
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev = cur
+	}
+	return prev[len(b)]
+}
+
+func insertLine(lines []string, at int, line string) []string {
+	lines = append(lines, "")
+	copy(lines[at+1:], lines[at:])
+	lines[at] = line
+	return lines
+}
+
+func leadingSpace(s string) string {
+	return s[:len(s)-len(strings.TrimLeft(s, " \t"))]
+}
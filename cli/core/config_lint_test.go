@@ -0,0 +1,82 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLintConfigTomlType(t *testing.T) {
+	issues := LintConfigToml([]byte("type = \"agnet\"\n"))
+	require.Len(t, issues, 1)
+	assert.Equal(t, "type", issues[0].Key)
+	assert.Equal(t, 1, issues[0].Line)
+	assert.Equal(t, `use type = "agent"`, issues[0].Fix)
+
+	issues = LintConfigToml([]byte("type = \"mcp\"\n"))
+	require.Len(t, issues, 1)
+	assert.Contains(t, issues[0].Message, "MCP servers")
+	assert.Equal(t, "type = \"function\"\n", string(FixConfigToml([]byte("type = \"mcp\"\n"), issues)))
+
+	issues = LintConfigToml([]byte("type = \"database\"\n"))
+	require.Len(t, issues, 1)
+	assert.Empty(t, issues[0].Fix)
+
+	assert.Empty(t, LintConfigToml([]byte("type = \"volumetemplate\"\ndefaultSize = 512\n")))
+}
+
+func TestLintConfigTomlBuildTarget(t *testing.T) {
+	issues := LintConfigToml([]byte("[build]\ntarget = \"production\"\nargs = { PYTHON_VERSION = \"3.12\" }\n"))
+	require.Len(t, issues, 1)
+	assert.Equal(t, "build.target", issues[0].Key)
+	assert.Equal(t, 2, issues[0].Line)
+	assert.Empty(t, issues[0].Fix)
+}
+
+func TestLintConfigTomlFixes(t *testing.T) {
+	content := `type = "vt"
+name = "files"
+
+[runtime]
+timeout = 900
+
+[[runtime.ports]]
+name = "http"
+target = 80
+
+[[triggers]]
+type = "http-async"
+timeout = "15m"
+`
+	issues := LintConfigToml([]byte(content))
+	require.Len(t, issues, 3)
+
+	expected := `type = "vt"
+defaultSize = 1024
+name = "files"
+
+[runtime]
+timeout = "15m"
+
+[[runtime.ports]]
+name = "http"
+target = 80
+protocol = "HTTP"
+
+[[triggers]]
+type = "http-async"
+timeout = "15m"
+`
+	fixed := FixConfigToml([]byte(content), issues)
+	assert.Equal(t, expected, string(fixed))
+	assert.Empty(t, LintConfigToml(fixed))
+}
+
+func TestFormatDurationSeconds(t *testing.T) {
+	assert.Equal(t, "15m", FormatDurationSeconds(900))
+	assert.Equal(t, "2h", FormatDurationSeconds(7200))
+	assert.Equal(t, "1d", FormatDurationSeconds(86400))
+	assert.Equal(t, "90s", FormatDurationSeconds(90))
+	assert.Equal(t, "0s", FormatDurationSeconds(0))
+}
@@ -0,0 +1,209 @@
+package core
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+// tomlSection is a [table] of a TOML document, spanning the lines [start, end)
+type tomlSection struct {
+	name  string // dotted table name, "" for the top-level keys
+	array bool   // [[array of tables]], never edited
+	start int
+	end   int
+}
+
+// SaveConfigSets writes --set overrides into the blaxel.toml of folder, keeping
+// the rest of the file (comments, ordering, formatting) untouched. Existing keys
+// are rewritten in place and missing ones are added to their table. It returns
+// one line per override describing what was written.
+func SaveConfigSets(folder string, sets []string) ([]string, error) {
+	parsed, err := parseConfigSets(sets)
+	if err != nil {
+		return nil, err
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+	path := filepath.Join(cwd, folder, "blaxel.toml")
+	content, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	lines := []string{}
+	if len(content) > 0 {
+		lines = strings.Split(strings.TrimSuffix(string(content), "\n"), "\n")
+	}
+	written := []string{}
+	for _, set := range parsed {
+		var action string
+		lines, action, err = setTomlKey(lines, set.key, set.literal)
+		if err != nil {
+			return nil, err
+		}
+		written = append(written, fmt.Sprintf("%s = %s (%s)", set.key, set.literal, action))
+	}
+
+	updated := strings.Join(lines, "\n") + "\n"
+	var check map[string]interface{}
+	if _, err := toml.Decode(updated, &check); err != nil {
+		return nil, fmt.Errorf("could not update %s without breaking it: %w", path, err)
+	}
+	if err := os.WriteFile(path, []byte(updated), 0644); err != nil {
+		return nil, fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return written, nil
+}
+
+// setTomlKey sets the dotted key to literal in lines and reports whether the key was "updated" or "added"
+func setTomlKey(lines []string, key, literal string) ([]string, string, error) {
+	sections := tomlSections(lines)
+	table, leaf := "", key
+	if i := strings.LastIndex(key, "."); i >= 0 {
+		table, leaf = key[:i], key[i+1:]
+	}
+
+	anchor, anchorKey := -1, ""
+	for _, section := range sections {
+		if section.array || (section.name != "" && !strings.HasPrefix(key, section.name+".")) {
+			continue
+		}
+		rest := key
+		if section.name != "" {
+			rest = strings.TrimPrefix(key, section.name+".")
+		}
+		for i := section.start; i < section.end; i++ {
+			lineKey, value, ok := tomlKeyValue(lines[i])
+			if !ok {
+				continue
+			}
+			switch {
+			case lineKey == rest:
+				end := tomlValueEnd(lines, i, value)
+				replaced := append([]string{}, lines[:i]...)
+				line := leadingSpace(lines[i]) + rest + " = " + literal
+				if end == i {
+					line += tomlTrailingComment(value)
+				}
+				replaced = append(replaced, line)
+				return append(replaced, lines[end+1:]...), "updated", nil
+			case strings.HasPrefix(rest, lineKey+".") && strings.HasPrefix(value, "{"):
+				return nil, "", fmt.Errorf("cannot save %s: %s is an inline table in blaxel.toml", key, lineKey)
+			case table != "" && strings.HasPrefix(lineKey, strings.TrimPrefix(table, section.name+".")+".") && section.name != table:
+				anchor, anchorKey = tomlValueEnd(lines, i, value), rest
+			}
+		}
+	}
+
+	for _, section := range sections {
+		if !section.array && section.name == table {
+			at := section.start
+			for i := section.start; i < section.end; i++ {
+				if trimmed := strings.TrimSpace(lines[i]); trimmed != "" && !strings.HasPrefix(trimmed, "#") {
+					at = i + 1
+				}
+			}
+			return insertLine(lines, at, leaf+" = "+literal), "added", nil
+		}
+	}
+	if anchor >= 0 {
+		return insertLine(lines, anchor+1, anchorKey+" = "+literal), "added", nil
+	}
+
+	if len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) != "" {
+		lines = append(lines, "")
+	}
+	return append(lines, "["+table+"]", leaf+" = "+literal), "added", nil
+}
+
+// tomlSections splits lines into the top-level section followed by one section per table header
+func tomlSections(lines []string) []tomlSection {
+	sections := []tomlSection{{name: "", start: 0, end: len(lines)}}
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if !strings.HasPrefix(trimmed, "[") {
+			continue
+		}
+		sections[len(sections)-1].end = i
+		array := strings.HasPrefix(trimmed, "[[")
+		name := strings.Trim(strings.SplitN(trimmed, "]", 2)[0], "[ \t")
+		if array {
+			name = strings.Trim(strings.SplitN(trimmed, "]]", 2)[0], "[ \t")
+		}
+		sections = append(sections, tomlSection{name: normalizeTomlKey(name), array: array, start: i + 1, end: len(lines)})
+	}
+	return sections
+}
+
+// tomlKeyValue splits a "key = value" line, ignoring blank lines and comments
+func tomlKeyValue(line string) (string, string, bool) {
+	trimmed := strings.TrimSpace(line)
+	if trimmed == "" || strings.HasPrefix(trimmed, "#") || strings.HasPrefix(trimmed, "[") {
+		return "", "", false
+	}
+	key, value, ok := strings.Cut(trimmed, "=")
+	if !ok {
+		return "", "", false
+	}
+	return normalizeTomlKey(key), strings.TrimSpace(value), true
+}
+
+// tomlValueEnd returns the last line of the value starting on line i, following multi-line arrays and strings
+func tomlValueEnd(lines []string, i int, value string) int {
+	switch {
+	case strings.HasPrefix(value, `"""`) || strings.HasPrefix(value, "'''"):
+		quote := value[:3]
+		if strings.Count(value, quote) >= 2 {
+			return i
+		}
+		for j := i + 1; j < len(lines); j++ {
+			if strings.Contains(lines[j], quote) {
+				return j
+			}
+		}
+	case strings.HasPrefix(value, "["):
+		depth := strings.Count(value, "[") - strings.Count(value, "]")
+		for j := i + 1; j < len(lines) && depth > 0; j++ {
+			depth += strings.Count(lines[j], "[") - strings.Count(lines[j], "]")
+			if depth <= 0 {
+				return j
+			}
+		}
+	}
+	return i
+}
+
+// tomlTrailingComment returns the " # comment" following a single-line value, if any
+func tomlTrailingComment(value string) string {
+	var quote rune
+	escaped := false
+	for i, c := range value {
+		switch {
+		case escaped:
+			escaped = false
+		case quote == '"' && c == '\\':
+			escaped = true
+		case quote != 0 && c == quote:
+			quote = 0
+		case quote == 0 && (c == '"' || c == '\''):
+			quote = c
+		case quote == 0 && c == '#':
+			return " " + value[i:]
+		}
+	}
+	return ""
+}
+
+func normalizeTomlKey(key string) string {
+	parts := strings.Split(key, ".")
+	for i, part := range parts {
+		parts[i] = strings.Trim(strings.TrimSpace(part), `"'`)
+	}
+	return strings.Join(parts, ".")
+}
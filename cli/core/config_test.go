@@ -471,6 +471,76 @@ workspace = "test-workspace"
 	assert.Equal(t, "my-function", config.Name)
 }
 
+func TestReadConfigTomlWorkspaceFlag(t *testing.T) {
+	original := config
+	originalWorkspace := workspace
+	defer func() {
+		config = original
+		workspace = originalWorkspace
+		workspaceFlagSet = false
+	}()
+
+	tempDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "blaxel.toml"), []byte(`workspace = "toml-workspace"`), 0644))
+	originalDir, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(tempDir))
+	defer func() { _ = os.Chdir(originalDir) }()
+
+	workspace = "context-workspace"
+	ResetConfig()
+	readConfigToml("", false)
+	assert.Equal(t, "toml-workspace", workspace, "blaxel.toml overrides the current context")
+
+	workspace = "staging"
+	workspaceFlagSet = true
+	ResetConfig()
+	readConfigToml("", false)
+	assert.Equal(t, "staging", workspace, "--workspace overrides blaxel.toml")
+}
+
+func TestCheckConfigWorkspace(t *testing.T) {
+	original := config
+	originalWorkspace, originalContext := workspace, contextWorkspace
+	defer func() {
+		config = original
+		workspace, contextWorkspace = originalWorkspace, originalContext
+		workspaceFlagSet = false
+	}()
+
+	tests := []struct {
+		name          string
+		toml          string
+		context       string
+		flag          string
+		expectedError string
+	}{
+		{name: "no workspace in blaxel.toml", context: "staging"},
+		{name: "same workspace", toml: "prod", context: "prod"},
+		{name: "mismatch", toml: "prod", context: "staging", expectedError: "blaxel.toml pins workspace 'prod' but the current workspace is 'staging'"},
+		{name: "flag selects blaxel.toml", toml: "prod", context: "staging", flag: "prod"},
+		{name: "flag selects the context", toml: "prod", context: "staging", flag: "staging"},
+		{name: "flag matches neither", toml: "prod", context: "staging", flag: "dev", expectedError: "--workspace dev matches neither"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config = Config{Workspace: tt.toml}
+			contextWorkspace = tt.context
+			workspace, workspaceFlagSet = tt.toml, tt.flag != ""
+			if tt.flag != "" {
+				workspace = tt.flag
+			}
+			err := CheckConfigWorkspace()
+			if tt.expectedError == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.expectedError)
+		})
+	}
+}
+
 func TestResourceListExec(t *testing.T) {
 	r := &Resource{Kind: "Agent"}
 	result, err := r.ListExec()
@@ -489,3 +559,351 @@ func TestResourcePostFn(t *testing.T) {
 	result := r.PostFn("agent", "test-agent", nil)
 	assert.Nil(t, result)
 }
+
+func TestReadConfigTomlWithLocalOverrides(t *testing.T) {
+	original := config
+	defer func() { config = original }()
+
+	tempDir := t.TempDir()
+	baseContent := `
+type = "agent"
+name = "my-agent"
+port = 8080
+
+[env]
+LOG_LEVEL = "info"
+API_URL = "https://api.example.com"
+`
+	localContent := `
+port = 9090
+
+[env]
+LOG_LEVEL = "debug"
+`
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "blaxel.toml"), []byte(baseContent), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, LocalConfigFileName), []byte(localContent), 0644))
+
+	originalDir, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(tempDir))
+	defer func() { _ = os.Chdir(originalDir) }()
+
+	ResetConfig()
+	readConfigToml("", false)
+
+	assert.Equal(t, "my-agent", config.Name)
+	assert.Equal(t, 9090, config.Port)
+	assert.Equal(t, "debug", config.Env["LOG_LEVEL"])
+	assert.Equal(t, "https://api.example.com", config.Env["API_URL"])
+	assert.ElementsMatch(t, []string{"port", "env.LOG_LEVEL"}, GetLocalConfigOverrides())
+}
+
+func TestReadConfigTomlWithInvalidLocalOverrides(t *testing.T) {
+	original := config
+	defer func() {
+		config = original
+		ClearBlaxelTomlWarning()
+	}()
+
+	tempDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "blaxel.toml"), []byte(`name = "my-agent"`), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, LocalConfigFileName), []byte(`port = "not-a-number"`), 0644))
+
+	originalDir, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(tempDir))
+	defer func() { _ = os.Chdir(originalDir) }()
+
+	ResetConfig()
+	readConfigToml("", false)
+
+	assert.Contains(t, GetBlaxelTomlWarning(), LocalConfigFileName)
+}
+
+func TestApplyConfigSets(t *testing.T) {
+	original := config
+	defer func() { config = original }()
+
+	ResetConfig()
+	_, err := toml.Decode(`
+name = "my-agent"
+
+[runtime]
+memory = 4096
+generation = "mk3"
+`, &config)
+	require.NoError(t, err)
+
+	err = ApplyConfigSets([]string{
+		"runtime.memory=8192",
+		"runtime.maxScale=3",
+		"runtime.ratio=0.5",
+		"runtime.image=my-image:latest",
+		`runtime.tag="42"`,
+		"public=true",
+		"region=eu-lon-1",
+		"env.LOG_LEVEL=debug",
+	})
+	require.NoError(t, err)
+
+	runtime := *config.Runtime
+	assert.Equal(t, int64(8192), runtime["memory"])
+	assert.Equal(t, int64(3), runtime["maxScale"])
+	assert.Equal(t, 0.5, runtime["ratio"])
+	assert.Equal(t, "my-image:latest", runtime["image"])
+	assert.Equal(t, "42", runtime["tag"])
+	assert.Equal(t, "mk3", runtime["generation"], "keys not overridden are kept")
+	require.NotNil(t, config.Public)
+	assert.True(t, *config.Public)
+	assert.Equal(t, "eu-lon-1", config.Region)
+	assert.Equal(t, "my-agent", config.Name)
+	assert.Equal(t, "debug", config.Env["LOG_LEVEL"])
+}
+
+func TestApplyConfigSetsLastWins(t *testing.T) {
+	original := config
+	defer func() { config = original }()
+	ResetConfig()
+
+	require.NoError(t, ApplyConfigSets([]string{"runtime.memory=1", "region=eu-lon-1", "runtime.memory=2"}))
+	assert.Equal(t, int64(2), (*config.Runtime)["memory"])
+	assert.Equal(t, "eu-lon-1", config.Region)
+
+	parsed, err := parseConfigSets([]string{"runtime.memory=1", "region=eu-lon-1", "runtime.memory=2"})
+	require.NoError(t, err)
+	assert.Equal(t, []configSet{{key: "runtime.memory", literal: "2"}, {key: "region", literal: `"eu-lon-1"`}}, parsed)
+}
+
+func TestApplyConfigSetsErrors(t *testing.T) {
+	original := config
+	defer func() { config = original }()
+	ResetConfig()
+
+	assert.NoError(t, ApplyConfigSets(nil))
+	assert.Error(t, ApplyConfigSets([]string{"runtime.memory"}))
+	assert.Error(t, ApplyConfigSets([]string{"runtime..memory=1"}))
+	assert.Error(t, ApplyConfigSets([]string{"port=not-a-number"}))
+}
+
+func TestSaveConfigSets(t *testing.T) {
+	tempDir := t.TempDir()
+	original := `# My agent
+name = "my-agent"
+type = "agent"
+
+[runtime]
+# Memory in MB
+memory = 4096 # at least 2048
+generation = "mk3"
+
+[env]
+FOO = "bar"
+`
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "blaxel.toml"), []byte(original), 0644))
+
+	originalDir, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(tempDir))
+	defer func() { _ = os.Chdir(originalDir) }()
+
+	written, err := SaveConfigSets("", []string{"runtime.memory=8192", "runtime.maxScale=3", "name=other", "policies.0=x", "env.BAR=baz"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"runtime.memory = 8192 (updated)",
+		"runtime.maxScale = 3 (added)",
+		`name = "other" (updated)`,
+		`policies.0 = "x" (added)`,
+		`env.BAR = "baz" (added)`,
+	}, written)
+
+	content, err := os.ReadFile(filepath.Join(tempDir, "blaxel.toml"))
+	require.NoError(t, err)
+	assert.Equal(t, `# My agent
+name = "other"
+type = "agent"
+
+[runtime]
+# Memory in MB
+memory = 8192 # at least 2048
+generation = "mk3"
+maxScale = 3
+
+[env]
+FOO = "bar"
+BAR = "baz"
+
+[policies]
+0 = "x"
+`, string(content))
+}
+
+func TestSaveConfigSetsDottedKeys(t *testing.T) {
+	tempDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "blaxel.toml"), []byte("name = \"a\"\nruntime.memory = 4096\n\n[env]\nFOO = \"bar\"\n"), 0644))
+
+	originalDir, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(tempDir))
+	defer func() { _ = os.Chdir(originalDir) }()
+
+	_, err = SaveConfigSets("", []string{"runtime.maxScale=2", "runtime.memory=1024"})
+	require.NoError(t, err)
+
+	content, err := os.ReadFile(filepath.Join(tempDir, "blaxel.toml"))
+	require.NoError(t, err)
+	assert.Equal(t, "name = \"a\"\nruntime.memory = 1024\nruntime.maxScale = 2\n\n[env]\nFOO = \"bar\"\n", string(content))
+}
+
+func TestSaveConfigSetsInlineTable(t *testing.T) {
+	tempDir := t.TempDir()
+	original := "runtime = { memory = 4096 }\n"
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "blaxel.toml"), []byte(original), 0644))
+
+	originalDir, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(tempDir))
+	defer func() { _ = os.Chdir(originalDir) }()
+
+	_, err = SaveConfigSets("", []string{"runtime.memory=8192"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "inline table")
+
+	content, err := os.ReadFile(filepath.Join(tempDir, "blaxel.toml"))
+	require.NoError(t, err)
+	assert.Equal(t, original, string(content))
+}
+
+func TestTomlTrailingComment(t *testing.T) {
+	assert.Equal(t, " # in MB", tomlTrailingComment("4096 # in MB"))
+	assert.Equal(t, "", tomlTrailingComment(`"a # not a comment"`))
+	assert.Equal(t, " # real", tomlTrailingComment(`"a \" # quoted" # real`))
+	assert.Equal(t, " # real", tomlTrailingComment(`'C:\' # real`))
+	assert.Equal(t, "", tomlTrailingComment("4096"))
+}
+
+func TestReadConfigTomlWithEnvironment(t *testing.T) {
+	original := config
+	defer func() {
+		config = original
+		SetConfigEnvironment("")
+	}()
+
+	tempDir := t.TempDir()
+	content := `
+type = "agent"
+name = "my-agent"
+
+[runtime]
+memory = 4096
+maxScale = 1
+
+[env]
+LOG_LEVEL = "info"
+
+[env.production.runtime]
+memory = 8192
+
+[env.production.env]
+LOG_LEVEL = "warn"
+
+[env.staging.runtime]
+maxScale = 2
+`
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "blaxel.toml"), []byte(content), 0644))
+
+	originalDir, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(tempDir))
+	defer func() { _ = os.Chdir(originalDir) }()
+
+	t.Run("base config without environment", func(t *testing.T) {
+		ResetConfig()
+		SetConfigEnvironment("")
+		readConfigToml("", false)
+		require.Empty(t, GetBlaxelTomlWarning())
+		assert.Equal(t, int64(4096), (*config.Runtime)["memory"])
+		assert.Equal(t, Envs{"LOG_LEVEL": "info"}, config.Env)
+		assert.Len(t, config.Environments, 2)
+	})
+
+	t.Run("merges the selected environment", func(t *testing.T) {
+		ResetConfig()
+		SetConfigEnvironment("production")
+		readConfigToml("", false)
+		require.Empty(t, GetBlaxelTomlWarning())
+		assert.Equal(t, int64(8192), (*config.Runtime)["memory"])
+		assert.Equal(t, int64(1), (*config.Runtime)["maxScale"])
+		assert.Equal(t, Envs{"LOG_LEVEL": "warn"}, config.Env)
+	})
+
+	t.Run("--set wins over the environment", func(t *testing.T) {
+		ResetConfig()
+		SetConfigEnvironment("production")
+		readConfigToml("", false)
+		require.NoError(t, ApplyConfigSets([]string{"runtime.memory=2048"}))
+		assert.Equal(t, int64(2048), (*config.Runtime)["memory"])
+	})
+
+	t.Run("unknown environment falls back to the base", func(t *testing.T) {
+		ResetConfig()
+		SetConfigEnvironment("qa")
+		readConfigToml("", false)
+		require.Empty(t, GetBlaxelTomlWarning())
+		assert.Equal(t, int64(4096), (*config.Runtime)["memory"])
+		assert.Equal(t, int64(1), (*config.Runtime)["maxScale"])
+	})
+}
+
+func TestEnvsUnmarshalTOMLRejectsNonStrings(t *testing.T) {
+	var cfg Config
+	_, err := toml.Decode("[env]\nPORT = 8080\n", &cfg)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "env.PORT: expected a string")
+}
+
+func TestReadConfigTomlExpandsVariables(t *testing.T) {
+	original := config
+	defer func() { config = original }()
+	t.Setenv("REGISTRY", "ghcr.io/acme")
+	t.Setenv("LOG_LEVEL", "debug")
+	_ = os.Unsetenv("MISSING_TOKEN")
+
+	tempDir := t.TempDir()
+	content := `
+type = "agent"
+image = "${REGISTRY}/my-agent"
+
+[runtime]
+memory = 4096
+envs = ["$LOG_LEVEL"]
+
+[env]
+LOG_LEVEL = "$LOG_LEVEL"
+TOKEN = "${MISSING_TOKEN}"
+API_KEY = "$secrets.API_KEY"
+
+[[triggers]]
+type = "http"
+[triggers.configuration]
+path = "/${LOG_LEVEL}"
+`
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "blaxel.toml"), []byte(content), 0644))
+
+	originalDir, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(tempDir))
+	defer func() { _ = os.Chdir(originalDir) }()
+
+	ResetConfig()
+	readConfigToml("", false)
+	require.Empty(t, GetBlaxelTomlWarning())
+	assert.Equal(t, "ghcr.io/acme/my-agent", config.Image)
+	assert.Equal(t, int64(4096), (*config.Runtime)["memory"])
+	assert.Equal(t, []interface{}{"debug"}, (*config.Runtime)["envs"])
+	assert.Equal(t, "debug", config.Env["LOG_LEVEL"])
+	assert.Equal(t, "", config.Env["TOKEN"])
+	assert.Equal(t, "$secrets.API_KEY", config.Env["API_KEY"])
+	require.NotNil(t, config.Triggers)
+	assert.Equal(t, "/debug", (*config.Triggers)[0]["configuration"].(map[string]interface{})["path"])
+	assert.Equal(t, []string{"MISSING_TOKEN"}, UndefinedConfigVars())
+}
@@ -8,6 +8,7 @@ import (
 	"os"
 	"os/user"
 	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/charmbracelet/huh"
@@ -15,6 +16,15 @@ import (
 	"gopkg.in/yaml.v3"
 )
 
+// gitTemplate is the repository set with SetGitTemplate, used instead of the built-in templates
+var gitTemplate *GitTemplate
+
+// SetGitTemplate makes the next create flow scaffold from a git repository
+// instead of the built-in templates. nil restores the built-in templates.
+func SetGitTemplate(g *GitTemplate) {
+	gitTemplate = g
+}
+
 // RandomString generates a random alphanumeric string of the given length.
 func RandomString(length int) string {
 	const charset = "abcdefghijklmnopqrstuvwxyz0123456789"
@@ -49,11 +59,14 @@ type CreateFlowConfig struct {
 	SpinnerTitle string
 	// Optional: when set, append a section to blaxel.toml with this resource type (e.g., "agent" or "function").
 	BlaxelTomlResourceType string
+	// Optional: when set, scaffold from this git repository instead of the built-in templates.
+	GitTemplate *GitTemplate
 }
 
 type createFlowDeps struct {
 	RetrieveTemplates func(templateType string, noTTY bool, errorPrefix string) (Templates, error)
 	CloneTemplate     func(opts TemplateOptions, templates Templates, noTTY bool, errorPrefix string, spinnerTitle string) error
+	CloneGitTemplate  func(g GitTemplate, opts TemplateOptions, noTTY bool, errorPrefix string, spinnerTitle string) error
 	CleanTemplate     func(directory string)
 	EditBlaxelToml    func(resourceType string, projectName string, directory string) error
 	OutputFormat      func() string
@@ -63,6 +76,7 @@ func defaultCreateFlowDeps() createFlowDeps {
 	return createFlowDeps{
 		RetrieveTemplates: RetrieveTemplatesWithSpinner,
 		CloneTemplate:     CloneTemplateWithSpinner,
+		CloneGitTemplate:  CloneGitTemplateWithSpinner,
 		CleanTemplate:     CleanTemplate,
 		EditBlaxelToml:    EditBlaxelTomlInCurrentDir,
 		OutputFormat:      GetOutputFormat,
@@ -77,6 +91,9 @@ func fillCreateFlowDeps(deps createFlowDeps) createFlowDeps {
 	if deps.CloneTemplate == nil {
 		deps.CloneTemplate = defaults.CloneTemplate
 	}
+	if deps.CloneGitTemplate == nil {
+		deps.CloneGitTemplate = defaults.CloneGitTemplate
+	}
 	if deps.CleanTemplate == nil {
 		deps.CleanTemplate = defaults.CleanTemplate
 	}
@@ -104,6 +121,7 @@ func runCreateFlow(
 	promptFunc func(directory string, templates Templates) TemplateOptions,
 	successFunc func(opts TemplateOptions),
 ) {
+	cfg.GitTemplate = gitTemplate
 	if err := runCreateFlowWithDeps(dirArg, templateNameFlag, cfg, promptFunc, successFunc, defaultCreateFlowDeps()); err != nil {
 		ExitWithError(err)
 	}
@@ -135,62 +153,25 @@ func runCreateFlowWithDeps(
 		}
 	}
 
-	// Retrieve templates (with or without spinner)
-	templates, err := deps.RetrieveTemplates(cfg.TemplateType, cfg.NoTTY, cfg.ErrorPrefix)
-	if err != nil {
-		return err
-	}
-
-	// Resolve options
 	var opts TemplateOptions
-	switch {
-	case templateNameFlag != "":
-		// Template provided via flag. Determine directory (dirArg or templateName) and ensure it's free
-		selectedDir := dirArg
-		if selectedDir == "" {
-			selectedDir = templateNameFlag
-		}
-		if _, err := os.Stat(selectedDir); !os.IsNotExist(err) {
-			createErr := fmt.Errorf("directory '%s' already exists", selectedDir)
-			PrintError(cfg.ErrorPrefix, createErr)
-			return createErr
-		}
-		opts = CreateDefaultTemplateOptions(selectedDir, templateNameFlag, templates)
-		if opts.TemplateName == "" {
-			createErr := fmt.Errorf("template '%s' not found", templateNameFlag)
-			PrintError(cfg.ErrorPrefix, createErr)
-			printAvailableTemplates(templates, cfg.TemplateType)
-			return createErr
-		}
-	case cfg.NoTTY && cfg.TemplateType == "mcp":
-		// Special-case retained behavior: for MCP with --yes but no template we require directory and pick default
-		if dirArg == "" {
-			createErr := fmt.Errorf("directory name is required")
-			PrintError(cfg.ErrorPrefix, createErr)
-			return createErr
+	if cfg.GitTemplate != nil {
+		opts = TemplateOptions{
+			ProjectName:  dirArg,
+			Directory:    dirArg,
+			TemplateName: cfg.GitTemplate.URL,
 		}
-		opts = CreateDefaultTemplateOptions(dirArg, "", templates)
-	default:
-		// Interactive prompt
-		opts = promptFunc(dirArg, templates)
-		// Safety checks
-		if opts.Directory == "" {
-			createErr := fmt.Errorf("directory name is required")
-			PrintError(cfg.ErrorPrefix, createErr)
-			return createErr
+		if err := deps.CloneGitTemplate(*cfg.GitTemplate, opts, cfg.NoTTY, cfg.ErrorPrefix, cfg.SpinnerTitle); err != nil {
+			return err
 		}
-		if _, err := os.Stat(opts.Directory); !os.IsNotExist(err) {
-			createErr := fmt.Errorf("directory '%s' already exists", opts.Directory)
-			PrintError(cfg.ErrorPrefix, createErr)
-			return createErr
+		opts.Language = ModuleLanguage(opts.Directory)
+	} else {
+		var err error
+		opts, err = cloneBuiltinTemplate(dirArg, templateNameFlag, cfg, promptFunc, deps)
+		if err != nil {
+			return err
 		}
 	}
 
-	// Clone template using the unified helper
-	if err := deps.CloneTemplate(opts, templates, cfg.NoTTY, cfg.ErrorPrefix, cfg.SpinnerTitle); err != nil {
-		return err
-	}
-
 	deps.CleanTemplate(opts.Directory)
 
 	if cfg.TemplateType == "sandbox" {
@@ -233,6 +214,76 @@ func runCreateFlowWithDeps(
 	return nil
 }
 
+// cloneBuiltinTemplate resolves the built-in template from the flag or the
+// interactive prompt and clones it
+func cloneBuiltinTemplate(
+	dirArg string,
+	templateNameFlag string,
+	cfg CreateFlowConfig,
+	promptFunc func(directory string, templates Templates) TemplateOptions,
+	deps createFlowDeps,
+) (TemplateOptions, error) {
+	// Retrieve templates (with or without spinner)
+	templates, err := deps.RetrieveTemplates(cfg.TemplateType, cfg.NoTTY, cfg.ErrorPrefix)
+	if err != nil {
+		return TemplateOptions{}, err
+	}
+
+	// Resolve options
+	var opts TemplateOptions
+	switch {
+	case templateNameFlag != "":
+		// Template provided via flag. Determine directory (dirArg or templateName) and ensure it's free
+		selectedDir := dirArg
+		if selectedDir == "" {
+			selectedDir = templateNameFlag
+		}
+		if _, err := os.Stat(selectedDir); !os.IsNotExist(err) {
+			createErr := fmt.Errorf("directory '%s' already exists", selectedDir)
+			PrintError(cfg.ErrorPrefix, createErr)
+			return TemplateOptions{}, createErr
+		}
+		opts = CreateDefaultTemplateOptions(selectedDir, templateNameFlag, templates)
+		if opts.TemplateName == "" {
+			createErr := fmt.Errorf("template '%s' not found", templateNameFlag)
+			PrintError(cfg.ErrorPrefix, createErr)
+			printAvailableTemplates(templates, cfg.TemplateType)
+			return TemplateOptions{}, createErr
+		}
+	case cfg.NoTTY:
+		// Non-interactive without --template: use the default template and say which one
+		if dirArg == "" {
+			createErr := fmt.Errorf("directory name is required")
+			PrintError(cfg.ErrorPrefix, createErr)
+			return TemplateOptions{}, createErr
+		}
+		template := DefaultTemplate(templates, cfg.TemplateType)
+		opts = CreateDefaultTemplateOptions(dirArg, template.Name, templates)
+		PrintDiagnostic(fmt.Sprintf("Using default template %s (pass --template to choose another, see 'bl new %s --list')",
+			strings.TrimPrefix(templateDisplayName(template), "template-"), cfg.TemplateType))
+	default:
+		// Interactive prompt
+		opts = promptFunc(dirArg, templates)
+		// Safety checks
+		if opts.Directory == "" {
+			createErr := fmt.Errorf("directory name is required")
+			PrintError(cfg.ErrorPrefix, createErr)
+			return TemplateOptions{}, createErr
+		}
+		if _, err := os.Stat(opts.Directory); !os.IsNotExist(err) {
+			createErr := fmt.Errorf("directory '%s' already exists", opts.Directory)
+			PrintError(cfg.ErrorPrefix, createErr)
+			return TemplateOptions{}, createErr
+		}
+	}
+
+	// Clone template using the unified helper
+	if err := deps.CloneTemplate(opts, templates, cfg.NoTTY, cfg.ErrorPrefix, cfg.SpinnerTitle); err != nil {
+		return TemplateOptions{}, err
+	}
+	return opts, nil
+}
+
 func normalizeTemplateNameFlag(templateNameFlag string, templateType string) string {
 	if templateType == "sandbox" {
 		if templateName, ok := sandboxTemplateAlias(templateNameFlag); ok {
@@ -245,6 +296,43 @@ func normalizeTemplateNameFlag(templateNameFlag string, templateType string) str
 	return templateNameFlag
 }
 
+// isBlankTemplate reports whether t is a "from scratch" template
+func isBlankTemplate(t Template) bool {
+	name := strings.ToLower(templateDisplayName(t))
+	return strings.Contains(name, "template-blank") || strings.HasPrefix(name, "blank-") || name == "blank"
+}
+
+// DefaultTemplate returns the template used when none is chosen in
+// non-interactive mode: the first sandbox type for sandboxes, otherwise the
+// first non-blank template in name order (names carry a curation prefix).
+// templates must not be empty.
+func DefaultTemplate(templates Templates, templateType string) Template {
+	if templateType == "sandbox" {
+		return sandboxTemplatesForDisplay(templates)[0]
+	}
+	sorted := append(Templates{}, templates...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+	for _, t := range sorted {
+		if !isBlankTemplate(t) {
+			return t
+		}
+	}
+	return sorted[0]
+}
+
+// templateOptionLabel is the line shown for t in the interactive template picker
+func templateOptionLabel(t Template) string {
+	label := strings.TrimPrefix(templateDisplayName(t), "template-")
+	description := strings.TrimSpace(t.Description)
+	if description == "" {
+		return label
+	}
+	if len(description) > 60 {
+		description = strings.TrimSpace(description[:57]) + "..."
+	}
+	return fmt.Sprintf("%-28s %s", label, description)
+}
+
 func templateDisplayName(t Template) string {
 	return regexp.MustCompile(`^\d+-`).ReplaceAllString(t.Name, "")
 }
@@ -308,12 +396,6 @@ func PromptTemplateOptions(directory string, templates Templates, resource strin
 		options.Author = "blaxel"
 	}
 
-	stripRe := regexp.MustCompile(`^\d+-`)
-	isBlank := func(t Template) bool {
-		name := strings.ToLower(stripRe.ReplaceAllString(t.Name, ""))
-		return strings.Contains(name, "template-blank") || strings.HasPrefix(name, "blank-") || name == "blank"
-	}
-
 	totalTemplates := len(templates)
 	languages := templates.GetLanguages()
 
@@ -363,7 +445,7 @@ func PromptTemplateOptions(directory string, templates Templates, resource strin
 	// Decide if any blank exists globally to include start choice in the first form
 	anyHasBlank := false
 	for _, t := range templates {
-		if isBlank(t) {
+		if isBlankTemplate(t) {
 			anyHasBlank = true
 			break
 		}
@@ -407,7 +489,7 @@ func PromptTemplateOptions(directory string, templates Templates, resource strin
 	// Multiple templates for language
 	var blankTemplate *Template
 	for idx := range filtered {
-		if isBlank(filtered[idx]) {
+		if isBlankTemplate(filtered[idx]) {
 			blankTemplate = &filtered[idx]
 			break
 		}
@@ -425,19 +507,18 @@ func PromptTemplateOptions(directory string, templates Templates, resource strin
 		Title("Loading templates...").
 		Action(func() {
 			for _, t := range filtered {
-				if isBlank(t) {
+				if isBlankTemplate(t) {
 					continue
 				}
-				key := stripRe.ReplaceAllString(t.Name, "")
-				key = strings.TrimPrefix(key, "template-")
-				templateOptions = append(templateOptions, huh.NewOption(key, t.Name))
+				templateOptions = append(templateOptions, huh.NewOption(templateOptionLabel(t), t.Name))
 			}
 		}).
 		Run()
 	tmplSelect := huh.NewSelect[string]().
 		Title("Template").
-		Description("Template to use for your " + resource).
+		Description("Template to use for your " + resource + ", type to filter").
 		Options(templateOptions...).
+		Filtering(true).
 		Value(&options.TemplateName)
 	// Only set Height when there are enough options to need scrolling;
 	// setting Height triggers a huh viewport bug that hides earlier options.
@@ -3,8 +3,10 @@ package core
 import (
 	"errors"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"regexp"
+	"strings"
 	"testing"
 
 	blaxel "github.com/blaxel-ai/sdk-go"
@@ -513,3 +515,143 @@ func TestFinalizeSandboxTemplateRemovesDuplicateLowercaseDockerfile(t *testing.T
 	require.NoError(t, err)
 	assert.Contains(t, string(dockerfile), "npm install -g @anthropic-ai/claude-code@latest")
 }
+
+func TestRunCreateFlowWithDepsFromGit(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "new-agent")
+	var cloned GitTemplate
+	var edited string
+
+	var err error
+	stdout, _ := captureStandardStreams(t, func() {
+		err = runCreateFlowWithDeps(
+			dir,
+			"",
+			CreateFlowConfig{
+				TemplateType:           "agent",
+				NoTTY:                  true,
+				ErrorPrefix:            "Agent creation",
+				BlaxelTomlResourceType: "agent",
+				GitTemplate:            &GitTemplate{URL: "https://github.com/me/my-template", Branch: "v2"},
+			},
+			func(directory string, templates Templates) TemplateOptions {
+				t.Fatal("prompt should not run with a git template")
+				return TemplateOptions{}
+			},
+			func(opts TemplateOptions) {
+				t.Fatal("success should not run with structured output")
+			},
+			createFlowDeps{
+				RetrieveTemplates: func(templateType string, noTTY bool, errorPrefix string) (Templates, error) {
+					t.Fatal("built-in templates should not be retrieved with a git template")
+					return nil, nil
+				},
+				CloneGitTemplate: func(g GitTemplate, opts TemplateOptions, noTTY bool, errorPrefix string, spinnerTitle string) error {
+					cloned = g
+					require.NoError(t, os.MkdirAll(opts.Directory, 0755))
+					return os.WriteFile(filepath.Join(opts.Directory, "requirements.txt"), nil, 0644)
+				},
+				EditBlaxelToml: func(resourceType string, projectName string, directory string) error {
+					edited = resourceType + ":" + directory
+					return nil
+				},
+				OutputFormat: func() string { return "json" },
+			},
+		)
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, GitTemplate{URL: "https://github.com/me/my-template", Branch: "v2"}, cloned)
+	assert.Equal(t, "agent:"+dir, edited)
+	assert.Contains(t, stdout, `"template": "https://github.com/me/my-template"`)
+	assert.Contains(t, stdout, `"language": "python"`)
+}
+
+func TestGitTemplateClone(t *testing.T) {
+	repo := t.TempDir()
+	git := func(args ...string) {
+		cmd := exec.Command("git", append([]string{"-C", repo, "-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+		output, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(output))
+	}
+	git("init", "-q", "-b", "main")
+	require.NoError(t, os.WriteFile(filepath.Join(repo, "README.md"), []byte("main"), 0644))
+	git("add", ".")
+	git("commit", "-q", "-m", "main")
+	git("checkout", "-q", "-b", "v2")
+	require.NoError(t, os.WriteFile(filepath.Join(repo, "README.md"), []byte("v2"), 0644))
+	git("commit", "-q", "-am", "v2")
+
+	dir := filepath.Join(t.TempDir(), "out")
+	require.NoError(t, GitTemplate{URL: "file://" + repo, Branch: "main"}.Clone(TemplateOptions{Directory: dir}))
+	content, err := os.ReadFile(filepath.Join(dir, "README.md"))
+	require.NoError(t, err)
+	assert.Equal(t, "main", string(content))
+	assert.NoDirExists(t, filepath.Join(dir, ".git"))
+
+	err = GitTemplate{URL: "file://" + repo, Branch: "missing"}.Clone(TemplateOptions{Directory: filepath.Join(t.TempDir(), "missing")})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to clone")
+}
+
+func TestDefaultTemplate(t *testing.T) {
+	templates := Templates{
+		{Template: blaxel.Template{Name: "03-template-langgraph-py"}, Language: "python"},
+		{Template: blaxel.Template{Name: "01-template-blank-py"}, Language: "python"},
+		{Template: blaxel.Template{Name: "02-template-google-adk-py"}, Language: "python"},
+	}
+	assert.Equal(t, "02-template-google-adk-py", DefaultTemplate(templates, "agent").Name)
+
+	onlyBlank := Templates{{Template: blaxel.Template{Name: "template-blank-ts"}}}
+	assert.Equal(t, "template-blank-ts", DefaultTemplate(onlyBlank, "agent").Name)
+}
+
+func TestTemplateOptionLabel(t *testing.T) {
+	assert.Equal(t, "google-adk-py", templateOptionLabel(Template{Template: blaxel.Template{Name: "02-template-google-adk-py"}}))
+
+	label := templateOptionLabel(Template{Template: blaxel.Template{
+		Name:        "template-langgraph-py",
+		Description: strings.Repeat("a", 80),
+	}})
+	assert.True(t, strings.HasPrefix(label, "langgraph-py "))
+	assert.True(t, strings.HasSuffix(label, strings.Repeat("a", 57)+"..."))
+}
+
+func TestRunCreateFlowWithDepsNonInteractiveUsesDefaultTemplate(t *testing.T) {
+	var cloned TemplateOptions
+	var err error
+	_, stderr := captureStandardStreams(t, func() {
+		err = runCreateFlowWithDeps(
+			filepath.Join(t.TempDir(), "new-agent"),
+			"",
+			CreateFlowConfig{
+				TemplateType: "agent",
+				NoTTY:        true,
+				ErrorPrefix:  "Agent creation",
+			},
+			func(directory string, templates Templates) TemplateOptions {
+				t.Fatal("prompt should not run in non-interactive mode")
+				return TemplateOptions{}
+			},
+			func(opts TemplateOptions) {},
+			createFlowDeps{
+				RetrieveTemplates: func(templateType string, noTTY bool, errorPrefix string) (Templates, error) {
+					return Templates{
+						{Template: blaxel.Template{Name: "template-blank-py"}, Language: "python"},
+						{Template: blaxel.Template{Name: "template-google-adk-py"}, Language: "python"},
+					}, nil
+				},
+				CloneTemplate: func(opts TemplateOptions, templates Templates, noTTY bool, errorPrefix string, spinnerTitle string) error {
+					cloned = opts
+					return nil
+				},
+				CleanTemplate:  func(directory string) {},
+				EditBlaxelToml: func(resourceType string, projectName string, directory string) error { return nil },
+				OutputFormat:   func() string { return "" },
+			},
+		)
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "template-google-adk-py", cloned.TemplateName)
+	assert.Contains(t, stderr, "Using default template google-adk-py")
+}
@@ -0,0 +1,131 @@
+package core
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	blaxel "github.com/blaxel-ai/sdk-go"
+)
+
+// defaultTokenRefreshMargin is used when the token lifetime is unknown
+const defaultTokenRefreshMargin = time.Minute
+
+// DecodeTokenClaims decodes the claims of a JWT access token into v and
+// reports whether token is a JWT whose claims could be decoded
+func DecodeTokenClaims(token string, v any) bool {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return false
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return false
+	}
+	return json.Unmarshal(payload, v) == nil
+}
+
+// tokenExpiry returns when the access token expires and how long it was valid.
+// The expiry comes from the JWT exp claim, or from its iat claim plus the
+// expires_in value stored with the credentials.
+func tokenExpiry(creds blaxel.Credentials) (time.Time, time.Duration, bool) {
+	var claims struct {
+		Exp float64 `json:"exp"`
+		Iat float64 `json:"iat"`
+	}
+	if !DecodeTokenClaims(creds.AccessToken, &claims) {
+		return time.Time{}, 0, false
+	}
+
+	lifetime := time.Duration(creds.ExpiresIn) * time.Second
+	switch {
+	case claims.Exp > 0:
+		expiresAt := time.Unix(int64(claims.Exp), 0)
+		if lifetime == 0 && claims.Iat > 0 {
+			lifetime = expiresAt.Sub(time.Unix(int64(claims.Iat), 0))
+		}
+		return expiresAt, lifetime, true
+	case claims.Iat > 0 && lifetime > 0:
+		return time.Unix(int64(claims.Iat), 0).Add(lifetime), lifetime, true
+	}
+	return time.Time{}, 0, false
+}
+
+// TokenExpiresAt returns when the access token of creds expires, if known
+func TokenExpiresAt(creds blaxel.Credentials) (time.Time, bool) {
+	expiresAt, _, ok := tokenExpiry(creds)
+	return expiresAt, ok
+}
+
+// TokenIdentity returns the user the access token was issued to, taken from
+// its email, preferred_username or sub claim, or "" when it is not a JWT.
+func TokenIdentity(token string) string {
+	var claims struct {
+		Email             string `json:"email"`
+		PreferredUsername string `json:"preferred_username"`
+		Sub               string `json:"sub"`
+	}
+	if !DecodeTokenClaims(token, &claims) {
+		return ""
+	}
+	for _, value := range []string{claims.Email, claims.PreferredUsername, claims.Sub} {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// TokenNeedsRefresh reports whether the access token of creds is expired or
+// close enough to expiry that it should be refreshed before an API call. The
+// token is refreshed when less than a fifth of its lifetime remains.
+func TokenNeedsRefresh(creds blaxel.Credentials, now time.Time) bool {
+	if creds.APIKey != "" || creds.AccessToken == "" || creds.RefreshToken == "" {
+		return false
+	}
+	expiresAt, lifetime, ok := tokenExpiry(creds)
+	if !ok {
+		return false
+	}
+	margin := lifetime / 5
+	if margin <= 0 {
+		margin = defaultTokenRefreshMargin
+	}
+	return expiresAt.Sub(now) <= margin
+}
+
+// ErrSessionExpired is returned by FreshCredentials when the access token is
+// expired and cannot be refreshed, so the user has to log in again
+var ErrSessionExpired = errors.New("session expired")
+
+// FreshCredentials loads the credentials of workspace and, when the access
+// token is expired or about to expire, refreshes it the way the SDK client
+// does, which saves the new token to the config file. Credentials that don't
+// need a refresh are returned as-is. An error wrapping ErrSessionExpired is
+// returned when the token is expired and can't be refreshed.
+func FreshCredentials(ctx context.Context, workspace string) (blaxel.Credentials, error) {
+	creds, err := blaxel.LoadCredentials(workspace)
+	if err != nil {
+		return creds, err
+	}
+	if !TokenNeedsRefresh(creds, time.Now()) {
+		if expiresAt, _, ok := tokenExpiry(creds); ok && creds.APIKey == "" && creds.RefreshToken == "" && !expiresAt.After(time.Now()) {
+			return creds, fmt.Errorf("%w: access token for workspace %s is expired", ErrSessionExpired, workspace)
+		}
+		return creds, nil
+	}
+
+	// The SDK saves refreshed tokens through a callback registered with its
+	// default client options, which also load the default workspace
+	// environment, so the one of workspace is loaded again
+	_ = blaxel.DefaultClientOptions()
+	blaxel.InitializeEnvironment(workspace)
+	if _, err := creds.AuthHeaders(ctx, workspace); err != nil {
+		return creds, fmt.Errorf("%w: %w", ErrSessionExpired, err)
+	}
+	return blaxel.LoadCredentials(workspace)
+}
@@ -0,0 +1,138 @@
+package core
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	blaxel "github.com/blaxel-ai/sdk-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testJWT(claims map[string]any) string {
+	payload, _ := json.Marshal(claims)
+	return "eyJhbGciOiJub25lIn0." + base64.RawURLEncoding.EncodeToString(payload) + ".sig"
+}
+
+func TestTokenNeedsRefresh(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	iat := now.Add(-time.Hour).Unix()
+
+	tests := []struct {
+		name  string
+		creds blaxel.Credentials
+		want  bool
+	}{
+		{
+			name:  "api key",
+			creds: blaxel.Credentials{APIKey: "key"},
+		},
+		{
+			name:  "no refresh token",
+			creds: blaxel.Credentials{AccessToken: testJWT(map[string]any{"exp": now.Add(-time.Minute).Unix()})},
+		},
+		{
+			name:  "far from expiry",
+			creds: blaxel.Credentials{AccessToken: testJWT(map[string]any{"iat": iat, "exp": now.Add(time.Hour).Unix()}), RefreshToken: "r"},
+		},
+		{
+			name:  "close to expiry",
+			creds: blaxel.Credentials{AccessToken: testJWT(map[string]any{"iat": iat, "exp": now.Add(5 * time.Minute).Unix()}), RefreshToken: "r"},
+			want:  true,
+		},
+		{
+			name:  "expired",
+			creds: blaxel.Credentials{AccessToken: testJWT(map[string]any{"exp": now.Add(-time.Minute).Unix()}), RefreshToken: "r"},
+			want:  true,
+		},
+		{
+			name:  "expiry from iat and expires_in",
+			creds: blaxel.Credentials{AccessToken: testJWT(map[string]any{"iat": now.Add(-55 * time.Minute).Unix()}), RefreshToken: "r", ExpiresIn: 3600},
+			want:  true,
+		},
+		{
+			name:  "opaque token",
+			creds: blaxel.Credentials{AccessToken: "opaque", RefreshToken: "r", ExpiresIn: 3600},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, TokenNeedsRefresh(tt.creds, now))
+		})
+	}
+}
+
+func TestFreshCredentials(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	newToken := testJWT(map[string]any{"iat": time.Now().Unix(), "exp": time.Now().Add(time.Hour).Unix()})
+	var refreshBody map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&refreshBody)
+		if refreshBody["refresh_token"] != "good" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"error":"invalid_grant"}`))
+			return
+		}
+		_, _ = fmt.Fprintf(w, `{"access_token":%q,"refresh_token":"rotated","expires_in":3600}`, newToken)
+	}))
+	defer server.Close()
+	t.Setenv("BL_API_URL", server.URL)
+	defer blaxel.SetBaseURL(blaxel.GetBaseURL())
+
+	expired := testJWT(map[string]any{"exp": time.Now().Add(-time.Minute).Unix()})
+	writeCreds := func(creds blaxel.Credentials) {
+		require.NoError(t, blaxel.WriteConfig(blaxel.Config{
+			Context:    blaxel.ContextConfig{Workspace: "ws"},
+			Workspaces: []blaxel.WorkspaceConfig{{Name: "ws", Credentials: creds}},
+		}))
+	}
+
+	t.Run("refreshes and saves an expired token", func(t *testing.T) {
+		writeCreds(blaxel.Credentials{AccessToken: expired, RefreshToken: "good", DeviceCode: "device"})
+		creds, err := FreshCredentials(context.Background(), "ws")
+		require.NoError(t, err)
+		assert.Equal(t, newToken, creds.AccessToken)
+		assert.Equal(t, "device", refreshBody["device_code"])
+
+		saved, err := blaxel.LoadCredentials("ws")
+		require.NoError(t, err)
+		assert.Equal(t, newToken, saved.AccessToken)
+		assert.Equal(t, "rotated", saved.RefreshToken)
+		assert.Equal(t, 3600, saved.ExpiresIn)
+	})
+
+	t.Run("keeps a valid token", func(t *testing.T) {
+		writeCreds(blaxel.Credentials{AccessToken: newToken, RefreshToken: "unused"})
+		creds, err := FreshCredentials(context.Background(), "ws")
+		require.NoError(t, err)
+		assert.Equal(t, newToken, creds.AccessToken)
+	})
+
+	t.Run("refresh failure", func(t *testing.T) {
+		writeCreds(blaxel.Credentials{AccessToken: expired, RefreshToken: "revoked"})
+		_, err := FreshCredentials(context.Background(), "ws")
+		assert.ErrorIs(t, err, ErrSessionExpired)
+		assert.ErrorContains(t, err, "HTTP 401")
+
+		saved, _ := blaxel.LoadCredentials("ws")
+		assert.Equal(t, expired, saved.AccessToken)
+	})
+
+	t.Run("expired token without refresh token", func(t *testing.T) {
+		writeCreds(blaxel.Credentials{AccessToken: expired})
+		_, err := FreshCredentials(context.Background(), "ws")
+		assert.ErrorIs(t, err, ErrSessionExpired)
+	})
+
+	t.Run("unknown workspace", func(t *testing.T) {
+		_, err := FreshCredentials(context.Background(), "other")
+		assert.NotErrorIs(t, err, ErrSessionExpired)
+	})
+}
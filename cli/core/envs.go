@@ -13,9 +13,38 @@ var (
 	secretsEnvRegex = regexp.MustCompile(`^\$secrets\.([A-Za-z0-9_]+)(?::([^\s}]*))?$|^\$\{\s?secrets\.([A-Za-z0-9_]+)(?::([^}]*))?\s?\}$`)
 	// Matches $KEY, ${KEY}, ${KEY:default}
 	plainEnvRegex = regexp.MustCompile(`^\$\{\s?([A-Za-z0-9_]+)(?::([^}]*))?\s?\}$|^\$([A-Za-z0-9_]+)$`)
+	// Matches $$, ${KEY}, ${KEY:default} and $KEY anywhere in a string
+	configVarRegex = regexp.MustCompile(`\$\$|\$\{\s?([A-Za-z_][A-Za-z0-9_]*)(?::([^}]*))?\s?\}|\$([A-Za-z_][A-Za-z0-9_]*)`)
+	// Matches a valid environment variable name, a shell identifier
+	envNameRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
 )
 
 type Envs map[string]string
+
+// UnmarshalTOML decodes the [env] table of blaxel.toml, merging into the
+// existing variables. Sub-tables such as [env.production.runtime] are
+// environment sections, read separately by readConfigToml, and are skipped here.
+func (e *Envs) UnmarshalTOML(data interface{}) error {
+	values, ok := data.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("env: expected a table, got %T", data)
+	}
+	if *e == nil {
+		*e = Envs{}
+	}
+	for key, value := range values {
+		switch v := value.(type) {
+		case string:
+			(*e)[key] = v
+		case map[string]interface{}:
+			continue
+		default:
+			return fmt.Errorf("env.%s: expected a string, got %T", key, value)
+		}
+	}
+	return nil
+}
+
 type Env struct {
 	Name  string `json:"name"`
 	Value string `json:"value"`
@@ -65,6 +94,46 @@ func ResolveVarValue(v string) (string, string) {
 	return v, ""
 }
 
+// ExpandConfigVars replaces the ${KEY}, ${KEY:default} and $KEY references in
+// v with the process environment, then the loaded .env files. Undefined
+// variables without a default expand to empty and are returned in undefined.
+// $$ stands for a literal $, and $secrets.KEY references are left for
+// ResolveVarValue.
+func ExpandConfigVars(v string) (expanded string, undefined []string) {
+	var b strings.Builder
+	last := 0
+	for _, m := range configVarRegex.FindAllStringSubmatchIndex(v, -1) {
+		b.WriteString(v[last:m[0]])
+		last = m[1]
+		match := v[m[0]:m[1]]
+		name := ""
+		switch {
+		case match == "$$":
+			b.WriteString("$")
+			continue
+		case m[2] >= 0:
+			name = v[m[2]:m[3]]
+		default:
+			name = v[m[6]:m[7]]
+			if name == "secrets" && strings.HasPrefix(v[m[1]:], ".") {
+				b.WriteString(match)
+				continue
+			}
+		}
+		if value, ok := os.LookupEnv(name); ok {
+			b.WriteString(value)
+		} else if value := LookupSecret(name); value != "" {
+			b.WriteString(value)
+		} else if m[4] >= 0 {
+			b.WriteString(strings.TrimSpace(v[m[4]:m[5]]))
+		} else if !slices.Contains(undefined, name) {
+			undefined = append(undefined, name)
+		}
+	}
+	b.WriteString(v[last:])
+	return b.String(), undefined
+}
+
 func GetEnvs() []Env {
 	var envs []Env
 	for _, secret := range secrets {
@@ -120,3 +189,106 @@ func GetUniqueEnvs() []Env {
 	}
 	return namesList
 }
+
+// ValidateEnvNames checks that every name of envs is a valid shell identifier
+// ([A-Za-z_][A-Za-z0-9_]*), which containers require
+func ValidateEnvNames(envs []Env) error {
+	for _, env := range envs {
+		if !envNameRegex.MatchString(env.Name) {
+			return fmt.Errorf("invalid environment variable name %q: names must start with a letter or an underscore and contain only letters, digits and underscores", env.Name)
+		}
+	}
+	return nil
+}
+
+// envDefinition is one definition of an environment variable and its source:
+// the -s flag, a .env file or [env] in blaxel.toml
+type envDefinition struct {
+	value  string
+	source string
+}
+
+// envDefinitions returns the sorted names of the variables of GetEnvs, and
+// their definitions in the order of GetEnvs: the first one is deployed
+func envDefinitions() ([]string, map[string][]envDefinition) {
+	definitions := map[string][]envDefinition{}
+	names := []string{}
+	add := func(name, value, source string) {
+		if slices.Contains(ignoredEnvs, name) {
+			return
+		}
+		if _, ok := definitions[name]; !ok {
+			names = append(names, name)
+		}
+		definitions[name] = append(definitions[name], envDefinition{value: value, source: source})
+	}
+	for i, secret := range secrets {
+		add(secret.Name, secret.Value, secretSource(i))
+	}
+	for k, v := range config.Env {
+		resolved, _ := ResolveVarValue(v)
+		add(k, resolved, envTableSource)
+	}
+	slices.Sort(names)
+	return names, definitions
+}
+
+// envTableSource is the source of the variables of the [env] table
+const envTableSource = "[env] in blaxel.toml"
+
+// ResolvedEnv is a variable of GetUniqueEnvs with the source of its value
+type ResolvedEnv struct {
+	Name   string `json:"name" yaml:"name"`
+	Value  string `json:"value" yaml:"value"`
+	Source string `json:"source" yaml:"source"`
+	// Overridden are the other sources defining the variable
+	Overridden []string `json:"overridden,omitempty" yaml:"overridden,omitempty"`
+}
+
+// IsSecret reports whether the value comes from a secret, a -s flag or a .env
+// file, rather than the [env] table
+func (e ResolvedEnv) IsSecret() bool {
+	return e.Source != envTableSource
+}
+
+// ResolveEnvs returns the variables deployed by GetUniqueEnvs sorted by name,
+// each with the source its value comes from
+func ResolveEnvs() []ResolvedEnv {
+	names, definitions := envDefinitions()
+	envs := make([]ResolvedEnv, 0, len(names))
+	for _, name := range names {
+		defs := definitions[name]
+		env := ResolvedEnv{Name: name, Value: defs[0].value, Source: defs[0].source}
+		for _, def := range defs[1:] {
+			if def.source != env.Source && !slices.Contains(env.Overridden, def.source) {
+				env.Overridden = append(env.Overridden, def.source)
+			}
+		}
+		envs = append(envs, env)
+	}
+	return envs
+}
+
+// DuplicateEnvWarnings returns a warning for each variable defined with
+// different values by several of the -s flags, the .env files and the [env]
+// table of blaxel.toml, naming the source whose value is deployed. As in
+// GetUniqueEnvs, -s flags win over .env files, which win over [env].
+func DuplicateEnvWarnings() []string {
+	names, definitions := envDefinitions()
+	warnings := []string{}
+	for _, name := range names {
+		defs := definitions[name]
+		sources := []string{}
+		conflict := false
+		for _, def := range defs {
+			if !slices.Contains(sources, def.source) {
+				sources = append(sources, def.source)
+			}
+			conflict = conflict || def.value != defs[0].value
+		}
+		if conflict {
+			warnings = append(warnings, fmt.Sprintf("Environment variable %s is defined with different values in %s; the value from %s is used", name, strings.Join(sources, ", "), defs[0].source))
+		}
+	}
+	return warnings
+}
@@ -538,6 +538,38 @@ func TestResolveConfigVars(t *testing.T) {
 	})
 }
 
+func TestExpandConfigVars(t *testing.T) {
+	originalSecrets := secrets
+	defer func() { secrets = originalSecrets }()
+	secrets = Secrets{{Name: "FROM_DOTENV", Value: "dotenv-value"}}
+	t.Setenv("REGISTRY", "ghcr.io/acme")
+	_ = os.Unsetenv("FROM_DOTENV")
+	_ = os.Unsetenv("MISSING")
+
+	tests := []struct {
+		name      string
+		value     string
+		expected  string
+		undefined []string
+	}{
+		{"braces inside a value", "${REGISTRY}/my-agent:latest", "ghcr.io/acme/my-agent:latest", nil},
+		{"bare reference", "$REGISTRY/my-agent", "ghcr.io/acme/my-agent", nil},
+		{"value from .env files", "prefix-${FROM_DOTENV}", "prefix-dotenv-value", nil},
+		{"default", "${MISSING:fallback}", "fallback", nil},
+		{"undefined expands to empty", "a-${MISSING}-$MISSING-b", "a---b", []string{"MISSING"}},
+		{"escaped dollar", "pa$$word", "pa$word", nil},
+		{"secrets references are kept", "$secrets.API_KEY and ${secrets.API_KEY}", "$secrets.API_KEY and ${secrets.API_KEY}", nil},
+		{"no references", "plain", "plain", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expanded, undefined := ExpandConfigVars(tt.value)
+			assert.Equal(t, tt.expected, expanded)
+			assert.Equal(t, tt.undefined, undefined)
+		})
+	}
+}
+
 func TestGetUniqueEnvs(t *testing.T) {
 	// Save original state and restore after test
 	originalSecrets := secrets
@@ -569,3 +601,96 @@ func TestGetUniqueEnvs(t *testing.T) {
 	}
 	assert.Equal(t, 1, var1Count, "VAR1 should appear only once in unique envs")
 }
+
+func TestValidateEnvNames(t *testing.T) {
+	assert.NoError(t, ValidateEnvNames([]Env{{Name: "API_KEY"}, {Name: "_private"}, {Name: "v2"}}))
+
+	err := ValidateEnvNames([]Env{{Name: "OK"}, {Name: "2FA_KEY"}})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `"2FA_KEY"`)
+
+	for _, name := range []string{"", "MY-VAR", "MY VAR", "a.b"} {
+		assert.Error(t, ValidateEnvNames([]Env{{Name: name}}), name)
+	}
+}
+
+func TestDuplicateEnvWarnings(t *testing.T) {
+	originalSecrets := secrets
+	originalSources := secretSources
+	originalConfig := config
+	defer func() {
+		secrets = originalSecrets
+		secretSources = originalSources
+		config = originalConfig
+	}()
+
+	secrets = Secrets{
+		{Name: "TOKEN", Value: "from-flag"},
+		{Name: "TOKEN", Value: "from-file"},
+		{Name: "SAME", Value: "value"},
+		{Name: "API_KEY", Value: "key"},
+	}
+	secretSources = []string{"-s flag", ".env", ".env", ".env"}
+	config = Config{
+		Env: map[string]string{
+			"TOKEN":   "from-toml",
+			"SAME":    "value",
+			"API_KEY": "${secrets.API_KEY}",
+			"MODE":    "prod",
+		},
+	}
+
+	warnings := DuplicateEnvWarnings()
+	require.Len(t, warnings, 1)
+	assert.Equal(t, "Environment variable TOKEN is defined with different values in -s flag, .env, [env] in blaxel.toml; the value from -s flag is used", warnings[0])
+
+	// Secrets set without their sources, as some commands and tests do
+	secretSources = nil
+	warnings = DuplicateEnvWarnings()
+	require.Len(t, warnings, 1)
+	assert.Contains(t, warnings[0], "the value from secrets is used")
+}
+
+func TestResolveEnvs(t *testing.T) {
+	originalSecrets := secrets
+	originalSources := secretSources
+	originalConfig := config
+	defer func() {
+		secrets = originalSecrets
+		secretSources = originalSources
+		config = originalConfig
+	}()
+
+	secrets = Secrets{
+		{Name: "TOKEN", Value: "from-flag"},
+		{Name: "TOKEN", Value: "from-file"},
+		{Name: "DB_URL", Value: "postgres://db"},
+		{Name: "BL_API_KEY", Value: "ignored"},
+	}
+	secretSources = []string{"-s flag", ".env", ".env.local", ".env"}
+	config = Config{
+		Env: map[string]string{
+			"TOKEN":  "from-toml",
+			"REGION": "eu",
+		},
+	}
+
+	envs := ResolveEnvs()
+	assert.Equal(t, []ResolvedEnv{
+		{Name: "DB_URL", Value: "postgres://db", Source: ".env.local"},
+		{Name: "REGION", Value: "eu", Source: "[env] in blaxel.toml"},
+		{Name: "TOKEN", Value: "from-flag", Source: "-s flag", Overridden: []string{".env", "[env] in blaxel.toml"}},
+	}, envs)
+	assert.True(t, envs[0].IsSecret())
+	assert.False(t, envs[1].IsSecret())
+
+	// The same values as the deploy
+	for _, env := range GetUniqueEnvs() {
+		for _, resolved := range envs {
+			if resolved.Name == env.Name {
+				assert.Equal(t, env.Value, resolved.Value, env.Name)
+			}
+		}
+	}
+	assert.Len(t, GetUniqueEnvs(), len(envs))
+}
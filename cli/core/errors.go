@@ -2,9 +2,14 @@ package core
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net"
 	"net/http"
+	"os"
 	"strings"
+
+	blaxel "github.com/blaxel-ai/sdk-go"
 )
 
 type ErrorModel struct {
@@ -47,3 +52,111 @@ func ErrorHandler(request *http.Request, kind string, name string, body string)
 	}
 	return err
 }
+
+// Process exit codes, so CI pipelines can react to specific failure classes
+const (
+	ExitCodeError   = 1
+	ExitCodeConfig  = 2
+	ExitCodeNetwork = 3
+	ExitCodeAPI     = 4
+	ExitCodeBuild   = 5
+	ExitCodeUpload  = 6
+	ExitCodeTimeout = 7
+)
+
+// ExitCoder is implemented by errors exiting with a specific code
+type ExitCoder interface {
+	ExitCode() int
+}
+
+// ConfigError is an invalid blaxel.toml, flag or local file
+type ConfigError struct{ Err error }
+
+func (e *ConfigError) Error() string { return e.Err.Error() }
+func (e *ConfigError) Unwrap() error { return e.Err }
+func (e *ConfigError) ExitCode() int { return ExitCodeConfig }
+
+// NetworkError is a failure to reach the Blaxel API or a deployed resource
+type NetworkError struct{ Err error }
+
+func (e *NetworkError) Error() string { return e.Err.Error() }
+func (e *NetworkError) Unwrap() error { return e.Err }
+func (e *NetworkError) ExitCode() int { return ExitCodeNetwork }
+
+// APIError is a request rejected by the Blaxel API
+type APIError struct{ Err error }
+
+func (e *APIError) Error() string { return e.Err.Error() }
+func (e *APIError) Unwrap() error { return e.Err }
+func (e *APIError) ExitCode() int { return ExitCodeAPI }
+
+// BuildError is a failed build or deployment of a resource
+type BuildError struct{ Err error }
+
+func (e *BuildError) Error() string { return e.Err.Error() }
+func (e *BuildError) Unwrap() error { return e.Err }
+func (e *BuildError) ExitCode() int { return ExitCodeBuild }
+
+// UploadError is a failure to package or upload the code of a resource
+type UploadError struct{ Err error }
+
+func (e *UploadError) Error() string { return e.Err.Error() }
+func (e *UploadError) Unwrap() error { return e.Err }
+func (e *UploadError) ExitCode() int { return ExitCodeUpload }
+
+// TimeoutError is an operation that did not complete within its timeout
+type TimeoutError struct{ Err error }
+
+func (e *TimeoutError) Error() string { return e.Err.Error() }
+func (e *TimeoutError) Unwrap() error { return e.Err }
+func (e *TimeoutError) ExitCode() int { return ExitCodeTimeout }
+
+// ExitCode returns the process exit code for err. Errors without an explicit
+// class are classified as API or network errors from their cause.
+func ExitCode(err error) int {
+	if err == nil {
+		return ExitCodeError
+	}
+	var coder ExitCoder
+	if errors.As(err, &coder) {
+		return coder.ExitCode()
+	}
+	var apiErr *blaxel.Error
+	if errors.As(err, &apiErr) {
+		return ExitCodeAPI
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return ExitCodeNetwork
+	}
+	return ExitCodeError
+}
+
+// CommandError is returned by a command once it has failed. It is printed
+// like PrintError, under the name of the operation that failed.
+type CommandError struct {
+	Op  string
+	Err error
+}
+
+func (e *CommandError) Error() string { return e.Err.Error() }
+func (e *CommandError) Unwrap() error { return e.Err }
+
+// Fail returns err from a command as a failure of op
+func Fail(op string, err error) error {
+	return &CommandError{Op: op, Err: err}
+}
+
+// PrintCommandError prints an error returned by a command, with the
+// credential source hint when it is an auth failure
+func PrintCommandError(err error) {
+	var cmdErr *CommandError
+	if errors.As(err, &cmdErr) {
+		PrintError(cmdErr.Op, cmdErr.Err)
+		return
+	}
+	fmt.Fprintln(os.Stderr, "Error", err)
+	if IsAuthError(err) {
+		PrintAuthSourceHint()
+	}
+}
@@ -1,10 +1,14 @@
 package core
 
 import (
+	"errors"
+	"fmt"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
+	blaxel "github.com/blaxel-ai/sdk-go"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -145,3 +149,24 @@ func TestErrorHandlerIntegration(t *testing.T) {
 		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
 	})
 }
+
+func TestExitCode(t *testing.T) {
+	base := errors.New("boom")
+	assert.Equal(t, ExitCodeError, ExitCode(nil))
+	assert.Equal(t, ExitCodeError, ExitCode(base))
+	assert.Equal(t, ExitCodeConfig, ExitCode(&ConfigError{Err: base}))
+	assert.Equal(t, ExitCodeNetwork, ExitCode(&NetworkError{Err: base}))
+	assert.Equal(t, ExitCodeAPI, ExitCode(&APIError{Err: base}))
+	assert.Equal(t, ExitCodeBuild, ExitCode(&BuildError{Err: base}))
+	assert.Equal(t, ExitCodeUpload, ExitCode(&UploadError{Err: base}))
+	assert.Equal(t, ExitCodeTimeout, ExitCode(&TimeoutError{Err: base}))
+
+	// Classes survive wrapping
+	wrapped := Fail("Deploy", fmt.Errorf("error applying: %w", &UploadError{Err: base}))
+	assert.Equal(t, ExitCodeUpload, ExitCode(wrapped))
+	assert.Equal(t, "error applying: boom", wrapped.Error(), "messages are unchanged")
+
+	// Unclassified errors are classified from their cause
+	assert.Equal(t, ExitCodeAPI, ExitCode(fmt.Errorf("get: %w", &blaxel.Error{StatusCode: 404})))
+	assert.Equal(t, ExitCodeNetwork, ExitCode(fmt.Errorf("get: %w", &net.OpError{Op: "dial", Err: base})))
+}
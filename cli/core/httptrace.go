@@ -0,0 +1,374 @@
+package core
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/blaxel-ai/sdk-go/option"
+)
+
+// httpTrace is set by the --http-trace flag, BL_HTTP_TRACE sets it as well so
+// shell completions can be traced
+var httpTrace string
+
+// httpTraceBodyLimit is the number of bytes of each body kept in the trace
+const httpTraceBodyLimit = 64 * 1024
+
+// httpTraceEntry is one line of the --http-trace file
+type httpTraceEntry struct {
+	Time            time.Time         `json:"time"`
+	Method          string            `json:"method"`
+	URL             string            `json:"url"`
+	RequestHeaders  map[string]string `json:"requestHeaders,omitempty"`
+	RequestBody     string            `json:"requestBody,omitempty"`
+	Status          int               `json:"status,omitempty"`
+	ResponseHeaders map[string]string `json:"responseHeaders,omitempty"`
+	ResponseBody    string            `json:"responseBody,omitempty"`
+	DurationMs      int64             `json:"durationMs"`
+	Error           string            `json:"error,omitempty"`
+}
+
+// httpTraceWriter appends entries to the trace file, which is opened on the
+// first request
+type httpTraceWriter struct {
+	path string
+	mu   sync.Mutex
+	file *os.File
+	err  error
+}
+
+var (
+	httpTraceWriters   = map[string]*httpTraceWriter{}
+	httpTraceWritersMu sync.Mutex
+)
+
+// httpTracePath returns the file requests are traced to, "" when tracing is off
+func httpTracePath() string {
+	if httpTrace != "" {
+		return httpTrace
+	}
+	return os.Getenv("BL_HTTP_TRACE")
+}
+
+// resolveHTTPTracePath makes the trace file absolute, so that a command
+// moving to another directory, like bl deploy to its project, keeps tracing
+// to the same file
+func resolveHTTPTracePath() {
+	if path := httpTracePath(); path != "" {
+		if abs, err := filepath.Abs(path); err == nil {
+			httpTrace = abs
+		}
+	}
+}
+
+// HTTPTraceOptions returns the client options recording every API request in
+// the --http-trace file, none when tracing is off
+func HTTPTraceOptions() []option.RequestOption {
+	path := httpTracePath()
+	if path == "" {
+		return nil
+	}
+	httpTraceWritersMu.Lock()
+	defer httpTraceWritersMu.Unlock()
+	writer, ok := httpTraceWriters[path]
+	if !ok {
+		writer = &httpTraceWriter{path: path}
+		httpTraceWriters[path] = writer
+	}
+	return []option.RequestOption{option.WithMiddleware(writer.middleware)}
+}
+
+// middleware records the request and its response as a JSON line. The
+// response body is recorded as the caller reads it, so streamed responses are
+// not held back, and the line is written when it is closed.
+func (w *httpTraceWriter) middleware(req *http.Request, next option.MiddlewareNext) (*http.Response, error) {
+	entry := httpTraceEntry{
+		Time:           time.Now().UTC(),
+		Method:         req.Method,
+		URL:            req.URL.Redacted(),
+		RequestHeaders: redactHeaders(req.Header),
+	}
+	if req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			data, _ := io.ReadAll(io.LimitReader(body, httpTraceBodyLimit+1))
+			_ = body.Close()
+			entry.RequestBody = traceBody(data)
+		}
+	}
+
+	start := time.Now()
+	res, err := next(req)
+	if err != nil {
+		entry.DurationMs = time.Since(start).Milliseconds()
+		entry.Error = err.Error()
+		w.write(entry)
+		return res, err
+	}
+	entry.Status = res.StatusCode
+	entry.ResponseHeaders = redactHeaders(res.Header)
+	res.Body = &tracedBody{ReadCloser: res.Body, done: func(data []byte) {
+		entry.DurationMs = time.Since(start).Milliseconds()
+		entry.ResponseBody = traceBody(data)
+		w.write(entry)
+	}}
+	return res, nil
+}
+
+func (w *httpTraceWriter) write(entry httpTraceEntry) {
+	entry = redactTraceEntry(entry)
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.file == nil && w.err == nil {
+		w.file, w.err = os.OpenFile(w.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+		if w.err != nil {
+			PrintWarning(fmt.Sprintf("cannot write the HTTP trace: %v", w.err))
+		}
+	}
+	if w.file != nil {
+		_, _ = w.file.Write(append(data, '\n'))
+	}
+}
+
+// tracedBody keeps the first httpTraceBodyLimit bytes read from a response
+// body and hands them to done once the body is closed
+type tracedBody struct {
+	io.ReadCloser
+	buf  bytes.Buffer
+	done func(data []byte)
+	once sync.Once
+}
+
+func (b *tracedBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if room := httpTraceBodyLimit + 1 - b.buf.Len(); room > 0 {
+		b.buf.Write(p[:min(n, room)])
+	}
+	return n, err
+}
+
+func (b *tracedBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(func() { b.done(b.buf.Bytes()) })
+	return err
+}
+
+// traceBody returns a body as recorded in the trace: secrets redacted,
+// truncated to httpTraceBodyLimit, and binary content summarized
+func traceBody(data []byte) string {
+	truncated := len(data) > httpTraceBodyLimit
+	if truncated {
+		data = data[:httpTraceBodyLimit]
+	}
+	if len(data) == 0 {
+		return ""
+	}
+	if !utf8.Valid(data) {
+		return fmt.Sprintf("[%d bytes of binary data]", len(data))
+	}
+	body := redactBody(data, truncated)
+	if truncated {
+		body += fmt.Sprintf("... [truncated after %d bytes]", httpTraceBodyLimit)
+	}
+	return body
+}
+
+// secretKeyPattern matches the names of JSON fields and environment variables
+// holding secrets
+var secretKeyPattern = regexp.MustCompile(`(?i)(token|secret|password|passwd|api[_-]?key|authorization|credential|private[_-]?key)`)
+
+// secretTextPatterns match secrets left in bodies that are not JSON
+var secretTextPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`(?i)(bearer\s+)[A-Za-z0-9._~+/=-]+`),
+	regexp.MustCompile(`(?i)((?:token|secret|password|api[_-]?key)["']?\s*[:=]\s*["']?)[^\s"'&,}]+`),
+}
+
+// redactBody replaces the secrets of a body. Complete JSON documents have the
+// value of their secret fields replaced, as well as the value of {"name",
+// "value"} pairs with a secret name, like the environment variables of a
+// deployment, keeping the order of their fields and their numbers as is.
+// Other bodies are redacted with patterns.
+func redactBody(data []byte, truncated bool) string {
+	if !truncated {
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.UseNumber()
+		document, err := decodeOrderedJSON(decoder)
+		if err == nil && !decoder.More() {
+			var buf bytes.Buffer
+			if encodeOrderedJSON(&buf, redactJSON(document)) == nil {
+				return buf.String()
+			}
+		}
+	}
+	body := string(data)
+	for _, pattern := range secretTextPatterns {
+		body = pattern.ReplaceAllString(body, "${1}[redacted]")
+	}
+	return body
+}
+
+// orderedJSONObject is a JSON object with its fields in the order of the document
+type orderedJSONObject []orderedJSONField
+
+type orderedJSONField struct {
+	key   string
+	value interface{}
+}
+
+// decodeOrderedJSON reads a JSON value from decoder, which must use numbers,
+// with its objects as orderedJSONObject
+func decodeOrderedJSON(decoder *json.Decoder) (interface{}, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch token {
+	case json.Delim('{'):
+		object := orderedJSONObject{}
+		for decoder.More() {
+			key, err := decoder.Token()
+			if err != nil {
+				return nil, err
+			}
+			value, err := decodeOrderedJSON(decoder)
+			if err != nil {
+				return nil, err
+			}
+			object = append(object, orderedJSONField{key: key.(string), value: value})
+		}
+		_, err = decoder.Token()
+		return object, err
+	case json.Delim('['):
+		array := []interface{}{}
+		for decoder.More() {
+			value, err := decodeOrderedJSON(decoder)
+			if err != nil {
+				return nil, err
+			}
+			array = append(array, value)
+		}
+		_, err = decoder.Token()
+		return array, err
+	}
+	return token, nil
+}
+
+// encodeOrderedJSON writes a value read by decodeOrderedJSON as compact JSON
+func encodeOrderedJSON(buf *bytes.Buffer, value interface{}) error {
+	switch v := value.(type) {
+	case orderedJSONObject:
+		buf.WriteByte('{')
+		for i, field := range v {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			key, err := json.Marshal(field.key)
+			if err != nil {
+				return err
+			}
+			buf.Write(key)
+			buf.WriteByte(':')
+			if err := encodeOrderedJSON(buf, field.value); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+		return nil
+	case []interface{}:
+		buf.WriteByte('[')
+		for i, item := range v {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := encodeOrderedJSON(buf, item); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+		return nil
+	case json.Number:
+		buf.WriteString(v.String())
+		return nil
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	buf.Write(data)
+	return nil
+}
+
+func redactJSON(value interface{}) interface{} {
+	switch v := value.(type) {
+	case orderedJSONObject:
+		secretName := false
+		for _, field := range v {
+			if name, ok := field.value.(string); ok && field.key == "name" && secretKeyPattern.MatchString(name) {
+				secretName = true
+			}
+		}
+		for i, field := range v {
+			if secretName && field.key == "value" {
+				v[i].value = "[redacted]"
+				continue
+			}
+			if secretKeyPattern.MatchString(field.key) {
+				if _, isString := field.value.(string); isString {
+					v[i].value = "[redacted]"
+					continue
+				}
+			}
+			v[i].value = redactJSON(field.value)
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = redactJSON(item)
+		}
+	}
+	return value
+}
+
+// redactTraceEntry masks the secret values left in an entry, wherever they
+// appear (see RedactSecrets)
+func redactTraceEntry(entry httpTraceEntry) httpTraceEntry {
+	entry.URL = RedactSecrets(entry.URL)
+	entry.RequestBody = RedactSecrets(entry.RequestBody)
+	entry.ResponseBody = RedactSecrets(entry.ResponseBody)
+	entry.Error = RedactSecrets(entry.Error)
+	for name, value := range entry.RequestHeaders {
+		entry.RequestHeaders[name] = RedactSecrets(value)
+	}
+	for name, value := range entry.ResponseHeaders {
+		entry.ResponseHeaders[name] = RedactSecrets(value)
+	}
+	return entry
+}
+
+// redactHeaders returns the headers with the value of credentials replaced
+func redactHeaders(header http.Header) map[string]string {
+	if len(header) == 0 {
+		return nil
+	}
+	redacted := make(map[string]string, len(header))
+	for name, values := range header {
+		value := strings.Join(values, ", ")
+		if sensitiveHeaders[http.CanonicalHeaderKey(name)] {
+			value = "[redacted]"
+		}
+		redacted[name] = value
+	}
+	return redacted
+}
@@ -0,0 +1,161 @@
+package core
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPTraceMiddleware(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "trace.jsonl")
+	writer := &httpTraceWriter{path: path}
+	next := func(req *http.Request) (*http.Response, error) {
+		rec := httptest.NewRecorder()
+		rec.Header().Set("Content-Type", "application/json")
+		rec.Header().Set("Set-Cookie", "session=abc")
+		rec.WriteHeader(http.StatusCreated)
+		_, _ = rec.WriteString(`{"metadata":{"name":"my-agent"},"accessToken":"eyJhbGc"}`)
+		return rec.Result(), nil
+	}
+
+	body := `{"spec":{"runtime":{"envs":[{"name":"OPENAI_API_KEY","value":"sk-123"},{"name":"REGION","value":"eu"}]}}}`
+	req, err := http.NewRequest(http.MethodPut, "https://api.blaxel.ai/v0/agents/my-agent", strings.NewReader(body))
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer eyJhbGc")
+	req.Header.Set("Proxy-Authorization", "Basic dXNlcjpwYXNz")
+	req.Header.Set("X-Blaxel-Workspace", "main")
+	res, err := writer.middleware(req, next)
+	require.NoError(t, err)
+	data, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "eyJhbGc", "the caller gets the response unchanged")
+	require.NoError(t, res.Body.Close())
+	require.NoError(t, res.Body.Close())
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	require.Len(t, lines, 1, "one line per request, written once")
+	assert.NotContains(t, lines[0], "eyJhbGc")
+	assert.NotContains(t, lines[0], "sk-123")
+
+	var entry httpTraceEntry
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &entry))
+	assert.Equal(t, http.MethodPut, entry.Method)
+	assert.Equal(t, "https://api.blaxel.ai/v0/agents/my-agent", entry.URL)
+	assert.Equal(t, http.StatusCreated, entry.Status)
+	assert.Equal(t, "[redacted]", entry.RequestHeaders["Authorization"])
+	assert.Equal(t, "[redacted]", entry.RequestHeaders["Proxy-Authorization"])
+	assert.Equal(t, "[redacted]", entry.ResponseHeaders["Set-Cookie"])
+	assert.Equal(t, "main", entry.RequestHeaders["X-Blaxel-Workspace"])
+	assert.Contains(t, entry.RequestBody, `"REGION","value":"eu"`)
+	assert.Contains(t, entry.ResponseBody, `"name":"my-agent"`)
+	assert.Contains(t, entry.ResponseBody, `"accessToken":"[redacted]"`)
+}
+
+func TestHTTPTraceRedactsSecretValues(t *testing.T) {
+	originalSecrets := secrets
+	defer func() { secrets = originalSecrets }()
+	secrets = Secrets{{Name: "DB_URL", Value: "postgres://admin:hunter2@db"}}
+
+	path := filepath.Join(t.TempDir(), "trace.jsonl")
+	writer := &httpTraceWriter{path: path}
+	next := func(req *http.Request) (*http.Response, error) {
+		rec := httptest.NewRecorder()
+		_, _ = rec.WriteString("connection to postgres://admin:hunter2@db refused")
+		return rec.Result(), nil
+	}
+
+	// A secret value under a name the patterns do not catch
+	body := `{"spec":{"runtime":{"envs":[{"name":"DB_URL","value":"postgres://admin:hunter2@db"}]}}}`
+	req, err := http.NewRequest(http.MethodPut, "https://api.blaxel.ai/v0/agents/my-agent", strings.NewReader(body))
+	require.NoError(t, err)
+	res, err := writer.middleware(req, next)
+	require.NoError(t, err)
+	_, _ = io.ReadAll(res.Body)
+	require.NoError(t, res.Body.Close())
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(content), "hunter2")
+	var entry httpTraceEntry
+	require.NoError(t, json.Unmarshal(content, &entry))
+	assert.Contains(t, entry.RequestBody, `"value":"***"`)
+	assert.Equal(t, "connection to *** refused", entry.ResponseBody)
+}
+
+func TestHTTPTraceRedactsEscapedSecretValues(t *testing.T) {
+	originalSecrets := secrets
+	defer func() { secrets = originalSecrets }()
+	secrets = Secrets{{Name: "DB_PASS", Value: "p&ss1234"}}
+
+	path := filepath.Join(t.TempDir(), "trace.jsonl")
+	writer := &httpTraceWriter{path: path}
+	next := func(req *http.Request) (*http.Response, error) {
+		rec := httptest.NewRecorder()
+		_, _ = rec.WriteString(`{"error":"bad password p\u0026ss1234"}`)
+		return rec.Result(), nil
+	}
+
+	// The SDK escapes & in the JSON it sends
+	body := `{"spec":{"runtime":{"envs":[{"name":"DB_PASS","value":"p\u0026ss1234"}]}}}`
+	req, err := http.NewRequest(http.MethodPut, "https://api.blaxel.ai/v0/agents/my-agent", strings.NewReader(body))
+	require.NoError(t, err)
+	res, err := writer.middleware(req, next)
+	require.NoError(t, err)
+	_, _ = io.ReadAll(res.Body)
+	require.NoError(t, res.Body.Close())
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(content), "ss1234")
+}
+
+func TestHTTPTraceMiddlewareError(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "trace.jsonl")
+	writer := &httpTraceWriter{path: path}
+	req := httptest.NewRequest(http.MethodGet, "https://api.blaxel.ai/v0/agents", nil)
+	_, err := writer.middleware(req, func(req *http.Request) (*http.Response, error) {
+		return nil, io.ErrUnexpectedEOF
+	})
+	assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	var entry httpTraceEntry
+	require.NoError(t, json.Unmarshal(content, &entry))
+	assert.Equal(t, io.ErrUnexpectedEOF.Error(), entry.Error)
+	assert.Zero(t, entry.Status)
+}
+
+func TestTraceBody(t *testing.T) {
+	assert.Equal(t, "", traceBody(nil))
+	assert.Equal(t, "[3 bytes of binary data]", traceBody([]byte{0xff, 0xfe, 0x00}))
+	assert.Equal(t, "Authorization: Bearer [redacted]", traceBody([]byte("Authorization: Bearer eyJhbGc.abc")))
+	assert.Equal(t, "client_secret=[redacted]&grant_type=client_credentials", traceBody([]byte("client_secret=s3cr3t&grant_type=client_credentials")))
+	assert.Equal(t, `{"items":[{"token":"[redacted]"}]}`, traceBody([]byte(`{"items":[{"token":"abc"}]}`)))
+	// Fields keep their order and numbers are not turned into floats
+	assert.Equal(t, `{"spec":{"value":"[redacted]","name":"API_KEY"},"memory":9007199254740993,"ratio":1.50,"apiKey":"[redacted]"}`,
+		traceBody([]byte(`{"spec": {"value": "abc", "name": "API_KEY"}, "memory": 9007199254740993, "ratio": 1.50, "apiKey": "k"}`)))
+
+	large := traceBody([]byte(strings.Repeat("a", httpTraceBodyLimit+10)))
+	assert.True(t, strings.HasSuffix(large, "[truncated after 65536 bytes]"))
+	assert.Len(t, large, httpTraceBodyLimit+len("... [truncated after 65536 bytes]"))
+}
+
+func TestHTTPTraceOptions(t *testing.T) {
+	httpTrace = ""
+	t.Setenv("BL_HTTP_TRACE", "")
+	assert.Empty(t, HTTPTraceOptions())
+
+	t.Setenv("BL_HTTP_TRACE", filepath.Join(t.TempDir(), "trace.jsonl"))
+	assert.Len(t, HTTPTraceOptions(), 1)
+}
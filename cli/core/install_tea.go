@@ -86,7 +86,7 @@ var (
 // NewInstallationModel creates a new installation progress model
 func NewInstallationModel() Model {
 	s := spinner.New()
-	s.Spinner = spinner.Dot
+	s.Spinner = Spinner(spinner.Dot)
 	s.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("214")) // Changed from 12 to 214 (orange)
 
 	return Model{
@@ -392,7 +392,7 @@ func runInstallationSteps(p *tea.Program, t Template, opts TemplateOptions) erro
 		branch = "develop"
 	}
 
-	cloneCmd := exec.Command("git", "clone", "-b", branch, "--progress", t.URL, opts.Directory)
+	cloneCmd := exec.Command("git", "clone", "-b", branch, "--progress", "--", t.URL, opts.Directory)
 	if err := cloneCmd.Run(); err != nil {
 		p.Send(stepFailedMsg{step: 1, err: fmt.Errorf("failed to clone: %w", err)})
 		return err
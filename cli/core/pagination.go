@@ -40,8 +40,7 @@ type paginatedResponse struct {
 }
 
 // fetchPage fetches a single page from a paginated listing endpoint.
-func fetchPage(c *blaxel.Client, apiPath string, limit int, cursor string) (PaginatedResult, error) {
-	ctx := context.Background()
+func fetchPage(ctx context.Context, c *blaxel.Client, apiPath string, limit int, cursor string) (PaginatedResult, error) {
 	path := fmt.Sprintf("%s?limit=%d", apiPath, limit)
 	if cursor != "" {
 		path += "&cursor=" + url.QueryEscape(cursor)
@@ -66,9 +65,9 @@ func fetchPage(c *blaxel.Client, apiPath string, limit int, cursor string) (Pagi
 // ListPaginated fetches a single page of items starting from the given cursor.
 // Used when --cursor is supplied for explicit page-by-page navigation.
 func ListPaginated(resource *Resource, pageSize int, cursor string) (PaginatedResult, error) {
-	c := GetClient()
-	if c == nil {
-		return PaginatedResult{}, fmt.Errorf("client not initialized")
+	c, err := RequireClient()
+	if err != nil {
+		return PaginatedResult{}, err
 	}
 	if !resource.Paginated || resource.APIPath == "" {
 		return PaginatedResult{}, fmt.Errorf("resource %s does not support pagination", resource.Kind)
@@ -76,7 +75,7 @@ func ListPaginated(resource *Resource, pageSize int, cursor string) (PaginatedRe
 	if pageSize <= 0 || pageSize > DefaultPageLimit {
 		pageSize = DefaultPageLimit
 	}
-	return fetchPage(c, resource.APIPath, pageSize, cursor)
+	return fetchPage(context.Background(), c, resource.APIPath, pageSize, cursor)
 }
 
 // ListWithLimit fetches up to maxItems items, auto-paginating in pages of up to
@@ -85,9 +84,9 @@ func ListPaginated(resource *Resource, pageSize int, cursor string) (PaginatedRe
 // the last page fetched (so HasMore/NextCursor tell the caller whether there
 // are items beyond the requested limit).
 func ListWithLimit(resource *Resource, maxItems int) (PaginatedResult, error) {
-	c := GetClient()
-	if c == nil {
-		return PaginatedResult{}, fmt.Errorf("client not initialized")
+	c, err := RequireClient()
+	if err != nil {
+		return PaginatedResult{}, err
 	}
 	if !resource.Paginated || resource.APIPath == "" {
 		return PaginatedResult{}, fmt.Errorf("resource %s does not support pagination", resource.Kind)
@@ -105,7 +104,7 @@ func ListWithLimit(resource *Resource, maxItems int) (PaginatedResult, error) {
 			pageSize = remaining
 		}
 
-		result, err := fetchPage(c, resource.APIPath, pageSize, cursor)
+		result, err := fetchPage(context.Background(), c, resource.APIPath, pageSize, cursor)
 		if err != nil {
 			return PaginatedResult{}, err
 		}
@@ -139,9 +138,9 @@ func ListWithLimit(resource *Resource, maxItems int) (PaginatedResult, error) {
 // ListAllPaginated fetches every page from a paginated listing endpoint,
 // showing a progress indicator on stderr when the output is a terminal.
 func ListAllPaginated(resource *Resource) ([]any, error) {
-	c := GetClient()
-	if c == nil {
-		return nil, fmt.Errorf("client not initialized")
+	c, err := RequireClient()
+	if err != nil {
+		return nil, err
 	}
 	if !resource.Paginated || resource.APIPath == "" {
 		return nil, fmt.Errorf("resource %s does not support pagination", resource.Kind)
@@ -152,7 +151,7 @@ func ListAllPaginated(resource *Resource) ([]any, error) {
 	cursor := ""
 
 	for {
-		result, err := fetchPage(c, resource.APIPath, DefaultPageLimit, cursor)
+		result, err := fetchPage(context.Background(), c, resource.APIPath, DefaultPageLimit, cursor)
 		if err != nil {
 			return nil, err
 		}
@@ -178,3 +177,26 @@ func ListAllPaginated(resource *Resource) ([]any, error) {
 
 	return all, nil
 }
+
+// ListAllWithClient fetches every page of a paginated resource using the given
+// client, without progress output. Used to query workspaces other than the
+// current one.
+func ListAllWithClient(ctx context.Context, c *blaxel.Client, resource *Resource) ([]any, error) {
+	if !resource.Paginated || resource.APIPath == "" {
+		return nil, fmt.Errorf("resource %s does not support pagination", resource.Kind)
+	}
+
+	var all []any
+	cursor := ""
+	for {
+		result, err := fetchPage(ctx, c, resource.APIPath, DefaultPageLimit, cursor)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, result.Items...)
+		if !result.Meta.HasMore || result.Meta.NextCursor == "" {
+			return all, nil
+		}
+		cursor = result.Meta.NextCursor
+	}
+}
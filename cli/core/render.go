@@ -62,7 +62,37 @@ func Output(resource Resource, slices []interface{}, outputFormat string) {
 		printJson(resource, sortedSlices)
 		return
 	}
-	printTable(resource, sortedSlices)
+	if outputFormat == "name" {
+		printNames(resource, sortedSlices)
+		return
+	}
+	// Wide tables show full image names
+	resource, imageWidth := tableLayout(resource, outputFormat)
+	renderTable(resource, sortedSlices, imageWidth)
+}
+
+// wideResource returns resource with its wide fields and a full CREATED_AT and
+// UPDATED_AT timestamp added to the table columns
+func wideResource(resource Resource) Resource {
+	fields := make([]Field, 0, len(resource.Fields)+len(resource.WideFields)+2)
+	hasCreatedAt := false
+	for _, field := range resource.Fields {
+		if field.Key == "CREATED_AT" {
+			// Keep the time of day that the default table drops
+			hasCreatedAt = true
+			if field.Special == "date" {
+				field.Special = "datetime"
+			}
+		}
+		fields = append(fields, field)
+	}
+	fields = append(fields, resource.WideFields...)
+	if !hasCreatedAt {
+		fields = append(fields, Field{Key: "CREATED_AT", Value: "createdAt", Special: "datetime"})
+	}
+	fields = append(fields, Field{Key: "UPDATED_AT", Value: "updatedAt", Special: "datetime"})
+	resource.Fields = fields
+	return resource
 }
 
 func retrieveKey(itemMap map[string]interface{}, key string) string {
@@ -221,7 +251,8 @@ func navigateToKey(m map[string]interface{}, keys []string) interface{} {
 	return nil
 }
 
-func printTable(resource Resource, slices []interface{}) {
+// renderTable prints slices as a table, truncating images to imageWidth (0 keeps them whole)
+func renderTable(resource Resource, slices []interface{}, imageWidth int) {
 	t := table.NewWriter()
 	t.SetOutputMirror(os.Stdout)
 
@@ -229,9 +260,6 @@ func printTable(resource Resource, slices []interface{}) {
 	header := buildTableHeader(resource)
 	t.AppendHeader(header)
 
-	// Calculate dynamic image width once for all rows
-	imageWidth := getImageColumnWidth()
-
 	// Add rows to the table
 	for _, item := range slices {
 		if itemMap, ok := item.(map[string]interface{}); ok {
@@ -243,6 +271,38 @@ func printTable(resource Resource, slices []interface{}) {
 	t.Render()
 }
 
+// TableHeader returns the column names of resource for outputFormat ("table" or "wide")
+func TableHeader(resource Resource, outputFormat string) []string {
+	resource, _ = tableLayout(resource, outputFormat)
+	header := []string{}
+	for _, cell := range buildTableHeader(resource) {
+		header = append(header, fmt.Sprint(cell))
+	}
+	return header
+}
+
+// TableRow returns the cells of item as rendered in a table for outputFormat ("table" or "wide")
+func TableRow(resource Resource, item interface{}, outputFormat string) []string {
+	itemMap, ok := item.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	resource, imageWidth := tableLayout(resource, outputFormat)
+	row := []string{}
+	for _, cell := range buildTableRow(resource, itemMap, imageWidth) {
+		row = append(row, fmt.Sprint(cell))
+	}
+	return row
+}
+
+// tableLayout returns the columns and image width used to render resource for outputFormat
+func tableLayout(resource Resource, outputFormat string) (Resource, int) {
+	if outputFormat == "wide" {
+		return wideResource(resource), 0
+	}
+	return resource, getImageColumnWidth()
+}
+
 // buildTableHeader builds the table header dynamically based on Fields
 func buildTableHeader(resource Resource) table.Row {
 	header := table.Row{}
@@ -318,6 +378,9 @@ func retrieveFieldValue(itemMap map[string]interface{}, field Field, imageWidth
 		rawValue := retrieveKey(itemMap, field.Value)
 		// Remove "sandbox/" prefix if present
 		rawValue = strings.TrimPrefix(rawValue, "sandbox/")
+		if imageWidth <= 0 {
+			return rawValue
+		}
 		return truncateString(rawValue, imageWidth)
 	}
 
@@ -352,6 +415,39 @@ func formatDate(timestamp string, format string) string {
 	return localTime.Format(format)
 }
 
+// printNames prints the name of each item, one per line, for piping to other commands
+func printNames(resource Resource, slices []interface{}) {
+	for _, name := range ResourceNames(resource, slices) {
+		fmt.Println(name)
+	}
+}
+
+// ResourceNames returns the names of items, read from the NAME column of
+// resource, falling back to metadata.name and name
+func ResourceNames(resource Resource, slices []interface{}) []string {
+	keys := []string{"metadata.name", "name"}
+	for _, field := range resource.Fields {
+		if field.Key == "NAME" {
+			keys = append([]string{field.Value}, keys...)
+			break
+		}
+	}
+	names := []string{}
+	for _, item := range slices {
+		itemMap, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		for _, key := range keys {
+			if name := retrieveKey(itemMap, key); name != "" && name != "-" {
+				names = append(names, name)
+				break
+			}
+		}
+	}
+	return names
+}
+
 func printJson(resource Resource, slices []interface{}) {
 	formatted := []Result{}
 	for _, slice := range slices {
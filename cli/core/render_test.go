@@ -408,3 +408,55 @@ func TestRetrieveFieldValueImageTruncation(t *testing.T) {
 	result := retrieveFieldValue(itemMap, field, 20)
 	assert.LessOrEqual(t, len(result), 20)
 }
+
+func TestWideResource(t *testing.T) {
+	resource := Resource{
+		Kind: "Job",
+		Fields: []Field{
+			{Key: "NAME", Value: "name"},
+			{Key: "CREATED_AT", Value: "createdAt", Special: "date"},
+		},
+		WideFields: []Field{
+			{Key: "IMAGE", Value: "spec.runtime.image", Special: "image"},
+		},
+	}
+
+	wide := wideResource(resource)
+	keys := []string{}
+	for _, field := range wide.Fields {
+		keys = append(keys, field.Key)
+	}
+	assert.Equal(t, []string{"NAME", "CREATED_AT", "IMAGE", "UPDATED_AT"}, keys)
+	assert.Equal(t, "datetime", wide.Fields[1].Special)
+	// The original resource is left untouched
+	assert.Equal(t, "date", resource.Fields[1].Special)
+
+	wide = wideResource(Resource{Fields: []Field{{Key: "NAME", Value: "name"}}})
+	assert.Equal(t, "CREATED_AT", wide.Fields[1].Key)
+}
+
+func TestRetrieveFieldValueImageWithoutLimit(t *testing.T) {
+	item := map[string]interface{}{
+		"spec": map[string]interface{}{
+			"runtime": map[string]interface{}{"image": "sandbox/registry.example.com/a/very/long/image:tag"},
+		},
+	}
+	field := Field{Key: "IMAGE", Value: "spec.runtime.image", Special: "image"}
+	assert.Equal(t, "registry.example.com/a/very/long/image:tag", retrieveFieldValue(item, field, 0))
+	assert.Equal(t, "regist...", retrieveFieldValue(item, field, 9))
+}
+
+func TestResourceNames(t *testing.T) {
+	resource := Resource{Fields: []Field{{Key: "NAME", Value: "metadata.name"}}}
+	items := []interface{}{
+		map[string]interface{}{"metadata": map[string]interface{}{"name": "agent-a"}},
+		map[string]interface{}{"metadata": map[string]interface{}{"name": "agent-b"}},
+		map[string]interface{}{"metadata": map[string]interface{}{}},
+		"not a map",
+	}
+	assert.Equal(t, []string{"agent-a", "agent-b"}, ResourceNames(resource, items))
+
+	// Nested resources such as processes carry a top-level name
+	processes := []interface{}{map[string]interface{}{"name": "proc-1", "pid": "12"}}
+	assert.Equal(t, []string{"proc-1"}, ResourceNames(Resource{}, processes))
+}
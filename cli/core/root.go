@@ -23,8 +23,8 @@ import (
 var GITHUB_RELEASES_URL = "https://api.github.com/repos/blaxel-ai/toolkit/releases"
 var UPDATE_CLI_DOC_URL = "https://docs.blaxel.ai/cli-reference/introduction#update"
 
-// ANSI color codes
-const (
+// ANSI color codes, emptied when colors are disabled
+var (
 	colorYellow = "\033[33m"
 	colorCyan   = "\033[36m"
 	colorGreen  = "\033[32m"
@@ -233,8 +233,13 @@ var rootCmd = &cobra.Command{
 	Use:   "bl",
 	Short: "Blaxel CLI - manage and deploy AI agents, sandboxes, and resources",
 	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		AddCommandBreadcrumb(cmd)
+		applyColorSettings()
+		resolveHTTPTracePath()
+		workspaceFlagSet = cmd.Flags().Changed("workspace")
+
 		// Skip version warning for specific commands/conditions
-		shouldSkipWarning := skipVersionWarning ||
+		shouldSkipWarning := skipVersionWarning || quiet ||
 			cmd.Name() == "__complete" ||
 			cmd.Name() == "completion" ||
 			cmd.Name() == "token" ||
@@ -247,12 +252,13 @@ var rootCmd = &cobra.Command{
 			checkForUpdates(version)
 		}
 
-		// Load .env file for all commands except serve, deploy, run, and apply cause they use envFiles
+		// Load .env file for all commands except serve, deploy, run, apply and env cause they use envFiles
 		excludedCommands := map[string]bool{
 			"serve":  true,
 			"deploy": true,
 			"run":    true,
 			"apply":  true,
+			"env":    true,
 		}
 		if !excludedCommands[cmd.Name()] {
 			if err := godotenv.Load(); err != nil {
@@ -265,8 +271,8 @@ var rootCmd = &cobra.Command{
 
 		blaxel.ApplyEnvironmentOverrides()
 
-		// Skip config reading for deploy and push commands as they handle their own config logic
-		if cmd.Name() != "deploy" && cmd.Name() != "push" {
+		// Skip config reading for deploy, push and env commands as they handle their own config logic
+		if cmd.Name() != "deploy" && cmd.Name() != "push" && cmd.Name() != "env" {
 			readConfigToml("", true)
 		}
 
@@ -285,6 +291,7 @@ var rootCmd = &cobra.Command{
 			"help":             true,
 			"new":              true,
 			"docs":             true,
+			"env":              true,
 			"create-sandbox":   true,
 			"create-job":       true,
 			"create-mcp":       true,
@@ -301,8 +308,8 @@ var rootCmd = &cobra.Command{
 			// Check if BL_WORKSPACE is set or if there are workspaces in config
 			if workspace == "" {
 				cfg, _ := blaxel.LoadConfig()
-				if len(cfg.Workspaces) == 0 {
-					PrintError("Login required", fmt.Errorf("no workspace configured. Please run 'bl login' first to authenticate"))
+				if err := CheckWorkspaceConfigured(workspace, cfg); err != nil {
+					PrintError("Login required", err)
 					Exit(1)
 				}
 			}
@@ -317,46 +324,68 @@ var rootCmd = &cobra.Command{
 			}
 		}
 
-		// Get OS/arch and commit info for User-Agent
-		osArch := goruntime.GOOS + "/" + goruntime.GOARCH
-		commitHash := "unknown"
+		return initClient()
+	},
+}
 
-		// Check if commit was injected at build time via ldflags
-		if commit != "" {
-			if len(commit) > 7 {
-				commitHash = commit[:7]
-			} else {
-				commitHash = commit
-			}
-		}
+// clientWorkspace is the workspace the client was created for
+var clientWorkspace string
 
-		userAgent := fmt.Sprintf("blaxel/cli/golang/%s (%s) blaxel/%s", version, osArch, commitHash)
+// contextWorkspace is the workspace of BL_WORKSPACE or of the current context,
+// before --workspace and the workspace of blaxel.toml are applied
+var contextWorkspace string
 
-		// Build client options
-		opts := []option.RequestOption{
-			option.WithHeader("User-Agent", userAgent),
-		}
+// workspaceFlagSet is true when the workspace was given with --workspace,
+// which takes precedence over the workspace of blaxel.toml
+var workspaceFlagSet bool
 
-		if workspace != "" {
-			opts = append(opts, option.WithWorkspace(workspace))
-		}
+// initClient creates the client for the current workspace. Creating it
+// resolves the environment of the workspace (dev or prod) and the URLs of the
+// API, so it is created again when the workspace changes.
+func initClient() error {
+	// Get OS/arch and commit info for User-Agent
+	osArch := goruntime.GOOS + "/" + goruntime.GOARCH
+	commitHash := "unknown"
 
-		c, err := blaxel.NewClientFromConfig(workspace, opts...)
-		if err != nil {
-			return fmt.Errorf("failed to create client: %w", err)
+	// Check if commit was injected at build time via ldflags
+	if commit != "" {
+		if len(commit) > 7 {
+			commitHash = commit[:7]
+		} else {
+			commitHash = commit
 		}
-		client = c
+	}
 
-		// Resolve and store the authentication source so that error messages
-		// can tell the user where their credentials came from.
-		SetAuthSource(ResolveAuthSource(workspace))
+	userAgent := fmt.Sprintf("blaxel/cli/golang/%s (%s) blaxel/%s", version, osArch, commitHash)
 
-		// Register SDK CLI commands
-		ctx := context.Background()
-		RegisterResourceOperations(ctx)
+	// Build client options
+	opts := []option.RequestOption{
+		option.WithHeader("User-Agent", userAgent),
+	}
 
-		return nil
-	},
+	if workspace != "" {
+		opts = append(opts, option.WithWorkspace(workspace))
+	}
+	opts = append(opts, RequestLogOptions()...)
+
+	c, err := blaxel.NewClientFromConfig(workspace, opts...)
+	if err != nil {
+		return fmt.Errorf("failed to create client: %w", err)
+	}
+	client = c
+	clientWorkspace = workspace
+	SetSentryTag("workspace", workspace)
+
+	// Resolve and store the authentication source so that error messages
+	// can tell the user where their credentials came from.
+	SetAuthSource(ResolveAuthSource(workspace))
+	registerCredentialSecrets(workspace)
+
+	// Register SDK CLI commands
+	ctx := context.Background()
+	RegisterResourceOperations(ctx)
+
+	return nil
 }
 
 // completeWorkspaceNames returns a list of workspace names from the local config for shell completion
@@ -394,11 +423,16 @@ func Execute(releaseVersion string, releaseCommit string, releaseDate string) er
 	// Prompt for tracking consent if not already configured
 	promptForTracking()
 
-	rootCmd.PersistentFlags().StringVarP(&workspace, "workspace", "w", "", "Specify the workspace name")
-	rootCmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", "", "Output format. One of: pretty,yaml,json,table")
-	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "Enable verbose output")
+	rootCmd.PersistentFlags().StringVarP(&workspace, "workspace", "w", "", "Workspace to use for this command, instead of the current context and the workspace of blaxel.toml")
+	rootCmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", "", "Output format. One of: pretty,yaml,json,table,wide,name")
+	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "Enable verbose output, including every API request and response")
+	rootCmd.PersistentFlags().BoolVarP(&quiet, "quiet", "q", false, "Only print errors and results, without success, info, warning and progress messages")
+	rootCmd.MarkFlagsMutuallyExclusive("quiet", "verbose")
+	rootCmd.PersistentFlags().StringVar(&httpTrace, "http-trace", "", "Record every API request and response, with secrets redacted, as JSON lines in this file (also BL_HTTP_TRACE)")
 	rootCmd.PersistentFlags().BoolVarP(&utc, "utc", "u", false, "Enable UTC timezone")
 	rootCmd.PersistentFlags().BoolVarP(&skipVersionWarning, "skip-version-warning", "", false, "Skip version warning")
+	rootCmd.PersistentFlags().BoolVar(&strict, "strict", false, "Fail on configuration and validation warnings (also BL_STRICT=1)")
+	rootCmd.PersistentFlags().BoolVar(&noColor, "no-color", false, "Disable colors and use plain ASCII spinners and borders (also NO_COLOR)")
 
 	// Register workspace flag completion
 	_ = rootCmd.RegisterFlagCompletionFunc("workspace", completeWorkspaceNames)
@@ -420,6 +454,7 @@ func Execute(releaseVersion string, releaseCommit string, releaseDate string) er
 			workspace = ctx.Workspace
 		}
 	}
+	contextWorkspace = workspace
 	blaxel.InitializeEnvironment(workspace)
 
 	SetSentryTag("version", version)
@@ -453,6 +488,14 @@ func setEnvFiles(files []string) {
 
 func ReadConfigToml(folder string, setDefaultType bool) {
 	readConfigToml(folder, setDefaultType)
+	// Commands reading blaxel.toml themselves do it once the client is created,
+	// for the workspace it may set
+	if client != nil && workspace != clientWorkspace {
+		if err := initClient(); err != nil {
+			PrintError("Workspace", err)
+			ExitWithError(err)
+		}
+	}
 }
 
 func GetConfig() Config {
@@ -462,6 +505,7 @@ func GetConfig() Config {
 // ResetConfig resets the config to its zero value (useful for testing)
 func ResetConfig() {
 	config = Config{}
+	localConfigOverrides = nil
 }
 
 // SetConfigType sets the config type
@@ -474,6 +518,37 @@ func SetConfigImage(image string) {
 	config.Image = image
 }
 
+// CheckWorkspaceConfigured returns an actionable error when commands cannot
+// resolve a workspace: none is logged in, or none is selected as current.
+func CheckWorkspaceConfigured(workspace string, cfg blaxel.Config) error {
+	if workspace != "" {
+		return nil
+	}
+	names := []string{}
+	for _, ws := range cfg.Workspaces {
+		if ws.Name != "" {
+			names = append(names, ws.Name)
+		}
+	}
+	if len(names) == 0 {
+		return fmt.Errorf("no workspace configured; run 'bl login <workspace>' or pass -w <workspace>")
+	}
+	return fmt.Errorf("no current workspace selected; run 'bl workspaces <workspace>' to pick one of %s, or pass -w <workspace>", strings.Join(names, ", "))
+}
+
+// RequireClient returns the API client, or an actionable error explaining
+// why it could not be created instead of letting callers use a nil client.
+func RequireClient() (*blaxel.Client, error) {
+	if client != nil {
+		return client, nil
+	}
+	cfg, _ := blaxel.LoadConfig()
+	if err := CheckWorkspaceConfigured(workspace, cfg); err != nil {
+		return nil, err
+	}
+	return nil, fmt.Errorf("client not initialized for workspace '%s'; run 'bl login %s'", workspace, workspace)
+}
+
 // GetClient returns the current client
 func GetClient() *blaxel.Client {
 	return client
@@ -7,8 +7,10 @@ import (
 	"testing"
 	"time"
 
+	blaxel "github.com/blaxel-ai/sdk-go"
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestIsNewerVersion(t *testing.T) {
@@ -590,3 +592,41 @@ func TestIsTerminalInteractive(t *testing.T) {
 	// We just verify it returns a boolean
 	assert.IsType(t, true, result)
 }
+
+func TestCheckWorkspaceConfigured(t *testing.T) {
+	assert.NoError(t, CheckWorkspaceConfigured("my-ws", blaxel.Config{}))
+
+	err := CheckWorkspaceConfigured("", blaxel.Config{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no workspace configured")
+	assert.Contains(t, err.Error(), "bl login")
+	assert.Contains(t, err.Error(), "-w")
+
+	err = CheckWorkspaceConfigured("", blaxel.Config{Workspaces: []blaxel.WorkspaceConfig{{Name: "dev"}, {Name: "prod"}}})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no current workspace selected")
+	assert.Contains(t, err.Error(), "dev, prod")
+}
+
+func TestRequireClientWithoutConfig(t *testing.T) {
+	originalClient, originalWorkspace := client, workspace
+	defer func() { client, workspace = originalClient, originalWorkspace }()
+	t.Setenv("HOME", t.TempDir())
+
+	client = nil
+	workspace = ""
+	c, err := RequireClient()
+	assert.Nil(t, c)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no workspace configured; run 'bl login <workspace>' or pass -w <workspace>")
+
+	// Listing commands surface the same error instead of using a nil client
+	_, err = ListPaginated(&Resource{Kind: "Agent", APIPath: "agents", Paginated: true}, 10, "")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no workspace configured")
+
+	workspace = "my-ws"
+	_, err = RequireClient()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "bl login my-ws")
+}
@@ -0,0 +1,49 @@
+package core
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// runtimeDefaults are the runtime values of a first deploy when blaxel.toml
+// does not set them, so it gets sizing suited to its kind instead of server
+// defaults the user never sees
+var runtimeDefaults = map[string]map[string]interface{}{
+	"agent":    {"memory": int64(4096), "maxScale": int64(10)},
+	"function": {"memory": int64(2048), "maxScale": int64(10)},
+	"job":      {"memory": int64(4096), "maxConcurrentTasks": int64(10)},
+	"sandbox":  {"memory": int64(4096)},
+}
+
+// HasRuntimeDefaults reports whether resources of kind have runtime defaults
+func HasRuntimeDefaults(kind string) bool {
+	return len(runtimeDefaults[kind]) > 0
+}
+
+// ApplyRuntimeDefaults sets the defaults of kind missing from runtime and
+// returns the ones it applied, nil when runtime already sets them all
+func ApplyRuntimeDefaults(kind string, runtime map[string]interface{}) map[string]interface{} {
+	var applied map[string]interface{}
+	for key, value := range runtimeDefaults[kind] {
+		if _, ok := runtime[key]; ok {
+			continue
+		}
+		runtime[key] = value
+		if applied == nil {
+			applied = map[string]interface{}{}
+		}
+		applied[key] = value
+	}
+	return applied
+}
+
+// FormatRuntimeDefaults renders applied runtime defaults as sorted key=value pairs
+func FormatRuntimeDefaults(applied map[string]interface{}) string {
+	pairs := make([]string, 0, len(applied))
+	for key, value := range applied {
+		pairs = append(pairs, fmt.Sprintf("%s=%v", key, value))
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ", ")
+}
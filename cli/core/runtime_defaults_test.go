@@ -0,0 +1,34 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyRuntimeDefaults(t *testing.T) {
+	t.Run("fills missing values", func(t *testing.T) {
+		runtime := map[string]interface{}{}
+		applied := ApplyRuntimeDefaults("agent", runtime)
+		assert.Equal(t, map[string]interface{}{"memory": int64(4096), "maxScale": int64(10)}, applied)
+		assert.Equal(t, int64(4096), runtime["memory"])
+	})
+
+	t.Run("keeps configured values", func(t *testing.T) {
+		runtime := map[string]interface{}{"memory": int64(8192)}
+		applied := ApplyRuntimeDefaults("job", runtime)
+		assert.Equal(t, map[string]interface{}{"maxConcurrentTasks": int64(10)}, applied)
+		assert.Equal(t, int64(8192), runtime["memory"])
+	})
+
+	t.Run("nothing to apply", func(t *testing.T) {
+		runtime := map[string]interface{}{"memory": int64(1024)}
+		assert.Nil(t, ApplyRuntimeDefaults("sandbox", runtime))
+		assert.Nil(t, ApplyRuntimeDefaults("application", map[string]interface{}{}))
+	})
+}
+
+func TestFormatRuntimeDefaults(t *testing.T) {
+	assert.Equal(t, "maxScale=10, memory=2048", FormatRuntimeDefaults(map[string]interface{}{"memory": int64(2048), "maxScale": int64(10)}))
+	assert.Equal(t, "", FormatRuntimeDefaults(nil))
+}
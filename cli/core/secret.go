@@ -1,10 +1,17 @@
 package core
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"slices"
+	"sort"
 	"strings"
+	"sync"
 
 	"github.com/joho/godotenv"
 )
@@ -14,6 +21,10 @@ type Secrets []Env
 
 var secrets Secrets
 
+// secretSources records where each of secrets was read from: the -s flag or
+// the name of a .env file
+var secretSources []string
+
 func loadCommandSecrets() {
 	for _, secret := range commandSecrets {
 		parts := strings.Split(secret, "=")
@@ -25,9 +36,36 @@ func loadCommandSecrets() {
 			Name:  parts[0],
 			Value: strings.Join(parts[1:], "="),
 		})
+		secretSources = append(secretSources, "-s flag")
+	}
+}
+
+// envLayering is set with SetEnvLayering: the default .env file is then
+// followed by .env.<environment> and .env.local
+var envLayering bool
+
+// SetEnvLayering enables the loading of .env.<environment> and .env.local over
+// the default .env file, the environment being the one of SetConfigEnvironment
+func SetEnvLayering(enabled bool) {
+	envLayering = enabled
+}
+
+// layeredEnvFiles returns the env files to read, in order. With layering
+// enabled, the default .env is followed by .env.<environment> and .env.local.
+// Files set explicitly are read as they are.
+func layeredEnvFiles(files []string) []string {
+	if !envLayering || !slices.Equal(files, []string{".env"}) {
+		return files
+	}
+	layered := []string{".env"}
+	if configEnvironment != "" {
+		layered = append(layered, ".env."+configEnvironment)
 	}
+	return append(layered, ".env.local")
 }
 
+// readSecrets reads the env files of folder, a value of a later file
+// replacing the one of an earlier file. Missing layered files are skipped.
 func readSecrets(folder string) {
 	cwd, err := os.Getwd()
 	if err != nil {
@@ -35,18 +73,42 @@ func readSecrets(folder string) {
 		return
 	}
 
-	for _, file := range envFiles {
-		envMap, err := godotenv.Read(filepath.Join(cwd, folder, file))
+	files := layeredEnvFiles(envFiles)
+	layered := len(files) != len(envFiles)
+	read := map[string]int{}
+	loaded := []string{}
+	for _, file := range files {
+		path := file
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(cwd, folder, file)
+		}
+		envMap, err := godotenv.Read(path)
 		if err != nil {
-			return
+			if layered && errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+			break
 		}
+		loaded = append(loaded, file)
 		for key, value := range envMap {
+			if i, ok := read[key]; ok {
+				secrets[i].Value = value
+				if i < len(secretSources) {
+					secretSources[i] = file
+				}
+				continue
+			}
+			read[key] = len(secrets)
 			secrets = append(secrets, Env{
 				Name:  key,
 				Value: value,
 			})
+			secretSources = append(secretSources, file)
 		}
 	}
+	if layered && len(loaded) > 0 {
+		PrintInfo(fmt.Sprintf("Loaded env files: %s", strings.Join(loaded, ", ")))
+	}
 }
 
 // GetSecrets returns the current secrets
@@ -54,6 +116,20 @@ func GetSecrets() []Env {
 	return secrets
 }
 
+// ResetSecrets forgets the loaded secrets (useful for testing)
+func ResetSecrets() {
+	secrets = nil
+	secretSources = nil
+}
+
+// secretSource returns where the secret at index i was read from
+func secretSource(i int) string {
+	if i < len(secretSources) && len(secretSources) == len(secrets) {
+		return secretSources[i]
+	}
+	return "secrets"
+}
+
 func LookupSecret(name string) string {
 	for _, secret := range secrets {
 		if secret.Name == name {
@@ -62,3 +138,110 @@ func LookupSecret(name string) string {
 	}
 	return ""
 }
+
+// redactedSecret replaces secret values in RedactSecrets
+const redactedSecret = "***"
+
+// secretValues are the values masked by RedactSecrets on top of the loaded
+// secrets, such as the credentials of the workspace
+var (
+	secretValues   []string
+	secretValuesMu sync.Mutex
+)
+
+// RegisterSecretValue adds a value to mask in the output of the CLI, for
+// secrets that are not read from .env files or -s flags
+func RegisterSecretValue(value string) {
+	secretValuesMu.Lock()
+	defer secretValuesMu.Unlock()
+	if value != "" && !slices.Contains(secretValues, value) {
+		secretValues = append(secretValues, value)
+	}
+}
+
+// RedactSecrets masks the values of the loaded secrets (.env files and -s flags)
+// and of the registered ones found in s, as is or escaped in a JSON string.
+// Values shorter than 4 characters are left alone to avoid masking common
+// words and numbers.
+func RedactSecrets(s string) string {
+	values := []string{}
+	for _, secret := range secrets {
+		if len(secret.Value) >= 4 {
+			values = append(values, jsonEscapedForms(secret.Value)...)
+		}
+	}
+	secretValuesMu.Lock()
+	for _, value := range secretValues {
+		if len(value) >= 4 {
+			values = append(values, jsonEscapedForms(value)...)
+		}
+	}
+	secretValuesMu.Unlock()
+	if len(values) == 0 {
+		return s
+	}
+	// Longest first so a secret containing another one is masked whole
+	sort.Slice(values, func(i, j int) bool { return len(values[i]) > len(values[j]) })
+	for _, value := range values {
+		s = strings.ReplaceAll(s, value, redactedSecret)
+	}
+	return s
+}
+
+// jsonEscapedForms returns value and the forms it takes in a JSON string,
+// with and without HTML characters escaped
+func jsonEscapedForms(value string) []string {
+	forms := []string{value}
+	for _, escapeHTML := range []bool{true, false} {
+		var buf bytes.Buffer
+		encoder := json.NewEncoder(&buf)
+		encoder.SetEscapeHTML(escapeHTML)
+		if encoder.Encode(value) != nil {
+			continue
+		}
+		quoted := strings.TrimSuffix(buf.String(), "\n")
+		if escaped := quoted[1 : len(quoted)-1]; !slices.Contains(forms, escaped) {
+			forms = append(forms, escaped)
+		}
+	}
+	return forms
+}
+
+// RedactSecretsIn returns a copy of value, converted to maps and slices
+// through JSON, with RedactSecrets applied to each of its strings. It masks
+// secrets before value is marshaled, when escaping could hide them.
+func RedactSecretsIn(value interface{}) (interface{}, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var document interface{}
+	if err := decoder.Decode(&document); err != nil {
+		return nil, err
+	}
+	return redactStrings(document), nil
+}
+
+func redactStrings(value interface{}) interface{} {
+	switch v := value.(type) {
+	case string:
+		return RedactSecrets(v)
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+		f, _ := v.Float64()
+		return f
+	case map[string]interface{}:
+		for key, item := range v {
+			v[key] = redactStrings(item)
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = redactStrings(item)
+		}
+	}
+	return value
+}
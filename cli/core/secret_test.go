@@ -1,9 +1,12 @@
 package core
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestLookupSecret(t *testing.T) {
@@ -127,3 +130,103 @@ func TestSecretsType(t *testing.T) {
 	assert.Equal(t, "SECRET1", s[0].Name)
 	assert.Equal(t, "SECRET2", s[1].Name)
 }
+
+func TestRedactSecrets(t *testing.T) {
+	originalSecrets := secrets
+	defer func() { secrets = originalSecrets }()
+
+	secrets = Secrets{
+		{Name: "API_KEY", Value: "sk-abc123"},
+		{Name: "TOKEN", Value: "sk-abc123-long"},
+		{Name: "SHORT", Value: "on"},
+	}
+
+	assert.Equal(t, "key=*** token=***", RedactSecrets("key=sk-abc123 token=sk-abc123-long"))
+	assert.Equal(t, "turned on", RedactSecrets("turned on"))
+	assert.Equal(t, "nothing to hide", RedactSecrets("nothing to hide"))
+
+	secrets = Secrets{{Name: "DB_PASS", Value: `p&ss"1234`}}
+	assert.Equal(t, `{"value":"***"}`, RedactSecrets(`{"value":"p\u0026ss\"1234"}`))
+	assert.Equal(t, `{"value":"***"}`, RedactSecrets(`{"value":"p&ss\"1234"}`))
+}
+
+func TestRegisterSecretValue(t *testing.T) {
+	originalSecrets := secrets
+	originalValues := secretValues
+	defer func() {
+		secrets = originalSecrets
+		secretValues = originalValues
+	}()
+	secrets = nil
+	secretValues = nil
+
+	RegisterSecretValue("bl-api-key-123")
+	RegisterSecretValue("bl-api-key-123")
+	RegisterSecretValue("")
+	assert.Len(t, secretValues, 1)
+	assert.Equal(t, "using key ***", RedactSecrets("using key bl-api-key-123"))
+}
+
+func TestLayeredEnvFiles(t *testing.T) {
+	originalLayering := envLayering
+	originalEnvironment := configEnvironment
+	defer func() {
+		envLayering = originalLayering
+		configEnvironment = originalEnvironment
+	}()
+
+	envLayering = false
+	configEnvironment = "production"
+	assert.Equal(t, []string{".env"}, layeredEnvFiles([]string{".env"}))
+
+	envLayering = true
+	assert.Equal(t, []string{".env", ".env.production", ".env.local"}, layeredEnvFiles([]string{".env"}))
+	assert.Equal(t, []string{".env.ci"}, layeredEnvFiles([]string{".env.ci"}), "explicit files are read as they are")
+
+	configEnvironment = ""
+	assert.Equal(t, []string{".env", ".env.local"}, layeredEnvFiles([]string{".env"}))
+}
+
+func TestReadSecretsLayering(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".env"), []byte("DB_HOST=localhost\nAPI_KEY=dev-key\nDEBUG=true\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".env.production"), []byte("DB_HOST=db.internal\nAPI_KEY=prod-key\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".env.local"), []byte("API_KEY=my-key\n"), 0644))
+
+	originalDir, err := os.Getwd()
+	require.NoError(t, err)
+	originalSecrets, originalSources := secrets, secretSources
+	originalEnvFiles, originalCommandSecrets := envFiles, commandSecrets
+	originalLayering, originalEnvironment := envLayering, configEnvironment
+	defer func() {
+		_ = os.Chdir(originalDir)
+		secrets, secretSources = originalSecrets, originalSources
+		envFiles, commandSecrets = originalEnvFiles, originalCommandSecrets
+		envLayering, configEnvironment = originalLayering, originalEnvironment
+	}()
+	require.NoError(t, os.Chdir(dir))
+
+	ResetSecrets()
+	SetEnvLayering(true)
+	SetConfigEnvironment("production")
+	LoadCommandSecrets([]string{"DEBUG=false"})
+	ReadSecrets("", []string{".env"})
+
+	assert.Equal(t, "db.internal", LookupSecret("DB_HOST"))
+	assert.Equal(t, "my-key", LookupSecret("API_KEY"))
+	assert.Equal(t, "false", LookupSecret("DEBUG"), "-s flags win over the env files")
+	assert.Len(t, secrets, 4, "one entry per name read from the env files")
+
+	// A missing environment file is skipped
+	ResetSecrets()
+	SetConfigEnvironment("staging")
+	SetCommandSecrets(nil)
+	ReadSecrets("", []string{".env"})
+	assert.Equal(t, "localhost", LookupSecret("DB_HOST"))
+	assert.Equal(t, "my-key", LookupSecret("API_KEY"))
+
+	ResetSecrets()
+	SetEnvLayering(false)
+	ReadSecrets("", []string{".env"})
+	assert.Equal(t, "dev-key", LookupSecret("API_KEY"))
+}
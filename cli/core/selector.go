@@ -0,0 +1,115 @@
+package core
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// labelRequirement is one term of a label selector
+type labelRequirement struct {
+	key      string
+	value    string
+	operator string // "=", "!=" or "exists"
+}
+
+// LabelSelector filters resources on metadata.labels. All requirements must match.
+type LabelSelector []labelRequirement
+
+var labelKeyRe = regexp.MustCompile(`^[A-Za-z0-9]([A-Za-z0-9._/-]*[A-Za-z0-9])?$`)
+
+// ParseLabelSelector parses selectors of the form key=value, key==value,
+// key!=value or key (the label exists). Each entry may hold several
+// comma-separated terms.
+func ParseLabelSelector(selectors []string) (LabelSelector, error) {
+	selector := LabelSelector{}
+	for _, s := range selectors {
+		for _, term := range strings.Split(s, ",") {
+			term = strings.TrimSpace(term)
+			if term == "" {
+				continue
+			}
+			req := labelRequirement{operator: "exists", key: term}
+			switch {
+			case strings.Contains(term, "!="):
+				parts := strings.SplitN(term, "!=", 2)
+				req = labelRequirement{key: parts[0], value: parts[1], operator: "!="}
+			case strings.Contains(term, "=="):
+				parts := strings.SplitN(term, "==", 2)
+				req = labelRequirement{key: parts[0], value: parts[1], operator: "="}
+			case strings.Contains(term, "="):
+				parts := strings.SplitN(term, "=", 2)
+				req = labelRequirement{key: parts[0], value: parts[1], operator: "="}
+			}
+			req.key = strings.TrimSpace(req.key)
+			req.value = strings.TrimSpace(req.value)
+			if !labelKeyRe.MatchString(req.key) {
+				return nil, fmt.Errorf("invalid label selector %q: expected key=value, key!=value or key", term)
+			}
+			selector = append(selector, req)
+		}
+	}
+	return selector, nil
+}
+
+// Empty reports whether the selector has no requirement and matches everything
+func (s LabelSelector) Empty() bool {
+	return len(s) == 0
+}
+
+// RequiresLabel reports whether the selector has a key=value or key term, so
+// it can only match resources carrying some label. A selector made only of
+// key!=value terms also matches the resources without any label.
+func (s LabelSelector) RequiresLabel() bool {
+	for _, req := range s {
+		if req.operator != "!=" {
+			return true
+		}
+	}
+	return false
+}
+
+// Matches reports whether item's metadata.labels satisfy every requirement
+func (s LabelSelector) Matches(item interface{}) bool {
+	labels := map[string]interface{}{}
+	if itemMap, ok := item.(map[string]interface{}); ok {
+		if metadata, ok := itemMap["metadata"].(map[string]interface{}); ok {
+			if l, ok := metadata["labels"].(map[string]interface{}); ok {
+				labels = l
+			}
+		}
+	}
+	for _, req := range s {
+		value, exists := labels[req.key]
+		actual := fmt.Sprint(value)
+		switch req.operator {
+		case "exists":
+			if !exists {
+				return false
+			}
+		case "=":
+			if !exists || actual != req.value {
+				return false
+			}
+		case "!=":
+			if exists && actual == req.value {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Filter returns the items matching the selector
+func (s LabelSelector) Filter(items []interface{}) []interface{} {
+	if s.Empty() {
+		return items
+	}
+	filtered := []interface{}{}
+	for _, item := range items {
+		if s.Matches(item) {
+			filtered = append(filtered, item)
+		}
+	}
+	return filtered
+}
@@ -0,0 +1,61 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func labeled(name string, labels map[string]interface{}) interface{} {
+	return map[string]interface{}{
+		"metadata": map[string]interface{}{"name": name, "labels": labels},
+	}
+}
+
+func TestParseLabelSelector(t *testing.T) {
+	selector, err := ParseLabelSelector([]string{"team=payments,env!=dev", "owner", "tier==gold"})
+	require.NoError(t, err)
+	assert.Equal(t, LabelSelector{
+		{key: "team", value: "payments", operator: "="},
+		{key: "env", value: "dev", operator: "!="},
+		{key: "owner", operator: "exists"},
+		{key: "tier", value: "gold", operator: "="},
+	}, selector)
+
+	selector, err = ParseLabelSelector(nil)
+	require.NoError(t, err)
+	assert.True(t, selector.Empty())
+
+	_, err = ParseLabelSelector([]string{"=payments"})
+	assert.Error(t, err)
+	_, err = ParseLabelSelector([]string{"team name=x"})
+	assert.Error(t, err)
+}
+
+func TestLabelSelectorMatches(t *testing.T) {
+	payments := labeled("a", map[string]interface{}{"team": "payments", "env": "prod", "owner": "alice"})
+	search := labeled("b", map[string]interface{}{"team": "search", "env": "dev"})
+	unlabeled := map[string]interface{}{"metadata": map[string]interface{}{"name": "c"}}
+
+	tests := []struct {
+		selectors []string
+		want      []string
+	}{
+		{[]string{"team=payments"}, []string{"a"}},
+		{[]string{"env!=dev"}, []string{"a", "c"}},
+		{[]string{"owner"}, []string{"a"}},
+		{[]string{"team=search", "env=dev"}, []string{"b"}},
+		{[]string{"team=search,env=prod"}, nil},
+		{nil, []string{"a", "b", "c"}},
+	}
+	for _, tt := range tests {
+		selector, err := ParseLabelSelector(tt.selectors)
+		require.NoError(t, err)
+		var names []string
+		for _, item := range selector.Filter([]interface{}{payments, search, unlabeled}) {
+			names = append(names, item.(map[string]interface{})["metadata"].(map[string]interface{})["name"].(string))
+		}
+		assert.Equal(t, tt.want, names, "selectors %v", tt.selectors)
+	}
+}
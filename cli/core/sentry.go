@@ -2,9 +2,13 @@ package core
 
 import (
 	"os"
+	"strings"
 	"time"
 
+	blaxel "github.com/blaxel-ai/sdk-go"
 	"github.com/getsentry/sentry-go"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 )
 
 // SentryDSN is the default Sentry DSN for the CLI
@@ -32,6 +36,10 @@ func InitSentry(cfg SentryConfig) error {
 		Environment:      environment,
 		Release:          cfg.Release,
 		AttachStacktrace: true,
+		BeforeSend: func(event *sentry.Event, _ *sentry.EventHint) *sentry.Event {
+			scrubSentryEvent(event)
+			return event
+		},
 	})
 	if err != nil {
 		return err
@@ -65,6 +73,105 @@ func SetSentryTag(key, value string) {
 	})
 }
 
+// breadcrumbsEnabled reports whether breadcrumbs are recorded: Sentry must be
+// initialized and tracking enabled
+func breadcrumbsEnabled() bool {
+	return SentryDSN != "" && blaxel.IsTrackingEnabled()
+}
+
+// AddBreadcrumb records a lifecycle event (config loaded, archive created...)
+// attached to the next error or panic sent to Sentry
+func AddBreadcrumb(category, message string, data map[string]interface{}) {
+	if !breadcrumbsEnabled() {
+		return
+	}
+	sentry.AddBreadcrumb(&sentry.Breadcrumb{
+		Category: category,
+		Message:  message,
+		Data:     data,
+		Level:    sentry.LevelInfo,
+	})
+}
+
+// AddCommandBreadcrumb records the command being run with its sanitized flags
+func AddCommandBreadcrumb(cmd *cobra.Command) {
+	if !breadcrumbsEnabled() {
+		return
+	}
+	AddBreadcrumb("command", cmd.CommandPath(), map[string]interface{}{"flags": sanitizedFlags(cmd)})
+}
+
+// secretFlags are the flags whose values are never sent to Sentry
+var secretFlags = map[string]bool{
+	"secrets":       true,
+	"registry-cred": true,
+	"set":           true,
+	"data":          true,
+	"header":        true,
+	"params":        true,
+}
+
+// secretFlagWords mark any other flag holding credentials
+var secretFlagWords = []string{"secret", "token", "password", "key", "cred", "auth"}
+
+// isSecretFlag reports whether the value of the named flag must be masked
+func isSecretFlag(name string) bool {
+	if secretFlags[name] {
+		return true
+	}
+	for _, word := range secretFlagWords {
+		if strings.Contains(name, word) {
+			return true
+		}
+	}
+	return false
+}
+
+// sanitizedFlags returns the flags set on the command line, with the values of
+// secret flags masked and loaded secret values redacted from the others
+func sanitizedFlags(cmd *cobra.Command) map[string]string {
+	flags := map[string]string{}
+	cmd.Flags().Visit(func(f *pflag.Flag) {
+		if isSecretFlag(f.Name) {
+			flags[f.Name] = redactedSecret
+			return
+		}
+		flags[f.Name] = RedactSecrets(f.Value.String())
+	})
+	return flags
+}
+
+// scrubSentryEvent redacts loaded secret values from an event before it is
+// sent. Secrets are read after the command breadcrumb is recorded, so this
+// runs at send time rather than when breadcrumbs are added.
+func scrubSentryEvent(event *sentry.Event) {
+	event.Message = RedactSecrets(event.Message)
+	for i := range event.Exception {
+		event.Exception[i].Value = RedactSecrets(event.Exception[i].Value)
+	}
+	for _, breadcrumb := range event.Breadcrumbs {
+		breadcrumb.Message = RedactSecrets(breadcrumb.Message)
+		breadcrumb.Data = scrubSentryData(breadcrumb.Data)
+	}
+}
+
+// scrubSentryData redacts loaded secret values from breadcrumb data
+func scrubSentryData(data map[string]interface{}) map[string]interface{} {
+	for key, value := range data {
+		switch v := value.(type) {
+		case string:
+			data[key] = RedactSecrets(v)
+		case map[string]string:
+			scrubbed := make(map[string]string, len(v))
+			for name, s := range v {
+				scrubbed[name] = RedactSecrets(s)
+			}
+			data[key] = scrubbed
+		}
+	}
+	return data
+}
+
 // RecoverWithSentry recovers from a panic and sends it to Sentry
 // Usage: defer core.RecoverWithSentry()
 func RecoverWithSentry() {
@@ -78,19 +185,16 @@ func RecoverWithSentry() {
 	}
 }
 
-// ExitWithError captures the error to Sentry and exits with code 1.
-// When the error looks like an auth failure it also prints a hint about
-// the credential source (env var vs config file) so the user can spot
-// stale or mismatched credentials immediately.
+// ExitWithError captures the error to Sentry and exits with the code of
+// ExitCode, 1 unless the error has a class. The error is printed beforehand
+// by PrintError or PrintCommandError, along with the credential source hint
+// of auth failures.
 func ExitWithError(err error) {
-	if IsAuthError(err) {
-		PrintAuthSourceHint()
-	}
 	if err != nil && SentryDSN != "" {
 		sentry.CaptureException(err)
 		sentry.Flush(2 * time.Second)
 	}
-	os.Exit(1)
+	os.Exit(ExitCode(err))
 }
 
 // ExitWithMessage captures a message to Sentry and exits with code 1
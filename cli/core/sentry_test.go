@@ -5,7 +5,10 @@ import (
 	"testing"
 	"time"
 
+	"github.com/getsentry/sentry-go"
+	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestSentryConfigStruct(t *testing.T) {
@@ -64,3 +67,56 @@ func TestRecoverWithSentryEmptyDSN(t *testing.T) {
 	// Should not panic when DSN is empty
 	RecoverWithSentry()
 }
+
+func TestAddBreadcrumbWithEmptyDSN(t *testing.T) {
+	SentryDSN = ""
+
+	// Should not panic
+	AddBreadcrumb("deploy", "archive created", nil)
+	AddCommandBreadcrumb(&cobra.Command{Use: "bl"})
+}
+
+func TestSanitizedFlags(t *testing.T) {
+	originalSecrets := secrets
+	defer func() { secrets = originalSecrets }()
+	secrets = Secrets{{Name: "API_KEY", Value: "sk-abc123"}}
+
+	cmd := &cobra.Command{Use: "deploy"}
+	cmd.Flags().StringSliceP("secrets", "s", nil, "")
+	cmd.Flags().String("registry-cred", "", "")
+	cmd.Flags().String("api-key", "", "")
+	cmd.Flags().String("name", "", "")
+	cmd.Flags().String("directory", "", "")
+	cmd.Flags().Bool("dryrun", false, "")
+	require.NoError(t, cmd.Flags().Parse([]string{"-s", "API_KEY=sk-abc123", "--registry-cred", "user:pass", "--api-key", "xyz", "--name", "app-sk-abc123", "--dryrun"}))
+
+	assert.Equal(t, map[string]string{
+		"secrets":       "***",
+		"registry-cred": "***",
+		"api-key":       "***",
+		"name":          "app-***",
+		"dryrun":        "true",
+	}, sanitizedFlags(cmd), "unset flags are left out")
+}
+
+func TestScrubSentryEvent(t *testing.T) {
+	originalSecrets := secrets
+	defer func() { secrets = originalSecrets }()
+	secrets = Secrets{{Name: "API_KEY", Value: "sk-abc123"}}
+
+	event := &sentry.Event{
+		Message:   "failed with sk-abc123",
+		Exception: []sentry.Exception{{Value: "bad key sk-abc123"}},
+		Breadcrumbs: []*sentry.Breadcrumb{{
+			Message: "bl run sk-abc123",
+			Data:    map[string]interface{}{"flags": map[string]string{"name": "sk-abc123"}, "size": 12},
+		}},
+	}
+	scrubSentryEvent(event)
+
+	assert.Equal(t, "failed with ***", event.Message)
+	assert.Equal(t, "bad key ***", event.Exception[0].Value)
+	assert.Equal(t, "bl run ***", event.Breadcrumbs[0].Message)
+	assert.Equal(t, map[string]string{"name": "***"}, event.Breadcrumbs[0].Data["flags"])
+	assert.Equal(t, 12, event.Breadcrumbs[0].Data["size"])
+}
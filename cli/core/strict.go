@@ -0,0 +1,41 @@
+package core
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+)
+
+// strict is set by the --strict flag, BL_STRICT=1 enables it as well
+var strict bool
+
+// IsStrict reports whether configuration and validation warnings must fail
+// the command, as requested with --strict or BL_STRICT.
+func IsStrict() bool {
+	if strict {
+		return true
+	}
+	enabled, _ := strconv.ParseBool(os.Getenv("BL_STRICT"))
+	return enabled
+}
+
+// SetStrict enables or disables strict mode
+func SetStrict(enabled bool) {
+	strict = enabled
+}
+
+// StrictWarning prints message as a warning. In strict mode the warning is
+// printed as an error for op instead and the command exits with a non-zero code.
+func StrictWarning(op string, message string) {
+	if IsStrict() {
+		err := StrictError(message)
+		PrintError(op, err)
+		ExitWithError(err)
+	}
+	PrintWarning(message)
+}
+
+// StrictError turns a warning message into the error reported in strict mode
+func StrictError(message string) error {
+	return fmt.Errorf("%s (warnings are errors with --strict)", message)
+}
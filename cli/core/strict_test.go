@@ -0,0 +1,37 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsStrict(t *testing.T) {
+	defer SetStrict(false)
+
+	tests := []struct {
+		name string
+		flag bool
+		env  string
+		want bool
+	}{
+		{name: "off by default"},
+		{name: "flag", flag: true, want: true},
+		{name: "env 1", env: "1", want: true},
+		{name: "env true", env: "true", want: true},
+		{name: "env 0", env: "0"},
+		{name: "env garbage", env: "yes please"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("BL_STRICT", tt.env)
+			SetStrict(tt.flag)
+			assert.Equal(t, tt.want, IsStrict())
+		})
+	}
+}
+
+func TestStrictError(t *testing.T) {
+	err := StrictError("--save-config has no effect without --set")
+	assert.EqualError(t, err, "--save-config has no effect without --set (warnings are errors with --strict)")
+}
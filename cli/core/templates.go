@@ -184,7 +184,7 @@ func EditBlaxelTomlInCurrentDir(resourceType string, resourceName string, resour
 		return fmt.Errorf("failed to read blaxel.toml: %w", err)
 	}
 
-	// Find the next available port starting from 1340
+	// Find the next available port, skipping the ones already in blaxel.toml
 	nextPort := findNextAvailablePort(string(existingContent))
 
 	// Open file for appending
@@ -204,7 +204,7 @@ func EditBlaxelTomlInCurrentDir(resourceType string, resourceName string, resour
 	return nil
 }
 
-// findNextAvailablePort parses the TOML content and finds the next available port starting from 1340
+// findNextAvailablePort parses the TOML content and finds the next port that is neither used in it nor bound on this host
 func findNextAvailablePort(content string) int {
 	usedPorts := make(map[int]bool)
 
@@ -220,12 +220,19 @@ func findNextAvailablePort(content string) int {
 		}
 	}
 
-	// Find the next available port starting from 1340
-	port := 1339
-	for usedPorts[port] {
+	return NextAvailablePort(usedPorts)
+}
+
+// firstAutoPort is the first port given to packages added to blaxel.toml
+const firstAutoPort = 1339
+
+// NextAvailablePort returns the first port from firstAutoPort that is neither
+// in used nor already bound on this host
+func NextAvailablePort(used map[int]bool) int {
+	port := firstAutoPort
+	for used[port] || !IsPortAvailable(port) {
 		port++
 	}
-
 	return port
 }
 
@@ -272,7 +279,7 @@ func (t Template) Clone(opts TemplateOptions) error {
 		return fmt.Errorf("git is not available on your system. Please install git and try again")
 	}
 	// We clone in a tmp dir, cause the template can contain variables and they will be evaluated
-	cloneDirCmd := exec.Command("git", "clone", "-b", branch, t.URL, opts.Directory)
+	cloneDirCmd := exec.Command("git", "clone", "-b", branch, "--", t.URL, opts.Directory)
 	if err := cloneDirCmd.Run(); err != nil {
 		return fmt.Errorf("failed to clone templates repository: %w", err)
 	}
@@ -298,6 +305,66 @@ func (t Template) Clone(opts TemplateOptions) error {
 	return nil
 }
 
+// GitTemplate is a template repository given with `bl new --from-git`
+type GitTemplate struct {
+	URL    string
+	Branch string // Branch or tag to clone, the remote default branch when empty
+}
+
+// Clone shallow clones the repository into opts.Directory, removes its .git
+// folder and installs dependencies like a built-in template
+func (g GitTemplate) Clone(opts TemplateOptions) error {
+	if !isCommandAvailable("git") {
+		return fmt.Errorf("git is not available on your system. Please install git and try again")
+	}
+	args := []string{"clone", "--depth", "1"}
+	if g.Branch != "" {
+		args = append(args, "--branch", g.Branch)
+	}
+	// "--" keeps a URL starting with "-" from being read as an option
+	args = append(args, "--", g.URL, opts.Directory)
+	output, err := exec.Command("git", args...).CombinedOutput()
+	if err != nil {
+		_ = os.RemoveAll(opts.Directory)
+		return fmt.Errorf("failed to clone %s: %s", g.URL, strings.TrimSpace(string(output)))
+	}
+
+	if err := os.RemoveAll(filepath.Join(opts.Directory, ".git")); err != nil {
+		return fmt.Errorf("failed to remove .git directory: %w", err)
+	}
+
+	switch ModuleLanguage(opts.Directory) {
+	case "python":
+		return installPythonDependencies(opts.Directory)
+	case "typescript":
+		return installTypescriptDependencies(opts.Directory)
+	}
+	return nil
+}
+
+// CloneGitTemplateWithSpinner clones a git template with optional spinner based on noTTY flag
+func CloneGitTemplateWithSpinner(g GitTemplate, opts TemplateOptions, noTTY bool, errorPrefix string, spinnerTitle string) error {
+	var cloneErr error
+	if noTTY {
+		cloneErr = g.Clone(opts)
+	} else {
+		spinnerErr := spinner.New().
+			Title(spinnerTitle).
+			Action(func() {
+				cloneErr = g.Clone(opts)
+			}).
+			Run()
+		if spinnerErr != nil {
+			cloneErr = spinnerErr
+		}
+	}
+	if cloneErr != nil {
+		PrintError(errorPrefix, cloneErr)
+		return cloneErr
+	}
+	return nil
+}
+
 // CreateDefaultTemplateOptions creates default options when template is specified via flag
 func CreateDefaultTemplateOptions(directory, templateName string, templates Templates) TemplateOptions {
 	// Find the template by name (supports both full name and display name)
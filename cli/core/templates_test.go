@@ -1,6 +1,8 @@
 package core
 
 import (
+	"fmt"
+	"net"
 	"os"
 	"path/filepath"
 	"testing"
@@ -439,3 +441,13 @@ func TestIsCommandAvailable(t *testing.T) {
 		assert.False(t, result)
 	})
 }
+
+func TestNextAvailablePortSkipsBoundPorts(t *testing.T) {
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%d", firstAutoPort))
+	if err != nil {
+		t.Skipf("port %d is not available: %v", firstAutoPort, err)
+	}
+	defer func() { _ = listener.Close() }()
+
+	assert.Greater(t, NextAvailablePort(map[int]bool{firstAutoPort + 1: true}), firstAutoPort+1)
+}
@@ -5,9 +5,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"net"
 	"os"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -15,6 +17,8 @@ import (
 	"github.com/charmbracelet/huh"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 	"gopkg.in/yaml.v3"
 )
 
@@ -32,7 +36,7 @@ func formatOperationId(operationId string) []string {
 }
 
 func getResults(action string, filePath string, recursive bool) ([]Result, error) {
-	return getResultsWrapper(action, filePath, recursive, 0)
+	return getResultsWrapper(action, filePath, recursive, 0, nil)
 }
 
 func handleSecret(filePath string, content string) (string, error) {
@@ -59,6 +63,7 @@ func handleSecret(filePath string, content string) (string, error) {
 	}
 	values := map[string]*string{}
 	fields := []huh.Field{}
+	missing := []string{}
 	i := 0
 	for _, match := range matches {
 		var value string
@@ -77,6 +82,9 @@ func handleSecret(filePath string, content string) (string, error) {
 		} else if defaultValue != "" {
 			value = defaultValue
 		} else {
+			if !slices.Contains(missing, secretName) {
+				missing = append(missing, secretName)
+			}
 			title := fmt.Sprintf("name: %s", secretName)
 			if i == 0 {
 				title = fmt.Sprintf("Secrets for %s\nname: %s", fileName, secretName)
@@ -87,6 +95,10 @@ func handleSecret(filePath string, content string) (string, error) {
 			i += 1
 		}
 	}
+	if len(missing) > 0 && filePath == "-" {
+		// stdin holds the manifest, the values cannot be prompted for
+		return content, fmt.Errorf("secrets %s are not set: pass them with -s or -e when reading from stdin", strings.Join(missing, ", "))
+	}
 	if len(fields) > 0 {
 		formTemplates := huh.NewForm(
 			huh.NewGroup(fields...),
@@ -103,7 +115,7 @@ func handleSecret(filePath string, content string) (string, error) {
 	return content, nil
 }
 
-func getResultsWrapper(action string, filePath string, recursive bool, n int) ([]Result, error) {
+func getResultsWrapper(action string, filePath string, recursive bool, n int, onFileError func(FileError)) ([]Result, error) {
 	var reader io.Reader
 	var results []Result
 	// Choisir la source (stdin ou fichier)
@@ -119,7 +131,7 @@ func getResultsWrapper(action string, filePath string, recursive bool, n int) ([
 			if n > 0 && !recursive && strings.Contains(filePath, string(filepath.Separator)) {
 				return nil, nil
 			}
-			return handleDirectory(action, filePath, recursive, n)
+			return handleDirectory(action, filePath, recursive, n, onFileError)
 		}
 		// Skip non-YAML files
 		if !strings.HasSuffix(strings.ToLower(filePath), ".yml") && !strings.HasSuffix(strings.ToLower(filePath), ".yaml") {
@@ -167,21 +179,24 @@ func getResultsWrapper(action string, filePath string, recursive bool, n int) ([
 	}
 	// Lire et parser les documents YAML
 	decoder := yaml.NewDecoder(strings.NewReader(contentStr))
-	for {
+	for document := 1; ; document++ {
 		var result Result
 		err := decoder.Decode(&result)
 		if err == io.EOF {
 			break
 		}
 		if err != nil {
-			return nil, fmt.Errorf("error decoding YAML: %v", err)
+			if filePath == "-" {
+				return nil, fmt.Errorf("error decoding YAML document %d from stdin: %v", document, err)
+			}
+			return nil, fmt.Errorf("error decoding YAML document %d: %v", document, err)
 		}
 		results = append(results, result)
 	}
 	return results, nil
 }
 
-func handleDirectory(action string, filePath string, recursive bool, n int) ([]Result, error) {
+func handleDirectory(action string, filePath string, recursive bool, n int, onFileError func(FileError)) ([]Result, error) {
 	var results []Result
 	files, err := os.ReadDir(filePath)
 	if err != nil {
@@ -190,8 +205,12 @@ func handleDirectory(action string, filePath string, recursive bool, n int) ([]R
 
 	for _, file := range files {
 		path := fmt.Sprintf("%s/%s", filePath, file.Name())
-		fileResults, err := getResultsWrapper(action, path, recursive, n+1)
+		fileResults, err := getResultsWrapper(action, path, recursive, n+1, onFileError)
 		if err != nil {
+			if onFileError != nil {
+				onFileError(FileError{Path: path, Err: err})
+				continue
+			}
 			Print(fmt.Sprintf("error getting results for file %s: %v", path, err))
 			continue
 		}
@@ -223,6 +242,27 @@ func ModuleLanguage(directory string) string {
 	return ""
 }
 
+// ReservePort binds port on all interfaces, or a port picked by the OS when
+// port is 0, and returns the listener holding it along with the bound port.
+// Closing the listener releases the port.
+func ReservePort(port int) (net.Listener, int, error) {
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to reserve port %d: %w", port, err)
+	}
+	return listener, listener.Addr().(*net.TCPAddr).Port, nil
+}
+
+// IsPortAvailable reports whether a TCP port can be bound on all interfaces
+func IsPortAvailable(port int) bool {
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
+	if err != nil {
+		return false
+	}
+	_ = listener.Close()
+	return true
+}
+
 // HasPythonEntryFile checks if common Python entry files exist in the given directory
 func HasPythonEntryFile(directory string) bool {
 	files := []string{
@@ -413,6 +453,9 @@ func PrintError(operation string, err error) {
 
 // PrintWarning prints a formatted warning message with colors
 func PrintWarning(message string) {
+	if IsQuiet() {
+		return
+	}
 	PrintDiagnostic(fmt.Sprintf("%s %s\n",
 		color.New(color.FgYellow, color.Bold).Sprint("⚠"),
 		color.New(color.FgYellow).Sprint(message)))
@@ -420,12 +463,18 @@ func PrintWarning(message string) {
 
 // PrintSuccess prints a formatted success message with colors
 func PrintSuccess(message string) {
+	if IsQuiet() {
+		return
+	}
 	Print(fmt.Sprintf("%s %s\n",
 		color.New(color.FgGreen, color.Bold).Sprint("✓"),
 		color.New(color.FgGreen).Sprint(message)))
 }
 
 func PrintInfo(message string) {
+	if IsQuiet() {
+		return
+	}
 	Print(fmt.Sprintf("%s %s\n",
 		color.New(color.FgBlue, color.Bold).Sprint("ℹ"),
 		color.New(color.FgBlue).Sprint(message)))
@@ -433,17 +482,29 @@ func PrintInfo(message string) {
 
 // PrintInfoWithCommand prints an info message followed by a command in white
 func PrintInfoWithCommand(message string, command string) {
+	if IsQuiet() {
+		return
+	}
 	Print(fmt.Sprintf("%s %s %s\n",
 		color.New(color.FgBlue, color.Bold).Sprint("ℹ"),
 		color.New(color.FgBlue).Sprint(message),
 		color.New(color.FgWhite, color.Bold).Sprint(command)))
 }
 
+// PrintDiagnostic prints a message to stderr, with the secret values masked
 func PrintDiagnostic(message string) {
-	message = strings.TrimSuffix(message, "\n")
+	message = RedactSecrets(strings.TrimSuffix(message, "\n"))
 	fmt.Fprintln(os.Stderr, message)
 }
 
+// PrintProgress prints a progress message to stdout, unless --quiet is set
+func PrintProgress(message string) {
+	if IsQuiet() {
+		return
+	}
+	fmt.Println(strings.TrimSuffix(message, "\n"))
+}
+
 func Print(message string) {
 	if IsInteractiveMode() {
 		return
@@ -452,7 +513,7 @@ func Print(message string) {
 	// When structured output is requested, route decorative messages to stderr
 	// so stdout contains only the structured data
 	outputFmt := GetOutputFormat()
-	if outputFmt == "json" || outputFmt == "yaml" {
+	if outputFmt == "json" || outputFmt == "yaml" || outputFmt == "name" {
 		fmt.Fprintln(os.Stderr, message)
 		return
 	}
@@ -507,6 +568,22 @@ func GetResults(action string, filePath string, recursive bool) ([]Result, error
 	return getResults(action, filePath, recursive)
 }
 
+// FileError is a file of a directory that could not be read or parsed
+type FileError struct {
+	Path string
+	Err  error
+}
+
+// GetResultsWithFileErrors works like GetResults but returns the files of a
+// directory that failed to parse instead of printing and skipping them
+func GetResultsWithFileErrors(action string, filePath string, recursive bool) ([]Result, []FileError, error) {
+	var fileErrors []FileError
+	results, err := getResultsWrapper(action, filePath, recursive, 0, func(fe FileError) {
+		fileErrors = append(fileErrors, fe)
+	})
+	return results, fileErrors, err
+}
+
 func IsVolumeTemplate(resourceType string) bool {
 	if resourceType == "volumetemplate" {
 		return true
@@ -826,3 +903,15 @@ func BuildServerEnvWarning(language string, resourceType string) string {
 
 	return warningMsg.String()
 }
+
+// AliasFlags makes each key of aliases another spelling of the flag it maps
+// to. Aliases are not listed in the help and are recorded under the name of
+// their flag, so Changed and the flag conflict checks see them as that flag.
+func AliasFlags(cmd *cobra.Command, aliases map[string]string) {
+	cmd.Flags().SetNormalizeFunc(func(_ *pflag.FlagSet, name string) pflag.NormalizedName {
+		if target, ok := aliases[name]; ok {
+			name = target
+		}
+		return pflag.NormalizedName(name)
+	})
+}
@@ -8,6 +8,7 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -61,6 +62,24 @@ func TestPrintErrorWritesToStderr(t *testing.T) {
 	assert.Contains(t, stderr, "bad input")
 }
 
+func TestPrintErrorMasksSecrets(t *testing.T) {
+	originalInteractive := interactiveMode
+	originalSecrets := secrets
+	interactiveMode = false
+	secrets = Secrets{{Name: "OPENAI_API_KEY", Value: "sk-live-abc123"}}
+	t.Cleanup(func() {
+		interactiveMode = originalInteractive
+		secrets = originalSecrets
+	})
+
+	_, stderr := captureStandardStreams(t, func() {
+		PrintError("Deploy", errors.New("invalid value sk-live-abc123 for OPENAI_API_KEY"))
+	})
+
+	assert.NotContains(t, stderr, "sk-live-abc123")
+	assert.Contains(t, stderr, "invalid value *** for OPENAI_API_KEY")
+}
+
 func TestPrintWarningWritesToStderr(t *testing.T) {
 	originalInteractive := interactiveMode
 	interactiveMode = false
@@ -577,3 +596,85 @@ console.log("No env usage");
 		assert.False(t, result)
 	})
 }
+
+func TestReservePort(t *testing.T) {
+	listener, port, err := ReservePort(0)
+	require.NoError(t, err)
+	assert.Greater(t, port, 0)
+	assert.False(t, IsPortAvailable(port), "the port must stay bound until the listener is closed")
+
+	_, _, err = ReservePort(port)
+	assert.Error(t, err)
+
+	require.NoError(t, listener.Close())
+	assert.True(t, IsPortAvailable(port))
+}
+
+// withStdin runs fn with content as os.Stdin
+func withStdin(t *testing.T, content string, fn func()) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "stdin")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	defer func() { _ = file.Close() }()
+
+	originalStdin := os.Stdin
+	os.Stdin = file
+	defer func() { os.Stdin = originalStdin }()
+	fn()
+}
+
+func TestGetResultsFromStdin(t *testing.T) {
+	manifest := "apiVersion: blaxel.ai/v1alpha1\nkind: Agent\nmetadata:\n  name: a\n---\napiVersion: blaxel.ai/v1alpha1\nkind: Sandbox\nmetadata:\n  name: b\n"
+	withStdin(t, manifest, func() {
+		results, err := GetResults("apply", "-", true)
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+		assert.Equal(t, "Agent", results[0].Kind)
+		assert.Equal(t, "Sandbox", results[1].Kind)
+	})
+}
+
+func TestGetResultsFromStdinReportsDocumentIndex(t *testing.T) {
+	manifest := "kind: Agent\n---\nkind: Sandbox\n---\nkind: [unclosed\n"
+	withStdin(t, manifest, func() {
+		_, err := GetResults("apply", "-", false)
+		assert.ErrorContains(t, err, "error decoding YAML document 3 from stdin")
+	})
+}
+
+func TestGetResultsFromStdinMissingSecrets(t *testing.T) {
+	manifest := "kind: Agent\nspec:\n  envs:\n    - value: ${secrets.MISSING_ONE}\n    - value: $secrets.MISSING_ONE\n    - value: $secrets.MISSING_TWO\n"
+	withStdin(t, manifest, func() {
+		_, err := GetResults("apply", "-", false)
+		assert.ErrorContains(t, err, "secrets MISSING_ONE, MISSING_TWO are not set: pass them with -s or -e when reading from stdin")
+	})
+}
+
+func TestAliasFlags(t *testing.T) {
+	var dryRun bool
+	newCmd := func() *cobra.Command {
+		cmd := &cobra.Command{Use: "test", Run: func(*cobra.Command, []string) {}}
+		cmd.Flags().BoolVar(&dryRun, "dryrun", false, "Dry run")
+		AliasFlags(cmd, map[string]string{"dry-run": "dryrun"})
+		return cmd
+	}
+
+	for _, arg := range []string{"--dryrun", "--dry-run"} {
+		dryRun = false
+		cmd := newCmd()
+		cmd.SetArgs([]string{arg})
+		require.NoError(t, cmd.Execute())
+		assert.True(t, dryRun, arg)
+		assert.True(t, cmd.Flags().Changed("dryrun"), arg)
+	}
+
+	cmd := newCmd()
+	var help bytes.Buffer
+	cmd.SetOut(&help)
+	cmd.SetArgs([]string{"--help"})
+	require.NoError(t, cmd.Execute())
+	assert.Contains(t, help.String(), "--dryrun")
+	assert.NotContains(t, help.String(), "--dry-run")
+}
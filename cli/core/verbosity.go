@@ -0,0 +1,73 @@
+package core
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/blaxel-ai/sdk-go/option"
+)
+
+// quiet is set by the --quiet flag
+var quiet bool
+
+// IsQuiet reports whether only errors and the results of the command must be
+// printed, as requested with --quiet. Success, info and warning messages and
+// the progress of non-interactive deploys are left out.
+func IsQuiet() bool {
+	return quiet
+}
+
+// sensitiveHeaders are the request and response headers whose value is never
+// logged
+var sensitiveHeaders = map[string]bool{
+	"Authorization":          true,
+	"X-Blaxel-Authorization": true,
+	"X-Blaxel-Api-Key":       true,
+	"Api-Key":                true,
+	"X-Api-Key":              true,
+	"Cookie":                 true,
+	"Set-Cookie":             true,
+	"Proxy-Authorization":    true,
+}
+
+// RequestLogOptions returns the client options logging every API request
+// with --verbose and recording it with --http-trace, none otherwise
+func RequestLogOptions() []option.RequestOption {
+	opts := HTTPTraceOptions()
+	if verbose {
+		opts = append(opts, option.WithMiddleware(httpLogMiddleware))
+	}
+	return opts
+}
+
+// httpLogMiddleware logs every API request and its response to stderr with
+// --verbose: method, URL and headers, with credentials scrubbed, then status
+// and duration.
+func httpLogMiddleware(req *http.Request, next option.MiddlewareNext) (*http.Response, error) {
+	PrintDiagnostic(fmt.Sprintf("→ %s %s", req.Method, req.URL.Redacted()))
+	for _, line := range scrubbedHeaders(req.Header) {
+		PrintDiagnostic("  " + line)
+	}
+	start := time.Now()
+	res, err := next(req)
+	elapsed := time.Since(start).Round(time.Millisecond)
+	if err != nil {
+		PrintDiagnostic(fmt.Sprintf("← %s %s failed after %s: %v", req.Method, req.URL.Redacted(), elapsed, err))
+		return res, err
+	}
+	PrintDiagnostic(fmt.Sprintf("← %s %s %s (%s)", res.Status, req.Method, req.URL.Redacted(), elapsed))
+	return res, nil
+}
+
+// scrubbedHeaders returns the headers as sorted "Name: value" lines, with the
+// value of credentials replaced
+func scrubbedHeaders(header http.Header) []string {
+	lines := make([]string, 0, len(header))
+	for name, value := range redactHeaders(header) {
+		lines = append(lines, fmt.Sprintf("%s: %s", name, value))
+	}
+	sort.Strings(lines)
+	return lines
+}
@@ -0,0 +1,75 @@
+package core
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuietSuppressesMessages(t *testing.T) {
+	quiet = true
+	t.Cleanup(func() { quiet = false })
+
+	stdout, stderr := captureStandardStreams(t, func() {
+		PrintSuccess("done")
+		PrintInfo("info")
+		PrintInfoWithCommand("Logs:", "bl logs")
+		PrintWarning("careful")
+		PrintProgress("Uploading...")
+		PrintError("Deploy", errors.New("boom"))
+	})
+	assert.Empty(t, stdout)
+	assert.NotContains(t, stderr, "careful")
+	assert.Contains(t, stderr, "boom", "errors are still printed")
+
+	quiet = false
+	stdout, _ = captureStandardStreams(t, func() {
+		PrintProgress("Uploading...")
+	})
+	assert.Equal(t, "Uploading...\n", stdout)
+}
+
+func TestHTTPLogMiddleware(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "https://api.blaxel.ai/v0/agents?limit=5", nil)
+	req.Header.Set("Authorization", "Bearer secret-token")
+	req.Header.Set("X-Blaxel-Api-Key", "secret-key")
+	req.Header.Set("X-Blaxel-Workspace", "my-ws")
+
+	var res *http.Response
+	var err error
+	_, stderr := captureStandardStreams(t, func() {
+		res, err = httpLogMiddleware(req, func(r *http.Request) (*http.Response, error) {
+			rec := httptest.NewRecorder()
+			rec.WriteHeader(http.StatusNotFound)
+			return rec.Result(), nil
+		})
+	})
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, res.StatusCode)
+	assert.Contains(t, stderr, "→ GET https://api.blaxel.ai/v0/agents?limit=5")
+	assert.Contains(t, stderr, "Authorization: [redacted]")
+	assert.Contains(t, stderr, "X-Blaxel-Api-Key: [redacted]")
+	assert.Contains(t, stderr, "X-Blaxel-Workspace: my-ws")
+	assert.Contains(t, stderr, "← 404 Not Found GET https://api.blaxel.ai/v0/agents?limit=5")
+	assert.NotContains(t, stderr, "secret")
+
+	_, stderr = captureStandardStreams(t, func() {
+		_, err = httpLogMiddleware(req, func(r *http.Request) (*http.Response, error) {
+			return nil, errors.New("connection refused")
+		})
+	})
+	assert.Error(t, err)
+	assert.Contains(t, stderr, "failed after")
+	assert.Contains(t, stderr, "connection refused")
+}
+
+func TestRequestLogOptions(t *testing.T) {
+	assert.Empty(t, RequestLogOptions())
+	verbose = true
+	t.Cleanup(func() { verbose = false })
+	assert.Len(t, RequestLogOptions(), 1)
+}
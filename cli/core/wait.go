@@ -0,0 +1,172 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	blaxel "github.com/blaxel-ai/sdk-go"
+)
+
+// DefaultWaitInterval is the time between two polls of WaitForStatus
+const DefaultWaitInterval = 3 * time.Second
+
+// ErrWaitTimeout is returned by WaitForStatus when the timeout expires
+var ErrWaitTimeout = errors.New("timed out")
+
+// StatusError is returned by WaitForStatus when the resource reaches one of
+// the failed statuses
+type StatusError struct {
+	Kind   string
+	Name   string
+	Status string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("%s %s is %s", e.Kind, e.Name, e.Status)
+}
+
+// WaitOptions configures WaitForStatus
+type WaitOptions struct {
+	// Interval between two polls, DefaultWaitInterval when zero
+	Interval time.Duration
+	// Timeout after which waiting stops with ErrWaitTimeout, none when zero
+	Timeout time.Duration
+	// Done lists the statuses that end the wait successfully
+	Done []string
+	// Failed lists the statuses that end the wait with a *StatusError
+	Failed []string
+	// Settle ignores a Failed status already there when waiting starts,
+	// until the status changes or this long has passed, so the failure of a
+	// previous deploy is not taken for the new one
+	Settle time.Duration
+	// Accept, when set, can hold off a Done or Failed status
+	Accept func(status string) bool
+	// OnChange is called each time the status changes
+	OnChange func(status string)
+	// OnPoll is called with the status after every successful poll
+	OnPoll func(status string)
+	// Fetch returns the current status, GetResourceStatus when nil
+	Fetch func(ctx context.Context) (string, error)
+}
+
+// WaitForStatus polls the status of the resource kind/name until it reaches
+// one of opts.Done or opts.Failed, and returns the last status seen. Errors
+// while fetching the status are retried on the next poll.
+func WaitForStatus(ctx context.Context, kind, name string, opts WaitOptions) (string, error) {
+	fetch := opts.Fetch
+	if fetch == nil {
+		fetch = func(ctx context.Context) (string, error) {
+			return GetResourceStatus(ctx, kind, name)
+		}
+	}
+	interval := opts.Interval
+	if interval <= 0 {
+		interval = DefaultWaitInterval
+	}
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+
+	initial := ""
+	if opts.Settle > 0 {
+		initial, _ = fetch(ctx)
+	}
+	start := time.Now()
+	changed := false
+	last := ""
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			if opts.Timeout > 0 && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return last, fmt.Errorf("%w waiting for %s %s after %s", ErrWaitTimeout, kind, name, opts.Timeout)
+			}
+			return last, ctx.Err()
+		case <-ticker.C:
+		}
+
+		status, err := fetch(ctx)
+		if err != nil {
+			continue
+		}
+		if opts.OnPoll != nil {
+			opts.OnPoll(status)
+		}
+		if status != last {
+			last = status
+			if opts.OnChange != nil {
+				opts.OnChange(status)
+			}
+		}
+
+		changed = changed || status != initial
+		if opts.Accept != nil && !opts.Accept(status) {
+			continue
+		}
+		if slices.Contains(opts.Done, status) {
+			return status, nil
+		}
+		if slices.Contains(opts.Failed, status) {
+			if !changed && time.Since(start) < opts.Settle {
+				continue
+			}
+			return status, &StatusError{Kind: kind, Name: name, Status: status}
+		}
+	}
+}
+
+// GetResourceStatus returns the status of the resource kind/name, or UNKNOWN
+// when the resource has none
+func GetResourceStatus(ctx context.Context, kind, name string) (string, error) {
+	client := GetClient()
+
+	var result interface{}
+	var err error
+
+	switch kind {
+	case "agent":
+		result, err = client.Agents.Get(ctx, name, blaxel.AgentGetParams{})
+	case "function":
+		result, err = client.Functions.Get(ctx, name, blaxel.FunctionGetParams{})
+	case "job":
+		result, err = client.Jobs.Get(ctx, name, blaxel.JobGetParams{})
+	case "sandbox":
+		result, err = client.Sandboxes.Get(ctx, name, blaxel.SandboxGetParams{})
+	case "application":
+		result, err = client.Applications.Get(ctx, name)
+	case "volume-template", "volumetemplate", "vt":
+		result, err = client.VolumeTemplates.Get(ctx, name)
+	default:
+		return "", fmt.Errorf("unknown resource type: %s", kind)
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	// Convert result to map
+	jsonData, err := json.Marshal(result)
+	if err != nil {
+		return "", err
+	}
+
+	var resource map[string]interface{}
+	if err := json.Unmarshal(jsonData, &resource); err != nil {
+		return "", err
+	}
+
+	// Extract status from the resource
+	if status, ok := resource["status"].(string); ok {
+		return status, nil
+	}
+
+	return "UNKNOWN", nil
+}
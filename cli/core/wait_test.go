@@ -0,0 +1,128 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// statusSequence returns a fetch function replaying statuses, then repeating the last one
+func statusSequence(statuses ...string) func(context.Context) (string, error) {
+	i := 0
+	return func(context.Context) (string, error) {
+		status := statuses[i]
+		if i < len(statuses)-1 {
+			i++
+		}
+		if status == "" {
+			return "", errors.New("temporary error")
+		}
+		return status, nil
+	}
+}
+
+func TestWaitForStatusDone(t *testing.T) {
+	changes := []string{}
+	polls := 0
+	status, err := WaitForStatus(context.Background(), "agent", "my-agent", WaitOptions{
+		Interval: time.Millisecond,
+		Done:     []string{"DEPLOYED"},
+		Failed:   []string{"FAILED"},
+		Fetch:    statusSequence("BUILDING", "", "BUILDING", "DEPLOYING", "DEPLOYED"),
+		OnChange: func(status string) { changes = append(changes, status) },
+		OnPoll:   func(string) { polls++ },
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "DEPLOYED", status)
+	assert.Equal(t, []string{"BUILDING", "DEPLOYING", "DEPLOYED"}, changes)
+	assert.Equal(t, 4, polls, "fetch errors are retried without a callback")
+}
+
+func TestWaitForStatusFailed(t *testing.T) {
+	status, err := WaitForStatus(context.Background(), "agent", "my-agent", WaitOptions{
+		Interval: time.Millisecond,
+		Done:     []string{"DEPLOYED"},
+		Failed:   []string{"FAILED"},
+		Fetch:    statusSequence("BUILDING", "FAILED"),
+	})
+	var statusErr *StatusError
+	require.ErrorAs(t, err, &statusErr)
+	assert.Equal(t, "FAILED", status)
+	assert.Equal(t, "agent my-agent is FAILED", err.Error())
+}
+
+func TestWaitForStatusTimeout(t *testing.T) {
+	status, err := WaitForStatus(context.Background(), "job", "my-job", WaitOptions{
+		Interval: time.Millisecond,
+		Timeout:  20 * time.Millisecond,
+		Done:     []string{"DEPLOYED"},
+		Fetch:    statusSequence("BUILDING"),
+	})
+	assert.ErrorIs(t, err, ErrWaitTimeout)
+	assert.ErrorContains(t, err, "timed out waiting for job my-job after 20ms")
+	assert.Equal(t, "BUILDING", status)
+}
+
+func TestWaitForStatusSettle(t *testing.T) {
+	// The FAILED status of the previous deploy is ignored until it changes
+	status, err := WaitForStatus(context.Background(), "agent", "my-agent", WaitOptions{
+		Interval: time.Millisecond,
+		Done:     []string{"DEPLOYED"},
+		Failed:   []string{"FAILED"},
+		Settle:   time.Hour,
+		Fetch:    statusSequence("FAILED", "FAILED", "FAILED", "BUILDING", "DEPLOYED"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "DEPLOYED", status)
+
+	// and accepted once the grace period is over
+	status, err = WaitForStatus(context.Background(), "agent", "my-agent", WaitOptions{
+		Interval: time.Millisecond,
+		Done:     []string{"DEPLOYED"},
+		Failed:   []string{"FAILED"},
+		Settle:   10 * time.Millisecond,
+		Fetch:    statusSequence("FAILED"),
+	})
+	assert.Error(t, err)
+	assert.Equal(t, "FAILED", status)
+
+	// a Done status is never held back
+	status, err = WaitForStatus(context.Background(), "agent", "my-agent", WaitOptions{
+		Interval: time.Millisecond,
+		Timeout:  time.Second,
+		Done:     []string{"DEPLOYED"},
+		Failed:   []string{"FAILED"},
+		Settle:   time.Hour,
+		Fetch:    statusSequence("DEPLOYED"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "DEPLOYED", status)
+}
+
+func TestWaitForStatusAccept(t *testing.T) {
+	sawBuilding := false
+	status, err := WaitForStatus(context.Background(), "agent", "my-agent", WaitOptions{
+		Interval: time.Millisecond,
+		Done:     []string{"DEPLOYED"},
+		Accept:   func(status string) bool { return status != "DEPLOYED" || sawBuilding },
+		OnChange: func(status string) { sawBuilding = sawBuilding || status == "BUILDING" },
+		Fetch:    statusSequence("DEPLOYED", "DEPLOYED", "BUILDING", "DEPLOYED"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "DEPLOYED", status)
+	assert.True(t, sawBuilding)
+}
+
+func TestWaitForStatusCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := WaitForStatus(ctx, "agent", "my-agent", WaitOptions{
+		Interval: time.Hour,
+		Fetch:    statusSequence("BUILDING"),
+	})
+	assert.ErrorIs(t, err, context.Canceled)
+}
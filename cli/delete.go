@@ -3,10 +3,14 @@ package cli
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"reflect"
+	"sync"
+	"time"
 
+	blaxel "github.com/blaxel-ai/sdk-go"
 	"github.com/blaxel-ai/toolkit/cli/core"
 	"github.com/spf13/cobra"
 	"gopkg.in/yaml.v3"
@@ -21,6 +25,11 @@ func init() {
 func DeleteCmd() *cobra.Command {
 	var filePath string
 	var recursive bool
+	var wait bool
+	var timeout time.Duration
+	var yes bool
+	var concurrency int
+	var ignoreNotFound bool
 	cmd := &cobra.Command{
 		Use:   "delete",
 		Short: "Delete resources from your workspace",
@@ -46,16 +55,38 @@ Before Deleting:
 
 Note: Deleting an agent/job stops it immediately but may not delete associated
 storage volumes. Use 'bl get volumes' to see persistent storage and delete
-separately if needed.`,
+separately if needed.
+
+In a terminal, delete asks for confirmation first: type the name of the
+resource, or yes after the list of resources when deleting several of them.
+Any other answer cancels the deletion and exits with code 1. Pass --yes to
+skip it. There is no prompt in CI or when stdin or stdout is not a terminal,
+so scripts are not blocked.
+
+Several resources are deleted in parallel, up to --concurrency at a time, and
+a summary of the deleted and failed ones is printed at the end. With
+--ignore-not-found, resources that do not exist are not reported as failures,
+so cleanup scripts can be run again safely.
+
+Resources go through the DELETING status before they are gone. With --wait,
+the command returns only once every deleted resource is gone, and fails with
+exit code 7 when one still exists after --timeout. Use it in teardown scripts
+instead of sleeping.`,
 		Example: `  # Delete by name (using subcommands)
   bl delete agent my-agent
   bl delete job my-job
   bl delete sandbox my-sandbox
 
+  # Delete without confirmation
+  bl delete agent my-agent --yes
+
   # Delete multiple resources by name
   bl delete volume vol1 vol2 vol3
   bl delete agent agent1 agent2
 
+  # Clean up, whether the resources still exist or not
+  bl delete agents agent1 agent2 agent3 --ignore-not-found --yes
+
   # Delete a sandbox preview
   bl delete sandbox my-sandbox preview my-preview
 
@@ -71,6 +102,9 @@ separately if needed.`,
   # Delete from stdin (useful in pipelines)
   cat resource.yaml | bl delete -f -
 
+  # Return once the resources are gone, so they can be created again
+  bl delete -f ./resources/ -R --wait --timeout 2m
+
   # Safe deletion workflow
   bl get agent my-agent    # Review resource first
   bl delete agent my-agent # Delete after confirmation
@@ -88,7 +122,10 @@ separately if needed.`,
   bl delete sandboxes $(bl get sandboxes -o json | jq -r '.[] | select(.status == "FAILED") | .metadata.name')
   bl delete agents $(bl get agents -o json | jq -r '.[] | select(.metadata.name | contains("test")) | .metadata.name')
   bl delete volumes $(bl get volumes -o json | jq -r '.[] | select(.metadata.labels.environment == "dev") | .metadata.name')
-  bl delete sandboxes $(bl get sandboxes -o json | jq -r '.[] | select(.metadata.name | test("^temp-")) | .metadata.name')`,
+  bl delete sandboxes $(bl get sandboxes -o json | jq -r '.[] | select(.metadata.name | test("^temp-")) | .metadata.name')
+
+  # Names only, filtered by label (no jq needed)
+  bl delete agents $(bl get agents -l env=dev -o name)`,
 		Run: func(cmd *cobra.Command, args []string) {
 			results, err := core.GetResults("delete", filePath, recursive)
 			if err != nil {
@@ -98,32 +135,44 @@ separately if needed.`,
 			}
 
 			// At this point, results contains all your YAML documents
-			hasFailures := false
-			var deleted []deleteEntry
-			var failed []deleteEntry
+			var targets []deleteEntry
 			for _, result := range results {
 				for _, resource := range core.GetResources() {
 					if resource.Kind == result.Kind {
 						name := result.Metadata.(map[string]interface{})["name"].(string)
-						if err := DeleteFn(resource, name); err != nil {
-							hasFailures = true
-							failed = append(failed, deleteEntry{Kind: resource.Kind, Name: name})
-						} else {
-							deleted = append(deleted, deleteEntry{Kind: resource.Kind, Name: name})
-						}
+						targets = append(targets, deleteEntry{Kind: resource.Kind, Name: name, resource: resource})
 					}
 				}
 			}
+			confirmDeletionOrExit(targets, yes)
+
+			deleted, failed := deleteTargets(targets, concurrency, ignoreNotFound)
+			hasFailures := len(failed) > 0
 
+			var waitErr error
+			if wait {
+				waitErr = waitForDeletions(deleted, timeout)
+			}
+			if len(targets) > 1 {
+				printDeleteSummary(deleted, failed)
+			}
 			printDeleteStructuredOutput(deleted, failed)
 			if hasFailures {
 				core.ExitWithError(fmt.Errorf("one or more deletions failed"))
 			}
+			if waitErr != nil {
+				core.ExitWithError(waitErr)
+			}
 		},
 	}
 
 	cmd.Flags().BoolVarP(&recursive, "recursive", "R", false, "Process the directory used in -f, --filename recursively. Useful when you want to manage related manifests organized within the same directory.")
 	cmd.Flags().StringVarP(&filePath, "filename", "f", "", "containing the resource to delete.")
+	cmd.PersistentFlags().BoolVar(&wait, "wait", false, "Wait until the deleted resources are gone")
+	cmd.PersistentFlags().DurationVar(&timeout, "timeout", 5*time.Minute, "Maximum time to wait with --wait, 0 waits forever")
+	cmd.PersistentFlags().BoolVarP(&yes, "yes", "y", false, "Delete without asking for confirmation")
+	cmd.PersistentFlags().IntVar(&concurrency, "concurrency", 8, "Maximum number of resources deleted in parallel")
+	cmd.PersistentFlags().BoolVar(&ignoreNotFound, "ignore-not-found", false, "Treat resources that do not exist as deleted")
 	err := cmd.MarkFlagRequired("filename")
 	if err != nil {
 		fmt.Println(err)
@@ -165,21 +214,29 @@ separately if needed.`,
 					}
 				}
 
-				hasFailures := false
-				var deleted []deleteEntry
-				var failed []deleteEntry
+				targets := make([]deleteEntry, 0, len(args))
 				for _, name := range args {
-					if err := DeleteFn(resource, name); err != nil {
-						hasFailures = true
-						failed = append(failed, deleteEntry{Kind: resource.Kind, Name: name})
-					} else {
-						deleted = append(deleted, deleteEntry{Kind: resource.Kind, Name: name})
-					}
+					targets = append(targets, deleteEntry{Kind: resource.Kind, Name: name, resource: resource})
+				}
+				confirmDeletionOrExit(targets, yes)
+
+				deleted, failed := deleteTargets(targets, concurrency, ignoreNotFound)
+				hasFailures := len(failed) > 0
+
+				var waitErr error
+				if wait {
+					waitErr = waitForDeletions(deleted, timeout)
+				}
+				if len(targets) > 1 {
+					printDeleteSummary(deleted, failed)
 				}
 				printDeleteStructuredOutput(deleted, failed)
 				if hasFailures {
 					core.ExitWithError(fmt.Errorf("one or more deletions failed"))
 				}
+				if waitErr != nil {
+					core.ExitWithError(waitErr)
+				}
 			},
 		}
 		cmd.AddCommand(subcmd)
@@ -189,21 +246,35 @@ separately if needed.`,
 }
 
 func DeleteFn(resource *core.Resource, name string) error {
-	if resource.Delete == nil {
-		hint := nestedResourceHint(resource, "delete")
-		err := fmt.Errorf("'bl delete %s' is not supported directly.%s", resource.Singular, hint)
+	if err := deleteResource(resource, name); err != nil {
 		fmt.Fprintln(os.Stderr, err)
 		return err
 	}
+	printDeleted(resource.Kind, name)
+	return nil
+}
+
+// printDeleted tells that a resource was deleted, unless the output is structured
+func printDeleted(kind, name string) {
+	outputFmt := core.GetOutputFormat()
+	if outputFmt != "json" && outputFmt != "yaml" {
+		fmt.Printf("Resource %s:%s deleted\n", kind, name)
+	}
+}
+
+// deleteResource deletes the resource without printing anything
+func deleteResource(resource *core.Resource, name string) error {
+	if resource.Delete == nil {
+		hint := nestedResourceHint(resource, "delete")
+		return fmt.Errorf("'bl delete %s' is not supported directly.%s", resource.Singular, hint)
+	}
 
 	ctx := context.Background()
 
 	// Use reflect to call the function
 	funcValue := reflect.ValueOf(resource.Delete)
 	if funcValue.Kind() != reflect.Func {
-		err := fmt.Errorf("fn is not a valid function")
-		fmt.Fprintln(os.Stderr, err)
-		return err
+		return fmt.Errorf("fn is not a valid function")
 	}
 
 	// Build arguments: (ctx, name, ...opts)
@@ -235,22 +306,69 @@ func DeleteFn(resource *core.Resource, name string) error {
 	}
 
 	if err, ok := results[1].Interface().(error); ok && err != nil {
-		fmt.Fprintln(os.Stderr, err)
 		return err
 	}
 
 	// The new SDK returns typed responses, not *http.Response
 	// Success if we get here without error
-	outputFmt := core.GetOutputFormat()
-	if outputFmt != "json" && outputFmt != "yaml" {
-		fmt.Printf("Resource %s:%s deleted\n", resource.Kind, name)
-	}
 	return nil
 }
 
+// deleteTargets deletes the targets, up to concurrency at a time, and returns
+// the deleted and failed ones in the order of targets. With ignoreNotFound, a
+// resource that does not exist counts as deleted.
+func deleteTargets(targets []deleteEntry, concurrency int, ignoreNotFound bool) (deleted []deleteEntry, failed []deleteEntry) {
+	errs := make([]error, len(targets))
+	sem := make(chan struct{}, max(concurrency, 1))
+	var wg sync.WaitGroup
+	for i, target := range targets {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			err := deleteResource(target.resource, target.Name)
+			var apiErr *blaxel.Error
+			switch {
+			case err == nil:
+				printDeleted(target.Kind, target.Name)
+			case ignoreNotFound && errors.As(err, &apiErr) && apiErr.StatusCode == 404:
+				err = nil
+			default:
+				fmt.Fprintf(os.Stderr, "Resource %s:%s error: %s\n", target.Kind, target.Name, extractErrorMessage(err))
+			}
+			errs[i] = err
+		}()
+	}
+	wg.Wait()
+
+	for i, target := range targets {
+		if errs[i] != nil {
+			target.Error = extractErrorMessage(errs[i])
+			failed = append(failed, target)
+		} else {
+			deleted = append(deleted, target)
+		}
+	}
+	return deleted, failed
+}
+
+// printDeleteSummary prints the number of deleted and failed resources
+// followed by each failure
+func printDeleteSummary(deleted []deleteEntry, failed []deleteEntry) {
+	core.Print(fmt.Sprintf("\nSummary: %d deleted, %d failed\n", len(deleted), len(failed)))
+	for _, entry := range failed {
+		core.Print(fmt.Sprintf("  - %s:%s: %s\n", entry.Kind, entry.Name, entry.Error))
+	}
+}
+
 type deleteEntry struct {
-	Kind string `json:"kind"`
-	Name string `json:"name"`
+	Kind  string `json:"kind"`
+	Name  string `json:"name"`
+	Error string `json:"error,omitempty" yaml:"error,omitempty"`
+
+	resource *core.Resource
 }
 
 func printDeleteStructuredOutput(deleted []deleteEntry, failed []deleteEntry) {
@@ -0,0 +1,54 @@
+package cli
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/blaxel-ai/toolkit/cli/core"
+)
+
+// deleteConfirmationNeeded reports whether deletions must be confirmed: in a
+// terminal, outside CI, unless --yes was passed
+func deleteConfirmationNeeded(yes bool) bool {
+	return !yes && core.IsTerminalInteractive() && !core.IsCIEnvironment()
+}
+
+// confirmDeletion asks to confirm the deletion of entries on out and reads
+// the answer from in. A single resource is confirmed by typing its name,
+// several by typing yes after the list of what will be removed.
+func confirmDeletion(entries []deleteEntry, workspace string, in io.Reader, out io.Writer) bool {
+	if len(entries) == 0 {
+		return true
+	}
+	expected := "yes"
+	if len(entries) == 1 {
+		expected = entries[0].Name
+		fmt.Fprintf(out, "This will permanently delete %s %s from workspace %s.\n", entries[0].Kind, entries[0].Name, workspace)
+		fmt.Fprintf(out, "Type the name of the %s to confirm: ", strings.ToLower(entries[0].Kind))
+	} else {
+		fmt.Fprintf(out, "This will permanently delete %d resources from workspace %s:\n", len(entries), workspace)
+		for _, entry := range entries {
+			fmt.Fprintf(out, "  - %s %s\n", entry.Kind, entry.Name)
+		}
+		fmt.Fprint(out, "Type yes to confirm: ")
+	}
+
+	answer, _ := bufio.NewReader(in).ReadString('\n')
+	return strings.TrimSpace(answer) == expected
+}
+
+// confirmDeletionOrExit confirms the deletion of entries when needed and
+// exits with code 1 when it is declined, so scripts do not go on as if the
+// resources were deleted
+func confirmDeletionOrExit(entries []deleteEntry, yes bool) {
+	if !deleteConfirmationNeeded(yes) {
+		return
+	}
+	if !confirmDeletion(entries, core.GetWorkspace(), os.Stdin, os.Stderr) {
+		fmt.Fprintln(os.Stderr, "Deletion cancelled.")
+		os.Exit(core.ExitCodeError)
+	}
+}
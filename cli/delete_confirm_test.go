@@ -0,0 +1,35 @@
+package cli
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfirmDeletion(t *testing.T) {
+	single := []deleteEntry{{Kind: "Agent", Name: "my-agent"}}
+	several := []deleteEntry{{Kind: "Agent", Name: "my-agent"}, {Kind: "Function", Name: "my-mcp"}}
+
+	var out bytes.Buffer
+	assert.True(t, confirmDeletion(single, "prod", strings.NewReader("my-agent\n"), &out))
+	assert.Contains(t, out.String(), "delete Agent my-agent from workspace prod")
+	assert.Contains(t, out.String(), "Type the name of the agent")
+	assert.False(t, confirmDeletion(single, "prod", strings.NewReader("yes\n"), &out))
+	assert.False(t, confirmDeletion(single, "prod", strings.NewReader(""), &out))
+
+	out.Reset()
+	assert.True(t, confirmDeletion(several, "prod", strings.NewReader(" yes \n"), &out))
+	assert.Contains(t, out.String(), "delete 2 resources from workspace prod")
+	assert.Contains(t, out.String(), "  - Function my-mcp\n")
+	assert.False(t, confirmDeletion(several, "prod", strings.NewReader("y\n"), &out))
+
+	assert.True(t, confirmDeletion(nil, "prod", strings.NewReader(""), &out))
+}
+
+func TestDeleteConfirmationNeeded(t *testing.T) {
+	assert.False(t, deleteConfirmationNeeded(true))
+	t.Setenv("CI", "true")
+	assert.False(t, deleteConfirmationNeeded(false))
+}
@@ -0,0 +1,50 @@
+package cli
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	blaxel "github.com/blaxel-ai/sdk-go"
+	"github.com/blaxel-ai/toolkit/cli/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeleteTargets(t *testing.T) {
+	var running, peak atomic.Int32
+	resource := &core.Resource{
+		Kind: "Agent",
+		Delete: func(ctx context.Context, name string) (map[string]interface{}, error) {
+			if n := running.Add(1); n > peak.Load() {
+				peak.Store(n)
+			}
+			defer running.Add(-1)
+			time.Sleep(5 * time.Millisecond)
+			if name == "missing" {
+				req := httptest.NewRequest(http.MethodDelete, "https://api.blaxel.ai/v0/agents/missing", nil)
+				return nil, &blaxel.Error{StatusCode: 404, Request: req, Response: &http.Response{StatusCode: 404}}
+			}
+			return map[string]interface{}{}, nil
+		},
+	}
+	targets := []deleteEntry{}
+	for _, name := range []string{"a", "b", "missing", "c", "d", "e"} {
+		targets = append(targets, deleteEntry{Kind: "Agent", Name: name, resource: resource})
+	}
+
+	deleted, failed := deleteTargets(targets, 2, false)
+	assert.LessOrEqual(t, peak.Load(), int32(2))
+	assert.Len(t, deleted, 5)
+	assert.Equal(t, "a", deleted[0].Name, "in the order of the targets")
+	if assert.Len(t, failed, 1) {
+		assert.Equal(t, "missing", failed[0].Name)
+		assert.NotEmpty(t, failed[0].Error)
+	}
+
+	deleted, failed = deleteTargets(targets, 4, true)
+	assert.Len(t, deleted, 6)
+	assert.Empty(t, failed)
+}
@@ -0,0 +1,89 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	blaxel "github.com/blaxel-ai/sdk-go"
+	"github.com/blaxel-ai/toolkit/cli/core"
+)
+
+// deletedStatus is reported by waitForDeletion once the resource is not found
+const deletedStatus = "DELETED"
+
+// deleteWaitInterval is the time between two checks of a deleted resource
+var deleteWaitInterval = 2 * time.Second
+
+// deletionStatus returns the status of a resource being deleted, deletedStatus
+// once the API does not find it anymore
+func deletionStatus(resource *core.Resource, name string) (string, error) {
+	item, err := GetExec(resource, name)
+	var apiErr *blaxel.Error
+	if errors.As(err, &apiErr) && apiErr.StatusCode == 404 {
+		return deletedStatus, nil
+	}
+	if err != nil {
+		return "", err
+	}
+	itemMap, _ := item.(map[string]interface{})
+	if status, ok := itemMap["status"].(string); ok {
+		return status, nil
+	}
+	return "UNKNOWN", nil
+}
+
+// waitForDeletion polls the resource until it is gone or ctx is done
+func waitForDeletion(ctx context.Context, resource *core.Resource, name string) error {
+	_, err := core.WaitForStatus(ctx, resource.Kind, name, core.WaitOptions{
+		Interval: deleteWaitInterval,
+		Done:     []string{deletedStatus},
+		Fetch: func(ctx context.Context) (string, error) {
+			return deletionStatus(resource, name)
+		},
+	})
+	if errors.Is(err, context.DeadlineExceeded) {
+		// Checked once more, the time may have run out waiting for other resources
+		if status, _ := deletionStatus(resource, name); status == deletedStatus {
+			err = nil
+		}
+	}
+	if err != nil {
+		return err
+	}
+	if outputFmt := core.GetOutputFormat(); outputFmt != "json" && outputFmt != "yaml" {
+		fmt.Printf("Resource %s:%s gone\n", resource.Kind, name)
+	}
+	return nil
+}
+
+// waitForDeletions waits for every deleted resource to be gone, all within
+// timeout when it is not zero. Resources still there are reported and the
+// first error is returned, a *core.TimeoutError when the timeout expired.
+func waitForDeletions(deleted []deleteEntry, timeout time.Duration) error {
+	ctx := context.Background()
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	var firstErr error
+	for _, entry := range deleted {
+		if entry.resource == nil || entry.resource.Get == nil {
+			continue
+		}
+		err := waitForDeletion(ctx, entry.resource, entry.Name)
+		if errors.Is(err, context.DeadlineExceeded) {
+			err = &core.TimeoutError{Err: fmt.Errorf("%s %s still exists after %s, raise --timeout to wait longer", entry.Kind, entry.Name, timeout)}
+		}
+		if err != nil {
+			core.PrintError("Delete", err)
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+	return firstErr
+}
@@ -0,0 +1,57 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	blaxel "github.com/blaxel-ai/sdk-go"
+	"github.com/blaxel-ai/toolkit/cli/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeDeletingResource is a resource reported DELETING for the first polls,
+// then not found
+func fakeDeletingResource(polls *int, deletingFor int) *core.Resource {
+	return &core.Resource{
+		Kind: "Agent",
+		Get: func(ctx context.Context, name string) (map[string]interface{}, error) {
+			*polls++
+			if *polls > deletingFor {
+				req := httptest.NewRequest(http.MethodGet, "https://api.blaxel.ai/v0/agents/"+name, nil)
+				return nil, &blaxel.Error{StatusCode: 404, Request: req, Response: &http.Response{StatusCode: 404}}
+			}
+			return map[string]interface{}{"status": "DELETING"}, nil
+		},
+	}
+}
+
+func TestWaitForDeletion(t *testing.T) {
+	deleteWaitInterval = time.Millisecond
+	t.Cleanup(func() { deleteWaitInterval = 2 * time.Second })
+
+	polls := 0
+	resource := fakeDeletingResource(&polls, 2)
+	status, err := deletionStatus(resource, "my-agent")
+	require.NoError(t, err)
+	assert.Equal(t, "DELETING", status)
+
+	require.NoError(t, waitForDeletion(context.Background(), resource, "my-agent"))
+	assert.Equal(t, 3, polls)
+}
+
+func TestWaitForDeletionTimeout(t *testing.T) {
+	deleteWaitInterval = time.Millisecond
+	t.Cleanup(func() { deleteWaitInterval = 2 * time.Second })
+
+	polls := 0
+	resource := fakeDeletingResource(&polls, 1_000_000)
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	err := waitForDeletion(ctx, resource, "my-agent")
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+}
@@ -3,11 +3,15 @@ package cli
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"os/signal"
+	"path"
 	"path/filepath"
+	"slices"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -15,9 +19,11 @@ import (
 
 	"archive/tar"
 	"archive/zip"
+	"compress/gzip"
 	"net/http"
 
 	blaxel "github.com/blaxel-ai/sdk-go"
+	"github.com/blaxel-ai/sdk-go/option"
 	"github.com/blaxel-ai/toolkit/cli/core"
 	"github.com/blaxel-ai/toolkit/cli/deploy"
 	mon "github.com/blaxel-ai/toolkit/cli/monitor"
@@ -49,6 +55,35 @@ func DeployCmd() *cobra.Command {
 	var dockerConfigPath string
 	var timeoutStr string
 	var buildEnvPath string
+	var buildArgs []string
+	var concurrency int
+	var configSets []string
+	var saveConfig bool
+	var verifyURL bool
+	var untilHealthy bool
+	var healthPath string
+	var healthTimeout time.Duration
+	var outputManifest string
+	var strictEnv bool
+	var jsonLogs bool
+	var logDir string
+	var logMaxSize int
+	var buildLogPath string
+	var noWait bool
+	var noCache bool
+	var blEnv string
+	var noEnvLayering bool
+	var noWalkUp bool
+	var onlyProjects []string
+	var excludeProjects []string
+	var onlyChanged bool
+	var changedBase string
+	var waitFor string
+	var stuckAfter time.Duration
+	var gzipArchive bool
+	var reproducible bool
+	var followSymlinks bool
+	var maxArchiveSize int
 
 	cmd := &cobra.Command{
 		Use:     "deploy",
@@ -70,27 +105,233 @@ A blaxel.toml configuration file is required. By default, the command looks
 for it in the current directory. Use -d to specify a subdirectory containing
 the blaxel.toml (useful for monorepo setups).
 
+Without blaxel.toml in the current directory, the command looks for it in the
+parent directories, up to the top of the git repository, and deploys from the
+nearest one as if it was run there. When that blaxel.toml is a monorepo root
+declaring a package that contains the current directory, only that package is
+deployed, with the type it is declared with, and gets the secrets of the
+root project like in a recursive deploy. File paths given as flags stay
+relative to the current directory. Pass --no-walk-up to deploy the current
+directory as is.
+
 If the blaxel.toml contains an 'image' field pointing to a registry image,
 the platform will pull the image and transform it via metamorph before deploying.
 For private registries, supply credentials via --registry-cred or --docker-config.
 
 Interactive vs Non-Interactive:
 - Interactive (default): Shows live logs and deployment progress with TUI
-- Non-interactive (--yes or CI): Runs without interactive UI, suitable for automation
+- Non-interactive (--yes or CI): Runs without interactive UI, suitable for automation.
+  The command follows the build and prints each status change. When the build
+  fails, its last log lines are printed before exiting with an error, so the
+  cause shows up in the CI output. Pass --no-wait to return right after the
+  upload instead.
 
 Environment Variables and Secrets:
 Use -e to load .env files or -s to pass secrets directly via command line.
+Without -e, .env is loaded, then .env.<name> with --bl-env <name>, then
+.env.local, each file present overriding the values of the previous ones.
+Pass --no-env-layering to load .env only.
 Secrets are injected into your container at runtime and never stored in images.
+Their values are shown as *** in errors, warnings, --dryrun output and the
+--verbose and --http-trace request logs.
+Variable names must be valid shell identifiers ([A-Za-z_][A-Za-z0-9_]*). When a
+variable is set with different values in several places, -s wins over the .env
+files, which win over [env] in blaxel.toml, and a warning names the one used.
+
+Workspace:
+When blaxel.toml sets a workspace that differs from the current one
+(BL_WORKSPACE or 'bl workspaces'), the deploy is refused so a project pinned
+to one workspace is not deployed to another by mistake. Pass --workspace with
+either of the two to choose where to deploy.
+
+Environments:
+blaxel.toml can hold per-environment overrides in [env.NAME] sections, for
+example [env.production.runtime] with memory = 8192. Pass --bl-env production
+to merge that section over the base config. Environments without a section
+deploy the base config. Values are applied in this order, later ones winning:
+blaxel.toml, the --bl-env section, blaxel.local.toml, then --set.
+
+Variables:
+String values of blaxel.toml and blaxel.local.toml can reference variables
+with $VAR, ${VAR} or ${VAR:default}, for example image = "${REGISTRY}/my-agent".
+They are expanded from the shell environment, then from the loaded .env files.
+Undefined variables expand to empty with a warning; pass --strict-env to fail
+instead. Write $$ for a literal $.
+
+Configuration Overrides:
+Use --set key=value to override blaxel.toml values for this deploy only, for
+example to change memory or maxScale per environment from CI. Dotted keys
+navigate into tables such as runtime, creating keys as needed. Values are
+read as integers, floats or booleans when possible and as strings otherwise;
+wrap a value in double quotes to force a string. Overrides are applied after
+blaxel.toml and blaxel.local.toml are read. Add --save-config to write the
+overrides into blaxel.toml once the deploy succeeds, so the next deploy
+reproduces them without flags. Comments and formatting are kept.
+
+Runtime Defaults:
+When the [runtime] section of blaxel.toml omits memory or scaling, the first
+deploy of a resource gets defaults suited to its type: agents get 4096 MB and
+maxScale 10, functions 2048 MB and maxScale 10, jobs 4096 MB and
+maxConcurrentTasks 10, and sandboxes 4096 MB. Later deploys do not send them,
+so they never undo a change made since, with bl scale for example. Values set
+in blaxel.toml or with --set always win. --dryrun lists the defaults that were
+applied.
 
 Monorepo Support:
-Use -d to deploy a specific subdirectory, or -R to recursively deploy
-all projects in a monorepo (looks for blaxel.toml in subdirectories).`,
+By default (-r), the project in the current directory is deployed together
+with every package declared in its blaxel.toml ([agent.NAME], [function.NAME],
+[job.NAME] sections). Use -d to deploy a single project from a subdirectory
+instead: -d always targets one package and cannot be combined with -r.
+The chosen mode is printed before deploying. In recursive mode, each line of
+output is prefixed with the colored name of its project, and a summary of the
+status and duration of every project is printed at the end. The command exits
+with an error listing the projects that failed, if any.
+--only and --exclude select some of the projects: each value is a resource
+type (agent, function, job...) or a name pattern such as billing-*, the
+project of the current directory being named root. A project is deployed when
+it matches one of the --only values, if any, and none of the --exclude ones.
+The deploy fails when no project is selected.
+--only-changed deploys only the projects with files changed between the
+merge base of --base (origin/main by default) and HEAD, per git diff. A
+package also counts as changed when one of the shared paths listed in its
+dependsOn (e.g. dependsOn = ["libs/common"]) changed, and the root project
+when a file outside of the packages changed. Outside of a git repository, or
+when --base cannot be resolved, every project is deployed with a warning.
+
+URL Check:
+A resource can report DEPLOYED before it actually serves requests. Add
+--verify-url to call the URL of a deployed agent, function or sandbox once the
+deploy succeeds. Gateway errors and connection failures are retried for about
+30 seconds to ride out cold starts; any other response counts as reachable.
+With --no-wait, the command first waits for the DEPLOYED status anyway. The
+command exits with an error when the URL never responds.
+
+Add --until-healthy to wait, once the resource is DEPLOYED, until its health
+path (--health-path, /health by default) answers with a 2xx status, for example
+before the next CI stage calls it. The path is probed every 5 seconds and the
+command exits with a timeout error when it is still failing after
+--health-timeout. Attempts are printed with --verbose. Resource types that are
+not served over HTTP, such as jobs and volume templates, are skipped with a
+warning. As with --verify-url, --no-wait still waits for the DEPLOYED status.
+In a monorepo, every project is checked after its own deploy.
+
+Build Logs:
+Add --json-logs to write build logs to NDJSON files (one JSON object per line)
+in --log-dir, for example to archive them from CI. A file is rotated once it
+reaches --log-max-size MB and files are numbered in order (NAME.0001.ndjson,
+NAME.0002.ndjson, ...). Values of the loaded secrets are redacted from every
+line. The log directory is never uploaded.
+
+Add --build-log-file to also write the build logs and status transitions to a
+single text file as they arrive, one line per message prefixed with its UTC
+time and resource. Unlike the interactive UI, the file stays once the deploy is
+over, which helps debugging a failed build. It works in both modes, is
+redacted like --json-logs and is never uploaded.
+
+Deploy Stages:
+A deploy goes through UPLOADING, BUILDING, DEPLOYING and DEPLOYED. By default
+the command succeeds once the resource is DEPLOYED. Pass --wait-for with an
+earlier status to succeed as soon as that status, or a later one, is reached,
+for example to start the next CI stage while the image is still building.
+--verify-url and --until-healthy require DEPLOYED.
+
+Build Args:
+Docker build args (ARG in the Dockerfile) come from the [build] section of
+blaxel.toml, then from the .env.build file (or --build-env-file), then from
+--build-arg KEY=VALUE flags, each one overriding the previous ones:
+
+  [build]
+  args = { PYTHON_VERSION = "3.12" }
+
+Build args are stored in the image, so they must not hold secrets: a warning
+is printed when the name of a build arg looks like a secret or is a loaded
+secret. Pass secrets with -s or an .env file instead. Selecting the stage of a
+multi-stage Dockerfile (target) is not supported yet: the build service has
+no option for it and builds the last stage, so 'bl config validate' reports a
+target set in [build].
+
+Build Cache:
+Builds reuse the image layers cached by previous builds of the resource. When
+a dependency was cached in a bad state, a build can keep failing or running
+stale code until something invalidates the cache ("works on rebuild" issues).
+Add --no-cache to ask the build service for a build from scratch. The API has
+no build option for it, so the request is carried by the x-blaxel-no-cache
+label of the generated resource. The build is slower, so only use it when
+needed; the label is not sent again and the next deploy uses the cache.
+
+Manifest:
+Add --output-manifest FILE to save the resources the deploy applies, the
+generated one and those of the .blaxel directory, as a multi-document YAML
+file, for auditing or GitOps. It is written before the apply, also with
+--dryrun, and keeps the generated labels so it can be re-applied with
+bl apply -f FILE. Env values coming from loaded secrets are written as
+${secrets.NAME} references, resolved again by bl apply.
+
+Volume Templates:
+The files of a volume template are uploaded as a tar archive. Add --gzip to
+compress it into a .tar.gz first, which makes uploads of text much smaller.
+Files that are already compressed cost little extra since the fastest level
+is used. The archive size and compression ratio are printed after compression.
+It is off by default since the upload fails where the platform does not
+accept application/gzip yet. --gzip is rejected for other resource types; in
+a recursive deploy it only applies to the volume template projects.
+
+Archives:
+The project files are uploaded as a zip archive, or as a tar archive for
+volume templates. By default entries keep the modification times and
+permissions of the files, and tar entries their owners. With --reproducible
+the entries are sorted by path, every time is set to 1980-01-01, ownership is
+removed and permissions become 0644, or 0755 for directories and executables,
+so the same sources give the same archive on any machine.
+
+Symlinks are archived as links and not followed, so a symlinked node_modules
+does not pull gigabytes into the archive. Links to an absolute path or outside
+of the directory would be broken once deployed and are skipped with a warning.
+With --follow-symlinks the content they point to is archived instead; broken
+links and links looping back to a parent directory are then skipped.
+
+Before the archive is written, the size of the files to include is added up.
+Above --max-archive-size (512 MB by default) the deploy fails right away and
+lists the largest files and directories, usually a virtualenv, a node_modules
+or a dataset that belongs in .blaxelignore. Set it to 0 to disable the check.
+
+Stuck Deploys:
+When a resource stays in the same UPLOADING, BUILDING or DEPLOYING status for
+--stuck-after (10 minutes by default), a hint is printed once with what to
+check: workspace policies, region capacity and quotas, and the resource status
+and logs. The delay starts over whenever the status changes. Set
+--stuck-after 0 to turn the hint off.
+
+Strict Mode:
+Pass --strict or set BL_STRICT=1 to fail the deploy on any configuration
+warning instead of continuing: blaxel.toml errors, a missing entrypoint or
+HOST/PORT usage, undefined variables, a name that had to be made URL-safe and
+flags that have no effect. Warnings about the platform itself, such as rate
+limiting, are still only printed. Packages of a monorepo inherit the setting.
+
+Rate Limiting:
+Resources are deployed in parallel, all at once by default or up to
+--concurrency at a time. When the platform or registry answers with 429 Too
+Many Requests, the rejected applies or upload are retried with backoff and the
+concurrency is halved for the rest of the deploy. In a monorepo, the projects
+deployed after it start from that lower concurrency. A note is printed at the
+end when this happened.
+
+Exit Codes:
+A failed deploy exits with a code telling what failed, so CI pipelines can
+react to it: 1 for other errors, 2 for an invalid configuration or flag, 3 for
+a network error, 4 for an error returned by the Blaxel API, 5 for a failed
+build or deployment, 6 for a failed upload and 7 for a timeout, such as
+--until-healthy still failing after --health-timeout.`,
 		Example: `  # Basic deployment (interactive mode with live logs)
   bl deploy
 
   # Non-interactive deployment (for CI/CD)
   bl deploy --yes
 
+  # Return as soon as the code is uploaded, without following the build
+  bl deploy --yes --no-wait
+
   # Deploy with environment variables
   bl deploy -e .env.production
 
@@ -103,19 +344,132 @@ all projects in a monorepo (looks for blaxel.toml in subdirectories).`,
   # Dry run to validate configuration
   bl deploy --dryrun
 
+  # Fail in CI on any configuration warning
+  bl deploy --yes --strict
+
+  # Save the applied resources for GitOps
+  bl deploy --yes --output-manifest deploy.yaml
+
+  # Return as soon as the image build has started
+  bl deploy --yes --wait-for BUILDING
+
+  # Deploy to staging then production, without switching workspace
+  bl deploy --yes -w staging && bl deploy --yes -w production
+
   # Deploy specific subdirectory in monorepo
   bl deploy -d ./packages/my-agent
 
-  # Deploy specifying a resource type
-  bl deploy --type sandbox
+  # Deploy a directory whose blaxel.toml has no or the wrong type as an agent
+  bl deploy --type agent
 
   # Deploy with Docker build args from a .env.build file
   bl deploy --build-env-file .env.build.production
 
+  # Override a build arg for this deploy
+  bl deploy --build-arg PYTHON_VERSION=3.13
+
   # Recursively deploy all projects in monorepo
-  bl deploy -R`,
-		Run: func(cmd *cobra.Command, args []string) {
+  bl deploy -R
+
+  # Deploy only the agents of the monorepo, except the experimental ones
+  bl deploy --only agent --exclude 'exp-*'
+
+  # In CI, deploy only the projects changed by the pull request
+  bl deploy --only-changed --base origin/main
+
+  # Override runtime settings for this deploy
+  bl deploy --set runtime.memory=8192 --set runtime.maxScale=3
+
+  # Deploy with the [env.production] overrides of blaxel.toml, loading
+  # .env.production over .env when present
+  bl deploy --bl-env production
+
+  # Fail if blaxel.toml references a variable missing from the env files
+  bl deploy -e .env.production --strict-env
+
+  # Keep the overrides in blaxel.toml once the deploy succeeds
+  bl deploy --set runtime.memory=8192 --save-config
+
+  # Check that the deployed agent answers on its URL
+  bl deploy --yes --verify-url
+
+  # Wait until the deployed agent answers on /healthz before moving on
+  bl deploy --yes --until-healthy --health-path /healthz --health-timeout 5m
+
+  # Archive build logs from CI as rotated NDJSON files
+  bl deploy --yes --json-logs --log-dir ./artifacts/logs
+
+  # Keep the build logs of an interactive deploy in a text file
+  bl deploy --build-log-file build.log
+
+  # Rebuild the image without the layer cache
+  bl deploy --no-cache
+
+  # Deploy the package of the monorepo the current directory belongs to
+  cd packages/my-agent/src && bl deploy
+
+  # Deploy at most two resources at a time
+  bl deploy --concurrency 2`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// Failures are printed by main, like PrintError
+			cmd.SilenceErrors = true
+			cmd.SilenceUsage = true
+			if err := checkDeployFlagConflicts(cmd); err != nil {
+				return core.Fail("Deploy", &core.ConfigError{Err: err})
+			}
+			waitStatus, err := parseDeployWaitStatus(waitFor)
+			if err == nil && verifyURL && waitStatus != "DEPLOYED" {
+				err = fmt.Errorf("--verify-url needs the DEPLOYED status and cannot be combined with --wait-for %s", waitStatus)
+			}
+			if err == nil && untilHealthy && waitStatus != "DEPLOYED" {
+				err = fmt.Errorf("--until-healthy needs the DEPLOYED status and cannot be combined with --wait-for %s", waitStatus)
+			}
+			if err == nil && !untilHealthy && (cmd.Flags().Changed("health-path") || cmd.Flags().Changed("health-timeout")) {
+				err = fmt.Errorf("--health-path and --health-timeout only apply with --until-healthy")
+			}
+			if err == nil && untilHealthy && healthTimeout <= 0 {
+				err = fmt.Errorf("--health-timeout must be positive, got %s", healthTimeout)
+			}
+			if err != nil {
+				return core.Fail("Deploy", &core.ConfigError{Err: err})
+			}
+			pkgRoot := ""
+			if folder == "" && !noWalkUp {
+				// Paths given on the command line are relative to where it runs
+				paths := []*string{&outputManifest, &buildLogPath, &logDir, &buildEnvPath, &dockerConfigPath}
+				if cmd.Flags().Changed("env-file") {
+					for i := range envFiles {
+						paths = append(paths, &envFiles[i])
+					}
+				}
+				if err := absPaths(paths...); err != nil {
+					return core.Fail("Deploy", err)
+				}
+				var pkgType string
+				pkgType, pkgRoot, err = walkUpToProject()
+				if err != nil {
+					return core.Fail("Deploy", err)
+				}
+				if resourceType == "" {
+					resourceType = pkgType
+				}
+			}
+			core.SetConfigEnvironment(blEnv)
+			core.SetEnvLayering(!noEnvLayering)
 			core.LoadCommandSecrets(commandSecrets)
+			if pkgRoot != "" {
+				// Like the packages of a recursive deploy, the package gets
+				// the secrets of the root project before its own
+				cwd, err := os.Getwd()
+				if err != nil {
+					return core.Fail("Deploy", err)
+				}
+				rel, err := filepath.Rel(cwd, pkgRoot)
+				if err != nil {
+					return core.Fail("Deploy", err)
+				}
+				core.ReadSecrets(rel, envFiles)
+			}
 			core.ReadSecrets(folder, envFiles)
 			// If the user did not explicitly set --yes, decide default based on TTY and CI
 			if !cmd.Flags().Changed("yes") {
@@ -146,12 +500,28 @@ all projects in a monorepo (looks for blaxel.toml in subdirectories).`,
 				// Read config without setting default type, we'll handle that below
 				core.ReadConfigToml("", false)
 			}
+			if undefined := core.UndefinedConfigVars(); len(undefined) > 0 {
+				msg := fmt.Sprintf("blaxel.toml references undefined variables: %s", strings.Join(undefined, ", "))
+				if strictEnv {
+					err := fmt.Errorf("%s; set them in the environment or a .env file", msg)
+					return core.Fail("Deploy", &core.ConfigError{Err: err})
+				}
+				core.StrictWarning("Deploy", msg+" (expanded to empty, use --strict-env to fail instead)")
+			}
+			if _, ok := core.GetConfig().Environments[blEnv]; blEnv != "" && !ok {
+				core.StrictWarning("Deploy", fmt.Sprintf("No [env.%s] section in blaxel.toml, using the base configuration", blEnv))
+			}
+			if err := core.ApplyConfigSets(configSets); err != nil {
+				return core.Fail("Deploy", &core.ConfigError{Err: err})
+			}
+			if err := core.CheckConfigWorkspace(); err != nil {
+				return core.Fail("Deploy", &core.ConfigError{Err: err})
+			}
 
 			cwd, err := os.Getwd()
 			if err != nil {
 				err = fmt.Errorf("failed to get current working directory: %w", err)
-				core.PrintError("Deploy", err)
-				core.ExitWithError(err)
+				return core.Fail("Deploy", err)
 			}
 
 			// Additional deployment directory, for blaxel yaml files
@@ -167,22 +537,36 @@ all projects in a monorepo (looks for blaxel.toml in subdirectories).`,
 
 			// Slugify the name to ensure it's URL-safe
 			if name != "" {
-				name = core.Slugify(name)
+				if slug := core.Slugify(name); slug != name {
+					core.StrictWarning("Deploy", fmt.Sprintf("Name %q is not URL-safe, deploying it as %q", name, slug))
+					name = slug
+				}
 			}
 
 			// Resolve Docker registry credentials
 			projectDir := filepath.Join(cwd, folder)
 			dockerConfigJSON, dockerErr := core.ResolveDockerConfig(projectDir, registryCreds, dockerConfigPath)
 			if dockerErr != nil {
-				core.PrintError("Deploy", fmt.Errorf("failed to resolve Docker registry credentials: %w", dockerErr))
-				core.ExitWithError(dockerErr)
+				return core.Fail("Deploy", &core.ConfigError{Err: fmt.Errorf("failed to resolve Docker registry credentials: %w", dockerErr)})
 			}
 
 			// Resolve build-env args
 			envArgs, buildEnvErr := core.ReadBuildEnv(projectDir, buildEnvPath)
 			if buildEnvErr != nil {
-				core.PrintError("Deploy", fmt.Errorf("failed to read .env.build file: %w", buildEnvErr))
-				core.ExitWithError(buildEnvErr)
+				return core.Fail("Deploy", &core.ConfigError{Err: fmt.Errorf("failed to read .env.build file: %w", buildEnvErr)})
+			}
+			flagArgs, err := core.ParseBuildArgs(buildArgs)
+			if err != nil {
+				return core.Fail("Deploy", &core.ConfigError{Err: err})
+			}
+			if len(flagArgs) > 0 {
+				// --build-arg values win over .env.build and blaxel.toml
+				if envArgs == nil {
+					envArgs = map[string]string{}
+				}
+				for key, value := range flagArgs {
+					envArgs[key] = value
+				}
 			}
 			var tomlBuildArgs map[string]string
 			if cfg := core.GetConfig(); cfg.Build != nil {
@@ -190,7 +574,17 @@ all projects in a monorepo (looks for blaxel.toml in subdirectories).`,
 			}
 			buildEnvContent, buildArgCount := core.MergeBuildEnvContent(tomlBuildArgs, envArgs)
 			if buildEnvContent != nil {
-				fmt.Printf("Build args: %d variable(s) detected\n", buildArgCount)
+				core.PrintProgress(fmt.Sprintf("Build args: %d variable(s) detected", buildArgCount))
+				allArgs := map[string]string{}
+				for key, value := range tomlBuildArgs {
+					allArgs[key] = value
+				}
+				for key, value := range envArgs {
+					allArgs[key] = value
+				}
+				if names := core.SecretBuildArgs(allArgs); len(names) > 0 {
+					core.StrictWarning("Deploy", fmt.Sprintf("Build args %s look like secrets: build args are stored in the image, pass secrets with -s or .env instead", strings.Join(names, ", ")))
+				}
 			}
 
 			// Parse timeout
@@ -198,16 +592,26 @@ all projects in a monorepo (looks for blaxel.toml in subdirectories).`,
 			if timeoutStr != "" {
 				parsed, parseErr := time.ParseDuration(timeoutStr)
 				if parseErr != nil {
-					core.PrintError("Deploy", fmt.Errorf("invalid timeout value %q: %w (use format like 30m, 1h)", timeoutStr, parseErr))
-					core.ExitWithError(parseErr)
+					return core.Fail("Deploy", &core.ConfigError{Err: fmt.Errorf("invalid timeout value %q: %w (use format like 30m, 1h)", timeoutStr, parseErr)})
 				}
 				if parsed <= 0 {
-					core.PrintError("Deploy", fmt.Errorf("timeout must be a positive duration, got %q", timeoutStr))
-					core.ExitWithError(fmt.Errorf("invalid timeout"))
+					return core.Fail("Deploy", &core.ConfigError{Err: fmt.Errorf("timeout must be a positive duration, got %q", timeoutStr)})
 				}
 				deployTimeout = parsed
 			}
 
+			if concurrency < 0 {
+				err := fmt.Errorf("--concurrency must be positive or 0, got %d", concurrency)
+				return core.Fail("Deploy", &core.ConfigError{Err: err})
+			}
+			if logMaxSize < 1 {
+				err := fmt.Errorf("--log-max-size must be at least 1, got %d", logMaxSize)
+				return core.Fail("Deploy", &core.ConfigError{Err: err})
+			}
+			if !jsonLogs {
+				logDir = ""
+			}
+
 			deployment := Deployment{
 				dir:              deployDir,
 				folder:           folder,
@@ -219,12 +623,27 @@ all projects in a monorepo (looks for blaxel.toml in subdirectories).`,
 				timeout:          deployTimeout,
 				timeoutExplicit:  timeoutStr != "",
 				skipBuild:        skipBuild,
+				noCache:          noCache,
+				throttle:         deploy.NewThrottle(concurrency),
+				logDir:           logDir,
+				buildLogPath:     buildLogPath,
+				waitFor:          waitStatus,
+				stuckAfter:       stuckAfter,
+				gzipArchive:      gzipArchive,
+				reproducible:     reproducible,
+				followSymlinks:   followSymlinks,
+				maxArchiveSize:   int64(maxArchiveSize) * 1024 * 1024,
+			}
+			if path := os.Getenv(deploy.ThrottleStateEnv); path != "" {
+				deployment.throttle.LoadLimit(path)
 			}
 
 			// Check for blaxel.toml validation warnings first
 			blaxelTomlWarning := core.GetBlaxelTomlWarning()
 			if blaxelTomlWarning != "" {
-				handleConfigWarning(blaxelTomlWarning, noTTY)
+				if err := handleConfigWarning(blaxelTomlWarning, noTTY); err != nil {
+					return core.Fail("Deploy", &core.ConfigError{Err: err})
+				}
 				core.ClearBlaxelTomlWarning()
 			}
 
@@ -234,11 +653,16 @@ all projects in a monorepo (looks for blaxel.toml in subdirectories).`,
 				core.SetConfigType(resourceType)
 				config.Type = resourceType
 			}
+			if err := validateDeployResourceType(config.Type); err != nil {
+				return core.Fail("Deploy", &core.ConfigError{Err: err})
+			}
 
 			if !skipBuild && config.Image == "" {
 				validationWarning := deployment.validateDeploymentConfig(config)
 				if validationWarning != "" {
-					handleConfigWarning(validationWarning, noTTY)
+					if err := handleConfigWarning(validationWarning, noTTY); err != nil {
+						return core.Fail("Deploy", &core.ConfigError{Err: err})
+					}
 				}
 			}
 			if config.Type == "" && resourceType == "" {
@@ -252,7 +676,7 @@ all projects in a monorepo (looks for blaxel.toml in subdirectories).`,
 						os.Exit(0)
 					}
 				} else {
-					core.SetConfigType("sandbox")
+					return core.Fail("Deploy", &core.ConfigError{Err: fmt.Errorf("no resource type to deploy: set 'type' in blaxel.toml or pass --type (agent, function, job, sandbox, application, volume-template)")})
 				}
 			}
 
@@ -265,21 +689,128 @@ all projects in a monorepo (looks for blaxel.toml in subdirectories).`,
 				language := core.ModuleLanguage(projectDir)
 				if !core.CheckServerEnvUsage(folder, language) {
 					serverEnvWarning := core.BuildServerEnvWarning(language, config.Type)
-					handleConfigWarning(serverEnvWarning, noTTY)
+					if err := handleConfigWarning(serverEnvWarning, noTTY); err != nil {
+						return core.Fail("Deploy", &core.ConfigError{Err: err})
+					}
+				}
+			}
+
+			if saveConfig && len(configSets) == 0 {
+				core.StrictWarning("Deploy", "--save-config has no effect without --set")
+			}
+
+			filter := packageFilter{only: onlyProjects, exclude: excludeProjects}
+			if onlyChanged {
+				filter.changedSince = changedBase
+			} else if cmd.Flags().Changed("base") {
+				return core.Fail("Deploy", &core.ConfigError{Err: fmt.Errorf("--base is the git ref of --only-changed and needs it")})
+			}
+			if !filter.empty() {
+				if err := filter.validate(); err != nil {
+					return core.Fail("Deploy", &core.ConfigError{Err: err})
+				}
+				if !recursive {
+					err := fmt.Errorf("--only, --exclude and --only-changed select the projects of a recursive deploy and cannot be used with -d or --recursive=false")
+					return core.Fail("Deploy", &core.ConfigError{Err: err})
 				}
 			}
 
 			if recursive {
-				if deployPackage(dryRun, name) {
-					return
+				packageArgs := []string{}
+				if strictEnv {
+					packageArgs = append(packageArgs, "--strict-env")
+				}
+				if core.IsStrict() {
+					packageArgs = append(packageArgs, "--strict")
+				}
+				if cmd.Flags().Changed("wait-for") {
+					packageArgs = append(packageArgs, "--wait-for", waitStatus)
+				}
+				if noCache {
+					packageArgs = append(packageArgs, "--no-cache")
+				}
+				if untilHealthy {
+					packageArgs = append(packageArgs, "--until-healthy", "--health-path", healthPath, "--health-timeout", healthTimeout.String())
+				}
+				if noEnvLayering {
+					packageArgs = append(packageArgs, "--no-env-layering")
+				}
+				if cmd.Flags().Changed("workspace") {
+					packageArgs = append(packageArgs, "--workspace", core.GetWorkspace())
+				}
+				for _, buildArg := range buildArgs {
+					packageArgs = append(packageArgs, "--build-arg", buildArg)
+				}
+				if cmd.Flags().Changed("stuck-after") {
+					packageArgs = append(packageArgs, "--stuck-after", stuckAfter.String())
+				}
+				if gzipArchive {
+					packageArgs = append(packageArgs, "--gzip")
+				}
+				if reproducible {
+					packageArgs = append(packageArgs, "--reproducible")
 				}
+				if followSymlinks {
+					packageArgs = append(packageArgs, "--follow-symlinks")
+				}
+				if cmd.Flags().Changed("max-archive-size") {
+					packageArgs = append(packageArgs, "--max-archive-size", strconv.Itoa(maxArchiveSize))
+				}
+				if jsonLogs {
+					absLogDir, err := filepath.Abs(logDir)
+					if err != nil {
+						return core.Fail("Deploy", err)
+					}
+					packageArgs = append(packageArgs, "--json-logs", "--log-dir", absLogDir, "--log-max-size", strconv.Itoa(logMaxSize))
+				}
+				// Reported before any package is deployed, so --strict stops
+				// the deploy instead of failing it afterwards
+				warnIgnoredFlags := func() {
+					if saveConfig {
+						core.StrictWarning("Deploy", "--save-config is ignored when deploying several packages")
+					}
+					if verifyURL {
+						core.StrictWarning("Deploy", "--verify-url is ignored when deploying several packages")
+					}
+					if outputManifest != "" {
+						core.StrictWarning("Deploy", "--output-manifest is ignored when deploying several packages")
+					}
+					if buildLogPath != "" {
+						core.StrictWarning("Deploy", "--build-log-file is ignored when deploying several packages, use --json-logs")
+					}
+				}
+				deployed, err := deployPackage(dryRun, name, concurrency, packageArgs, filter, warnIgnoredFlags)
+				if err != nil {
+					return core.Fail("Deploy", err)
+				}
+				if deployed {
+					return nil
+				}
+			}
+			if gzipArchive && !core.IsVolumeTemplate(config.Type) {
+				err := fmt.Errorf("--gzip only applies to volume templates, this project is a %s", config.Type)
+				return core.Fail("Deploy", &core.ConfigError{Err: err})
+			}
+			if folder != "" {
+				printDeployMode(fmt.Sprintf("single project in %s", folder))
+			} else {
+				printDeployMode("single project in the current directory")
 			}
 
 			err = deployment.Generate(skipBuild)
 			if err != nil {
 				err = fmt.Errorf("error generating blaxel deployment: %w", err)
-				core.PrintError("Deploy", err)
-				core.ExitWithError(err)
+				// Configuration and API errors keep their exit code
+				if core.ExitCode(err) == core.ExitCodeError {
+					err = &core.UploadError{Err: err}
+				}
+				return core.Fail("Deploy", err)
+			}
+
+			if outputManifest != "" {
+				if err := deployment.WriteManifest(outputManifest); err != nil {
+					return core.Fail("Deploy", &core.ConfigError{Err: err})
+				}
 			}
 
 			if dryRun {
@@ -287,19 +818,32 @@ all projects in a monorepo (looks for blaxel.toml in subdirectories).`,
 					err := deployment.printDryRunStructuredOutput(outputFmt, skipBuild)
 					if err != nil {
 						err = fmt.Errorf("error printing structured dry run: %w", err)
-						core.PrintError("Deploy", err)
-						core.ExitWithError(err)
+						return core.Fail("Deploy", err)
 					}
 				} else {
 					err := deployment.Print(skipBuild)
 					if err != nil {
 						err = fmt.Errorf("error printing blaxel deployment: %w", err)
-						core.PrintError("Deploy", err)
-						core.ExitWithError(err)
+						return core.Fail("Deploy", err)
 					}
 				}
-				return
+				return nil
+			}
+
+			if jsonLogs {
+				deployment.buildLogs, err = deploy.NewLogFileWriter(logDir, deployment.name, int64(logMaxSize)*1024*1024, core.RedactSecrets)
+				if err != nil {
+					return core.Fail("Deploy", err)
+				}
+			}
+
+			if buildLogPath != "" {
+				deployment.buildLogFile, err = deploy.NewBuildLogFile(buildLogPath, core.RedactSecrets)
+				if err != nil {
+					return core.Fail("Deploy", err)
+				}
 			}
+			followBuild := noTTY && !noWait
 
 			startTime := time.Now()
 
@@ -307,41 +851,98 @@ all projects in a monorepo (looks for blaxel.toml in subdirectories).`,
 				err = deployment.ApplyInteractive()
 			} else {
 				err = deployment.Apply()
+				if err == nil && !noWait {
+					err = deployment.waitWithBuildLogs()
+				}
+			}
+			if deployment.buildLogs != nil {
+				deployment.closeBuildLogs()
+			}
+			if deployment.buildLogFile != nil {
+				deployment.closeBuildLogFile()
 			}
 
 			deployFailed := err != nil
 			if deployFailed {
-				err = fmt.Errorf("error applying blaxel deployment: %w", err)
+				err = classifyDeployError(fmt.Errorf("error applying blaxel deployment: %w", err))
 				if !isStructured {
-					core.PrintError("Deploy", err)
-					core.ExitWithError(err)
+					return core.Fail("Deploy", err)
 				}
 			}
 
 			if isStructured {
 				deployment.printStructuredOutput(outputFmt, startTime, deployFailed, err)
 				if deployFailed {
-					core.ExitWithError(err)
+					return core.Fail("Deploy", err)
 				}
 			} else if noTTY {
 				deployment.Ready()
 			}
+
+			if verifyURL {
+				if err := deployment.verifyURL(noTTY && !followBuild); err != nil {
+					return core.Fail("Deploy", err)
+				}
+			}
+
+			if untilHealthy {
+				if err := deployment.waitHealthy(noTTY && !followBuild && !verifyURL, healthPath, healthTimeout); err != nil {
+					return core.Fail("Deploy", err)
+				}
+			}
+
+			if saveConfig && len(configSets) > 0 {
+				if err := saveDeployConfigSets(folder, configSets, isStructured); err != nil {
+					return core.Fail("Deploy", &core.ConfigError{Err: err})
+				}
+			}
+			return nil
 		},
 	}
 	cmd.Flags().StringVarP(&name, "name", "n", "", "Optional name for the deployment")
 	cmd.Flags().BoolVarP(&dryRun, "dryrun", "", false, "Dry run the deployment")
+	core.AliasFlags(cmd, map[string]string{"dry-run": "dryrun", "resource-type": "type"})
+	cmd.Flags().StringVar(&outputManifest, "output-manifest", "", "Write the resources applied by the deploy to this file as multi-document YAML")
 	cmd.Flags().BoolVarP(&recursive, "recursive", "r", true, "Deploy recursively")
+	cmd.Flags().StringSliceVar(&onlyProjects, "only", []string{}, "Deploy only the monorepo projects of these resource types or name patterns (e.g. agent,billing-*)")
+	cmd.Flags().StringSliceVar(&excludeProjects, "exclude", []string{}, "Skip the monorepo projects of these resource types or name patterns (e.g. function)")
+	cmd.Flags().BoolVar(&onlyChanged, "only-changed", false, "Deploy only the monorepo projects changed since --base, according to git")
+	cmd.Flags().StringVar(&changedBase, "base", defaultChangedBase, "Git ref --only-changed compares HEAD to")
 	cmd.Flags().StringVarP(&folder, "directory", "d", "", "Deployment app path, can be a sub directory")
+	cmd.Flags().BoolVar(&noWalkUp, "no-walk-up", false, "Without blaxel.toml in the current directory, deploy it as is instead of looking for the project in the parent directories")
 	cmd.Flags().StringSliceVarP(&envFiles, "env-file", "e", []string{".env"}, "Environment file to load")
+	cmd.Flags().BoolVar(&noEnvLayering, "no-env-layering", false, "Load only .env, not .env.<bl-env> and .env.local over it")
 	cmd.Flags().StringSliceVarP(&commandSecrets, "secrets", "s", []string{}, "Secrets to deploy")
 	cmd.Flags().BoolVarP(&skipBuild, "skip-build", "", false, "Skip the build step")
-	cmd.Flags().StringVarP(&resourceType, "type", "t", "", "Resource type (sandbox, agent, function, job, application). Defaults to blaxel.toml type or 'sandbox'")
+	cmd.Flags().BoolVar(&noCache, "no-cache", false, "Build the image from scratch, without reusing the layers cached by previous builds")
+	cmd.Flags().StringVarP(&resourceType, "type", "t", "", "Resource type overriding the blaxel.toml type (sandbox, agent, function, job, application, volume-template)")
 	cmd.Flags().BoolVarP(&noTTY, "yes", "y", false, "Skip interactive mode")
 	cmd.Flags().BoolVar(&experimental, "experimental", false, "Enable experimental features (e.g. USER directive support)")
 	cmd.Flags().StringArrayVarP(&registryCreds, "registry-cred", "c", []string{}, "Registry credentials (format: registry=username:password, repeatable)")
 	cmd.Flags().StringVar(&dockerConfigPath, "docker-config", "", "Path to a Docker config.json file with registry credentials")
 	cmd.Flags().StringVar(&timeoutStr, "timeout", "", "Timeout for build and deployment monitoring (e.g. 30m, 1h). Defaults to 1h")
 	cmd.Flags().StringVar(&buildEnvPath, "build-env-file", "", "Path to a build env file with Docker build args (default: auto-detect .env.build)")
+	cmd.Flags().StringArrayVar(&buildArgs, "build-arg", []string{}, "Docker build arg overriding blaxel.toml and .env.build (format: KEY=VALUE, repeatable)")
+	cmd.Flags().StringArrayVar(&configSets, "set", []string{}, "Override a blaxel.toml value for this deploy (format: key=value, e.g. runtime.memory=8192, repeatable)")
+	cmd.Flags().StringVar(&blEnv, "bl-env", "", "Environment whose [env.<name>] blaxel.toml sections are merged over the base config (e.g. production)")
+	cmd.Flags().BoolVar(&saveConfig, "save-config", false, "After a successful deploy, write the --set overrides into blaxel.toml")
+	cmd.Flags().BoolVar(&strictEnv, "strict-env", false, "Fail when blaxel.toml references a variable that is not defined instead of expanding it to empty")
+	cmd.Flags().BoolVar(&verifyURL, "verify-url", false, "After a successful deploy of an agent, function or sandbox, check that its URL responds")
+	cmd.Flags().BoolVar(&untilHealthy, "until-healthy", false, "After a successful deploy of an agent, function or sandbox, wait until its health path answers with a 2xx status")
+	cmd.Flags().StringVar(&healthPath, "health-path", "/health", "Path probed by --until-healthy")
+	cmd.Flags().DurationVar(&healthTimeout, "health-timeout", 2*time.Minute, "How long --until-healthy waits for a 2xx status")
+	cmd.Flags().BoolVar(&jsonLogs, "json-logs", false, "Write build logs as NDJSON files in --log-dir, rotated by size, with secret values redacted")
+	cmd.Flags().StringVar(&logDir, "log-dir", "build-logs", "Directory of the --json-logs files")
+	cmd.Flags().IntVar(&logMaxSize, "log-max-size", 10, "Size in MB at which a --json-logs file is rotated")
+	cmd.Flags().StringVar(&buildLogPath, "build-log-file", "", "Write the build logs and status transitions to this file, one timestamped line each")
+	cmd.Flags().IntVar(&concurrency, "concurrency", 0, "Maximum number of resources deployed in parallel, 0 for no limit, lowered automatically when rate limited")
+	cmd.Flags().DurationVar(&stuckAfter, "stuck-after", deploy.DefaultStuckAfter, "Print a hint when a resource stays this long in the same in-progress status, 0 disables it")
+	cmd.Flags().BoolVar(&gzipArchive, "gzip", false, "Gzip the archive of a volume template before uploading it, if the platform accepts application/gzip uploads")
+	cmd.Flags().BoolVar(&reproducible, "reproducible", false, "Build the archive byte for byte the same from the same sources: sorted entries, fixed times, no ownership")
+	cmd.Flags().BoolVar(&followSymlinks, "follow-symlinks", false, "Archive the content symlinks point to instead of the links themselves")
+	cmd.Flags().IntVar(&maxArchiveSize, "max-archive-size", 512, "Size in MB the files to archive can add up to before the deploy fails, 0 disables the check")
+	cmd.Flags().BoolVar(&noWait, "no-wait", false, "In non-interactive mode, return right after the upload instead of following the build")
+	cmd.Flags().StringVar(&waitFor, "wait-for", "DEPLOYED", "Status at which the deploy stops monitoring and succeeds (UPLOADING, BUILDING, DEPLOYING or DEPLOYED)")
 	return cmd
 }
 
@@ -351,6 +952,11 @@ type Deployment struct {
 	folder                 string
 	blaxelDeployments      []core.Result
 	archive                *os.File
+	archiveSize            int64
+	gzipArchive            bool
+	reproducible           bool
+	followSymlinks         bool
+	maxArchiveSize         int64
 	cwd                    string
 	progressCallback       func(status string, progress int)
 	uploadProgressCallback func(bytesUploaded, totalBytes int64)
@@ -362,6 +968,16 @@ type Deployment struct {
 	timeout                time.Duration
 	timeoutExplicit        bool
 	skipBuild              bool
+	noCache                bool
+	throttle               *deploy.Throttle
+	logDir                 string
+	buildLogs              *deploy.LogFileWriter
+	buildLogPath           string
+	buildLogFile           *deploy.BuildLogFile
+	additionalDeps         [][]int
+	runtimeDefaults        map[string]interface{}
+	waitFor                string
+	stuckAfter             time.Duration
 }
 
 func (d *Deployment) Generate(skipBuild bool) error {
@@ -378,7 +994,14 @@ func (d *Deployment) Generate(skipBuild bool) error {
 	}
 
 	// Generate the blaxel deployment yaml
-	d.blaxelDeployments = []core.Result{d.GenerateDeployment(skipBuild)}
+	result, err := d.GenerateDeployment(skipBuild)
+	if err != nil {
+		return err
+	}
+	for _, warning := range core.DuplicateEnvWarnings() {
+		core.PrintWarning(warning)
+	}
+	d.blaxelDeployments = []core.Result{result}
 
 	// Volume-template needs archive even without build (for file upload)
 	config := core.GetConfig()
@@ -392,14 +1015,17 @@ func (d *Deployment) Generate(skipBuild bool) error {
 				outputFmt := core.GetOutputFormat()
 				isStructured := outputFmt == "json" || outputFmt == "yaml"
 				if !isStructured {
-					fmt.Println("Compressing volume template files...")
+					core.PrintProgress("Compressing volume template files...")
 				}
 				err = d.Tar()
 				if err != nil {
 					return fmt.Errorf("failed to tar file: %w", err)
 				}
 				if !isStructured {
-					fmt.Println("Compression completed")
+					core.PrintProgress("Compression completed")
+					if summary := d.compressionSummary(); summary != "" {
+						core.PrintProgress(summary)
+					}
 				}
 			}
 		} else {
@@ -413,11 +1039,16 @@ func (d *Deployment) Generate(skipBuild bool) error {
 	return nil
 }
 
-// handleConfigWarning displays a warning and asks for confirmation in interactive mode
-func handleConfigWarning(warning string, noTTY bool) {
+// handleConfigWarning displays a warning and asks for confirmation in interactive
+// mode. It returns an error with --strict.
+func handleConfigWarning(warning string, noTTY bool) error {
 	// Route warning to stderr so it never pollutes structured JSON/YAML output
 	fmt.Fprintln(os.Stderr, warning)
 
+	if core.IsStrict() {
+		return core.StrictError("configuration warning")
+	}
+
 	// In non-interactive mode, just show warning and continue
 	if noTTY {
 		core.PrintWarning("Continuing with deployment despite configuration warning...")
@@ -460,6 +1091,7 @@ func handleConfigWarning(warning string, noTTY bool) {
 		signal.Stop(sigChan)
 		fmt.Println()
 	}
+	return nil
 }
 
 // validateDeploymentConfig checks if the project has proper configuration for deployment
@@ -650,7 +1282,9 @@ func dockerfileProvidesSandboxAPI(content string) bool {
 	return len(stageIsSandbox) > 0 && stageIsSandbox[len(stageIsSandbox)-1]
 }
 
-func (d *Deployment) GenerateDeployment(skipBuild bool) core.Result {
+// GenerateDeployment returns the resource deployed from blaxel.toml, or an
+// error when the configuration cannot be deployed
+func (d *Deployment) GenerateDeployment(skipBuild bool) (core.Result, error) {
 	var Spec map[string]interface{}
 	var Kind string
 
@@ -662,21 +1296,28 @@ func (d *Deployment) GenerateDeployment(skipBuild bool) core.Result {
 
 	// Convert human-readable timeout values (e.g., "1h", "30m") to seconds
 	if err := core.ConvertRuntimeTimeouts(runtime); err != nil {
-		core.PrintError("Deployment", err)
-		core.ExitWithError(err)
+		return core.Result{}, &core.ConfigError{Err: err}
 	}
 
 	// Convert human-readable timeout values in triggers
 	if err := core.ConvertTriggersTimeouts(config.Triggers); err != nil {
-		core.PrintError("Deployment", err)
-		core.ExitWithError(err)
+		return core.Result{}, &core.ConfigError{Err: err}
 	}
 
-	runtime["envs"] = core.GetUniqueEnvs()
+	// Defaults only size a first deploy, a deployed resource keeps the values
+	// it has, which bl scale may have changed
+	if core.HasRuntimeDefaults(config.Type) && !resourceExists(config.Type, d.name) {
+		d.runtimeDefaults = core.ApplyRuntimeDefaults(config.Type, runtime)
+	}
+
+	envs := core.GetUniqueEnvs()
+	if err := core.ValidateEnvNames(envs); err != nil {
+		return core.Result{}, &core.ConfigError{Err: err}
+	}
+	runtime["envs"] = envs
 	if config.Type == "function" {
 		runtime["type"] = "mcp"
 	}
-
 	if config.Image != "" {
 		runtime["image"] = config.Image
 		if d.dockerConfigJSON != nil {
@@ -689,8 +1330,7 @@ func (d *Deployment) GenerateDeployment(skipBuild bool) core.Result {
 		// Skip image resolution for volume-template as it doesn't use runtime/image
 		resource, err := getResource(config.Type, d.name)
 		if err != nil {
-			core.PrintError("Deployment", err)
-			core.ExitWithError(err)
+			return core.Result{}, err
 		}
 
 		if spec, ok := resource["spec"].(map[string]interface{}); ok {
@@ -712,8 +1352,7 @@ func (d *Deployment) GenerateDeployment(skipBuild bool) core.Result {
 			}
 			if !imageFound {
 				err := fmt.Errorf("no image found for %s. please deploy with a build first", d.name)
-				core.PrintError("Deployment", err)
-				core.ExitWithError(err)
+				return core.Result{}, &core.ConfigError{Err: err}
 			}
 		}
 	}
@@ -811,6 +1450,9 @@ func (d *Deployment) GenerateDeployment(skipBuild bool) core.Result {
 	if d.experimental {
 		labels["x-blaxel-experimental"] = "true"
 	}
+	if d.noCache && labels["x-blaxel-auto-generated"] == "true" {
+		labels["x-blaxel-no-cache"] = "true"
+	}
 	return core.Result{
 		ApiVersion: "blaxel.ai/v1alpha1",
 		Kind:       Kind,
@@ -819,7 +1461,7 @@ func (d *Deployment) GenerateDeployment(skipBuild bool) core.Result {
 			"labels": labels,
 		},
 		Spec: Spec,
-	}
+	}, nil
 }
 
 func getResource(resourceType, name string) (map[string]interface{}, error) {
@@ -870,67 +1512,28 @@ func getResource(resourceType, name string) (map[string]interface{}, error) {
 }
 
 func getResourceStatus(resourceType, name string) (string, error) {
-	ctx := context.Background()
-	client := core.GetClient()
-
-	var result interface{}
-	var err error
-
-	switch resourceType {
-	case "agent":
-		result, err = client.Agents.Get(ctx, name, blaxel.AgentGetParams{})
-	case "function":
-		result, err = client.Functions.Get(ctx, name, blaxel.FunctionGetParams{})
-	case "job":
-		result, err = client.Jobs.Get(ctx, name, blaxel.JobGetParams{})
-	case "sandbox":
-		result, err = client.Sandboxes.Get(ctx, name, blaxel.SandboxGetParams{})
-	case "application":
-		result, err = client.Applications.Get(ctx, name)
-	case "volume-template", "volumetemplate", "vt":
-		result, err = client.VolumeTemplates.Get(ctx, name)
-	default:
-		return "", fmt.Errorf("unknown resource type: %s", resourceType)
-	}
-
-	if err != nil {
-		return "", err
-	}
-
-	// Convert result to map
-	jsonData, err := json.Marshal(result)
-	if err != nil {
-		return "", err
-	}
-
-	var resource map[string]interface{}
-	if err := json.Unmarshal(jsonData, &resource); err != nil {
-		return "", err
-	}
-
-	// Extract status from the resource
-	if status, ok := resource["status"].(string); ok {
-		return status, nil
-	}
-
-	return "UNKNOWN", nil
+	return core.GetResourceStatus(context.Background(), resourceType, name)
 }
 
 func (d *Deployment) Apply() error {
+	if d.throttle == nil {
+		d.throttle = deploy.NewThrottle(0)
+	}
+	defer d.printThrottleNote()
+
 	outputFmt := core.GetOutputFormat()
 	isStructured := outputFmt == "json" || outputFmt == "yaml"
 
 	blaxelDir := filepath.Join(d.cwd, ".blaxel")
 	if _, err := os.Stat(blaxelDir); err == nil {
 		if !isStructured {
-			fmt.Println("Applying additional resources from .blaxel directory...")
+			core.PrintProgress("Applying additional resources from .blaxel directory...")
 		}
-		_, err = Apply(blaxelDir, WithRecursive(true))
-		if err != nil {
+		if err := d.applyBlaxelDir(blaxelDir, !isStructured); err != nil {
 			return fmt.Errorf("failed to apply .blaxel directory: %w", err)
 		}
 	}
-	applyResults, err := ApplyResources(d.blaxelDeployments)
+	applyResults, err := d.applyResources(d.blaxelDeployments, func(msg string) { core.PrintWarning(msg) })
 	if err != nil {
 		return fmt.Errorf("failed to apply deployment: %w", err)
 	}
@@ -975,7 +1578,7 @@ func (d *Deployment) Apply() error {
 				case "application":
 					resourceLabel = "application code"
 				}
-				fmt.Printf("Uploading %s...\n", resourceLabel)
+				core.PrintProgress(fmt.Sprintf("Uploading %s...", resourceLabel))
 			}
 
 			err := d.UploadWithRetry(result.Result.UploadURL, func() (string, error) {
@@ -994,7 +1597,7 @@ func (d *Deployment) Apply() error {
 				return fmt.Errorf("failed to upload file: %w", err)
 			}
 			if !isStructured {
-				fmt.Println("Upload completed")
+				core.PrintProgress("Upload completed")
 			}
 		}
 	}
@@ -1003,6 +1606,10 @@ func (d *Deployment) Apply() error {
 }
 
 func (d *Deployment) ApplyInteractive() error {
+	if d.throttle == nil {
+		d.throttle = deploy.NewThrottle(0)
+	}
+
 	// Create resources for interactive UI
 	resources := make([]*deploy.Resource, 0)
 
@@ -1035,8 +1642,10 @@ func (d *Deployment) ApplyInteractive() error {
 		// Real mode: read .blaxel directory to get resource count
 		results, err := core.GetResults("apply", blaxelDir, true)
 		if err == nil && len(results) > 0 {
+			additionalResults := []core.Result{}
 			for _, result := range results {
 				if metadata, ok := result.Metadata.(map[string]interface{}); ok {
+					additionalResults = append(additionalResults, result)
 					name := "unknown"
 					if n, exists := metadata["name"]; exists {
 						name = fmt.Sprintf("%v", n)
@@ -1058,6 +1667,10 @@ func (d *Deployment) ApplyInteractive() error {
 					})
 				}
 			}
+			d.additionalDeps, err = core.ApplyDependencies(additionalResults)
+			if err != nil {
+				return fmt.Errorf("failed to order the resources of the .blaxel directory: %w", err)
+			}
 		}
 
 		// Add all additional resources
@@ -1066,6 +1679,11 @@ func (d *Deployment) ApplyInteractive() error {
 
 	// Create interactive model
 	model := deploy.NewInteractiveModel(resources)
+	if d.buildLogFile != nil {
+		model.SetLogTee(func(resource *deploy.Resource, log string) {
+			d.writeBuildLogFile(resource.Kind, resource.Name, log)
+		})
+	}
 
 	// Start the interactive UI
 	p := tea.NewProgram(model, tea.WithAltScreen())
@@ -1079,6 +1697,7 @@ func (d *Deployment) ApplyInteractive() error {
 	if _, err := p.Run(); err != nil {
 		return fmt.Errorf("error running interactive UI: %w", err)
 	}
+	d.printThrottleNote()
 
 	// Check if any resources failed
 	for _, r := range resources {
@@ -1109,11 +1728,35 @@ func (d *Deployment) runInteractiveDeployment(resources []*deploy.Resource, addi
 	// Start all deployments in parallel
 	var wg sync.WaitGroup
 
-	// Deploy additional resources
+	// Deploy additional resources, each one once its dependencies are done
+	done := make([]chan struct{}, len(additionalResources))
+	applied := make([]bool, len(additionalResources))
+	for k := range done {
+		done[k] = make(chan struct{})
+	}
 	for i := mainResourceCount; i < len(resources); i++ {
 		wg.Add(1)
 		go func(idx int) {
 			defer wg.Done()
+			k := idx - mainResourceCount
+			defer close(done[k])
+
+			var deps []int
+			if k < len(d.additionalDeps) {
+				deps = d.additionalDeps[k]
+			}
+			for _, j := range deps {
+				dep := resources[mainResourceCount+j]
+				model.AddBuildLog(idx, fmt.Sprintf("Waiting for %s/%s", dep.Kind, dep.Name))
+				<-done[j]
+				if !applied[j] {
+					model.UpdateResource(idx, deploy.StatusFailed, "Dependency failed", fmt.Errorf("%s/%s was not deployed", dep.Kind, dep.Name))
+					return
+				}
+			}
+
+			d.throttle.Acquire()
+			defer d.throttle.Release()
 			defer func() {
 				if r := recover(); r != nil {
 					fmt.Printf("PANIC in additional resource deployment: %v\n", r)
@@ -1125,7 +1768,7 @@ func (d *Deployment) runInteractiveDeployment(resources []*deploy.Resource, addi
 			model.UpdateResource(idx, deploy.StatusDeploying, "Applying resource", nil)
 
 			// Real deployment
-			d.deployAdditionalResource(resource, model, idx)
+			applied[k] = d.deployAdditionalResource(resource, model, idx)
 		}(i)
 	}
 
@@ -1134,6 +1777,8 @@ func (d *Deployment) runInteractiveDeployment(resources []*deploy.Resource, addi
 		wg.Add(1)
 		go func(idx int, depl core.Result) {
 			defer wg.Done()
+			d.throttle.Acquire()
+			defer d.throttle.Release()
 			defer func() {
 				if r := recover(); r != nil {
 					fmt.Printf("PANIC in main resource deployment: %v\n", r)
@@ -1175,6 +1820,9 @@ func (d *Deployment) deployResourceInteractive(resource *deploy.Resource, model
 			return
 		}
 		model.AddBuildLog(idx, "Compression completed (100%)")
+		if summary := d.compressionSummary(); summary != "" {
+			model.AddBuildLog(idx, summary)
+		}
 	}
 
 	// Start deployment
@@ -1183,7 +1831,7 @@ func (d *Deployment) deployResourceInteractive(resource *deploy.Resource, model
 
 	// Real deployment
 	model.AddBuildLog(idx, "Applying resource to platform...")
-	applyResults, err := ApplyResources([]core.Result{deployment})
+	applyResults, err := d.applyResources([]core.Result{deployment}, func(msg string) { model.AddBuildLog(idx, msg) })
 	if err != nil {
 		model.UpdateResource(idx, deploy.StatusFailed, "Failed to apply", err)
 		model.AddBuildLog(idx, fmt.Sprintf("Failed to apply resource: %v", err))
@@ -1369,149 +2017,112 @@ func (d *Deployment) deployResourceInteractive(resource *deploy.Resource, model
 		// Wait for backend to update status after apply/upload
 		time.Sleep(1000 * time.Millisecond)
 		model.AddBuildLog(idx, "Verifying deployment status...")
+		d.monitorResource(resource, model, idx, d.timeout, "Deployed successfully")
+	} else {
+		// For resources that don't need monitoring (VolumeTemplate, Model, Policy, etc.), just mark as complete
+		model.AddBuildLog(idx, fmt.Sprintf("Resource type %s does not require status monitoring", resource.Kind))
+		model.UpdateResource(idx, deploy.StatusComplete, "Deployed successfully", nil)
+		model.AddBuildLog(idx, "✓ Volume template deployed successfully!")
+	}
+}
 
-		// Get initial status before monitoring - this helps detect stale FAILED status from previous builds
-		initialStatus, err := getResourceStatus(strings.ToLower(resource.Kind), resource.Name)
-		if err != nil {
-			// If we can't get initial status, assume it's not FAILED to avoid false positives
-			model.AddBuildLog(idx, fmt.Sprintf("Warning: Could not get initial status: %v", err))
-			initialStatus = "UNKNOWN"
-		}
+// additionalTimeout is how long a resource of the .blaxel directory may take
+// to be deployed. Additional resources use a shorter default (10m) than the
+// main resource (1h), but respect the user-specified --timeout if explicitly
+// provided.
+func (d *Deployment) additionalTimeout() time.Duration {
+	if d.timeoutExplicit {
+		return d.timeout
+	}
+	return 10 * time.Minute
+}
 
-		// Start monitoring the resource status
-		statusTicker := time.NewTicker(3 * time.Second)
-		defer statusTicker.Stop()
-		statusTimeout := time.After(d.timeout)
+// applyBlaxelDir applies the resources of the .blaxel directory in dependency
+// order. Before applying a resource, it waits for each of its dependencies
+// that the platform deploys to be DEPLOYED, and fails when one of them failed.
+// Other failures are reported by ApplyResources without stopping the deploy.
+func (d *Deployment) applyBlaxelDir(blaxelDir string, verbose bool) error {
+	results, err := core.GetResults("apply", blaxelDir, true)
+	if err != nil {
+		return fmt.Errorf("error getting results: %w", err)
+	}
+	if results, err = core.SortResultsForApply(results); err != nil {
+		return &core.ConfigError{Err: err}
+	}
+	deps, err := core.ApplyDependencies(results)
+	if err != nil {
+		return &core.ConfigError{Err: err}
+	}
 
-		// Grace period for stale FAILED status - if we don't see any status change within this time,
-		// accept that the FAILED status is real (handles case where new deployment fails immediately)
-		var staleFailedGracePeriod <-chan time.Time
-		if initialStatus == "FAILED" {
-			staleFailedGracePeriod = time.After(15 * time.Second)
+	failed := make([]bool, len(results))
+	ready := make([]bool, len(results))
+	for i, result := range results {
+		kind, name := blaxelDirResultName(result)
+		for _, j := range deps[i] {
+			if ready[j] {
+				continue
+			}
+			depKind, depName := blaxelDirResultName(results[j])
+			if failed[j] {
+				return fmt.Errorf("cannot apply %s/%s: its dependency %s/%s failed to apply", kind, name, depKind, depName)
+			}
+			if verbose {
+				core.PrintInfo(fmt.Sprintf("Waiting for %s/%s to be deployed before applying %s/%s", depKind, depName, kind, name))
+			}
+			if err := d.waitForDependency(depKind, depName); err != nil {
+				return fmt.Errorf("cannot apply %s/%s: %w", kind, name, err)
+			}
+			ready[j] = true
 		}
-		staleGracePeriodExpired := false
 
-		var logWatcher interface{ Stop() }
-		buildLogStarted := false
-		lastStatus := ""           // Track last status to avoid duplicate logs
-		sawBuildingStatus := false // Track if we've seen BUILDING status
-		sawStatusChange := false   // Track if status has changed from initial (new build started)
-
-		for {
-			select {
-			case <-statusTimeout:
-				if logWatcher != nil {
-					logWatcher.Stop()
-				}
-				model.UpdateResource(idx, deploy.StatusFailed, "Deployment timeout", fmt.Errorf("deployment timed out after %s", d.timeout))
-				return
-			case <-staleFailedGracePeriod:
-				// Grace period expired - if status is still FAILED, accept it as real
-				staleGracePeriodExpired = true
-			case <-statusTicker.C:
-				status, err := getResourceStatus(strings.ToLower(resource.Kind), resource.Name)
-				if err != nil {
-					// Continue polling on temporary errors
-					continue
-				}
+		applyResults, err := d.applyResources([]core.Result{result}, func(msg string) { core.PrintWarning(msg) })
+		if err != nil {
+			return fmt.Errorf("error applying resources: %w", err)
+		}
+		failed[i] = hasFailedApplyResult(applyResults)
+	}
+	return nil
+}
 
-				// Track if we've seen the status change from initial (indicates new build has started)
-				if status != initialStatus {
-					sawStatusChange = true
-				}
-
-				// Only log status changes
-				if status != lastStatus {
-					lastStatus = status
-
-					// Map API status to our UI status and update
-					switch status {
-					case "UPLOADING":
-						model.UpdateResource(idx, deploy.StatusUploading, "Uploading code", nil)
-						model.AddBuildLog(idx, "Status changed to: UPLOADING")
-					case "BUILDING":
-						sawBuildingStatus = true
-						model.UpdateResource(idx, deploy.StatusBuilding, "Building image", nil)
-						model.AddBuildLog(idx, "Status changed to: BUILDING")
-
-						// Start build log watcher if not already started
-						if !buildLogStarted {
-							buildLogStarted = true
-							client := core.GetClient()
-							workspace := core.GetWorkspace()
-
-							// Start build log watcher in background
-							lw := mon.NewBuildLogWatcher(
-								client,
-								workspace,
-								strings.ToLower(resource.Kind),
-								resource.Name,
-								func(log string) {
-									model.AddBuildLog(idx, log)
-								},
-								d.timeout,
-							)
-							lw.Start()
-							logWatcher = lw
-						}
-					case "DEPLOYING":
-						if logWatcher != nil {
-							logWatcher.Stop()
-							logWatcher = nil
-						}
-						model.UpdateResource(idx, deploy.StatusDeploying, "Deploying to cluster", nil)
-						model.AddBuildLog(idx, "Status changed to: DEPLOYING")
-					case "DEPLOYED":
-						// If skipBuild is false (AutoGenerated=true), we MUST have seen BUILDING status
-						if resource.AutoGenerated && !sawBuildingStatus {
-							// This is a mistake - continue monitoring
-							continue
-						}
-						if logWatcher != nil {
-							logWatcher.Stop()
-						}
+// blaxelDirResultName returns the kind and name of a resource of the .blaxel
+// directory
+func blaxelDirResultName(result core.Result) (string, string) {
+	metadata, _ := result.Metadata.(map[string]interface{})
+	name, _ := metadata["name"].(string)
+	return result.Kind, name
+}
 
-						model.UpdateResource(idx, deploy.StatusComplete, "Deployed successfully", nil)
-						model.AddBuildLog(idx, fmt.Sprintf("Deployment completed with status: %s", status))
-						return
-					case "FAILED":
-						// Ignore stale FAILED status from previous builds, unless:
-						// 1. We've seen the status change (new build started and then failed)
-						// 2. The grace period has expired (no status change = new build failed immediately)
-						// 3. Initial status wasn't FAILED (no stale status to worry about)
-						if initialStatus == "FAILED" && !sawStatusChange && !staleGracePeriodExpired {
-							continue
-						}
-						if logWatcher != nil {
-							logWatcher.Stop()
-						}
-						model.UpdateResource(idx, deploy.StatusFailed, "Deployment failed", fmt.Errorf("resource deployment failed"))
-						model.AddBuildLog(idx, "Status changed to: FAILED - Deployment failed")
-						return
-					case "DEACTIVATED", "DEACTIVATING", "DELETING":
-						if logWatcher != nil {
-							logWatcher.Stop()
-						}
-						model.UpdateResource(idx, deploy.StatusFailed, fmt.Sprintf("Unexpected status: %s", status), fmt.Errorf("resource is being deactivated or deleted"))
-						model.AddBuildLog(idx, fmt.Sprintf("Unexpected status: %s", status))
-						return
-					default:
-						// Continue monitoring for unknown statuses
-						model.UpdateResource(idx, deploy.StatusDeploying, fmt.Sprintf("Status: %s", status), nil)
-						model.AddBuildLog(idx, fmt.Sprintf("Status: %s", status))
-					}
-				}
-			}
-		}
-	} else {
-		// For resources that don't need monitoring (VolumeTemplate, Model, Policy, etc.), just mark as complete
-		model.AddBuildLog(idx, fmt.Sprintf("Resource type %s does not require status monitoring", resource.Kind))
-		model.UpdateResource(idx, deploy.StatusComplete, "Deployed successfully", nil)
-		model.AddBuildLog(idx, "✓ Volume template deployed successfully!")
+// waitForDependency waits until the resource kind/name is DEPLOYED when the
+// platform deploys resources of its kind. Other kinds are ready once applied.
+func (d *Deployment) waitForDependency(kind, name string) error {
+	resourceType := strings.ToLower(kind)
+	switch resourceType {
+	case "agent", "function", "job", "sandbox", "application":
+	default:
+		return nil
 	}
+	timeout := d.additionalTimeout()
+	_, err := core.WaitForStatus(context.Background(), resourceType, name, core.WaitOptions{
+		Timeout: timeout,
+		Done:    []string{"DEPLOYED"},
+		Failed:  deployFailedStatuses,
+		Settle:  deployStaleStatusGrace,
+	})
+	var statusErr *core.StatusError
+	switch {
+	case errors.Is(err, core.ErrWaitTimeout):
+		return &core.TimeoutError{Err: fmt.Errorf("its dependency %s/%s is not deployed after %s", kind, name, timeout)}
+	case errors.As(err, &statusErr):
+		return fmt.Errorf("its dependency %s/%s ended with status %s", kind, name, statusErr.Status)
+	case err != nil:
+		return fmt.Errorf("error waiting for its dependency %s/%s: %w", kind, name, err)
+	}
+	return nil
 }
 
-func (d *Deployment) deployAdditionalResource(resource *deploy.Resource, model *deploy.InteractiveModel, idx int) {
+// deployAdditionalResource applies a resource of the .blaxel directory and
+// follows its status, it returns whether it was deployed
+func (d *Deployment) deployAdditionalResource(resource *deploy.Resource, model *deploy.InteractiveModel, idx int) bool {
 	model.AddBuildLog(idx, fmt.Sprintf("Starting deployment of %s/%s", resource.Kind, resource.Name))
 
 	// Apply the resource
@@ -1523,11 +2134,11 @@ func (d *Deployment) deployAdditionalResource(resource *deploy.Resource, model *
 			if metadata, ok := result.Metadata.(map[string]interface{}); ok {
 				if name, exists := metadata["name"]; exists && fmt.Sprintf("%v", name) == resource.Name {
 					// Apply this specific resource
-					results, err := ApplyResources([]core.Result{result})
+					results, err := d.applyResources([]core.Result{result}, func(msg string) { model.AddBuildLog(idx, msg) })
 					if err != nil {
 						model.UpdateResource(idx, deploy.StatusFailed, "Failed to apply", err)
 						model.AddBuildLog(idx, fmt.Sprintf("Failed to apply resource: %v", err))
-						return
+						return false
 					}
 					for _, result := range results {
 						if result.Result.Status == "failed" {
@@ -1537,7 +2148,7 @@ func (d *Deployment) deployAdditionalResource(resource *deploy.Resource, model *
 							}
 							model.UpdateResource(idx, deploy.StatusFailed, errorDetails, nil)
 							model.AddBuildLog(idx, fmt.Sprintf("Resource %s failed to apply: %s", result.Name, errorDetails))
-							return
+							return false
 						}
 						// Store callback secret from apply result if present (only available on first deployment)
 						if result.Result.CallbackSecret != "" {
@@ -1566,111 +2177,12 @@ func (d *Deployment) deployAdditionalResource(resource *deploy.Resource, model *
 						model.AddBuildLog(idx, "Verifying deployment status...")
 
 						// Simple status monitoring for additional resources
-						// Additional resources use a shorter default (10m) than the main resource (1h),
-						// but respect the user-specified --timeout if explicitly provided.
-						additionalTimeout := 10 * time.Minute
-						if d.timeoutExplicit {
-							additionalTimeout = d.timeout
-						}
-						ticker := time.NewTicker(3 * time.Second)
-						timeout := time.After(additionalTimeout)
-						lastStatus := "" // Track last status to avoid duplicate logs
-						var logWatcher interface{ Stop() }
-						buildLogStarted := false
-						sawBuildingStatus := false // Track if we've seen BUILDING status
-
-						for {
-							select {
-							case <-timeout:
-								if logWatcher != nil {
-									logWatcher.Stop()
-								}
-								model.UpdateResource(idx, deploy.StatusFailed, "Timeout", fmt.Errorf("deployment timed out after %s", additionalTimeout))
-								ticker.Stop()
-								return
-							case <-ticker.C:
-								status, err := getResourceStatus(strings.ToLower(resource.Kind), resource.Name)
-								if err != nil {
-									continue
-								}
-
-								// Logs handling
-								if status != lastStatus {
-									lastStatus = status
-									model.AddBuildLog(idx, fmt.Sprintf("Status: %s", status))
-
-									switch status {
-									case "UPLOADING":
-										model.UpdateResource(idx, deploy.StatusUploading, "Uploading code", nil)
-									case "BUILDING":
-										sawBuildingStatus = true
-										model.UpdateResource(idx, deploy.StatusBuilding, "Building image", nil)
-
-										// Start build log watcher if not already started
-										if !buildLogStarted {
-											buildLogStarted = true
-											client := core.GetClient()
-											workspace := core.GetWorkspace()
-
-											lw := mon.NewBuildLogWatcher(
-												client,
-												workspace,
-												strings.ToLower(resource.Kind),
-												resource.Name,
-												func(log string) {
-													model.AddBuildLog(idx, log)
-												},
-												additionalTimeout,
-											)
-											lw.Start()
-											logWatcher = lw
-										}
-									case "DEPLOYING":
-										if logWatcher != nil {
-											logWatcher.Stop()
-											logWatcher = nil
-										}
-										model.UpdateResource(idx, deploy.StatusDeploying, "Deploying to cluster", nil)
-									case "DEPLOYED":
-										// If skipBuild is false (AutoGenerated=true), we MUST have seen BUILDING status
-										if resource.AutoGenerated && !sawBuildingStatus {
-											// This is a mistake - continue monitoring
-											continue
-										}
-										if logWatcher != nil {
-											logWatcher.Stop()
-										}
-
-										model.UpdateResource(idx, deploy.StatusComplete, "Applied successfully", nil)
-										ticker.Stop()
-										return
-									case "FAILED":
-										if logWatcher != nil {
-											logWatcher.Stop()
-										}
-										model.UpdateResource(idx, deploy.StatusFailed, "Failed", fmt.Errorf("deployment failed"))
-										ticker.Stop()
-										return
-									case "DEACTIVATED", "DEACTIVATING", "DELETING":
-										if logWatcher != nil {
-											logWatcher.Stop()
-										}
-										model.UpdateResource(idx, deploy.StatusFailed, fmt.Sprintf("Unexpected status: %s", status), fmt.Errorf("resource is being deactivated or deleted"))
-										ticker.Stop()
-										return
-									default:
-										// Continue monitoring for unknown statuses
-										model.UpdateResource(idx, deploy.StatusDeploying, fmt.Sprintf("Status: %s", status), nil)
-									}
-								}
-							}
-						}
-					} else {
-						// Non-monitored resources complete immediately
-						model.UpdateResource(idx, deploy.StatusComplete, "Applied successfully", nil)
-						model.AddBuildLog(idx, "Resource applied successfully")
+						return d.monitorResource(resource, model, idx, d.additionalTimeout(), "Applied successfully") == nil
 					}
-					break
+					// Non-monitored resources complete immediately
+					model.UpdateResource(idx, deploy.StatusComplete, "Applied successfully", nil)
+					model.AddBuildLog(idx, "Resource applied successfully")
+					return true
 				}
 			}
 		}
@@ -1679,6 +2191,7 @@ func (d *Deployment) deployAdditionalResource(resource *deploy.Resource, model *
 		model.UpdateResource(idx, deploy.StatusComplete, "Applied successfully", nil)
 		model.AddBuildLog(idx, "Resource marked as complete")
 	}
+	return true
 }
 
 func (d *Deployment) printStructuredOutput(outputFmt string, startTime time.Time, failed bool, deployErr error) {
@@ -1752,6 +2265,8 @@ type dryRunResult struct {
 	DryRun    bool          `json:"dryRun" yaml:"dryRun"`
 	Resources []core.Result `json:"resources" yaml:"resources"`
 	Files     []dryRunFile  `json:"files,omitempty" yaml:"files,omitempty"`
+	// RuntimeDefaults are the runtime values set by bl because blaxel.toml omits them
+	RuntimeDefaults map[string]interface{} `json:"runtimeDefaults,omitempty" yaml:"runtimeDefaults,omitempty"`
 }
 
 func (d *Deployment) printDryRunStructuredOutput(outputFmt string, skipBuild bool) error {
@@ -1773,19 +2288,37 @@ func (d *Deployment) renderDryRunStructuredOutput(outputFmt string, skipBuild bo
 	if err != nil {
 		return nil, err
 	}
-	result := dryRunResult{
-		DryRun:    true,
-		Resources: d.blaxelDeployments,
-		Files:     files,
-	}
+	// The env values of the resources may hold secrets, masked before they
+	// are escaped by the encoder
+	resources := make([]core.Result, len(d.blaxelDeployments))
+	for i, resource := range d.blaxelDeployments {
+		if resource.Metadata, err = core.RedactSecretsIn(resource.Metadata); err != nil {
+			return nil, err
+		}
+		if resource.Spec, err = core.RedactSecretsIn(resource.Spec); err != nil {
+			return nil, err
+		}
+		resources[i] = resource
+	}
+	result := dryRunResult{
+		DryRun:          true,
+		Resources:       resources,
+		Files:           files,
+		RuntimeDefaults: d.runtimeDefaults,
+	}
+	var data []byte
 	switch outputFmt {
 	case "json":
-		return json.MarshalIndent(result, "", "  ")
+		data, err = json.MarshalIndent(result, "", "  ")
 	case "yaml":
-		return yaml.Marshal(result)
+		data, err = yaml.Marshal(result)
 	default:
 		return nil, fmt.Errorf("unsupported dry-run output format %q", outputFmt)
 	}
+	if err != nil {
+		return nil, err
+	}
+	return []byte(core.RedactSecrets(string(data))), nil
 }
 
 func (d *Deployment) collectDryRunFiles(skipBuild bool) ([]dryRunFile, error) {
@@ -1797,7 +2330,7 @@ func (d *Deployment) collectDryRunFiles(skipBuild bool) ([]dryRunFile, error) {
 		return nil, nil
 	}
 	if core.IsVolumeTemplate(config.Type) {
-		return collectDryRunTarFiles(d.archive.Name())
+		return collectDryRunTarFiles(d.archive.Name(), d.gzipArchive)
 	}
 	return collectDryRunZipFiles(d.archive.Name())
 }
@@ -1827,14 +2360,13 @@ func collectDryRunZipFiles(path string) ([]dryRunFile, error) {
 	return files, nil
 }
 
-func collectDryRunTarFiles(path string) ([]dryRunFile, error) {
-	tarFile, err := os.Open(path)
+func collectDryRunTarFiles(path string, gzipped bool) ([]dryRunFile, error) {
+	tarReader, closeTar, err := openTarReader(path, gzipped)
 	if err != nil {
-		return nil, fmt.Errorf("failed to reopen tar file: %w", err)
+		return nil, err
 	}
-	defer func() { _ = tarFile.Close() }()
+	defer closeTar()
 
-	tarReader := tar.NewReader(tarFile)
 	var files []dryRunFile
 	for {
 		header, err := tarReader.Next()
@@ -1853,6 +2385,9 @@ func collectDryRunTarFiles(path string) ([]dryRunFile, error) {
 }
 
 func (d *Deployment) Ready() {
+	if core.IsQuiet() {
+		return
+	}
 	config := core.GetConfig()
 
 	// Don't show URL for volume-template deployments
@@ -1930,6 +2465,9 @@ func (d *Deployment) UploadWithRetry(url string, refreshURL func() (string, erro
 	for attempt := range maxRetries {
 		if attempt > 0 {
 			backoff := time.Duration(1<<uint(attempt-1)) * time.Second
+			if errors.Is(lastErr, errUploadRateLimited) {
+				backoff = d.rateLimited(attempt-1, nil)
+			}
 			time.Sleep(backoff)
 			newURL, err := refreshURL()
 			if err != nil {
@@ -1943,7 +2481,7 @@ func (d *Deployment) UploadWithRetry(url string, refreshURL func() (string, erro
 			return nil
 		}
 	}
-	return lastErr
+	return &core.UploadError{Err: lastErr}
 }
 
 func (d *Deployment) Upload(url string) error {
@@ -1978,12 +2516,16 @@ func (d *Deployment) Upload(url string) error {
 
 	// Set the content length
 	req.ContentLength = fileInfo.Size()
+	core.AddBreadcrumb("deploy", "upload started", map[string]interface{}{"size": fileInfo.Size()})
 
 	// Set the content type based on file extension
 	config := core.GetConfig()
-	if core.IsVolumeTemplate(config.Type) {
+	switch {
+	case core.IsVolumeTemplate(config.Type) && d.gzipArchive:
+		req.Header.Set("Content-Type", "application/gzip")
+	case core.IsVolumeTemplate(config.Type):
 		req.Header.Set("Content-Type", "application/x-tar")
-	} else {
+	default:
 		req.Header.Set("Content-Type", "application/zip")
 	}
 
@@ -1996,6 +2538,9 @@ func (d *Deployment) Upload(url string) error {
 	defer func() { _ = resp.Body.Close() }()
 
 	// Check the response status
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return fmt.Errorf("upload failed with status: %s: %w", resp.Status, errUploadRateLimited)
+	}
 	if resp.StatusCode != http.StatusOK {
 		return fmt.Errorf("upload failed with status: %s", resp.Status)
 	}
@@ -2003,28 +2548,145 @@ func (d *Deployment) Upload(url string) error {
 	return nil
 }
 
+// errUploadRateLimited is wrapped by Upload when the registry answers 429
+var errUploadRateLimited = errors.New("rate limited by the registry")
+
+// maxRateLimitRetries is how many times a rate limited apply is retried
+const maxRateLimitRetries = 5
+
+// applyResources applies results with ApplyResources, retrying the ones the
+// platform answered with 429 with backoff and a lower deploy concurrency.
+// onThrottle, when set, is told about each retry.
+func (d *Deployment) applyResources(results []core.Result, onThrottle func(string)) ([]ApplyResult, error) {
+	applyResults, err := ApplyResources(results)
+	for attempt := 0; err == nil && attempt < maxRateLimitRetries && hasRateLimitedResult(applyResults); attempt++ {
+		time.Sleep(d.rateLimited(attempt, onThrottle))
+		var retried []ApplyResult
+		retried, err = ApplyResources(rateLimitedResults(results, applyResults))
+		applyResults = mergeApplyResults(applyResults, retried)
+	}
+	return applyResults, err
+}
+
+// rateLimitedResults returns the results whose apply failed with 429 Too Many
+// Requests in applyResults
+func rateLimitedResults(results []core.Result, applyResults []ApplyResult) []core.Result {
+	limited := map[string]bool{}
+	for _, result := range applyResults {
+		if result.Result.Status == "failed" && result.Result.RateLimited {
+			limited[result.Kind+"/"+result.Name] = true
+		}
+	}
+	retry := []core.Result{}
+	for _, result := range results {
+		metadata, _ := result.Metadata.(map[string]interface{})
+		name, _ := metadata["name"].(string)
+		if limited[result.Kind+"/"+name] {
+			retry = append(retry, result)
+		}
+	}
+	return retry
+}
+
+// mergeApplyResults replaces the results of applyResults by the ones of the
+// same resource in retried
+func mergeApplyResults(applyResults, retried []ApplyResult) []ApplyResult {
+	merged := append([]ApplyResult(nil), applyResults...)
+	for _, result := range retried {
+		for i := range merged {
+			if merged[i].Kind == result.Kind && merged[i].Name == result.Name {
+				merged[i] = result
+			}
+		}
+	}
+	return merged
+}
+
+// hasRateLimitedResult reports whether any apply failed with 429 Too Many Requests
+func hasRateLimitedResult(results []ApplyResult) bool {
+	for _, result := range results {
+		if result.Result.Status == "failed" && result.Result.RateLimited {
+			return true
+		}
+	}
+	return false
+}
+
+// rateLimited lowers the deploy concurrency after a 429 response and returns
+// how long to wait before retrying
+func (d *Deployment) rateLimited(attempt int, onThrottle func(string)) time.Duration {
+	if d.throttle == nil {
+		d.throttle = deploy.NewThrottle(0)
+	}
+	backoff := d.throttle.RateLimited(attempt)
+	if path := os.Getenv(deploy.ThrottleStateEnv); path != "" {
+		_ = d.throttle.SaveLimit(path)
+	}
+	if onThrottle != nil {
+		_, current := d.throttle.Limits()
+		onThrottle(fmt.Sprintf("Rate limited by the platform, retrying in %s with concurrency %d", backoff, current))
+	}
+	return backoff
+}
+
+// printThrottleNote tells the user when rate limiting slowed down the deploy
+func (d *Deployment) printThrottleNote() {
+	if d.throttle == nil || !d.throttle.Throttled() {
+		return
+	}
+	initial, current := d.throttle.Limits()
+	from := strconv.Itoa(initial)
+	if initial <= 0 {
+		from = "unlimited"
+	}
+	core.PrintWarning(fmt.Sprintf("Deploy was rate limited: concurrency was reduced from %s to %d. Use --concurrency %d to avoid throttling", from, current, current))
+}
+
+// defaultIgnoredPaths are excluded from the deployment archive when the
+// project has no .blaxelignore file
+var defaultIgnoredPaths = []string{
+	".blaxel",
+	".env.build",
+	core.LocalConfigFileName,
+	".docker",
+	".git",
+	"dist",
+	".venv",
+	"venv",
+	"node_modules",
+	".env",
+	".next",
+	"__pycache__",
+}
+
 func (d *Deployment) IgnoredPaths() []string {
+	ignoredPaths := d.blaxelIgnorePaths()
+	// Never upload the build logs of --json-logs and --build-log-file
+	for _, path := range []string{d.logDir, d.buildLogPath} {
+		if path == "" {
+			continue
+		}
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(d.cwd, path)
+		}
+		if rel, err := filepath.Rel(d.cwd, path); err == nil && !strings.HasPrefix(rel, "..") {
+			ignoredPaths = append(ignoredPaths, rel)
+		}
+	}
+	return ignoredPaths
+}
+
+// blaxelIgnorePaths returns the paths listed in .blaxelignore, or the default ones without it
+func (d *Deployment) blaxelIgnorePaths() []string {
 	content, err := os.ReadFile(filepath.Join(d.cwd, ".blaxelignore"))
 	if err != nil {
-		return []string{
-			".blaxel",
-			".env.build",
-			".docker",
-			".git",
-			"dist",
-			".venv",
-			"venv",
-			"node_modules",
-			".env",
-			".next",
-			"__pycache__",
-		}
+		return append([]string{}, defaultIgnoredPaths...)
 	}
 
 	// Parse the .blaxelignore file, filtering out comments and empty lines
 	lines := strings.Split(string(content), "\n")
-	// Always exclude .env.build regardless of .blaxelignore content
-	ignoredPaths := []string{".env.build"}
+	// Always exclude .env.build and local config overrides regardless of .blaxelignore content
+	ignoredPaths := []string{".env.build", core.LocalConfigFileName}
 	for _, line := range lines {
 		// Trim whitespace
 		line = strings.TrimSpace(line)
@@ -2131,6 +2793,61 @@ func (t *tarArchiveWriter) close() error {
 	return t.writer.Close()
 }
 
+// archiveEntry is a file or directory to add to the deploy archive
+type archiveEntry struct {
+	path    string
+	relPath string
+	size    int64
+}
+
+// checkArchiveSize fails when the files to archive add up to more than
+// --max-archive-size, listing the largest top-level entries so the user
+// knows what to ignore
+func (d *Deployment) checkArchiveSize(entries []archiveEntry, volumeTemplate bool) error {
+	if d.maxArchiveSize <= 0 {
+		return nil
+	}
+	var total int64
+	sizes := map[string]int64{}
+	for _, entry := range entries {
+		total += entry.size
+		top, _, nested := strings.Cut(entry.relPath, "/")
+		if nested {
+			top += "/"
+		}
+		sizes[top] += entry.size
+	}
+	if total <= d.maxArchiveSize {
+		return nil
+	}
+
+	names := make([]string, 0, len(sizes))
+	for name := range sizes {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if sizes[names[i]] != sizes[names[j]] {
+			return sizes[names[i]] > sizes[names[j]]
+		}
+		return names[i] < names[j]
+	})
+	if len(names) > 5 {
+		names = names[:5]
+	}
+
+	var msg strings.Builder
+	fmt.Fprintf(&msg, "the files to archive add up to %s, more than --max-archive-size %s. Largest entries:\n", formatBytes(total), formatBytes(d.maxArchiveSize))
+	for _, name := range names {
+		fmt.Fprintf(&msg, "  %-30s %s\n", name, formatBytes(sizes[name]))
+	}
+	if volumeTemplate {
+		msg.WriteString("Remove what should not be in the volume template, or raise --max-archive-size (0 disables the check)")
+	} else {
+		msg.WriteString("Add what should not be deployed to .blaxelignore, or raise --max-archive-size (0 disables the check)")
+	}
+	return &core.ConfigError{Err: errors.New(msg.String())}
+}
+
 func (d *Deployment) createArchive(_ string, writer archiveWriter) error {
 	config := core.GetConfig()
 
@@ -2156,53 +2873,104 @@ func (d *Deployment) createArchive(_ string, writer archiveWriter) error {
 		}
 	}
 
-	// Count total files for progress tracking (only for volume-template)
-	var totalFiles int
-	var processedFiles int
-	if core.IsVolumeTemplate(config.Type) && d.progressCallback != nil {
-		_ = filepath.WalkDir(archiveRoot, func(path string, info os.DirEntry, err error) error {
-			if err != nil || path == archiveRoot {
-				return nil
+	// Collect the entries first so they can be sorted for reproducible archives
+	var entries []archiveEntry
+	// ancestors are the real paths of the symlinks followed to reach dir, so a
+	// link to a directory above them does not loop forever. Links to the same
+	// directory from different places are all followed.
+	var walk func(dir, shownDir string, ancestors []string) error
+	walk = func(dir, shownDir string, ancestors []string) error {
+		return filepath.WalkDir(dir, func(realPath string, info os.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+
+			// Paths under a followed symlink are archived under the link
+			path := shownDir
+			if rel, err := filepath.Rel(dir, realPath); err == nil && rel != "." {
+				path = filepath.Join(shownDir, rel)
 			}
-			// Exclude blaxel.toml from the count (it won't be archived)
-			if filepath.Base(path) == "blaxel.toml" {
+
+			// Only apply ignore logic for non-volume-template types
+			if !core.IsVolumeTemplate(config.Type) && d.shouldIgnorePath(path, ignoredPaths) {
 				return nil
 			}
-			totalFiles++
-			return nil
-		})
-	}
 
-	err := filepath.WalkDir(archiveRoot, func(path string, info os.DirEntry, err error) error {
-		if err != nil {
-			return err
-		}
+			// For volume-templates, exclude blaxel.toml from the archive
+			if core.IsVolumeTemplate(config.Type) && filepath.Base(path) == "blaxel.toml" {
+				return nil
+			}
 
-		// Only apply ignore logic for non-volume-template types
-		if !core.IsVolumeTemplate(config.Type) && d.shouldIgnorePath(path, ignoredPaths) {
-			return nil
-		}
+			if path == archiveRoot {
+				return nil
+			}
 
-		// For volume-templates, exclude blaxel.toml from the archive
-		if core.IsVolumeTemplate(config.Type) && filepath.Base(path) == "blaxel.toml" {
-			return nil
-		}
+			relPath, err := filepath.Rel(archiveRoot, path)
+			if err != nil {
+				return err
+			}
+			// Normalize to forward slashes for archive paths (zip/tar expect forward slashes)
+			relPath = toArchivePath(relPath)
+
+			if info.Type()&os.ModeSymlink != 0 {
+				if !d.followSymlinks {
+					if !symlinkInside(archiveRoot, path) {
+						core.PrintWarning(fmt.Sprintf("Skipping symlink %s: it points outside of the archived directory, use --follow-symlinks to archive its content", relPath))
+						return nil
+					}
+				} else {
+					target, err := filepath.EvalSymlinks(path)
+					if err != nil {
+						core.PrintWarning(fmt.Sprintf("Skipping broken symlink %s", relPath))
+						return nil
+					}
+					if targetInfo, err := os.Stat(target); err == nil && targetInfo.IsDir() {
+						chain := append(slices.Clone(ancestors), realPath)
+						for _, ancestor := range chain {
+							if pathWithin(target, ancestor) {
+								core.PrintWarning(fmt.Sprintf("Skipping symlink %s: it loops back to %s", relPath, target))
+								return nil
+							}
+						}
+						return walk(target, path, chain)
+					}
+				}
+			}
 
-		if path == archiveRoot {
+			entry := archiveEntry{path: path, relPath: relPath}
+			if !info.IsDir() {
+				if fileInfo, err := d.statArchiveFile(path); err == nil {
+					entry.size = fileInfo.Size()
+				}
+			}
+			entries = append(entries, entry)
 			return nil
-		}
+		})
+	}
+	// The real path of the root is walked so links can be compared with it
+	realRoot, err := filepath.EvalSymlinks(archiveRoot)
+	if err != nil {
+		realRoot = archiveRoot
+	}
+	err = walk(realRoot, archiveRoot, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create archive: %w", err)
+	}
 
-		relPath, err := filepath.Rel(archiveRoot, path)
-		if err != nil {
-			return err
-		}
+	if err := d.checkArchiveSize(entries, core.IsVolumeTemplate(config.Type)); err != nil {
+		return err
+	}
 
-		// Normalize to forward slashes for archive paths (zip/tar expect forward slashes)
-		relPath = toArchivePath(relPath)
+	// WalkDir sorts each directory on its own, so "a/b" comes before "a-b"
+	if d.reproducible {
+		sort.Slice(entries, func(i, j int) bool { return entries[i].relPath < entries[j].relPath })
+	}
 
-		err = writer.addFile(path, relPath)
-		if err != nil {
-			return err
+	totalFiles := len(entries)
+	processedFiles := 0
+	for _, entry := range entries {
+		if err := writer.addFile(entry.path, entry.relPath); err != nil {
+			return fmt.Errorf("failed to create archive: %w", err)
 		}
 
 		// Report progress for volume-template
@@ -2214,12 +2982,6 @@ func (d *Deployment) createArchive(_ string, writer archiveWriter) error {
 			}
 			d.progressCallback(fmt.Sprintf("Compressing files (%d/%d)", processedFiles, totalFiles), progress)
 		}
-
-		return nil
-	})
-
-	if err != nil {
-		return fmt.Errorf("failed to create archive: %w", err)
 	}
 
 	if d.folder != "" {
@@ -2269,16 +3031,34 @@ func (d *Deployment) Zip() error {
 	}
 
 	d.archive = zipFile
+	core.AddBreadcrumb("deploy", "archive created", map[string]interface{}{"format": "zip"})
 	return nil
 }
 
 func (d *Deployment) Tar() error {
-	tarFile, err := os.CreateTemp("", ".blaxel.tar")
+	pattern := ".blaxel.tar"
+	if d.gzipArchive {
+		pattern = ".blaxel-*.tar.gz"
+	}
+	tarFile, err := os.CreateTemp("", pattern)
 	if err != nil {
 		return fmt.Errorf("failed to create temp file: %w", err)
 	}
 
-	tarWriter := tar.NewWriter(tarFile)
+	// Count the bytes of the tar itself, before compression
+	counter := &countingWriter{writer: tarFile}
+	var gzipWriter *gzip.Writer
+	if d.gzipArchive {
+		// The lowest level keeps the overhead negligible on files that are
+		// already compressed, while text still shrinks a lot
+		gzipWriter, err = gzip.NewWriterLevel(tarFile, gzip.BestSpeed)
+		if err != nil {
+			_ = tarFile.Close()
+			return fmt.Errorf("failed to create gzip writer: %w", err)
+		}
+		counter.writer = gzipWriter
+	}
+	tarWriter := tar.NewWriter(counter)
 
 	writer := &tarArchiveWriter{writer: tarWriter, deployment: d}
 	if err := d.createArchive(".tar", writer); err != nil {
@@ -2292,6 +3072,12 @@ func (d *Deployment) Tar() error {
 		_ = tarFile.Close()
 		return fmt.Errorf("failed to close tar writer: %w", err)
 	}
+	if gzipWriter != nil {
+		if err := gzipWriter.Close(); err != nil {
+			_ = tarFile.Close()
+			return fmt.Errorf("failed to close gzip writer: %w", err)
+		}
+	}
 
 	// Close the file
 	if err := tarFile.Close(); err != nil {
@@ -2299,19 +3085,127 @@ func (d *Deployment) Tar() error {
 	}
 
 	d.archive = tarFile
+	d.archiveSize = counter.written
+	data := map[string]interface{}{"format": "tar", "size": counter.written}
+	if d.gzipArchive {
+		data["format"] = "tar.gz"
+		if info, err := os.Stat(tarFile.Name()); err == nil {
+			data["compressedSize"] = info.Size()
+		}
+	}
+	core.AddBreadcrumb("deploy", "archive created", data)
 	return nil
 }
 
+// countingWriter counts the bytes written through it
+type countingWriter struct {
+	writer  io.Writer
+	written int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.writer.Write(p)
+	c.written += int64(n)
+	return n, err
+}
+
+// compressionSummary describes the size of the tar archive and, when it is
+// gzipped, the compression ratio
+func (d *Deployment) compressionSummary() string {
+	if d.archive == nil {
+		return ""
+	}
+	if !d.gzipArchive {
+		return fmt.Sprintf("Archive size: %s", formatBytes(d.archiveSize))
+	}
+	info, err := os.Stat(d.archive.Name())
+	if err != nil || d.archiveSize == 0 {
+		return ""
+	}
+	return fmt.Sprintf("Archive size: %s gzipped from %s (%.0f%% of the original)", formatBytes(info.Size()), formatBytes(d.archiveSize), float64(info.Size())*100/float64(d.archiveSize))
+}
+
+// openTarReader opens the tar archive at path, gzipped or not
+func openTarReader(path string, gzipped bool) (*tar.Reader, func(), error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to reopen tar file: %w", err)
+	}
+	if !gzipped {
+		return tar.NewReader(file), func() { _ = file.Close() }, nil
+	}
+	gzipReader, err := gzip.NewReader(file)
+	if err != nil {
+		_ = file.Close()
+		return nil, nil, fmt.Errorf("failed to read gzip archive: %w", err)
+	}
+	return tar.NewReader(gzipReader), func() { _ = gzipReader.Close(); _ = file.Close() }, nil
+}
+
+// statArchiveFile returns the file info of an archive entry, which describes
+// the link itself for symlinks unless they are followed
+func (d *Deployment) statArchiveFile(path string) (os.FileInfo, error) {
+	if d.followSymlinks {
+		return os.Stat(path)
+	}
+	return os.Lstat(path)
+}
+
+// symlinkInside reports whether the symlink at path points to a relative
+// target within root, so it still resolves once the archive is extracted
+func symlinkInside(root, path string) bool {
+	target, err := os.Readlink(path)
+	if err != nil || filepath.IsAbs(target) {
+		return false
+	}
+	rel, err := filepath.Rel(root, filepath.Join(filepath.Dir(path), target))
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// pathWithin reports whether path is dir or one of its descendants
+func pathWithin(dir, path string) bool {
+	rel, err := filepath.Rel(dir, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// reproducibleModTime is the modification time of every entry of a
+// reproducible archive, the earliest one zip can store
+var reproducibleModTime = time.Date(1980, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// reproducibleMode keeps only the type and whether the file is executable,
+// since permissions depend on the umask of the machine
+func reproducibleMode(mode os.FileMode) os.FileMode {
+	switch {
+	case mode.IsDir():
+		return os.ModeDir | 0755
+	case mode&os.ModeSymlink != 0:
+		return os.ModeSymlink | 0777
+	case mode&0111 != 0:
+		return 0755
+	default:
+		return 0644
+	}
+}
+
+// normalizeTarHeader removes from header what differs between two machines or
+// two checkouts of the same sources: times, ownership and permissions
+func normalizeTarHeader(header *tar.Header, mode os.FileMode) {
+	header.ModTime = reproducibleModTime
+	header.AccessTime = time.Time{}
+	header.ChangeTime = time.Time{}
+	header.Uid = 0
+	header.Gid = 0
+	header.Uname = ""
+	header.Gname = ""
+	header.PAXRecords = nil
+	header.Mode = int64(reproducibleMode(mode).Perm())
+}
+
 func (d *Deployment) addFileToZip(zipWriter *zip.Writer, filePath string, headerName string) error {
 	// Normalize header name to forward slashes (zip spec requires forward slashes)
 	headerName = toArchivePath(headerName)
 
-	if _, err := os.Stat(filePath); err == nil {
-		fileInfo, err := os.Stat(filePath)
-		if err != nil {
-			return fmt.Errorf("failed to stat %s: %w", headerName, err)
-		}
-
+	if fileInfo, err := d.statArchiveFile(filePath); err == nil {
 		header, err := zip.FileInfoHeader(fileInfo)
 		if err != nil {
 			return fmt.Errorf("failed to create zip header: %w", err)
@@ -2320,16 +3214,35 @@ func (d *Deployment) addFileToZip(zipWriter *zip.Writer, filePath string, header
 		// Set the header name to the specified headerName
 		if fileInfo.IsDir() {
 			header.Name = headerName + "/" // Add trailing slash for directories
+		} else if fileInfo.Mode()&os.ModeSymlink != 0 {
+			// Symlinks are stored with their target as content
+			header.Name = headerName
+			header.Method = zip.Store
 		} else {
 			header.Name = headerName
 			header.Method = zip.Deflate
 		}
+		if d.reproducible {
+			header.Modified = reproducibleModTime
+			header.SetMode(reproducibleMode(fileInfo.Mode()))
+		}
 
 		writer, err := zipWriter.CreateHeader(header)
 		if err != nil {
 			return fmt.Errorf("failed to create zip writer: %w", err)
 		}
 
+		if fileInfo.Mode()&os.ModeSymlink != 0 {
+			target, err := os.Readlink(filePath)
+			if err != nil {
+				return fmt.Errorf("failed to read symlink %s: %w", headerName, err)
+			}
+			if _, err := writer.Write([]byte(target)); err != nil {
+				return fmt.Errorf("failed to write symlink %s to zip: %w", headerName, err)
+			}
+			return nil
+		}
+
 		// If it's a file, write its content to the zip
 		if !fileInfo.IsDir() {
 			file, err := os.Open(filePath)
@@ -2351,13 +3264,7 @@ func (d *Deployment) addFileToTar(tarWriter *tar.Writer, filePath string, header
 	// Normalize header name to forward slashes (tar spec expects forward slashes)
 	headerName = toArchivePath(headerName)
 
-	if _, err := os.Lstat(filePath); err == nil {
-		// Use Lstat instead of Stat to not follow symlinks
-		fileInfo, err := os.Lstat(filePath)
-		if err != nil {
-			return fmt.Errorf("failed to stat %s: %w", headerName, err)
-		}
-
+	if fileInfo, err := d.statArchiveFile(filePath); err == nil {
 		// For symlinks, we need to read the link target
 		linkTarget := ""
 		if fileInfo.Mode()&os.ModeSymlink != 0 {
@@ -2378,6 +3285,9 @@ func (d *Deployment) addFileToTar(tarWriter *tar.Writer, filePath string, header
 		} else {
 			header.Name = headerName
 		}
+		if d.reproducible {
+			normalizeTarHeader(header, fileInfo.Mode())
+		}
 
 		err = tarWriter.WriteHeader(header)
 		if err != nil {
@@ -2402,8 +3312,12 @@ func (d *Deployment) addFileToTar(tarWriter *tar.Writer, filePath string, header
 }
 
 func (d *Deployment) Print(skipBuild bool) error {
+	if len(d.runtimeDefaults) > 0 {
+		fmt.Printf("# Runtime defaults applied: %s\n", core.FormatRuntimeDefaults(d.runtimeDefaults))
+		fmt.Println("# Set them in the [runtime] section of blaxel.toml or with --set runtime.KEY=VALUE to change them")
+	}
 	for _, deployment := range d.blaxelDeployments {
-		fmt.Print(deployment.ToString())
+		fmt.Print(core.RedactSecrets(deployment.ToString()))
 		fmt.Println("---")
 	}
 	config := core.GetConfig()
@@ -2439,6 +3353,124 @@ func (d *Deployment) Print(skipBuild bool) error {
 	return nil
 }
 
+// WriteManifest writes the generated resources, followed by the resources of
+// the .blaxel directory, to path as a multi-document YAML file that bl apply
+// can read back
+func (d *Deployment) WriteManifest(path string) error {
+	docs := []string{}
+	for _, deployment := range d.blaxelDeployments {
+		doc, err := manifestDocument(deployment)
+		if err != nil {
+			return err
+		}
+		docs = append(docs, doc)
+	}
+	extra, err := blaxelDirDocuments(filepath.Join(d.cwd, ".blaxel"))
+	if err != nil {
+		return err
+	}
+	docs = append(docs, extra...)
+
+	content := strings.Join(docs, "---\n")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		return fmt.Errorf("failed to write manifest: %w", err)
+	}
+	core.PrintDiagnostic(fmt.Sprintf("Manifest written to %s", path))
+	return nil
+}
+
+// manifestDocument renders a generated resource as YAML, with env values
+// coming from loaded secrets replaced by ${secrets.NAME} references
+func manifestDocument(resource core.Result) (string, error) {
+	var node yaml.Node
+	if err := yaml.Unmarshal([]byte(resource.ToString()), &node); err != nil {
+		return "", fmt.Errorf("failed to render %s: %w", resource.Kind, err)
+	}
+	secretEnvRefs(&node)
+	var b strings.Builder
+	encoder := yaml.NewEncoder(&b)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&node); err != nil {
+		return "", fmt.Errorf("failed to render %s: %w", resource.Kind, err)
+	}
+	_ = encoder.Close()
+	return b.String(), nil
+}
+
+// secretEnvRefs replaces the value of every {name, value} env whose value is
+// one of the loaded secrets with a ${secrets.NAME} reference
+func secretEnvRefs(node *yaml.Node) {
+	if node.Kind == yaml.MappingNode {
+		var name string
+		var value *yaml.Node
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			switch node.Content[i].Value {
+			case "name":
+				name = node.Content[i+1].Value
+			case "value":
+				value = node.Content[i+1]
+			}
+		}
+		if value != nil && value.Kind == yaml.ScalarNode {
+			if secret := secretNameForValue(name, value.Value); secret != "" {
+				value.Value = fmt.Sprintf("${secrets.%s}", secret)
+				value.Style = 0
+			}
+		}
+	}
+	for _, child := range node.Content {
+		secretEnvRefs(child)
+	}
+}
+
+// secretNameForValue returns the name of the loaded secret holding value,
+// preferring the secret called name
+func secretNameForValue(name, value string) string {
+	if value == "" {
+		return ""
+	}
+	if core.LookupSecret(name) == value {
+		return name
+	}
+	for _, secret := range core.GetSecrets() {
+		if secret.Value == value {
+			return secret.Name
+		}
+	}
+	return ""
+}
+
+// blaxelDirDocuments returns the YAML files of dir as they are, so their
+// $secrets references are kept. A missing directory has no documents.
+func blaxelDirDocuments(dir string) ([]string, error) {
+	docs := []string{}
+	err := filepath.WalkDir(dir, func(path string, entry os.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) && path == dir {
+				return filepath.SkipDir
+			}
+			return err
+		}
+		ext := filepath.Ext(path)
+		if entry.IsDir() || (ext != ".yaml" && ext != ".yml") {
+			return nil
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		doc := strings.TrimPrefix(strings.TrimSpace(string(content)), "---")
+		if doc = strings.TrimSpace(doc); doc != "" {
+			docs = append(docs, doc+"\n")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", dir, err)
+	}
+	return docs, nil
+}
+
 func (d *Deployment) PrintZip() error {
 	// Reopen the file to get the reader
 	zipFile, err := os.Open(d.archive.Name())
@@ -2467,14 +3499,13 @@ func (d *Deployment) PrintZip() error {
 
 func (d *Deployment) PrintTar() error {
 	// Reopen the file to get the reader
-	tarFile, err := os.Open(d.archive.Name())
+	tarReader, closeTar, err := openTarReader(d.archive.Name(), d.gzipArchive)
 	if err != nil {
-		return fmt.Errorf("failed to reopen tar file: %w", err)
+		return err
 	}
-	defer func() { _ = tarFile.Close() }()
+	defer closeTar()
 
 	// Print the content of the tar file
-	tarReader := tar.NewReader(tarFile)
 
 	for {
 		header, err := tarReader.Next()
@@ -2490,43 +3521,653 @@ func (d *Deployment) PrintTar() error {
 	return nil
 }
 
-func deployPackage(dryRun bool, name string) bool {
-	commands, err := getDeployCommands(dryRun, name)
+// deployResourceTypes are the values accepted for the blaxel.toml type and --type
+var deployResourceTypes = []string{"agent", "function", "job", "sandbox", "application", "volume-template", "volumetemplate", "vt"}
+
+// validateDeployResourceType reports an unsupported resource type. An empty type is valid
+// and resolved later by prompting, or rejected when the deploy is not interactive.
+func validateDeployResourceType(resourceType string) error {
+	if resourceType == "" || slices.Contains(deployResourceTypes, resourceType) {
+		return nil
+	}
+	return fmt.Errorf("unsupported resource type %q: expected one of agent, function, job, sandbox, application, volume-template. Fix 'type' in blaxel.toml or pass --type", resourceType)
+}
+
+// deployFlagConflict is a pair of deploy flags that cannot be combined
+type deployFlagConflict struct {
+	a, b   string
+	reason string
+}
+
+// deployFlagConflicts lists the incompatible deploy flag combinations
+var deployFlagConflicts = []deployFlagConflict{
+	{"recursive", "directory", "-d deploys a single project, -r deploys every project of the monorepo"},
+	{"directory", "no-walk-up", "the project of -d is never looked for in the parent directories"},
+	{"skip-build", "build-env-file", "build args are only used when building the image"},
+	{"skip-build", "no-cache", "no image is built with --skip-build"},
+	{"skip-build", "build-arg", "build args are only used when building the image"},
+	{"env-file", "no-env-layering", "the files passed with -e are loaded as they are, without layering"},
+	{"dryrun", "save-config", "overrides are only saved after a successful deploy"},
+	{"dryrun", "verify-url", "the URL is only checked after a successful deploy"},
+	{"dryrun", "until-healthy", "the health path is only checked after a successful deploy"},
+	{"dryrun", "json-logs", "a dry run does not build anything"},
+	{"dryrun", "build-log-file", "a dry run does not build anything"},
+	{"dryrun", "wait-for", "a dry run does not deploy anything"},
+	{"no-wait", "wait-for", "--no-wait does not wait for any status"},
+	{"no-wait", "json-logs", "build logs are collected by following the build"},
+	{"no-wait", "build-log-file", "build logs are collected by following the build"},
+}
+
+// checkDeployFlagConflicts reports the first incompatible pair of flags set on
+// the command line. A boolean flag only counts when it is set to true.
+func checkDeployFlagConflicts(cmd *cobra.Command) error {
+	isSet := func(name string) bool {
+		flag := cmd.Flags().Lookup(name)
+		if flag == nil || !flag.Changed {
+			return false
+		}
+		return flag.Value.Type() != "bool" || flag.Value.String() == "true"
+	}
+	for _, conflict := range deployFlagConflicts {
+		if isSet(conflict.a) && isSet(conflict.b) {
+			return fmt.Errorf("--%s and --%s cannot be used together: %s", conflict.a, conflict.b, conflict.reason)
+		}
+	}
+	return nil
+}
+
+// saveDeployConfigSets writes the --set overrides into blaxel.toml and reports
+// each written key. Output goes to stderr with structured output to keep stdout parseable.
+func saveDeployConfigSets(folder string, configSets []string, isStructured bool) error {
+	out := os.Stdout
+	if isStructured {
+		out = os.Stderr
+	}
+	written, err := core.SaveConfigSets(folder, configSets)
 	if err != nil {
-		err = fmt.Errorf("failed to get package commands: %w", err)
-		core.PrintError("Deploy", err)
-		core.ExitWithError(err)
+		return fmt.Errorf("deployed, but failed to save --set overrides: %w", err)
 	}
+	fmt.Fprintf(out, "Saved to %s:\n", filepath.Join(folder, "blaxel.toml"))
+	for _, line := range written {
+		fmt.Fprintf(out, "  %s\n", line)
+	}
+	return nil
+}
 
-	if len(commands) == 1 {
-		return false
+// buildLogHandler returns the callback receiving the build logs of a resource,
+// also writing them to the --json-logs files when enabled
+func (d *Deployment) buildLogHandler(kind, name string, show func(string)) func(string) {
+	if d.buildLogs == nil {
+		return show
 	}
+	return func(log string) {
+		_ = d.buildLogs.Write(kind, name, log)
+		show(log)
+	}
+}
 
-	server.RunCommands(commands, true)
-	return true
+// writeBuildLogFile writes log to the --build-log-file when enabled
+func (d *Deployment) writeBuildLogFile(kind, name, log string) {
+	if d.buildLogFile != nil {
+		_ = d.buildLogFile.Write(kind, name, log)
+	}
+}
+
+// buildLogFlushDelay lets the build log watcher fetch the last lines of a
+// failed build before it is stopped
+var buildLogFlushDelay = 3 * time.Second
+
+// waitWithBuildLogs follows the build started by a non-interactive deploy until
+// the resource reaches the --wait-for status, writing its logs to the
+// --json-logs files and the --build-log-file. When the build fails, its last
+// log lines are printed to explain why.
+func (d *Deployment) waitWithBuildLogs() error {
+	kind := strings.ToLower(core.GetConfig().Type)
+	if core.IsVolumeTemplate(kind) {
+		return nil
+	}
+	if !core.IsQuiet() {
+		destinations := []string{}
+		if d.buildLogs != nil {
+			destinations = append(destinations, d.logDir)
+		}
+		if d.buildLogFile != nil {
+			destinations = append(destinations, d.buildLogFile.Path())
+		}
+		if len(destinations) > 0 {
+			core.PrintDiagnostic(fmt.Sprintf("Writing build logs of %s %s to %s...", kind, d.name, strings.Join(destinations, " and ")))
+		} else {
+			core.PrintDiagnostic(fmt.Sprintf("Waiting for %s %s to reach %s...", kind, d.name, d.waitStatus()))
+		}
+	}
+	tail := deploy.NewLogTail(deploy.DefaultLogTailLines)
+	show := func(log string) {
+		tail.Add(log)
+		d.writeBuildLogFile(kind, d.name, log)
+	}
+	watcher := mon.NewBuildLogWatcher(core.GetClient(), core.GetWorkspace(), kind, d.name, d.buildLogHandler(kind, d.name, show), d.timeout)
+	watcher.Start()
+	err := d.pollStatus(kind, d.waitStatus())
+	if errors.Is(err, errDeployFailed) {
+		time.Sleep(buildLogFlushDelay)
+	}
+	watcher.Stop()
+	if errors.Is(err, errDeployFailed) {
+		printBuildFailure(kind, d.name, tail.Lines())
+	}
+	return err
+}
+
+// printBuildFailure prints the last build log lines of a failed deploy to
+// stderr, or where to find them when there are none
+func printBuildFailure(kind, name string, lines []string) {
+	if len(lines) == 0 {
+		core.PrintDiagnostic(fmt.Sprintf("The deployment of %s %s failed without build logs, check them with: bl logs %s %s", kind, name, kind, name))
+		return
+	}
+	core.PrintDiagnostic(fmt.Sprintf("The deployment of %s %s failed, last %d lines of its build logs:", kind, name, len(lines)))
+	for _, line := range lines {
+		core.PrintDiagnostic("  " + line)
+	}
+}
+
+// closeBuildLogs closes the --json-logs files and tells where they are
+func (d *Deployment) closeBuildLogs() {
+	if err := d.buildLogs.Close(); err != nil {
+		core.PrintWarning(fmt.Sprintf("Build logs are incomplete: %v", err))
+	}
+	files := d.buildLogs.Files()
+	switch len(files) {
+	case 0:
+		core.PrintDiagnostic(fmt.Sprintf("No build logs were written to %s", d.logDir))
+	case 1:
+		core.PrintDiagnostic(fmt.Sprintf("Build logs written to %s", files[0]))
+	default:
+		core.PrintDiagnostic(fmt.Sprintf("Build logs written to %d files in %s (%s to %s)", len(files), d.logDir, filepath.Base(files[0]), filepath.Base(files[len(files)-1])))
+	}
+}
+
+// closeBuildLogFile closes the --build-log-file and tells where it is
+func (d *Deployment) closeBuildLogFile() {
+	if err := d.buildLogFile.Close(); err != nil {
+		core.PrintWarning(fmt.Sprintf("Build log file is incomplete: %v", err))
+	}
+	core.PrintDiagnostic(fmt.Sprintf("Build logs written to %s", d.buildLogFile.Path()))
+}
+
+// deployURLCheckAttempts and deployURLCheckInterval bound how long --verify-url
+// waits for a cold-starting resource to answer
+const (
+	deployURLCheckAttempts = 6
+	deployURLCheckInterval = 5 * time.Second
+)
+
+// deployURLPaths maps the resource types checked by --verify-url to their run URL path
+var deployURLPaths = map[string]string{
+	"agent":    "agents",
+	"function": "functions",
+	"sandbox":  "sandboxes",
+}
+
+// verifyURL checks that the deployed resource answers on its URL and exits with
+// an error when it does not. Deploys run with --no-wait return before the build
+// is done, so waitDeployed first waits for the DEPLOYED status. Messages go to
+// stderr like the deploy mode message.
+func (d *Deployment) verifyURL(waitDeployed bool) error {
+	url, err := d.deployedURL("--verify-url", waitDeployed)
+	if err != nil || url == "" {
+		return err
+	}
+
+	status, err := waitForURL(func() (int, error) { return probeURL(url) }, deployURLCheckAttempts, deployURLCheckInterval)
+	if err != nil {
+		return &core.NetworkError{Err: fmt.Errorf("deployed, but %s is not responding: %w", url, err)}
+	}
+	if !core.IsQuiet() {
+		core.PrintDiagnostic(fmt.Sprintf("%s %s",
+			color.New(color.FgGreen, color.Bold).Sprint("✓"),
+			color.New(color.FgGreen).Sprintf("%s responded with status %d", url, status)))
+	}
+	return nil
+}
+
+// deployedURL returns the URL of the deployed resource checked by flag, ""
+// with a warning when the resource type is not served. With waitDeployed, it
+// first waits for the DEPLOYED status.
+func (d *Deployment) deployedURL(flag string, waitDeployed bool) (string, error) {
+	config := core.GetConfig()
+	typePath, ok := deployURLPaths[config.Type]
+	if !ok {
+		core.StrictWarning("Deploy", fmt.Sprintf("%s only applies to agents, functions and sandboxes, not %s", flag, config.Type))
+		return "", nil
+	}
+	if waitDeployed {
+		if !core.IsQuiet() {
+			core.PrintDiagnostic(fmt.Sprintf("Waiting for %s %s to be deployed...", config.Type, d.name))
+		}
+		if err := d.pollStatus(config.Type, "DEPLOYED"); err != nil {
+			return "", classifyDeployError(fmt.Errorf("could not check the URL of %s %s: %w", config.Type, d.name, err))
+		}
+	}
+	if d.metadataURL != "" {
+		return d.metadataURL, nil
+	}
+	return fmt.Sprintf("%s/%s/%s/%s", blaxel.GetRunURL(), core.GetWorkspace(), typePath, d.name), nil
+}
+
+// waitHealthy probes the health path of the deployed resource until it
+// answers with a 2xx status, for --until-healthy. It fails once timeout is
+// over. Attempts are printed with --verbose.
+func (d *Deployment) waitHealthy(waitDeployed bool, healthPath string, timeout time.Duration) error {
+	url, err := d.deployedURL("--until-healthy", waitDeployed)
+	if err != nil || url == "" {
+		return err
+	}
+	url = strings.TrimSuffix(url, "/") + "/" + strings.TrimPrefix(healthPath, "/")
+
+	if !core.IsQuiet() {
+		core.PrintDiagnostic(fmt.Sprintf("Waiting for %s to be healthy...", url))
+	}
+	status, err := waitForHealthy(func() (int, error) { return probeURL(url) }, timeout, deployURLCheckInterval, func(attempt, status int, err error) {
+		if !core.GetVerbose() {
+			return
+		}
+		if err != nil {
+			core.PrintDiagnostic(fmt.Sprintf("Health check %d: %v", attempt, err))
+		} else {
+			core.PrintDiagnostic(fmt.Sprintf("Health check %d: status %d", attempt, status))
+		}
+	})
+	if err != nil {
+		return &core.TimeoutError{Err: fmt.Errorf("deployed, but %s is not healthy: %w", url, err)}
+	}
+	if !core.IsQuiet() {
+		core.PrintDiagnostic(fmt.Sprintf("%s %s",
+			color.New(color.FgGreen, color.Bold).Sprint("✓"),
+			color.New(color.FgGreen).Sprintf("%s is healthy (status %d)", url, status)))
+	}
+	return nil
+}
+
+// waitForHealthy calls probe every interval until it gets a 2xx status or
+// timeout is over, reporting each attempt to onAttempt
+func waitForHealthy(probe func() (int, error), timeout, interval time.Duration, onAttempt func(attempt, status int, err error)) (int, error) {
+	deadline := time.Now().Add(timeout)
+	var lastErr error
+	for attempt := 1; ; attempt++ {
+		status, err := probe()
+		onAttempt(attempt, status, err)
+		switch {
+		case err != nil:
+			lastErr = err
+		case status >= 200 && status < 300:
+			return status, nil
+		default:
+			lastErr = fmt.Errorf("status %d", status)
+		}
+		if time.Now().Add(interval).After(deadline) {
+			return 0, fmt.Errorf("still failing after %s and %d attempts: %w", timeout, attempt, lastErr)
+		}
+		time.Sleep(interval)
+	}
+}
+
+// deployWaitStatuses are the statuses accepted by --wait-for, in the order a
+// deploy goes through them
+var deployWaitStatuses = []string{"UPLOADING", "BUILDING", "DEPLOYING", "DEPLOYED"}
+
+// parseDeployWaitStatus validates a --wait-for value and returns it upper-cased
+func parseDeployWaitStatus(status string) (string, error) {
+	status = strings.ToUpper(strings.TrimSpace(status))
+	if !slices.Contains(deployWaitStatuses, status) {
+		return "", fmt.Errorf("invalid --wait-for status %q: expected one of %s", status, strings.Join(deployWaitStatuses, ", "))
+	}
+	return status, nil
+}
+
+// deployStatusesFrom returns target and the deploy stages after it, so a
+// stage the platform skips (BUILDING with --skip-build) still counts
+func deployStatusesFrom(target string) []string {
+	if i := slices.Index(deployWaitStatuses, target); i >= 0 {
+		return deployWaitStatuses[i:]
+	}
+	return nil
 }
 
-func getDeployCommands(dryRun bool, defaultName string) ([]server.PackageCommand, error) {
+// waitStatus returns the --wait-for status of the deployment, DEPLOYED by default
+func (d *Deployment) waitStatus() string {
+	if d.waitFor == "" {
+		return "DEPLOYED"
+	}
+	return d.waitFor
+}
+
+// deployFailedStatuses end the monitoring of a deploy with an error
+var deployFailedStatuses = []string{"FAILED", "DEACTIVATED", "DEACTIVATING", "DELETING"}
+
+// deployStaleStatusGrace is how long a FAILED status left by the previous
+// deploy is ignored when it does not change
+const deployStaleStatusGrace = 15 * time.Second
+
+// rolloutStarted returns a core.WaitOptions.Accept that holds off DEPLOYED
+// until another status was seen or settle has passed, so a resource that is
+// still DEPLOYED with its previous revision is not taken for rolled out, while
+// a rollout done between two polls is not waited for until the timeout
+func rolloutStarted(settle time.Duration) func(status string) bool {
+	start := time.Now()
+	started := false
+	return func(status string) bool {
+		started = started || status != "DEPLOYED" || time.Since(start) >= settle
+		return started
+	}
+}
+
+// monitorResource follows the status of an applied resource in the interactive
+// UI until it reaches --wait-for, fails or times out, which is returned as an
+// error. Build logs are streamed while it is BUILDING.
+func (d *Deployment) monitorResource(resource *deploy.Resource, model *deploy.InteractiveModel, idx int, timeout time.Duration, doneMessage string) error {
+	kind := strings.ToLower(resource.Kind)
+	var logWatcher interface{ Stop() }
+	stopBuildLogs := func() {
+		if logWatcher != nil {
+			logWatcher.Stop()
+			logWatcher = nil
+		}
+	}
+	defer stopBuildLogs()
+
+	buildLogStarted := false
+	sawBuildingStatus := false
+	stuck := deploy.NewStuckDetector(d.stuckAfter)
+	target := d.waitStatus()
+	status, err := core.WaitForStatus(context.Background(), kind, resource.Name, core.WaitOptions{
+		Timeout: timeout,
+		Done:    deployStatusesFrom(target),
+		Failed:  deployFailedStatuses,
+		Settle:  deployStaleStatusGrace,
+		// If skipBuild is false (AutoGenerated=true), we MUST have seen BUILDING status
+		Accept: func(status string) bool {
+			return status != "DEPLOYED" || !resource.AutoGenerated || sawBuildingStatus
+		},
+		OnPoll: func(status string) {
+			if fired, elapsed := stuck.Observe(status, time.Now()); fired {
+				model.AddBuildLog(idx, "Warning: "+deploy.StuckHint(kind, resource.Name, status, elapsed))
+			}
+		},
+		OnChange: func(status string) {
+			switch status {
+			case "UPLOADING":
+				model.UpdateResource(idx, deploy.StatusUploading, "Uploading code", nil)
+			case "BUILDING":
+				sawBuildingStatus = true
+				model.UpdateResource(idx, deploy.StatusBuilding, "Building image", nil)
+				if !buildLogStarted {
+					buildLogStarted = true
+					lw := mon.NewBuildLogWatcher(
+						core.GetClient(),
+						core.GetWorkspace(),
+						kind,
+						resource.Name,
+						d.buildLogHandler(kind, resource.Name, func(log string) {
+							model.AddBuildLog(idx, log)
+						}),
+						timeout,
+					)
+					lw.Start()
+					logWatcher = lw
+				}
+			case "DEPLOYING":
+				stopBuildLogs()
+				model.UpdateResource(idx, deploy.StatusDeploying, "Deploying to cluster", nil)
+			case "DEPLOYED", "FAILED", "DEACTIVATED", "DEACTIVATING", "DELETING":
+				// Reported once the wait is over, a stale status may still change
+				return
+			default:
+				model.UpdateResource(idx, deploy.StatusDeploying, fmt.Sprintf("Status: %s", status), nil)
+				model.AddBuildLog(idx, fmt.Sprintf("Status: %s", status))
+				return
+			}
+			model.AddBuildLog(idx, fmt.Sprintf("Status changed to: %s", status))
+		},
+	})
+	stopBuildLogs()
+
+	var statusErr *core.StatusError
+	switch {
+	case errors.Is(err, core.ErrWaitTimeout):
+		model.UpdateResource(idx, deploy.StatusFailed, "Deployment timeout", fmt.Errorf("deployment timed out after %s", timeout))
+	case errors.As(err, &statusErr) && status == "FAILED":
+		model.UpdateResource(idx, deploy.StatusFailed, "Deployment failed", fmt.Errorf("resource deployment failed"))
+		model.AddBuildLog(idx, "Status changed to: FAILED - Deployment failed")
+	case err != nil:
+		model.UpdateResource(idx, deploy.StatusFailed, fmt.Sprintf("Unexpected status: %s", status), fmt.Errorf("resource is being deactivated or deleted"))
+		model.AddBuildLog(idx, fmt.Sprintf("Unexpected status: %s", status))
+	case status == "DEPLOYED":
+		model.UpdateResource(idx, deploy.StatusComplete, doneMessage, nil)
+		model.AddBuildLog(idx, fmt.Sprintf("Deployment completed with status: %s", status))
+	default:
+		model.UpdateResource(idx, deploy.StatusComplete, fmt.Sprintf("Reached %s", status), nil)
+		model.AddBuildLog(idx, fmt.Sprintf("Stopped monitoring at status %s (--wait-for %s)", status, target))
+	}
+	return err
+}
+
+// classifyDeployError marks a failed deploy as a build error, unless its cause
+// already gives it a class (upload, API, network...)
+func classifyDeployError(err error) error {
+	if core.ExitCode(err) != core.ExitCodeError {
+		return err
+	}
+	return &core.BuildError{Err: err}
+}
+
+// errDeployFailed is returned by pollStatus when the resource status is FAILED
+var errDeployFailed = errors.New("resource deployment failed")
+
+// pollStatus polls the status of the resource until it reaches target or a
+// later stage. The status left by the previous deploy is ignored until it
+// changes or 15s pass. A hint is printed when the status stays the same for
+// --stuck-after.
+func (d *Deployment) pollStatus(resourceType, target string) error {
+	stuck := deploy.NewStuckDetector(d.stuckAfter)
+	_, err := core.WaitForStatus(context.Background(), resourceType, d.name, core.WaitOptions{
+		Timeout: d.timeout,
+		Done:    deployStatusesFrom(target),
+		Failed:  deployFailedStatuses,
+		Settle:  deployStaleStatusGrace,
+		OnPoll: func(status string) {
+			if fired, elapsed := stuck.Observe(status, time.Now()); fired {
+				core.PrintWarning(deploy.StuckHint(resourceType, d.name, status, elapsed))
+			}
+		},
+		OnChange: func(status string) {
+			if !core.IsQuiet() {
+				core.PrintDiagnostic(fmt.Sprintf("Status changed to: %s", status))
+			}
+			d.writeBuildLogFile(resourceType, d.name, fmt.Sprintf("Status changed to: %s", status))
+		},
+	})
+	var statusErr *core.StatusError
+	if errors.As(err, &statusErr) && statusErr.Status == "FAILED" {
+		return errDeployFailed
+	}
+	return err
+}
+
+// probeURL sends an authenticated GET to url and returns the response status.
+// Error statuses are returned as a status since the resource did respond.
+func probeURL(url string) (int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	var res *http.Response
+	err := core.GetClient().Execute(ctx, http.MethodGet, "", nil, nil,
+		option.WithBaseURL(url),
+		option.WithResponseBodyInto(&res),
+		option.WithMaxRetries(0),
+	)
+	var apiErr *blaxel.Error
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	_ = res.Body.Close()
+	return res.StatusCode, nil
+}
+
+// waitForURL calls probe until it gets a response that is not a gateway error,
+// trying attempts times with interval between tries to ride out cold starts
+func waitForURL(probe func() (int, error), attempts int, interval time.Duration) (int, error) {
+	var lastErr error
+	for i := 0; i < attempts; i++ {
+		if i > 0 {
+			time.Sleep(interval)
+		}
+		status, err := probe()
+		switch {
+		case err != nil:
+			lastErr = err
+		case status == http.StatusBadGateway || status == http.StatusServiceUnavailable || status == http.StatusGatewayTimeout:
+			lastErr = fmt.Errorf("status %d", status)
+		default:
+			return status, nil
+		}
+	}
+	return 0, fmt.Errorf("no response after %d attempts: %w", attempts, lastErr)
+}
+
+// printDeployMode tells which projects are about to be deployed. It goes to
+// stderr so it stays visible in interactive mode and out of structured output.
+func printDeployMode(mode string) {
+	if core.IsQuiet() {
+		return
+	}
+	core.PrintDiagnostic(fmt.Sprintf("%s %s",
+		color.New(color.FgBlue, color.Bold).Sprint("ℹ"),
+		color.New(color.FgBlue).Sprint("Deploy mode: "+mode)))
+}
+
+// deployPackage deploys the projects of a monorepo, each one in its own bl
+// deploy process, and reports whether it did. beforeDeploy, when set, is called
+// once it is known that several projects are deployed, before the first one.
+func deployPackage(dryRun bool, name string, concurrency int, extraArgs []string, filter packageFilter, beforeDeploy func()) (bool, error) {
+	commands, types, err := getDeployCommands(dryRun, name, concurrency, extraArgs)
+	if err != nil {
+		return false, fmt.Errorf("failed to get package commands: %w", err)
+	}
+
+	if filter.changedSince != "" {
+		filter.changed, err = changedDeployProjects(filter.changedSince, commands)
+		if err != nil {
+			core.PrintWarning(fmt.Sprintf("%v; deploying every project", err))
+		}
+	}
+
+	if !filter.empty() {
+		selected := []server.PackageCommand{}
+		projects := []string{}
+		for i, command := range commands {
+			if filter.matches(command.Name, types[i]) {
+				selected = append(selected, command)
+			}
+			projects = append(projects, fmt.Sprintf("%s (%s)", command.Name, types[i]))
+		}
+		if len(selected) == 0 && filter.changed != nil {
+			core.PrintInfo(fmt.Sprintf("No project changed since %s, nothing to deploy", filter.changedSince))
+			return true, nil
+		}
+		if len(selected) == 0 {
+			return false, &core.ConfigError{Err: fmt.Errorf("no project matches %s, the projects are %s", filter, strings.Join(projects, ", "))}
+		}
+		// The root project alone is deployed in this process
+		if len(selected) == 1 && selected[0].Name == "root" {
+			return false, nil
+		}
+		commands = selected
+	} else if len(commands) == 1 {
+		return false, nil
+	}
+
+	// The projects are deployed one after the other, each one starting from
+	// the concurrency the rate limiting of the previous ones left
+	stateDir, err := os.MkdirTemp("", "bl-deploy-")
+	if err != nil {
+		return false, fmt.Errorf("failed to create a temporary directory: %w", err)
+	}
+	defer func() { _ = os.RemoveAll(stateDir) }()
+	names := make([]string, len(commands))
+	for i, command := range commands {
+		names[i] = command.Name
+		commands[i].Color = server.PackageColors[i%len(server.PackageColors)]
+		if commands[i].Envs == nil {
+			commands[i].Envs = core.CommandEnv{}
+		}
+		commands[i].Envs.Set(deploy.ThrottleStateEnv, filepath.Join(stateDir, "throttle"))
+	}
+	if beforeDeploy != nil {
+		beforeDeploy()
+	}
+	printDeployMode(fmt.Sprintf("recursive, %d projects (%s)", len(commands), strings.Join(names, ", ")))
+	results := server.RunCommandsWithResults(commands)
+	printPackageSummary(results)
+
+	failed := []string{}
+	for _, result := range results {
+		if result.Err != nil {
+			failed = append(failed, result.Name)
+		}
+	}
+	if len(failed) > 0 {
+		return true, fmt.Errorf("%d of %d projects failed: %s", len(failed), len(results), strings.Join(failed, ", "))
+	}
+	return true, nil
+}
+
+// printPackageSummary prints the status and duration of each project of a
+// recursive deploy
+func printPackageSummary(results []server.CommandResult) {
+	nameWidth := len("PROJECT")
+	for _, result := range results {
+		nameWidth = max(nameWidth, len(result.Name))
+	}
+	fmt.Println()
+	fmt.Printf("%-*s  %-9s  %s\n", nameWidth, "PROJECT", "STATUS", "DURATION")
+	for _, result := range results {
+		status := color.New(color.FgGreen).Sprintf("%-9s", "succeeded")
+		if result.Err != nil {
+			status = color.New(color.FgRed).Sprintf("%-9s", "failed")
+		}
+		fmt.Printf("%-*s  %s  %s\n", nameWidth, result.Name, status, result.Duration.Round(time.Second))
+	}
+}
+
+// getDeployCommands returns the bl deploy command of the root project and of
+// every package, each one given extraArgs, along with their resource types
+func getDeployCommands(dryRun bool, defaultName string, concurrency int, extraArgs []string) ([]server.PackageCommand, []string, error) {
 	pwd, err := os.Getwd()
 	if err != nil {
-		return nil, fmt.Errorf("error getting current directory: %v", err)
+		return nil, nil, fmt.Errorf("error getting current directory: %v", err)
 	}
 	command := server.PackageCommand{
 		Name:    "root",
 		Cwd:     pwd,
 		Command: "bl",
-		Args:    []string{"deploy", "--recursive=false", "--skip-version-warning"},
+		Args:    []string{"deploy", "--recursive=false", "--no-walk-up", "--skip-version-warning", "--concurrency", strconv.Itoa(concurrency)},
 	}
 	if dryRun {
 		command.Args = append(command.Args, "--dryrun")
 	}
+	config := core.GetConfig()
+	command.Args = append(command.Args, packageDeployArgs(extraArgs, config.Type)...)
 	if defaultName != "" {
 		command.Args = append(command.Args, "--name", defaultName)
 	}
 	commands := []server.PackageCommand{}
-	config := core.GetConfig()
+	types := []string{}
 	if !config.SkipRoot {
 		commands = append(commands, command)
+		types = append(types, config.Type)
 	}
 	packages := server.GetAllPackages(core.GetConfig())
 	for name, pkg := range packages {
@@ -2537,12 +4178,16 @@ func getDeployCommands(dryRun bool, defaultName string) ([]server.PackageCommand
 			Args: []string{
 				"deploy",
 				"--recursive=false",
+				"--no-walk-up",
 				"--skip-version-warning",
+				"--concurrency",
+				strconv.Itoa(concurrency),
 			},
 		}
 		if dryRun {
 			command.Args = append(command.Args, "--dryrun")
 		}
+		command.Args = append(command.Args, packageDeployArgs(extraArgs, pkg.Type)...)
 		for _, envFile := range core.GetEnvFiles() {
 			command.Args = append(command.Args, "--env-file", envFile)
 		}
@@ -2550,8 +4195,94 @@ func getDeployCommands(dryRun bool, defaultName string) ([]server.PackageCommand
 			command.Args = append(command.Args, "-s", fmt.Sprintf("%s=%s", secret.Name, secret.Value))
 		}
 		commands = append(commands, command)
+		types = append(types, pkg.Type)
+	}
+	return commands, types, nil
+}
+
+// packageDeployArgs returns the extraArgs of a recursive deploy that apply to
+// a project of resourceType. --gzip is only given to the volume templates.
+func packageDeployArgs(extraArgs []string, resourceType string) []string {
+	if core.IsVolumeTemplate(resourceType) {
+		return extraArgs
+	}
+	return slices.DeleteFunc(slices.Clone(extraArgs), func(arg string) bool { return arg == "--gzip" })
+}
+
+// packageFilter selects the projects of a recursive deploy with --only and
+// --exclude. Each value is a resource type or a pattern on the project name,
+// the root project being named root.
+type packageFilter struct {
+	only    []string
+	exclude []string
+	// changedSince is the git ref of --only-changed, "" to deploy every project
+	changedSince string
+	// changed are the projects with changes since changedSince, nil when they
+	// are not known and every project is deployed
+	changed map[string]bool
+}
+
+func (f packageFilter) empty() bool {
+	return len(f.only) == 0 && len(f.exclude) == 0 && f.changedSince == ""
+}
+
+// validate checks the syntax of the name patterns
+func (f packageFilter) validate() error {
+	for _, value := range append(append([]string{}, f.only...), f.exclude...) {
+		if _, err := path.Match(value, ""); err != nil {
+			return fmt.Errorf("invalid project pattern %q: %w", value, err)
+		}
+	}
+	return nil
+}
+
+// matches reports whether the project name of type resourceType is selected:
+// it matches one of the only values, when set, and none of the exclude values
+func (f packageFilter) matches(name, resourceType string) bool {
+	matchesAny := func(values []string) bool {
+		for _, value := range values {
+			if strings.EqualFold(value, resourceType) {
+				return true
+			}
+			if ok, _ := path.Match(value, name); ok {
+				return true
+			}
+		}
+		return false
+	}
+	if len(f.only) > 0 && !matchesAny(f.only) {
+		return false
+	}
+	if f.changed != nil && !f.changed[name] {
+		return false
+	}
+	return !matchesAny(f.exclude)
+}
+
+func (f packageFilter) String() string {
+	parts := []string{}
+	if len(f.only) > 0 {
+		parts = append(parts, "--only "+strings.Join(f.only, ","))
+	}
+	if len(f.exclude) > 0 {
+		parts = append(parts, "--exclude "+strings.Join(f.exclude, ","))
+	}
+	if f.changedSince != "" {
+		parts = append(parts, "--only-changed --base "+f.changedSince)
+	}
+	return strings.Join(parts, " ")
+}
+
+// resourceExists reports whether resourceType/name is deployed. It is taken as
+// deployed when that cannot be told, so runtime defaults never replace the
+// values of a live resource.
+func resourceExists(resourceType, name string) bool {
+	if core.GetClient() == nil {
+		return true
 	}
-	return commands, nil
+	_, err := core.GetResourceStatus(context.Background(), resourceType, name)
+	var apiErr *blaxel.Error
+	return !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound
 }
 
 // isBlaxelErrorDeploy checks if an error is a blaxel API error and sets the apiErr pointer
@@ -0,0 +1,81 @@
+package deploy
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// BuildLogFile writes the build logs and status transitions of a deploy to a
+// single text file, one timestamped line per message, as they arrive. Every
+// message goes through redact before being written.
+type BuildLogFile struct {
+	mu     sync.Mutex
+	path   string
+	file   *os.File
+	redact func(string) string
+	now    func() time.Time
+	err    error
+}
+
+// NewBuildLogFile creates the file at path, and its directory, truncating it
+// when it exists. redact may be nil.
+func NewBuildLogFile(path string, redact func(string) string) (*BuildLogFile, error) {
+	if redact == nil {
+		redact = func(s string) string { return s }
+	}
+	if dir := filepath.Dir(path); dir != "" {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return nil, fmt.Errorf("failed to create build log directory %s: %w", dir, err)
+		}
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create build log file %s: %w", path, err)
+	}
+	return &BuildLogFile{path: path, file: file, redact: redact, now: time.Now}, nil
+}
+
+// Path returns the path of the file
+func (f *BuildLogFile) Path() string {
+	return f.path
+}
+
+// Write appends message for the resource kind/name, prefixing each of its
+// lines with the time and the resource. The first error is kept and returned
+// by Close, so callers streaming logs can ignore it.
+func (f *BuildLogFile) Write(kind, name, message string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.err != nil || f.file == nil {
+		return f.err
+	}
+
+	prefix := fmt.Sprintf("%s %s/%s ", f.now().UTC().Format(time.RFC3339Nano), strings.ToLower(kind), name)
+	var b strings.Builder
+	for _, line := range strings.Split(strings.TrimRight(f.redact(message), "\n"), "\n") {
+		b.WriteString(prefix)
+		b.WriteString(strings.TrimRight(line, "\r"))
+		b.WriteByte('\n')
+	}
+	if _, err := f.file.WriteString(b.String()); err != nil {
+		f.err = fmt.Errorf("failed to write build logs: %w", err)
+	}
+	return f.err
+}
+
+// Close closes the file and returns the first error met while writing
+func (f *BuildLogFile) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.file != nil {
+		if err := f.file.Close(); err != nil && f.err == nil {
+			f.err = err
+		}
+		f.file = nil
+	}
+	return f.err
+}
@@ -0,0 +1,38 @@
+package deploy
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildLogFileWritesTimestampedLines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ci", "build.log")
+	file, err := NewBuildLogFile(path, func(s string) string { return strings.ReplaceAll(s, "s3cr3t", "****") })
+	require.NoError(t, err)
+	file.now = func() time.Time { return time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC) }
+
+	require.NoError(t, file.Write("Agent", "my-agent", "Status changed to: BUILDING"))
+	require.NoError(t, file.Write("agent", "my-agent", "step 1\nTOKEN=s3cr3t\n"))
+	require.NoError(t, file.Close())
+	assert.Equal(t, path, file.Path())
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "2025-03-01T12:00:00Z agent/my-agent Status changed to: BUILDING\n"+
+		"2025-03-01T12:00:00Z agent/my-agent step 1\n"+
+		"2025-03-01T12:00:00Z agent/my-agent TOKEN=****\n", string(content))
+}
+
+func TestBuildLogFileIgnoresWritesAfterClose(t *testing.T) {
+	file, err := NewBuildLogFile(filepath.Join(t.TempDir(), "build.log"), nil)
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+	assert.NoError(t, file.Write("agent", "a", "late"))
+	assert.NoError(t, file.Close())
+}
@@ -54,6 +54,7 @@ type InteractiveModel struct {
 	height                int
 	mu                    sync.RWMutex
 	program               *tea.Program
+	logTee                func(resource *Resource, log string)
 }
 
 // Messages for updating the model
@@ -99,7 +100,7 @@ var (
 // NewInteractiveModel creates a new interactive deployment model
 func NewInteractiveModel(resources []*Resource) *InteractiveModel {
 	s := spinner.New()
-	s.Spinner = spinner.Dot
+	s.Spinner = core.Spinner(spinner.Dot)
 	s.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("214"))
 
 	vp := viewport.New(80, 20)
@@ -527,6 +528,9 @@ func (m *InteractiveModel) UpdateResource(idx int, status DeployStatus, statusTe
 	if m == nil {
 		return
 	}
+	if err != nil {
+		m.tee(idx, fmt.Sprintf("%s: %v", statusText, err))
+	}
 	if m.program == nil {
 		// Program not set yet, wait a bit for it to be initialized
 		time.Sleep(10 * time.Millisecond)
@@ -547,6 +551,7 @@ func (m *InteractiveModel) AddBuildLog(idx int, log string) {
 	if m == nil {
 		return
 	}
+	m.tee(idx, log)
 	if m.program == nil {
 		// Program not set yet, wait a bit for it to be initialized
 		time.Sleep(10 * time.Millisecond)
@@ -582,6 +587,21 @@ func (m *InteractiveModel) SetProgram(p *tea.Program) {
 	}
 }
 
+// SetLogTee sets a function receiving every build log line and error of the
+// resources, so they can be kept once the UI exits
+func (m *InteractiveModel) SetLogTee(tee func(resource *Resource, log string)) {
+	if m != nil {
+		m.logTee = tee
+	}
+}
+
+// tee passes log to the function set with SetLogTee
+func (m *InteractiveModel) tee(idx int, log string) {
+	if m.logTee != nil && idx >= 0 && idx < len(m.resources) {
+		m.logTee(m.resources[idx], log)
+	}
+}
+
 // SetCallbackSecret sets the callback secret for a resource
 func (r *Resource) SetCallbackSecret(secret string) {
 	if r != nil {
@@ -118,6 +118,25 @@ func TestNewInteractiveModel(t *testing.T) {
 	assert.False(t, model.waitingForQuitConfirm)
 }
 
+func TestInteractiveModelLogTee(t *testing.T) {
+	resources := []*Resource{{Kind: "Agent", Name: "agent-1"}}
+	model := NewInteractiveModel(resources)
+
+	var lines []string
+	model.SetLogTee(func(resource *Resource, log string) {
+		lines = append(lines, resource.Name+": "+log)
+	})
+	model.AddBuildLog(0, "Status changed to: BUILDING")
+	model.AddBuildLog(3, "out of range")
+	model.UpdateResource(0, StatusFailed, "Deployment failed", errors.New("resource deployment failed"))
+	model.UpdateResource(0, StatusComplete, "Done", nil)
+
+	assert.Equal(t, []string{
+		"agent-1: Status changed to: BUILDING",
+		"agent-1: Deployment failed: resource deployment failed",
+	}, lines)
+}
+
 func TestInteractiveModelInit(t *testing.T) {
 	model := NewInteractiveModel([]*Resource{})
 
@@ -0,0 +1,132 @@
+package deploy
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// DefaultLogFileMaxSize is the size at which a build log file is rotated
+const DefaultLogFileMaxSize = 10 * 1024 * 1024
+
+// LogEntry is one line of a build log file
+type LogEntry struct {
+	Time     string `json:"time"`
+	Kind     string `json:"kind"`
+	Name     string `json:"name"`
+	Message  string `json:"message"`
+	Sequence int    `json:"seq"`
+}
+
+// LogFileWriter writes build logs as NDJSON files under a directory. A file is
+// closed once it would grow past maxSize and the next one is numbered after it
+// (PREFIX.0001.ndjson, PREFIX.0002.ndjson, ...), so no single file grows
+// unbounded and finished files can be archived as they are rotated. Every
+// message goes through redact before being written.
+type LogFileWriter struct {
+	mu       sync.Mutex
+	dir      string
+	prefix   string
+	maxSize  int64
+	redact   func(string) string
+	file     *os.File
+	size     int64
+	index    int
+	sequence int
+	files    []string
+	err      error
+}
+
+// NewLogFileWriter creates dir and returns a writer of files named after prefix,
+// rotated at maxSize bytes. A maxSize of 0 or less uses DefaultLogFileMaxSize.
+// redact may be nil.
+func NewLogFileWriter(dir, prefix string, maxSize int64, redact func(string) string) (*LogFileWriter, error) {
+	if maxSize <= 0 {
+		maxSize = DefaultLogFileMaxSize
+	}
+	if redact == nil {
+		redact = func(s string) string { return s }
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create log directory %s: %w", dir, err)
+	}
+	return &LogFileWriter{dir: dir, prefix: prefix, maxSize: maxSize, redact: redact}, nil
+}
+
+// Write appends a log line for the resource kind/name. The first error is
+// kept and returned by Close, so callers streaming logs can ignore it.
+func (w *LogFileWriter) Write(kind, name, message string) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.err != nil {
+		return w.err
+	}
+
+	w.sequence++
+	line, err := json.Marshal(LogEntry{
+		Time:     time.Now().UTC().Format(time.RFC3339Nano),
+		Kind:     kind,
+		Name:     name,
+		Message:  w.redact(message),
+		Sequence: w.sequence,
+	})
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+
+	if w.file == nil || (w.size > 0 && w.size+int64(len(line)) > w.maxSize) {
+		if err := w.rotate(); err != nil {
+			w.err = err
+			return err
+		}
+	}
+	n, err := w.file.Write(line)
+	w.size += int64(n)
+	if err != nil {
+		w.err = fmt.Errorf("failed to write build logs: %w", err)
+	}
+	return w.err
+}
+
+// rotate closes the current file and opens the next numbered one
+func (w *LogFileWriter) rotate() error {
+	if w.file != nil {
+		if err := w.file.Close(); err != nil {
+			return err
+		}
+	}
+	w.index++
+	path := filepath.Join(w.dir, fmt.Sprintf("%s.%04d.ndjson", w.prefix, w.index))
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to create log file %s: %w", path, err)
+	}
+	w.file = file
+	w.size = 0
+	w.files = append(w.files, path)
+	return nil
+}
+
+// Files returns the log files written so far, in order
+func (w *LogFileWriter) Files() []string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return append([]string{}, w.files...)
+}
+
+// Close closes the current log file and returns the first error met while writing
+func (w *LogFileWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.file != nil {
+		if err := w.file.Close(); err != nil && w.err == nil {
+			w.err = err
+		}
+		w.file = nil
+	}
+	return w.err
+}
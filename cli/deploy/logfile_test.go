@@ -0,0 +1,95 @@
+package deploy
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readLogEntries(t *testing.T, path string) []LogEntry {
+	t.Helper()
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	defer func() { _ = file.Close() }()
+
+	entries := []LogEntry{}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var entry LogEntry
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &entry))
+		entries = append(entries, entry)
+	}
+	require.NoError(t, scanner.Err())
+	return entries
+}
+
+func TestLogFileWriterRotates(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "logs")
+	writer, err := NewLogFileWriter(dir, "my-agent", 300, nil)
+	require.NoError(t, err)
+
+	for i := 0; i < 6; i++ {
+		require.NoError(t, writer.Write("agent", "my-agent", strings.Repeat("x", 50)))
+	}
+	require.NoError(t, writer.Close())
+
+	files := writer.Files()
+	require.Greater(t, len(files), 1)
+	assert.Equal(t, filepath.Join(dir, "my-agent.0001.ndjson"), files[0])
+	assert.Equal(t, filepath.Join(dir, "my-agent.0002.ndjson"), files[1])
+
+	seq := 0
+	for _, path := range files {
+		info, err := os.Stat(path)
+		require.NoError(t, err)
+		assert.LessOrEqual(t, info.Size(), int64(300))
+		for _, entry := range readLogEntries(t, path) {
+			seq++
+			assert.Equal(t, seq, entry.Sequence)
+			assert.Equal(t, "agent", entry.Kind)
+			assert.Equal(t, "my-agent", entry.Name)
+		}
+	}
+	assert.Equal(t, 6, seq)
+}
+
+func TestLogFileWriterKeepsOversizedLines(t *testing.T) {
+	writer, err := NewLogFileWriter(t.TempDir(), "job", 10, nil)
+	require.NoError(t, err)
+
+	require.NoError(t, writer.Write("job", "my-job", "longer than the maximum size"))
+	require.NoError(t, writer.Write("job", "my-job", "second"))
+	require.NoError(t, writer.Close())
+
+	files := writer.Files()
+	require.Len(t, files, 2)
+	assert.Equal(t, "longer than the maximum size", readLogEntries(t, files[0])[0].Message)
+}
+
+func TestLogFileWriterRedacts(t *testing.T) {
+	writer, err := NewLogFileWriter(t.TempDir(), "my-agent", 0, func(s string) string {
+		return strings.ReplaceAll(s, "hunter2", "[REDACTED]")
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, writer.Write("agent", "my-agent", "password=hunter2"))
+	require.NoError(t, writer.Close())
+
+	entries := readLogEntries(t, writer.Files()[0])
+	require.Len(t, entries, 1)
+	assert.Equal(t, "password=[REDACTED]", entries[0].Message)
+	assert.NotEmpty(t, entries[0].Time)
+}
+
+func TestLogFileWriterWithoutLogs(t *testing.T) {
+	writer, err := NewLogFileWriter(t.TempDir(), "my-agent", 0, nil)
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+	assert.Empty(t, writer.Files())
+}
@@ -0,0 +1,41 @@
+package deploy
+
+import "sync"
+
+// DefaultLogTailLines is the number of build log lines kept to explain a
+// failed build
+const DefaultLogTailLines = 30
+
+// LogTail keeps the last lines of a build log, so they can be printed when
+// the build fails without keeping the whole log in memory
+type LogTail struct {
+	mu    sync.Mutex
+	max   int
+	lines []string
+}
+
+// NewLogTail creates a tail keeping up to max lines. A max of 0 or less uses
+// DefaultLogTailLines.
+func NewLogTail(max int) *LogTail {
+	if max <= 0 {
+		max = DefaultLogTailLines
+	}
+	return &LogTail{max: max}
+}
+
+// Add appends a line, dropping the oldest one once the tail is full
+func (t *LogTail) Add(line string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.lines = append(t.lines, line)
+	if len(t.lines) > t.max {
+		t.lines = append([]string(nil), t.lines[len(t.lines)-t.max:]...)
+	}
+}
+
+// Lines returns the lines kept, oldest first
+func (t *LogTail) Lines() []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]string{}, t.lines...)
+}
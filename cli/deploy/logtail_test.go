@@ -0,0 +1,25 @@
+package deploy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLogTailKeepsLastLines(t *testing.T) {
+	tail := NewLogTail(3)
+	assert.Empty(t, tail.Lines())
+
+	for _, line := range []string{"a", "b", "c", "d", "e"} {
+		tail.Add(line)
+	}
+	assert.Equal(t, []string{"c", "d", "e"}, tail.Lines())
+}
+
+func TestLogTailDefaultSize(t *testing.T) {
+	tail := NewLogTail(0)
+	for i := 0; i < DefaultLogTailLines+5; i++ {
+		tail.Add("line")
+	}
+	assert.Len(t, tail.Lines(), DefaultLogTailLines)
+}
@@ -0,0 +1,59 @@
+package deploy
+
+import (
+	"fmt"
+	"time"
+)
+
+// DefaultStuckAfter is how long a resource may stay in the same in-progress
+// status before a deploy warns that it may be stuck
+const DefaultStuckAfter = 10 * time.Minute
+
+// inProgressStatuses are the statuses a deploy is expected to leave on its own
+var inProgressStatuses = map[string]bool{
+	"UPLOADING": true,
+	"BUILDING":  true,
+	"DEPLOYING": true,
+}
+
+// StuckDetector tells when a resource has stayed in the same in-progress status
+// for longer than a threshold. It fires once per status and starts over when
+// the status changes.
+type StuckDetector struct {
+	after  time.Duration
+	status string
+	since  time.Time
+	fired  bool
+}
+
+// NewStuckDetector creates a detector firing after the given duration in the
+// same status. A duration of 0 or less disables it.
+func NewStuckDetector(after time.Duration) *StuckDetector {
+	return &StuckDetector{after: after}
+}
+
+// Observe records the status seen at now. It returns true the first time the
+// status has been unchanged for the threshold, along with how long it lasted.
+func (s *StuckDetector) Observe(status string, now time.Time) (bool, time.Duration) {
+	if s == nil || s.after <= 0 {
+		return false, 0
+	}
+	if status != s.status {
+		s.status = status
+		s.since = now
+		s.fired = false
+		return false, 0
+	}
+	elapsed := now.Sub(s.since)
+	if s.fired || !inProgressStatuses[status] || elapsed < s.after {
+		return false, 0
+	}
+	s.fired = true
+	return true, elapsed
+}
+
+// StuckHint explains what to check when kind name has been in status for elapsed
+func StuckHint(kind, name, status string, elapsed time.Duration) string {
+	return fmt.Sprintf("%s %s has been %s for %s and may be stuck: check the workspace policies, region capacity and quotas, then inspect it with 'bl get %s %s' and 'bl logs %s %s'",
+		kind, name, status, elapsed.Round(time.Second), kind, name, kind, name)
+}
@@ -0,0 +1,55 @@
+package deploy
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStuckDetector(t *testing.T) {
+	start := time.Unix(1_700_000_000, 0)
+	s := NewStuckDetector(10 * time.Minute)
+
+	fired, _ := s.Observe("DEPLOYING", start)
+	assert.False(t, fired)
+	fired, _ = s.Observe("DEPLOYING", start.Add(9*time.Minute))
+	assert.False(t, fired)
+
+	fired, elapsed := s.Observe("DEPLOYING", start.Add(10*time.Minute))
+	assert.True(t, fired)
+	assert.Equal(t, 10*time.Minute, elapsed)
+
+	fired, _ = s.Observe("DEPLOYING", start.Add(20*time.Minute))
+	assert.False(t, fired, "fires once per status")
+
+	// A status change starts over
+	fired, _ = s.Observe("BUILDING", start.Add(21*time.Minute))
+	assert.False(t, fired)
+	fired, _ = s.Observe("BUILDING", start.Add(31*time.Minute))
+	assert.True(t, fired)
+}
+
+func TestStuckDetectorIgnoresTerminalStatuses(t *testing.T) {
+	start := time.Unix(1_700_000_000, 0)
+	s := NewStuckDetector(time.Minute)
+	s.Observe("DEPLOYED", start)
+	fired, _ := s.Observe("DEPLOYED", start.Add(time.Hour))
+	assert.False(t, fired)
+}
+
+func TestStuckDetectorDisabled(t *testing.T) {
+	start := time.Unix(1_700_000_000, 0)
+	for _, s := range []*StuckDetector{NewStuckDetector(0), nil} {
+		s.Observe("DEPLOYING", start)
+		fired, _ := s.Observe("DEPLOYING", start.Add(time.Hour))
+		assert.False(t, fired)
+	}
+}
+
+func TestStuckHint(t *testing.T) {
+	hint := StuckHint("agent", "my-agent", "DEPLOYING", 12*time.Minute+300*time.Millisecond)
+	assert.Contains(t, hint, "agent my-agent has been DEPLOYING for 12m0s")
+	assert.Contains(t, hint, "'bl get agent my-agent'")
+	assert.Contains(t, hint, "'bl logs agent my-agent'")
+}
@@ -0,0 +1,123 @@
+package deploy
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	throttleBaseBackoff = 2 * time.Second
+	throttleMaxBackoff  = 30 * time.Second
+)
+
+// ThrottleStateEnv names the file through which the projects of a recursive
+// deploy, deployed one after the other, hand the concurrency left by rate
+// limiting to the next one
+const ThrottleStateEnv = "BL_DEPLOY_THROTTLE_STATE"
+
+// Throttle bounds how many resources are deployed at the same time. Each time
+// the platform answers with 429 Too Many Requests the bound is halved, so a
+// large deploy slows down instead of failing.
+type Throttle struct {
+	mu        sync.Mutex
+	cond      *sync.Cond
+	initial   int
+	limit     int
+	active    int
+	throttled bool
+}
+
+// NewThrottle creates a throttle allowing limit concurrent deployments.
+// A limit of 0 or less does not bound concurrency until rate limiting occurs.
+func NewThrottle(limit int) *Throttle {
+	t := &Throttle{initial: limit, limit: limit}
+	t.cond = sync.NewCond(&t.mu)
+	return t
+}
+
+// Acquire blocks until a deployment slot is free
+func (t *Throttle) Acquire() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for t.limit > 0 && t.active >= t.limit {
+		t.cond.Wait()
+	}
+	t.active++
+}
+
+// Release frees a slot taken by Acquire
+func (t *Throttle) Release() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.active--
+	t.cond.Broadcast()
+}
+
+// RateLimited records a 429 response for the given retry attempt (starting at 0),
+// halves the concurrency and returns how long to wait before retrying.
+func (t *Throttle) RateLimited(attempt int) time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.throttled = true
+	switch {
+	case t.limit <= 0:
+		// Unbounded so far: start from what is currently running
+		t.limit = max(t.active/2, 1)
+	case t.limit > 1:
+		t.limit /= 2
+	}
+
+	backoff := throttleBaseBackoff << attempt
+	if backoff <= 0 || backoff > throttleMaxBackoff {
+		backoff = throttleMaxBackoff
+	}
+	return backoff
+}
+
+// Throttled reports whether rate limiting reduced the concurrency
+func (t *Throttle) Throttled() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.throttled
+}
+
+// Limits returns the requested and the current effective concurrency
+func (t *Throttle) Limits() (initial, current int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.initial, t.limit
+}
+
+// LoadLimit lowers the concurrency to the one saved in path by a deploy that
+// was rate limited before. A missing or invalid file changes nothing.
+func (t *Throttle) LoadLimit(path string) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return
+	}
+	limit, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil || limit < 1 {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.limit <= 0 || limit < t.limit {
+		t.limit = limit
+		t.throttled = true
+	}
+}
+
+// SaveLimit writes the current concurrency to path when rate limiting
+// reduced it, for LoadLimit
+func (t *Throttle) SaveLimit(path string) error {
+	t.mu.Lock()
+	limit, throttled := t.limit, t.throttled
+	t.mu.Unlock()
+	if !throttled {
+		return nil
+	}
+	return os.WriteFile(path, []byte(strconv.Itoa(limit)), 0600)
+}
@@ -0,0 +1,97 @@
+package deploy
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestThrottleBoundsConcurrency(t *testing.T) {
+	throttle := NewThrottle(2)
+
+	var mu sync.Mutex
+	running, peak := 0, 0
+	var wg sync.WaitGroup
+	for range 6 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			throttle.Acquire()
+			defer throttle.Release()
+			mu.Lock()
+			running++
+			peak = max(peak, running)
+			mu.Unlock()
+			time.Sleep(5 * time.Millisecond)
+			mu.Lock()
+			running--
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	assert.LessOrEqual(t, peak, 2)
+	assert.False(t, throttle.Throttled())
+}
+
+func TestThrottleRateLimited(t *testing.T) {
+	throttle := NewThrottle(8)
+
+	assert.Equal(t, 2*time.Second, throttle.RateLimited(0))
+	initial, current := throttle.Limits()
+	assert.Equal(t, 8, initial)
+	assert.Equal(t, 4, current)
+	assert.True(t, throttle.Throttled())
+
+	assert.Equal(t, 4*time.Second, throttle.RateLimited(1))
+	throttle.RateLimited(2)
+	throttle.RateLimited(3)
+	_, current = throttle.Limits()
+	assert.Equal(t, 1, current, "concurrency never drops below 1")
+
+	assert.Equal(t, 30*time.Second, throttle.RateLimited(10), "backoff is capped")
+}
+
+func TestThrottleUnboundedRateLimited(t *testing.T) {
+	throttle := NewThrottle(0)
+	for range 4 {
+		throttle.Acquire()
+	}
+	throttle.RateLimited(0)
+	_, current := throttle.Limits()
+	assert.Equal(t, 2, current)
+}
+
+func TestThrottleSaveAndLoadLimit(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "throttle")
+
+	throttle := NewThrottle(8)
+	assert.NoError(t, throttle.SaveLimit(path))
+	_, err := os.Stat(path)
+	assert.True(t, os.IsNotExist(err), "nothing is saved without rate limiting")
+
+	throttle.RateLimited(0)
+	assert.NoError(t, throttle.SaveLimit(path))
+
+	next := NewThrottle(0)
+	next.LoadLimit(path)
+	initial, current := next.Limits()
+	assert.Equal(t, 0, initial)
+	assert.Equal(t, 4, current)
+	assert.True(t, next.Throttled())
+
+	lower := NewThrottle(2)
+	lower.LoadLimit(path)
+	_, current = lower.Limits()
+	assert.Equal(t, 2, current, "a lower concurrency is kept")
+	assert.False(t, lower.Throttled())
+
+	missing := NewThrottle(3)
+	missing.LoadLimit(filepath.Join(t.TempDir(), "missing"))
+	_, current = missing.Limits()
+	assert.Equal(t, 3, current)
+}
@@ -0,0 +1,107 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/blaxel-ai/toolkit/cli/core"
+	"github.com/blaxel-ai/toolkit/cli/server"
+)
+
+// defaultChangedBase is the git ref --only-changed compares HEAD to
+const defaultChangedBase = "origin/main"
+
+// gitChangedFiles returns the files changed between the merge base of base and
+// HEAD, relative to the current directory. Files outside of it are left out.
+func gitChangedFiles(base string) ([]string, error) {
+	out, err := exec.Command("git", "diff", "--name-only", "--relative", base+"...HEAD").Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && len(exitErr.Stderr) > 0 {
+			return nil, fmt.Errorf("git diff against %s failed: %s", base, strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return nil, fmt.Errorf("git diff against %s failed: %w", base, err)
+	}
+	files := []string{}
+	for _, line := range strings.Split(string(out), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			files = append(files, line)
+		}
+	}
+	return files, nil
+}
+
+// changedProjects returns the names of the projects with a changed file in
+// their directory or in one of their dependsOn paths. dirs maps each project
+// to its directory relative to the root, "" for the root project, which owns
+// the files outside of the other projects.
+func changedProjects(files []string, dirs map[string]string, dependsOn map[string][]string) map[string]bool {
+	changed := map[string]bool{}
+	for _, file := range files {
+		file = path.Clean(filepath.ToSlash(file))
+		owned := false
+		for name, dir := range dirs {
+			if dir == "" {
+				continue
+			}
+			if pathContains(dir, file) {
+				changed[name] = true
+				owned = true
+			}
+		}
+		for name, deps := range dependsOn {
+			for _, dep := range deps {
+				if pathContains(dep, file) {
+					changed[name] = true
+				}
+			}
+		}
+		if !owned {
+			for name, dir := range dirs {
+				if dir == "" {
+					changed[name] = true
+				}
+			}
+		}
+	}
+	return changed
+}
+
+// pathContains reports whether file is dir or is inside it, both relative to
+// the root
+func pathContains(dir, file string) bool {
+	dir = strings.TrimSuffix(path.Clean(filepath.ToSlash(dir)), "/")
+	return dir == "." || file == dir || strings.HasPrefix(file, dir+"/")
+}
+
+// changedDeployProjects returns the projects of commands with changes since
+// base, an error when git cannot tell, outside of a repository for instance
+func changedDeployProjects(base string, commands []server.PackageCommand) (map[string]bool, error) {
+	files, err := gitChangedFiles(base)
+	if err != nil {
+		return nil, err
+	}
+	pwd, err := os.Getwd()
+	if err != nil {
+		return nil, fmt.Errorf("error getting current directory: %w", err)
+	}
+	packages := server.GetAllPackages(core.GetConfig())
+	dirs := map[string]string{}
+	dependsOn := map[string][]string{}
+	for _, command := range commands {
+		dir, err := filepath.Rel(pwd, command.Cwd)
+		if err != nil || dir == "." {
+			dir = ""
+		}
+		dirs[command.Name] = filepath.ToSlash(dir)
+		if pkg, ok := packages[command.Name]; ok && command.Name != "root" {
+			dependsOn[command.Name] = pkg.DependsOn
+		}
+	}
+	return changedProjects(files, dirs, dependsOn), nil
+}
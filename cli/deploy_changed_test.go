@@ -0,0 +1,77 @@
+package cli
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChangedProjects(t *testing.T) {
+	dirs := map[string]string{"root": "", "search": "functions/search", "support": "agents/support"}
+	dependsOn := map[string][]string{"search": {"libs/common"}, "support": {"libs/llm", "pyproject.toml"}}
+
+	tests := []struct {
+		name     string
+		files    []string
+		expected map[string]bool
+	}{
+		{"nothing changed", nil, map[string]bool{}},
+		{"package file", []string{"functions/search/main.py"}, map[string]bool{"search": true}},
+		{"directory prefix is not enough", []string{"functions/search-v2/main.py"}, map[string]bool{"root": true}},
+		{"shared dependency", []string{"libs/common/db.py"}, map[string]bool{"search": true, "root": true}},
+		{"shared file", []string{"pyproject.toml"}, map[string]bool{"support": true, "root": true}},
+		{"root file", []string{"src/main.py"}, map[string]bool{"root": true}},
+		{"several", []string{"agents/support/a.py", "functions/search/b.py"}, map[string]bool{"search": true, "support": true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, changedProjects(tt.files, dirs, dependsOn))
+		})
+	}
+}
+
+func TestGitChangedFiles(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	originalDir, err := os.Getwd()
+	require.NoError(t, err)
+	defer func() { require.NoError(t, os.Chdir(originalDir)) }()
+
+	dir := t.TempDir()
+	git := func(args ...string) {
+		cmd := exec.Command("git", append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+		cmd.Dir = dir
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+	write := func(name string) {
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, filepath.Dir(name)), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(name), 0644))
+	}
+	git("init", "-q", "-b", "main")
+	write("blaxel.toml")
+	git("add", "-A")
+	git("commit", "-q", "-m", "initial")
+	git("checkout", "-q", "-b", "feature")
+	write("functions/search/main.py")
+	git("add", "-A")
+	git("commit", "-q", "-m", "change")
+
+	require.NoError(t, os.Chdir(dir))
+	files, err := gitChangedFiles("main")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"functions/search/main.py"}, files)
+
+	_, err = gitChangedFiles("does-not-exist")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "git diff against does-not-exist failed")
+
+	require.NoError(t, os.Chdir(t.TempDir()))
+	_, err = gitChangedFiles("main")
+	assert.Error(t, err, "outside of a repository")
+}
@@ -2,6 +2,7 @@ package cli
 
 import (
 	"archive/zip"
+	"context"
 	"encoding/json"
 	"io"
 	"net/http"
@@ -366,7 +367,8 @@ production = "python main.py"
 		cwd:    tempDir,
 	}
 
-	result := d.GenerateDeployment(false)
+	result, err := d.GenerateDeployment(false)
+	require.NoError(t, err)
 	assert.Equal(t, "Agent", result.Kind)
 	assert.Equal(t, "blaxel.ai/v1alpha1", result.ApiVersion)
 
@@ -374,6 +376,35 @@ production = "python main.py"
 	assert.Equal(t, "my-agent", metadata["name"])
 }
 
+// TestGenerateDeploymentNoCacheIntegration tests that --no-cache labels the generated resource
+func TestGenerateDeploymentNoCacheIntegration(t *testing.T) {
+	tempDir := t.TempDir()
+	originalDir, _ := os.Getwd()
+	defer func() { _ = os.Chdir(originalDir) }()
+
+	tomlContent := `name = "my-agent"
+type = "agent"
+workspace = "test-workspace"
+`
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "blaxel.toml"), []byte(tomlContent), 0644))
+	require.NoError(t, os.Chdir(tempDir))
+
+	core.ResetConfig()
+	core.ReadConfigToml("", true)
+
+	labels := func(d *Deployment) map[string]interface{} {
+		result, err := d.GenerateDeployment(false)
+		require.NoError(t, err)
+		return result.Metadata.(map[string]interface{})["labels"].(map[string]interface{})
+	}
+
+	d := &Deployment{name: "my-agent", cwd: tempDir, noCache: true}
+	assert.Equal(t, "true", labels(d)["x-blaxel-no-cache"])
+
+	d.noCache = false
+	assert.NotContains(t, labels(d), "x-blaxel-no-cache")
+}
+
 // TestGenerateDeploymentFunctionIntegration tests GenerateDeployment for function type
 func TestGenerateDeploymentFunctionIntegration(t *testing.T) {
 	tempDir := t.TempDir()
@@ -398,7 +429,8 @@ workspace = "test-workspace"
 		cwd:    tempDir,
 	}
 
-	result := d.GenerateDeployment(false)
+	result, err := d.GenerateDeployment(false)
+	require.NoError(t, err)
 	assert.Equal(t, "Function", result.Kind)
 
 	// Check that runtime type is set to "mcp" for functions
@@ -431,10 +463,102 @@ workspace = "test-workspace"
 		cwd:    tempDir,
 	}
 
-	result := d.GenerateDeployment(false)
+	result, err := d.GenerateDeployment(false)
+	require.NoError(t, err)
 	assert.Equal(t, "Job", result.Kind)
 }
 
+// TestGenerateDeploymentRuntimeDefaultsIntegration tests that GenerateDeployment
+// fills omitted runtime values with the defaults of the kind
+func TestGenerateDeploymentRuntimeDefaultsIntegration(t *testing.T) {
+	tempDir := t.TempDir()
+	originalDir, _ := os.Getwd()
+	defer func() { _ = os.Chdir(originalDir) }()
+
+	tomlContent := `name = "my-job"
+type = "job"
+workspace = "test-workspace"
+
+[runtime]
+memory = 8192
+`
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "blaxel.toml"), []byte(tomlContent), 0644))
+	require.NoError(t, os.Chdir(tempDir))
+
+	core.ResetConfig()
+	core.ReadConfigToml("", true)
+
+	deployed := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if !deployed {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":"not found"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"metadata":{"name":"my-job"},"status":"DEPLOYED"}`))
+	}))
+	defer server.Close()
+	defer core.SetClient(core.GetClient())
+	setupMockClient(t, server.URL)
+
+	d := &Deployment{
+		dir:  ".blaxel",
+		name: "my-job",
+		cwd:  tempDir,
+	}
+
+	result, err := d.GenerateDeployment(false)
+	require.NoError(t, err)
+	runtime := result.Spec.(map[string]interface{})["runtime"].(map[string]interface{})
+	assert.Equal(t, int64(8192), runtime["memory"])
+	assert.Equal(t, int64(10), runtime["maxConcurrentTasks"])
+	assert.Equal(t, map[string]interface{}{"maxConcurrentTasks": int64(10)}, d.runtimeDefaults)
+
+	// A deployed job keeps its values, bl scale may have changed them
+	deployed = true
+	core.ResetConfig()
+	core.ReadConfigToml("", true)
+	d = &Deployment{
+		dir:  ".blaxel",
+		name: "my-job",
+		cwd:  tempDir,
+	}
+	result, err = d.GenerateDeployment(false)
+	require.NoError(t, err)
+	runtime = result.Spec.(map[string]interface{})["runtime"].(map[string]interface{})
+	assert.NotContains(t, runtime, "maxConcurrentTasks")
+	assert.Nil(t, d.runtimeDefaults)
+}
+
+// TestGenerateDeploymentInvalidEnvIntegration tests that an invalid variable
+// name is returned as a configuration error
+func TestGenerateDeploymentInvalidEnvIntegration(t *testing.T) {
+	tempDir := t.TempDir()
+	originalDir, _ := os.Getwd()
+	defer func() { _ = os.Chdir(originalDir) }()
+
+	tomlContent := `name = "my-agent"
+type = "agent"
+workspace = "test-workspace"
+`
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "blaxel.toml"), []byte(tomlContent), 0644))
+	require.NoError(t, os.Chdir(tempDir))
+
+	core.ResetConfig()
+	core.ReadConfigToml("", true)
+	core.ResetSecrets()
+	defer core.ResetSecrets()
+	defer core.SetCommandSecrets(nil)
+	core.LoadCommandSecrets([]string{"MY-TOKEN=secret"})
+
+	d := &Deployment{name: "my-agent", cwd: tempDir}
+	_, err := d.GenerateDeployment(false)
+	var configErr *core.ConfigError
+	require.ErrorAs(t, err, &configErr)
+	assert.ErrorContains(t, err, "MY-TOKEN")
+}
+
 // TestGenerateDeploymentSandboxIntegration tests GenerateDeployment for sandbox type
 func TestGenerateDeploymentSandboxIntegration(t *testing.T) {
 	tempDir := t.TempDir()
@@ -460,7 +584,8 @@ region = "us-east-1"
 		cwd:    tempDir,
 	}
 
-	result := d.GenerateDeployment(false)
+	result, err := d.GenerateDeployment(false)
+	require.NoError(t, err)
 	assert.Equal(t, "Sandbox", result.Kind)
 
 	spec := result.Spec.(map[string]interface{})
@@ -493,7 +618,8 @@ defaultSize = 100
 		cwd:    tempDir,
 	}
 
-	result := d.GenerateDeployment(false)
+	result, err := d.GenerateDeployment(false)
+	require.NoError(t, err)
 	assert.Equal(t, "VolumeTemplate", result.Kind)
 
 	spec := result.Spec.(map[string]interface{})
@@ -525,7 +651,8 @@ policies = ["policy1", "policy2"]
 		cwd:    tempDir,
 	}
 
-	result := d.GenerateDeployment(false)
+	result, err := d.GenerateDeployment(false)
+	require.NoError(t, err)
 	spec := result.Spec.(map[string]interface{})
 	policies := spec["policies"].([]string)
 	assert.Contains(t, policies, "policy1")
@@ -561,7 +688,8 @@ maxScale = 10
 		cwd:    tempDir,
 	}
 
-	result := d.GenerateDeployment(false)
+	result, err := d.GenerateDeployment(false)
+	require.NoError(t, err)
 	spec := result.Spec.(map[string]interface{})
 	runtime := spec["runtime"].(map[string]interface{})
 	assert.Equal(t, int64(4096), runtime["memory"])
@@ -612,7 +740,8 @@ workspace = "test-workspace"
 		cwd:    tempDir,
 	}
 
-	result := d.GenerateDeployment(true) // skipBuild=true
+	result, err := d.GenerateDeployment(true) // skipBuild=true
+	require.NoError(t, err)
 	spec := result.Spec.(map[string]interface{})
 	runtime := spec["runtime"].(map[string]interface{})
 	assert.Equal(t, "registry.blaxel.ai/test-workspace/my-agent:existing-tag", runtime["image"])
@@ -690,7 +819,12 @@ workspace = "test-workspace"
 func TestHandleConfigWarningNonInteractive(t *testing.T) {
 	// Test with noTTY=true (should just print warning)
 	// This shouldn't panic
-	handleConfigWarning("Test warning message", true)
+	assert.NoError(t, handleConfigWarning("Test warning message", true))
+
+	core.SetStrict(true)
+	defer core.SetStrict(false)
+	err := handleConfigWarning("Test warning message", true)
+	assert.ErrorContains(t, err, "warnings are errors with --strict")
 }
 
 // TestDeploymentArchiveCreationIntegration tests creating archives
@@ -770,7 +904,8 @@ mountPath = "/data"
 		cwd:    tempDir,
 	}
 
-	result := d.GenerateDeployment(false)
+	result, err := d.GenerateDeployment(false)
+	require.NoError(t, err)
 	assert.Equal(t, "Sandbox", result.Kind)
 
 	spec := result.Spec.(map[string]interface{})
@@ -806,7 +941,8 @@ path = "/webhook"
 		cwd:    tempDir,
 	}
 
-	result := d.GenerateDeployment(false)
+	result, err := d.GenerateDeployment(false)
+	require.NoError(t, err)
 	assert.Equal(t, "Agent", result.Kind)
 
 	spec := result.Spec.(map[string]interface{})
@@ -890,11 +1026,12 @@ workspace = "test-workspace"
 	}
 
 	// Generate deployment first
-	result := d.GenerateDeployment(false)
+	result, err := d.GenerateDeployment(false)
+	require.NoError(t, err)
 	d.blaxelDeployments = []core.Result{result}
 
 	// Test Print with skipBuild=false (will create zip and print)
-	err := d.Print(false)
+	err = d.Print(false)
 	require.NoError(t, err)
 }
 
@@ -925,11 +1062,12 @@ defaultSize = 10
 	}
 
 	// Generate deployment first
-	result := d.GenerateDeployment(false)
+	result, err := d.GenerateDeployment(false)
+	require.NoError(t, err)
 	d.blaxelDeployments = []core.Result{result}
 
 	// Test Print with skipBuild=false (will create tar and print)
-	err := d.Print(false)
+	err = d.Print(false)
 	require.NoError(t, err)
 }
 
@@ -956,11 +1094,12 @@ workspace = "test-workspace"
 		cwd:    tempDir,
 	}
 
-	result := d.GenerateDeployment(false)
+	result, err := d.GenerateDeployment(false)
+	require.NoError(t, err)
 	d.blaxelDeployments = []core.Result{result}
 
 	// Test Print with skipBuild=true (should skip archive creation)
-	err := d.Print(true)
+	err = d.Print(true)
 	require.NoError(t, err)
 }
 
@@ -1201,3 +1340,45 @@ ENTRYPOINT ["/usr/local/bin/sandbox-api"]
 	t.Logf("bl push output (expected failure):\n%s", string(output))
 	assert.Error(t, err, "bl push without --registry-cred should fail when pulling from a private registry")
 }
+
+// TestApplyBlaxelDirStopsOnFailedDependencyIntegration tests that a resource of
+// the .blaxel directory is not applied when its dependency failed to apply
+func TestApplyBlaxelDirStopsOnFailedDependencyIntegration(t *testing.T) {
+	var puts []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPut || r.Method == http.MethodPost {
+			puts = append(puts, r.Method+" "+r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(map[string]string{"error": "invalid"})
+	}))
+	defer server.Close()
+	setupMockClient(t, server.URL)
+	core.RegisterResourceOperations(context.Background())
+
+	dir := t.TempDir()
+	manifests := `apiVersion: blaxel.ai/v1alpha1
+kind: Agent
+metadata:
+  name: consumer
+  dependsOn: Function/provider
+spec: {}
+---
+apiVersion: blaxel.ai/v1alpha1
+kind: Function
+metadata:
+  name: provider
+spec: {}
+`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "resources.yaml"), []byte(manifests), 0644))
+
+	d := &Deployment{}
+	err := d.applyBlaxelDir(dir, false)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cannot apply Agent/consumer: its dependency Function/provider failed to apply")
+	assert.NotEmpty(t, puts)
+	for _, put := range puts {
+		assert.NotContains(t, put, "consumer")
+	}
+}
@@ -2,10 +2,16 @@ package cli
 
 import (
 	"archive/tar"
+	"archive/zip"
+	"bytes"
 	"encoding/json"
+	"errors"
+	"io"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/blaxel-ai/toolkit/cli/core"
 	"github.com/stretchr/testify/assert"
@@ -51,6 +57,22 @@ func TestDeployCmd(t *testing.T) {
 	yesFlag := cmd.Flags().Lookup("yes")
 	assert.NotNil(t, yesFlag)
 	assert.Equal(t, "y", yesFlag.Shorthand)
+
+	concurrencyFlag := cmd.Flags().Lookup("concurrency")
+	assert.NotNil(t, concurrencyFlag)
+	assert.Equal(t, "0", concurrencyFlag.DefValue)
+
+	setFlag := cmd.Flags().Lookup("set")
+	assert.NotNil(t, setFlag)
+	assert.Equal(t, "stringArray", setFlag.Value.Type())
+
+	saveConfigFlag := cmd.Flags().Lookup("save-config")
+	assert.NotNil(t, saveConfigFlag)
+	assert.Equal(t, "false", saveConfigFlag.DefValue)
+
+	blEnvFlag := cmd.Flags().Lookup("bl-env")
+	assert.NotNil(t, blEnvFlag)
+	assert.Equal(t, "", blEnvFlag.DefValue)
 }
 
 func TestDeploymentDryRunStructuredOutputJSON(t *testing.T) {
@@ -86,6 +108,73 @@ func TestDeploymentDryRunStructuredOutputJSON(t *testing.T) {
 	assert.Empty(t, payload.Files)
 }
 
+func TestDeploymentDryRunStructuredOutputMasksSecrets(t *testing.T) {
+	core.ResetSecrets()
+	core.LoadCommandSecrets([]string{"OPENAI_API_KEY=sk-live-abc123"})
+	t.Cleanup(func() {
+		core.ResetSecrets()
+		core.SetCommandSecrets(nil)
+	})
+	deployment := Deployment{
+		blaxelDeployments: []core.Result{
+			{
+				Kind:     "Agent",
+				Metadata: map[string]interface{}{"name": "my-agent"},
+				Spec: map[string]interface{}{
+					"runtime": map[string]interface{}{
+						"envs": []core.Env{{Name: "OPENAI_API_KEY", Value: "sk-live-abc123"}},
+					},
+				},
+			},
+		},
+	}
+
+	for _, format := range []string{"json", "yaml"} {
+		output, err := deployment.renderDryRunStructuredOutput(format, true)
+		require.NoError(t, err)
+		assert.NotContains(t, string(output), "sk-live-abc123", format)
+		assert.Contains(t, string(output), "***", format)
+	}
+}
+
+func TestDeploymentDryRunStructuredOutputMasksEscapedSecrets(t *testing.T) {
+	core.ResetSecrets()
+	core.LoadCommandSecrets([]string{"DB_PASSWORD=p&ss1234", `QUOTED=<a\b:"c'd>`})
+	t.Cleanup(func() {
+		core.ResetSecrets()
+		core.SetCommandSecrets(nil)
+	})
+	deployment := Deployment{
+		blaxelDeployments: []core.Result{
+			{
+				Kind:     "Agent",
+				Metadata: map[string]interface{}{"name": "my-agent"},
+				Spec: map[string]interface{}{
+					"runtime": map[string]interface{}{
+						"memory": 4096,
+						"envs": []core.Env{
+							{Name: "DB_PASSWORD", Value: "p&ss1234"},
+							{Name: "QUOTED", Value: `<a\b:"c'd>`},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for _, format := range []string{"json", "yaml"} {
+		output, err := deployment.renderDryRunStructuredOutput(format, true)
+		require.NoError(t, err)
+		assert.NotContains(t, string(output), "ss1234", format)
+		assert.NotContains(t, string(output), "c'd", format)
+		assert.NotContains(t, string(output), "c''d", format)
+		assert.NotContains(t, string(output), `c\u0027d`, format)
+		assert.Contains(t, string(output), "4096", format)
+		assert.NotContains(t, string(output), "4096.", format)
+		assert.NotContains(t, string(output), `"4096"`, format)
+	}
+}
+
 func TestDeploymentDryRunStructuredOutputRejectsUnknownFormat(t *testing.T) {
 	deployment := Deployment{}
 
@@ -119,7 +208,8 @@ FOO = "bar"
 	core.ReadConfigToml("", true)
 
 	deployment := Deployment{name: "my-app", cwd: tempDir}
-	result := deployment.GenerateDeployment(false)
+	result, err := deployment.GenerateDeployment(false)
+	require.NoError(t, err)
 
 	assert.Equal(t, "Application", result.Kind)
 	spec := result.Spec.(map[string]interface{})
@@ -170,6 +260,7 @@ func TestDeploymentIgnoredPathsDefault(t *testing.T) {
 	assert.Contains(t, ignored, ".venv")
 	assert.Contains(t, ignored, "__pycache__")
 	assert.Contains(t, ignored, ".blaxel")
+	assert.Contains(t, ignored, core.LocalConfigFileName)
 }
 
 func TestDeploymentIgnoredPathsFromFile(t *testing.T) {
@@ -197,6 +288,30 @@ build
 	assert.Contains(t, ignored, "dist")
 	assert.Contains(t, ignored, "build")
 	assert.Contains(t, ignored, "*.log")
+	assert.Contains(t, ignored, core.LocalConfigFileName)
+}
+
+func TestDeploymentIgnoredPathsWithLogDir(t *testing.T) {
+	tempDir := t.TempDir()
+
+	d := Deployment{cwd: tempDir, logDir: "build-logs"}
+	assert.Contains(t, d.IgnoredPaths(), "build-logs")
+
+	d = Deployment{cwd: tempDir, logDir: filepath.Join(tempDir, "ci", "logs")}
+	assert.Contains(t, d.IgnoredPaths(), filepath.Join("ci", "logs"))
+
+	d = Deployment{cwd: tempDir, logDir: filepath.Join(filepath.Dir(tempDir), "outside")}
+	assert.NotContains(t, d.IgnoredPaths(), filepath.Join("..", "outside"))
+}
+
+func TestDeploymentIgnoredPathsWithBuildLogFile(t *testing.T) {
+	tempDir := t.TempDir()
+
+	d := Deployment{cwd: tempDir, buildLogPath: filepath.Join("artifacts", "build.log")}
+	assert.Contains(t, d.IgnoredPaths(), filepath.Join("artifacts", "build.log"))
+
+	d = Deployment{cwd: tempDir, buildLogPath: filepath.Join(filepath.Dir(tempDir), "build.log")}
+	assert.NotContains(t, d.IgnoredPaths(), filepath.Join("..", "build.log"))
 }
 
 func TestDeploymentShouldIgnorePath(t *testing.T) {
@@ -560,6 +675,244 @@ directory = "app"
 	assert.Contains(t, archivedFiles, "index.html")
 }
 
+func TestVolumeTemplateTarGzip(t *testing.T) {
+	tempDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "blaxel.toml"), []byte("name = \"my-volume\"\ntype = \"volume-template\"\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "data.txt"), []byte(strings.Repeat("compressible text\n", 1000)), 0644))
+
+	originalDir, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(tempDir))
+	defer func() { _ = os.Chdir(originalDir) }()
+	core.ResetConfig()
+	core.ReadConfigToml("", false)
+
+	d := Deployment{cwd: tempDir, gzipArchive: true}
+	require.NoError(t, d.Tar())
+	assert.True(t, strings.HasSuffix(d.archive.Name(), ".tar.gz"))
+
+	info, err := os.Stat(d.archive.Name())
+	require.NoError(t, err)
+	assert.Less(t, info.Size()*10, d.archiveSize, "text compresses to less than a tenth")
+	assert.Contains(t, d.compressionSummary(), "gzipped from")
+
+	files, err := collectDryRunTarFiles(d.archive.Name(), true)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	assert.Equal(t, "data.txt", files[0].Name)
+	assert.Equal(t, int64(18000), files[0].Size)
+}
+
+// buildArchive writes files into a new directory with the given mode and
+// modification time and returns the bytes of its reproducible archive
+func buildArchive(t *testing.T, resourceType string, files []string, mode os.FileMode, modTime time.Time) []byte {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "blaxel.toml"), []byte("name = \"my-app\"\ntype = \""+resourceType+"\"\n"), 0644))
+	for _, name := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte("content of "+name), mode))
+		require.NoError(t, os.Chtimes(path, modTime, modTime))
+	}
+
+	originalDir, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(dir))
+	defer func() { _ = os.Chdir(originalDir) }()
+	core.ResetConfig()
+	core.ReadConfigToml("", false)
+
+	d := Deployment{cwd: dir, reproducible: true}
+	if resourceType == "volume-template" {
+		require.NoError(t, d.Tar())
+	} else {
+		require.NoError(t, d.Zip())
+	}
+	data, err := os.ReadFile(d.archive.Name())
+	require.NoError(t, err)
+	return data
+}
+
+func TestReproducibleArchives(t *testing.T) {
+	files := []string{"a/b.txt", "a-b.txt", "main.py"}
+	for _, resourceType := range []string{"volume-template", "agent"} {
+		t.Run(resourceType, func(t *testing.T) {
+			first := buildArchive(t, resourceType, files, 0600, time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC))
+			second := buildArchive(t, resourceType, files, 0664, time.Date(2025, 9, 3, 18, 30, 0, 0, time.UTC))
+			assert.Equal(t, first, second)
+		})
+	}
+}
+
+func TestReproducibleArchiveOrder(t *testing.T) {
+	data := buildArchive(t, "volume-template", []string{"a/b.txt", "a-b.txt"}, 0644, time.Now())
+	tarReader := tar.NewReader(bytes.NewReader(data))
+	var names []string
+	for {
+		header, err := tarReader.Next()
+		if err != nil {
+			break
+		}
+		names = append(names, header.Name)
+		assert.Equal(t, reproducibleModTime, header.ModTime.UTC())
+		assert.Empty(t, header.Uname)
+	}
+	assert.Equal(t, []string{"a/", "a-b.txt", "a/b.txt"}, names, "sorted by full path, unlike WalkDir")
+}
+
+// symlinkFixture creates a volume template directory with symlinks to a file,
+// a directory, a file outside of it and its own root
+func symlinkFixture(t *testing.T) string {
+	base := t.TempDir()
+	root := filepath.Join(base, "root")
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "sub"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "blaxel.toml"), []byte("name = \"my-volume\"\ntype = \"volume-template\"\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "real.txt"), []byte("real"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "sub", "file.txt"), []byte("file"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(base, "outside.txt"), []byte("outside"), 0644))
+	require.NoError(t, os.Symlink("real.txt", filepath.Join(root, "link-in")))
+	require.NoError(t, os.Symlink("sub", filepath.Join(root, "dirlink")))
+	require.NoError(t, os.Symlink("../outside.txt", filepath.Join(root, "link-out")))
+	require.NoError(t, os.Symlink(".", filepath.Join(root, "loop")))
+
+	originalDir, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(root))
+	t.Cleanup(func() { _ = os.Chdir(originalDir) })
+	core.ResetConfig()
+	core.ReadConfigToml("", false)
+	return root
+}
+
+// tarEntries returns the headers of the tar archive of d by name
+func tarEntries(t *testing.T, d *Deployment) map[string]*tar.Header {
+	tarReader, closeTar, err := openTarReader(d.archive.Name(), false)
+	require.NoError(t, err)
+	defer closeTar()
+	headers := map[string]*tar.Header{}
+	for {
+		header, err := tarReader.Next()
+		if err != nil {
+			break
+		}
+		headers[header.Name] = header
+	}
+	return headers
+}
+
+func TestArchiveKeepsSymlinks(t *testing.T) {
+	d := &Deployment{cwd: symlinkFixture(t)}
+	require.NoError(t, d.Tar())
+	headers := tarEntries(t, d)
+
+	require.Contains(t, headers, "link-in")
+	assert.Equal(t, byte(tar.TypeSymlink), headers["link-in"].Typeflag)
+	assert.Equal(t, "real.txt", headers["link-in"].Linkname)
+	require.Contains(t, headers, "dirlink")
+	assert.Equal(t, byte(tar.TypeSymlink), headers["dirlink"].Typeflag)
+	assert.NotContains(t, headers, "dirlink/file.txt", "symlinked directories are not traversed")
+	assert.NotContains(t, headers, "link-out", "links outside of the directory are skipped")
+	assert.Contains(t, headers, "loop")
+}
+
+func TestArchiveFollowsSymlinks(t *testing.T) {
+	d := &Deployment{cwd: symlinkFixture(t), followSymlinks: true}
+	require.NoError(t, d.Tar())
+	headers := tarEntries(t, d)
+
+	require.Contains(t, headers, "link-in")
+	assert.Equal(t, byte(tar.TypeReg), headers["link-in"].Typeflag)
+	assert.Equal(t, int64(len("real")), headers["link-in"].Size)
+	assert.Equal(t, byte(tar.TypeDir), headers["dirlink/"].Typeflag)
+	assert.Contains(t, headers, "dirlink/file.txt")
+	assert.Equal(t, int64(len("outside")), headers["link-out"].Size)
+	assert.NotContains(t, headers, "loop/", "a link to a parent directory is not followed")
+}
+
+func TestArchiveFollowsSymlinksToTheSameDirectory(t *testing.T) {
+	root := symlinkFixture(t)
+	require.NoError(t, os.Symlink("sub", filepath.Join(root, "dirlink2")))
+	require.NoError(t, os.Symlink("..", filepath.Join(root, "sub", "up")))
+	d := &Deployment{cwd: root, followSymlinks: true}
+	require.NoError(t, d.Tar())
+	headers := tarEntries(t, d)
+
+	assert.Contains(t, headers, "dirlink/file.txt")
+	assert.Contains(t, headers, "dirlink2/file.txt", "a directory linked twice is archived under both links")
+	assert.NotContains(t, headers, "sub/up/", "a link to a parent of the link is not followed")
+	assert.NotContains(t, headers, "dirlink/up/", "a link to a parent of a followed link is not followed")
+}
+
+func TestZipKeepsSymlinks(t *testing.T) {
+	root := symlinkFixture(t)
+	require.NoError(t, os.WriteFile(filepath.Join(root, "blaxel.toml"), []byte("name = \"my-agent\"\ntype = \"agent\"\n"), 0644))
+	core.ResetConfig()
+	core.ReadConfigToml("", false)
+
+	d := &Deployment{cwd: root}
+	require.NoError(t, d.Zip())
+	zipReader, err := zip.OpenReader(d.archive.Name())
+	require.NoError(t, err)
+	defer func() { _ = zipReader.Close() }()
+
+	files := map[string]*zip.File{}
+	for _, file := range zipReader.File {
+		files[file.Name] = file
+	}
+	require.Contains(t, files, "link-in")
+	assert.NotZero(t, files["link-in"].Mode()&os.ModeSymlink)
+	content, err := files["link-in"].Open()
+	require.NoError(t, err)
+	target, err := io.ReadAll(content)
+	require.NoError(t, err)
+	assert.Equal(t, "real.txt", string(target))
+	assert.NotContains(t, files, "link-out")
+}
+
+func TestCheckArchiveSize(t *testing.T) {
+	entries := []archiveEntry{
+		{relPath: ".venv"},
+		{relPath: ".venv/lib/torch.so", size: 900},
+		{relPath: ".venv/lib/numpy.so", size: 200},
+		{relPath: "data.csv", size: 300},
+		{relPath: "main.py", size: 10},
+	}
+
+	d := &Deployment{maxArchiveSize: 2000}
+	assert.NoError(t, d.checkArchiveSize(entries, false))
+	d = &Deployment{}
+	assert.NoError(t, d.checkArchiveSize(entries, false), "no limit when zero")
+
+	d = &Deployment{maxArchiveSize: 1000}
+	err := d.checkArchiveSize(entries, false)
+	var configErr *core.ConfigError
+	require.ErrorAs(t, err, &configErr)
+	assert.Contains(t, err.Error(), "add up to 1.38 KB, more than --max-archive-size 1000 B")
+	assert.Regexp(t, `(?s)\.venv/ +1\.07 KB\n +data\.csv +300 B\n +main\.py +10 B`, err.Error())
+	assert.Contains(t, err.Error(), ".blaxelignore")
+
+	err = d.checkArchiveSize(entries, true)
+	assert.Contains(t, err.Error(), "volume template")
+}
+
+func TestZipFailsAboveMaxArchiveSize(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "blaxel.toml"), []byte("name = \"my-app\"\ntype = \"agent\"\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "big.bin"), make([]byte, 4096), 0644))
+
+	originalDir, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(dir))
+	defer func() { _ = os.Chdir(originalDir) }()
+	core.ResetConfig()
+	core.ReadConfigToml("", false)
+
+	d := Deployment{cwd: dir, maxArchiveSize: 1024}
+	err = d.Zip()
+	assert.ErrorContains(t, err, "big.bin")
+	assert.Equal(t, core.ExitCodeConfig, core.ExitCode(err))
+}
+
 func TestDeploymentReadBlaxelToml(t *testing.T) {
 	// Create a temp directory with blaxel.toml
 	tempDir, err := os.MkdirTemp("", "deploy_test")
@@ -801,3 +1154,295 @@ COPY --from=somewhere-else /thing /thing
 		})
 	}
 }
+
+func TestHasRateLimitedResult(t *testing.T) {
+	assert.False(t, hasRateLimitedResult(nil))
+	assert.False(t, hasRateLimitedResult([]ApplyResult{{Result: ResourceOperationResult{Status: "failed"}}}))
+	assert.False(t, hasRateLimitedResult([]ApplyResult{{Result: ResourceOperationResult{Status: "configured"}}}))
+	assert.True(t, hasRateLimitedResult([]ApplyResult{
+		{Result: ResourceOperationResult{Status: "configured"}},
+		{Result: ResourceOperationResult{Status: "failed", RateLimited: true}},
+	}))
+}
+
+func TestRateLimitedResults(t *testing.T) {
+	results := []core.Result{
+		{Kind: "Agent", Metadata: map[string]interface{}{"name": "a"}},
+		{Kind: "Function", Metadata: map[string]interface{}{"name": "b"}},
+		{Kind: "Agent", Metadata: map[string]interface{}{"name": "c"}},
+	}
+	applyResults := []ApplyResult{
+		{Kind: "Agent", Name: "a", Result: ResourceOperationResult{Status: "configured"}},
+		{Kind: "Function", Name: "b", Result: ResourceOperationResult{Status: "failed", RateLimited: true}},
+		{Kind: "Agent", Name: "c", Result: ResourceOperationResult{Status: "failed"}},
+	}
+
+	retry := rateLimitedResults(results, applyResults)
+	require.Len(t, retry, 1)
+	assert.Equal(t, "Function", retry[0].Kind)
+
+	merged := mergeApplyResults(applyResults, []ApplyResult{
+		{Kind: "Function", Name: "b", Result: ResourceOperationResult{Status: "configured"}},
+	})
+	assert.Equal(t, "configured", merged[0].Result.Status)
+	assert.Equal(t, "configured", merged[1].Result.Status)
+	assert.Equal(t, "failed", merged[2].Result.Status)
+	assert.False(t, hasRateLimitedResult(merged))
+	assert.True(t, applyResults[1].Result.RateLimited, "the results passed in are not changed")
+}
+
+func TestCheckDeployFlagConflicts(t *testing.T) {
+	for _, conflict := range deployFlagConflicts {
+		t.Run(conflict.a+"+"+conflict.b, func(t *testing.T) {
+			cmd := DeployCmd()
+			args := []string{}
+			for _, name := range []string{conflict.a, conflict.b} {
+				if cmd.Flags().Lookup(name).Value.Type() == "bool" {
+					args = append(args, "--"+name)
+				} else {
+					args = append(args, "--"+name, "value")
+				}
+			}
+			require.NoError(t, cmd.ParseFlags(args))
+
+			err := checkDeployFlagConflicts(cmd)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "--"+conflict.a+" and --"+conflict.b+" cannot be used together")
+		})
+	}
+
+	t.Run("compatible flags", func(t *testing.T) {
+		cmd := DeployCmd()
+		require.NoError(t, cmd.ParseFlags([]string{"-d", "packages/agent", "--recursive=false", "--skip-build", "--dryrun"}))
+		assert.NoError(t, checkDeployFlagConflicts(cmd))
+	})
+}
+
+func TestValidateDeployResourceType(t *testing.T) {
+	for _, resourceType := range []string{"", "agent", "function", "job", "sandbox", "application", "volume-template", "volumetemplate", "vt"} {
+		assert.NoError(t, validateDeployResourceType(resourceType), resourceType)
+	}
+
+	err := validateDeployResourceType("agnet")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unsupported resource type "agnet"`)
+	assert.Contains(t, err.Error(), "--type")
+
+	cmd := DeployCmd()
+	require.NoError(t, cmd.ParseFlags([]string{"--resource-type", "job"}))
+	assert.Equal(t, "job", cmd.Flags().Lookup("type").Value.String())
+}
+
+func TestWaitForURL(t *testing.T) {
+	t.Run("retries gateway errors until the resource answers", func(t *testing.T) {
+		responses := []int{502, 503, 404}
+		calls := 0
+		status, err := waitForURL(func() (int, error) {
+			calls++
+			return responses[calls-1], nil
+		}, 5, 0)
+		require.NoError(t, err)
+		assert.Equal(t, 404, status)
+		assert.Equal(t, 3, calls)
+	})
+
+	t.Run("fails after the last attempt", func(t *testing.T) {
+		calls := 0
+		_, err := waitForURL(func() (int, error) {
+			calls++
+			return 0, errors.New("connection refused")
+		}, 3, 0)
+		assert.EqualError(t, err, "no response after 3 attempts: connection refused")
+		assert.Equal(t, 3, calls)
+	})
+
+	t.Run("reports the last gateway status", func(t *testing.T) {
+		_, err := waitForURL(func() (int, error) { return 504, nil }, 2, 0)
+		assert.EqualError(t, err, "no response after 2 attempts: status 504")
+	})
+}
+
+func TestWaitForHealthy(t *testing.T) {
+	t.Run("retries until a 2xx status", func(t *testing.T) {
+		responses := []int{502, 404, 204}
+		var attempts []int
+		status, err := waitForHealthy(func() (int, error) {
+			return responses[len(attempts)], nil
+		}, time.Minute, 0, func(attempt, status int, err error) {
+			attempts = append(attempts, attempt)
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 204, status)
+		assert.Equal(t, []int{1, 2, 3}, attempts)
+	})
+
+	t.Run("fails once the timeout is over", func(t *testing.T) {
+		calls := 0
+		_, err := waitForHealthy(func() (int, error) {
+			calls++
+			return 0, errors.New("connection refused")
+		}, 0, 0, func(int, int, error) {})
+		assert.EqualError(t, err, "still failing after 0s and 1 attempts: connection refused")
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("reports the last status", func(t *testing.T) {
+		_, err := waitForHealthy(func() (int, error) { return 500, nil }, 0, 0, func(int, int, error) {})
+		assert.EqualError(t, err, "still failing after 0s and 1 attempts: status 500")
+	})
+}
+
+func TestParseDeployWaitStatus(t *testing.T) {
+	for input, want := range map[string]string{"DEPLOYED": "DEPLOYED", "building": "BUILDING", " Deploying ": "DEPLOYING", "uploading": "UPLOADING"} {
+		got, err := parseDeployWaitStatus(input)
+		require.NoError(t, err, input)
+		assert.Equal(t, want, got)
+	}
+
+	_, err := parseDeployWaitStatus("ready")
+	assert.ErrorContains(t, err, "expected one of UPLOADING, BUILDING, DEPLOYING, DEPLOYED")
+}
+
+func TestDeployStatusesFrom(t *testing.T) {
+	assert.Equal(t, []string{"BUILDING", "DEPLOYING", "DEPLOYED"}, deployStatusesFrom("BUILDING"), "a skipped stage still counts")
+	assert.Equal(t, []string{"DEPLOYED"}, deployStatusesFrom("DEPLOYED"))
+	assert.Empty(t, deployStatusesFrom("FAILED"))
+
+	d := &Deployment{}
+	assert.Equal(t, "DEPLOYED", d.waitStatus())
+}
+
+func TestWriteManifest(t *testing.T) {
+	defer core.ResetSecrets()
+	core.ResetSecrets()
+	core.LoadCommandSecrets([]string{"API_KEY=sk-abc123"})
+
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, ".blaxel"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".blaxel", "model.yaml"), []byte("---\napiVersion: blaxel.ai/v1alpha1\nkind: Model\nmetadata:\n  name: gpt\nspec:\n  token: ${secrets.OPENAI_KEY}\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".blaxel", "notes.txt"), []byte("ignored"), 0644))
+
+	d := &Deployment{
+		cwd: dir,
+		blaxelDeployments: []core.Result{{
+			ApiVersion: "blaxel.ai/v1alpha1",
+			Kind:       "Agent",
+			Metadata: map[string]interface{}{
+				"name":   "my-agent",
+				"labels": map[string]interface{}{"x-blaxel-auto-generated": "true"},
+			},
+			Spec: map[string]interface{}{
+				"runtime": map[string]interface{}{
+					"envs": []core.Env{{Name: "API_KEY", Value: "sk-abc123"}, {Name: "MODE", Value: "prod"}},
+				},
+			},
+		}},
+	}
+	path := filepath.Join(dir, "manifest.yaml")
+	require.NoError(t, d.WriteManifest(path))
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	manifest := string(content)
+	assert.NotContains(t, manifest, "sk-abc123")
+	assert.Contains(t, manifest, "value: ${secrets.API_KEY}")
+	assert.Contains(t, manifest, "value: prod")
+	assert.Contains(t, manifest, `x-blaxel-auto-generated: "true"`)
+	assert.Contains(t, manifest, "---\napiVersion: blaxel.ai/v1alpha1\nkind: Model")
+	assert.Contains(t, manifest, "token: ${secrets.OPENAI_KEY}", ".blaxel files are copied as they are")
+	assert.NotContains(t, manifest, "ignored")
+}
+
+func TestWriteManifestWithoutBlaxelDir(t *testing.T) {
+	dir := t.TempDir()
+	d := &Deployment{cwd: dir, blaxelDeployments: []core.Result{{ApiVersion: "blaxel.ai/v1alpha1", Kind: "Sandbox", Metadata: map[string]interface{}{"name": "sbx"}}}}
+	path := filepath.Join(dir, "manifest.yaml")
+	require.NoError(t, d.WriteManifest(path))
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "kind: Sandbox")
+	assert.NotContains(t, string(content), "---")
+}
+
+func TestPrintBuildFailure(t *testing.T) {
+	out := captureStderr(t, func() {
+		printBuildFailure("agent", "my-agent", []string{"Step 3/5 : RUN pip install -r requirements.txt", "ERROR: No matching distribution found for nope==1.0"})
+	})
+	assert.Contains(t, out, "The deployment of agent my-agent failed, last 2 lines of its build logs:")
+	assert.Contains(t, out, "  ERROR: No matching distribution found for nope==1.0")
+
+	out = captureStderr(t, func() { printBuildFailure("agent", "my-agent", nil) })
+	assert.Contains(t, out, "bl logs agent my-agent")
+}
+
+func TestPackageFilter(t *testing.T) {
+	assert.True(t, packageFilter{}.empty())
+
+	only := packageFilter{only: []string{"agent", "billing-*"}}
+	assert.True(t, only.matches("root", "agent"))
+	assert.True(t, only.matches("support", "Agent"))
+	assert.True(t, only.matches("billing-tools", "function"))
+	assert.False(t, only.matches("search", "function"))
+
+	exclude := packageFilter{only: []string{"agent"}, exclude: []string{"exp-*", "root"}}
+	assert.True(t, exclude.matches("support", "agent"))
+	assert.False(t, exclude.matches("exp-planner", "agent"))
+	assert.False(t, exclude.matches("root", "agent"))
+	assert.Equal(t, "--only agent --exclude exp-*,root", exclude.String())
+
+	assert.True(t, packageFilter{exclude: []string{"job"}}.matches("search", "function"))
+
+	err := packageFilter{only: []string{"billing-["}}.validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `invalid project pattern "billing-["`)
+}
+
+func TestPackageDeployArgs(t *testing.T) {
+	args := []string{"--strict", "--gzip", "--reproducible"}
+	assert.Equal(t, args, packageDeployArgs(args, "volume-template"))
+	assert.Equal(t, []string{"--strict", "--reproducible"}, packageDeployArgs(args, "agent"))
+	assert.Equal(t, []string{"--strict", "--gzip", "--reproducible"}, args, "the shared args must not be modified")
+}
+
+func TestGetDeployCommandsTypes(t *testing.T) {
+	originalDir, err := os.Getwd()
+	require.NoError(t, err)
+	defer func() { require.NoError(t, os.Chdir(originalDir)) }()
+
+	tempDir := t.TempDir()
+	tomlContent := `name = "support"
+type = "agent"
+
+[function.search]
+path = "functions/search"
+
+[job.reindex]
+path = "jobs/reindex"
+`
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "blaxel.toml"), []byte(tomlContent), 0644))
+	require.NoError(t, os.Chdir(tempDir))
+	core.ResetConfig()
+	core.ReadConfigToml("", false)
+	defer core.ResetConfig()
+
+	commands, types, err := getDeployCommands(true, "", 1, nil)
+	require.NoError(t, err)
+	require.Len(t, types, len(commands))
+	byName := map[string]string{}
+	for i, command := range commands {
+		byName[command.Name] = types[i]
+	}
+	assert.Equal(t, map[string]string{"root": "agent", "search": "function", "reindex": "job"}, byName)
+
+	_, err = deployPackage(true, "", 1, nil, packageFilter{only: []string{"model"}}, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no project matches --only model")
+	var configErr *core.ConfigError
+	assert.True(t, errors.As(err, &configErr))
+
+	warned := false
+	deployed, err := deployPackage(true, "", 1, nil, packageFilter{exclude: []string{"function", "job"}}, func() { warned = true })
+	require.NoError(t, err)
+	assert.False(t, deployed, "the root project alone is deployed in this process")
+	assert.False(t, warned, "flags ignored for several packages apply to a single one")
+}
@@ -0,0 +1,109 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/blaxel-ai/toolkit/cli/core"
+	"github.com/blaxel-ai/toolkit/cli/server"
+)
+
+// findConfigDir returns the nearest directory holding a blaxel.toml from start
+// upward, "" when there is none. The search stops at the top of the git
+// repository start is in, like git looks for its own directory.
+func findConfigDir(start string) string {
+	for dir := start; ; {
+		if _, err := os.Stat(filepath.Join(dir, "blaxel.toml")); err == nil {
+			return dir
+		}
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+			return ""
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// declaredPackage returns the name and directory of the package declared in
+// the blaxel.toml of root that contains dir, false when there is none
+func declaredPackage(root, dir string, config core.Config) (string, core.Package, bool) {
+	packages := server.GetAllPackages(config)
+	names := make([]string, 0, len(packages))
+	for name := range packages {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		pkg := packages[name]
+		pkgDir := filepath.Join(root, pkg.Path)
+		if pkgDir == root {
+			continue
+		}
+		if rel, err := filepath.Rel(pkgDir, dir); err == nil && !startsWithParent(rel) {
+			return name, pkg, true
+		}
+	}
+	return "", core.Package{}, false
+}
+
+// startsWithParent reports whether the relative path rel leaves its base
+func startsWithParent(rel string) bool {
+	return rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// walkUpToProject moves to the project to deploy when the current directory
+// has no blaxel.toml: the nearest directory above with one, or the package it
+// declares that contains the current directory. It returns the type of that
+// package and the directory of the blaxel.toml declaring it, "" otherwise.
+func walkUpToProject() (string, string, error) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return "", "", fmt.Errorf("failed to get current working directory: %w", err)
+	}
+	if _, err := os.Stat(filepath.Join(cwd, "blaxel.toml")); err == nil {
+		return "", "", nil
+	}
+	root := findConfigDir(cwd)
+	if root == "" {
+		return "", "", nil
+	}
+
+	target, pkgType, pkgRoot := root, "", ""
+	var config core.Config
+	if _, err := toml.DecodeFile(filepath.Join(root, "blaxel.toml"), &config); err != nil {
+		return "", "", &core.ConfigError{Err: fmt.Errorf("error reading %s: %w", filepath.Join(root, "blaxel.toml"), err)}
+	}
+	if name, pkg, ok := declaredPackage(root, cwd, config); ok {
+		target, pkgType, pkgRoot = filepath.Join(root, pkg.Path), pkg.Type, root
+		core.PrintInfo(fmt.Sprintf("Deploying package %s declared in %s", name, filepath.Join(root, "blaxel.toml")))
+	} else {
+		core.PrintInfo(fmt.Sprintf("Deploying the project of %s", filepath.Join(root, "blaxel.toml")))
+	}
+	if err := os.Chdir(target); err != nil {
+		return "", "", fmt.Errorf("failed to move to %s: %w", target, err)
+	}
+	return pkgType, pkgRoot, nil
+}
+
+// absPaths makes each non-empty path of paths absolute, so they still name
+// the same files once walkUpToProject changed the current directory
+func absPaths(paths ...*string) error {
+	for _, path := range paths {
+		if *path == "" {
+			continue
+		}
+		abs, err := filepath.Abs(*path)
+		if err != nil {
+			return err
+		}
+		*path = abs
+	}
+	return nil
+}
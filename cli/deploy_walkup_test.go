@@ -0,0 +1,81 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindConfigDir(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(root, ".git"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "blaxel.toml"), []byte("type = \"agent\"\n"), 0644))
+	src := filepath.Join(root, "agent", "src")
+	require.NoError(t, os.MkdirAll(src, 0755))
+
+	assert.Equal(t, root, findConfigDir(src))
+	assert.Equal(t, root, findConfigDir(root))
+
+	require.NoError(t, os.Remove(filepath.Join(root, "blaxel.toml")))
+	assert.Empty(t, findConfigDir(src), "the search stops at the top of the repository")
+}
+
+func TestWalkUpToProject(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(root, ".git"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "blaxel.toml"), []byte(`
+type = "agent"
+
+[function.search]
+path = "packages/search"
+`), 0644))
+	for _, dir := range []string{"packages/search/src", "packages/searching", "scripts"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(root, dir), 0755))
+	}
+	root, err := filepath.EvalSymlinks(root)
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		from     string
+		wantDir  string
+		wantType string
+		wantRoot bool
+	}{
+		{from: "packages/search/src", wantDir: "packages/search", wantType: "function", wantRoot: true},
+		{from: "packages/search", wantDir: "packages/search", wantType: "function", wantRoot: true},
+		{from: "packages/searching", wantDir: "."},
+		{from: "scripts", wantDir: "."},
+		{from: ".", wantDir: "."},
+	} {
+		t.Run(tc.from, func(t *testing.T) {
+			t.Chdir(filepath.Join(root, tc.from))
+			pkgType, pkgRoot, err := walkUpToProject()
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantType, pkgType)
+			if tc.wantRoot {
+				assert.Equal(t, root, pkgRoot)
+			} else {
+				assert.Empty(t, pkgRoot)
+			}
+			cwd, err := os.Getwd()
+			require.NoError(t, err)
+			assert.Equal(t, filepath.Join(root, tc.wantDir), cwd)
+		})
+	}
+}
+
+func TestAbsPaths(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+	dir, err := os.Getwd()
+	require.NoError(t, err)
+
+	manifest, logDir, unset := "out/manifest.yaml", "/var/log/bl", ""
+	require.NoError(t, absPaths(&manifest, &logDir, &unset))
+	assert.Equal(t, filepath.Join(dir, "out", "manifest.yaml"), manifest)
+	assert.Equal(t, "/var/log/bl", logDir)
+	assert.Empty(t, unset)
+}
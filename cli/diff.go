@@ -0,0 +1,261 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	blaxel "github.com/blaxel-ai/sdk-go"
+	"github.com/blaxel-ai/toolkit/cli/core"
+	"github.com/fatih/color"
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+func init() {
+	core.RegisterCommand("diff", func() *cobra.Command {
+		return DiffCmd()
+	})
+}
+
+func DiffCmd() *cobra.Command {
+	var filePath string
+	var recursive bool
+	var envFiles []string
+	var commandSecrets []string
+	var exitCode int
+	cmd := &cobra.Command{
+		Use:   "diff",
+		Short: "Show what apply would change",
+		Long: `Compare the resources of YAML files with what is deployed, like
+'kubectl diff', before running 'bl apply' with the same files.
+
+For each resource, the spec of the file is compared with the spec of the
+deployed resource and printed as a unified diff. Resources that do not exist
+yet show as fully new. Fields the file does not set are left out of the
+comparison, since the platform fills them with defaults. Values of the loaded
+secrets are redacted.
+
+The command exits with code 0 when nothing differs and --exit-code (1 by
+default) when something does, so CI can gate on it. Pass --exit-code 0 to
+always succeed. Errors exit with a code of their own: 2 for an invalid file or
+flag, 3 for a network error and 4 for an error returned by the Blaxel API, so
+--exit-code cannot be set between 2 and 7.`,
+		Example: `  # Preview the changes of a file
+  bl diff -f agent.yaml
+
+  # Preview the changes of a directory, recursively
+  bl diff -f ./resources/ -R
+
+  # Preview the manifest saved by a deploy
+  bl diff -f deploy.yaml -e .env.production`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if exitCode < 0 || (exitCode > core.ExitCodeError && exitCode <= core.ExitCodeTimeout) {
+				err := fmt.Errorf("--exit-code must be 0, 1 or above %d, lower codes report errors", core.ExitCodeTimeout)
+				core.PrintError("Diff", err)
+				core.ExitWithError(&core.ConfigError{Err: err})
+			}
+
+			core.LoadCommandSecrets(commandSecrets)
+			core.ReadSecrets("", envFiles)
+
+			results, err := core.GetResults("apply", filePath, recursive)
+			if err != nil {
+				err = fmt.Errorf("error getting results: %w", err)
+				core.PrintError("Diff", err)
+				core.ExitWithError(&core.ConfigError{Err: err})
+			}
+
+			changed := 0
+			for _, result := range results {
+				diff, err := diffResource(result)
+				if err != nil {
+					core.PrintError("Diff", err)
+					core.ExitWithError(err)
+				}
+				if diff != "" {
+					changed++
+					fmt.Print(colorizeDiff(core.RedactSecrets(diff)))
+				}
+			}
+
+			if changed == 0 {
+				core.PrintDiagnostic(fmt.Sprintf("No differences in %d resource(s)", len(results)))
+				return
+			}
+			core.PrintDiagnostic(fmt.Sprintf("%d of %d resource(s) differ", changed, len(results)))
+			if exitCode != 0 {
+				core.Exit(exitCode)
+			}
+		},
+	}
+
+	cmd.Flags().StringVarP(&filePath, "filename", "f", "", "Path to YAML file or directory to compare, - for stdin")
+	cmd.Flags().BoolVarP(&recursive, "recursive", "R", false, "Process the directory used in -f, --filename recursively")
+	cmd.Flags().StringSliceVarP(&envFiles, "env-file", "e", []string{".env"}, "Environment file to load")
+	cmd.Flags().StringSliceVarP(&commandSecrets, "secrets", "s", []string{}, "Secrets to substitute in the files")
+	cmd.Flags().IntVar(&exitCode, "exit-code", 1, "Exit code when differences are found")
+	err := cmd.MarkFlagRequired("filename")
+	if err != nil {
+		core.PrintError("Diff", err)
+		core.ExitWithError(err)
+	}
+
+	return cmd
+}
+
+// diffResource returns the unified diff between the spec of the deployed
+// resource and the spec of result, or "" when they match
+func diffResource(result core.Result) (string, error) {
+	var resource *core.Resource
+	for _, r := range core.GetResources() {
+		if r.Kind == result.Kind {
+			resource = r
+			break
+		}
+	}
+	if resource == nil {
+		return "", &core.ConfigError{Err: fmt.Errorf("unknown kind %q", result.Kind)}
+	}
+	metadata, _ := result.Metadata.(map[string]interface{})
+	name, _ := metadata["name"].(string)
+	if name == "" {
+		return "", &core.ConfigError{Err: fmt.Errorf("%s without metadata.name", result.Kind)}
+	}
+	if resource.Get == nil || resource.ParentField != "" {
+		core.PrintWarning(fmt.Sprintf("%s:%s cannot be fetched on its own, skipped", result.Kind, name))
+		return "", nil
+	}
+
+	desired, err := normalizeSpec(result.Spec)
+	if err != nil {
+		return "", &core.ConfigError{Err: fmt.Errorf("resource %s:%s: %w", result.Kind, name, err)}
+	}
+
+	fromFile := fmt.Sprintf("live/%s/%s", result.Kind, name)
+	live, err := GetExec(resource, name)
+	var apiErr *blaxel.Error
+	switch {
+	case errors.As(err, &apiErr) && apiErr.StatusCode == 404:
+		fromFile = "/dev/null"
+		live = nil
+	case err != nil:
+		// An unclassified failure must not exit with the code of differences found
+		if core.ExitCode(err) == core.ExitCodeError {
+			err = &core.APIError{Err: err}
+		}
+		return "", err
+	}
+	var current interface{}
+	if liveMap, ok := live.(map[string]interface{}); ok {
+		current = pruneToDesired(liveMap["spec"], desired)
+	}
+
+	diff, err := unifiedSpecDiff(current, desired, fromFile, fmt.Sprintf("manifest/%s/%s", result.Kind, name))
+	if err != nil {
+		return "", &core.ConfigError{Err: fmt.Errorf("resource %s:%s: %w", result.Kind, name, err)}
+	}
+	return diff, nil
+}
+
+// normalizeSpec converts a spec read from YAML to the JSON types of a
+// resource fetched from the API, so both sides compare equal
+func normalizeSpec(spec interface{}) (interface{}, error) {
+	if spec == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(spec)
+	if err != nil {
+		return nil, fmt.Errorf("invalid spec: %w", err)
+	}
+	var normalized interface{}
+	if err := json.Unmarshal(data, &normalized); err != nil {
+		return nil, fmt.Errorf("invalid spec: %w", err)
+	}
+	return normalized, nil
+}
+
+// pruneToDesired drops from the map fields of current the keys desired does
+// not set, so defaults filled by the platform do not show as changes. Array
+// items are pruned against the desired item at the same index.
+func pruneToDesired(current, desired interface{}) interface{} {
+	switch currentValue := current.(type) {
+	case map[string]interface{}:
+		desiredMap, ok := desired.(map[string]interface{})
+		if !ok {
+			return current
+		}
+		pruned := map[string]interface{}{}
+		for key, value := range currentValue {
+			if want, ok := desiredMap[key]; ok {
+				pruned[key] = pruneToDesired(value, want)
+			}
+		}
+		return pruned
+	case []interface{}:
+		desiredItems, ok := desired.([]interface{})
+		if !ok {
+			return current
+		}
+		pruned := make([]interface{}, len(currentValue))
+		for i, item := range currentValue {
+			if i < len(desiredItems) {
+				pruned[i] = pruneToDesired(item, desiredItems[i])
+			} else {
+				pruned[i] = item
+			}
+		}
+		return pruned
+	default:
+		return current
+	}
+}
+
+// unifiedSpecDiff renders both specs as YAML and returns their unified diff
+func unifiedSpecDiff(current, desired interface{}, fromFile, toFile string) (string, error) {
+	render := func(spec interface{}) (string, error) {
+		if spec == nil {
+			return "", nil
+		}
+		data, err := yaml.Marshal(spec)
+		return string(data), err
+	}
+	a, err := render(current)
+	if err != nil {
+		return "", err
+	}
+	b, err := render(desired)
+	if err != nil {
+		return "", err
+	}
+	if a == b {
+		return "", nil
+	}
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(a),
+		B:        difflib.SplitLines(b),
+		FromFile: fromFile,
+		ToFile:   toFile,
+		Context:  3,
+	})
+}
+
+// colorizeDiff colors the added and removed lines of a unified diff
+func colorizeDiff(diff string) string {
+	lines := strings.Split(diff, "\n")
+	for i, line := range lines {
+		switch {
+		case strings.HasPrefix(line, "+++"), strings.HasPrefix(line, "---"):
+			lines[i] = color.New(color.Bold).Sprint(line)
+		case strings.HasPrefix(line, "+"):
+			lines[i] = color.GreenString("%s", line)
+		case strings.HasPrefix(line, "-"):
+			lines[i] = color.RedString("%s", line)
+		case strings.HasPrefix(line, "@@"):
+			lines[i] = color.CyanString("%s", line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,83 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/fatih/color"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeSpec(t *testing.T) {
+	spec, err := normalizeSpec(map[string]interface{}{"runtime": map[string]interface{}{"memory": 4096}})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"runtime": map[string]interface{}{"memory": float64(4096)}}, spec)
+
+	spec, err = normalizeSpec(nil)
+	require.NoError(t, err)
+	assert.Nil(t, spec)
+}
+
+func TestPruneToDesired(t *testing.T) {
+	current := map[string]interface{}{
+		"enabled": true,
+		"runtime": map[string]interface{}{"memory": float64(2048), "generation": "mk3"},
+		"envs":    []interface{}{"a"},
+	}
+	desired := map[string]interface{}{
+		"runtime": map[string]interface{}{"memory": float64(4096)},
+		"envs":    []interface{}{"b"},
+	}
+	assert.Equal(t, map[string]interface{}{
+		"runtime": map[string]interface{}{"memory": float64(2048)},
+		"envs":    []interface{}{"a"},
+	}, pruneToDesired(current, desired))
+	assert.Nil(t, pruneToDesired(nil, desired))
+}
+
+func TestPruneToDesiredArrays(t *testing.T) {
+	current := map[string]interface{}{
+		"triggers": []interface{}{
+			map[string]interface{}{"type": "http", "id": "t-1", "configuration": map[string]interface{}{"path": "/a", "retry": float64(3)}},
+			map[string]interface{}{"type": "schedule", "id": "t-2"},
+		},
+	}
+	desired := map[string]interface{}{
+		"triggers": []interface{}{
+			map[string]interface{}{"type": "http", "configuration": map[string]interface{}{"path": "/a"}},
+		},
+	}
+	// Defaults inside items are dropped, extra items are kept so they show as removed
+	assert.Equal(t, map[string]interface{}{
+		"triggers": []interface{}{
+			map[string]interface{}{"type": "http", "configuration": map[string]interface{}{"path": "/a"}},
+			map[string]interface{}{"type": "schedule", "id": "t-2"},
+		},
+	}, pruneToDesired(current, desired))
+}
+
+func TestUnifiedSpecDiff(t *testing.T) {
+	desired := map[string]interface{}{"runtime": map[string]interface{}{"memory": float64(4096)}}
+
+	diff, err := unifiedSpecDiff(desired, desired, "live/Agent/a", "manifest/Agent/a")
+	require.NoError(t, err)
+	assert.Empty(t, diff)
+
+	current := map[string]interface{}{"runtime": map[string]interface{}{"memory": float64(2048)}}
+	diff, err = unifiedSpecDiff(current, desired, "live/Agent/a", "manifest/Agent/a")
+	require.NoError(t, err)
+	assert.Contains(t, diff, "--- live/Agent/a\n+++ manifest/Agent/a\n")
+	assert.Contains(t, diff, "-    memory: 2048\n+    memory: 4096\n")
+
+	// A resource that does not exist yet is fully new
+	diff, err = unifiedSpecDiff(nil, desired, "/dev/null", "manifest/Agent/a")
+	require.NoError(t, err)
+	assert.Contains(t, diff, "+runtime:\n+    memory: 4096\n")
+}
+
+func TestColorizeDiff(t *testing.T) {
+	defer func(noColor bool) { color.NoColor = noColor }(color.NoColor)
+	color.NoColor = true
+	diff := "--- a\n+++ b\n@@ -1 +1 @@\n-x\n+y\n"
+	assert.Equal(t, diff, colorizeDiff(diff))
+}
@@ -0,0 +1,130 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/blaxel-ai/toolkit/cli/core"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+func init() {
+	core.RegisterCommand("env", func() *cobra.Command {
+		return EnvCmd()
+	})
+}
+
+func EnvCmd() *cobra.Command {
+	var envFiles []string
+	var commandSecrets []string
+	var blEnv string
+	var noEnvLayering bool
+
+	cmd := &cobra.Command{
+		Use:   "env [directory]",
+		Args:  cobra.MaximumNArgs(1),
+		Short: "Show the environment variables a deploy would set",
+		Long: `Show the environment variables 'bl deploy' would set on the resource of a
+project, without deploying anything, along with the source each value comes
+from.
+
+Variables come from the -s flags, the .env files and the [env] table of
+blaxel.toml. When a variable is defined in several places, -s wins over the
+.env files, which win over [env]; the sources that lost are listed as well.
+Without -e, .env.<name> (with --bl-env <name>) and .env.local are loaded over
+.env, as in 'bl deploy'.
+
+Values coming from secrets (-s flags and .env files) are shown as ***, as are
+[env] values resolved from a secret. Names that are not valid shell identifiers,
+which 'bl deploy' rejects, are reported.`,
+		Example: `  # Show the variables of the project in the current directory
+  bl env
+
+  # Show the variables of a project of a monorepo for production
+  bl env ./my-agent --bl-env production
+
+  # Check where a variable comes from
+  bl env -o json | jq '.[] | select(.name == "OPENAI_API_KEY")'`,
+		Run: func(cmd *cobra.Command, args []string) {
+			folder := ""
+			if len(args) == 1 {
+				folder = args[0]
+			}
+			if cmd.Flags().Changed("env-file") && noEnvLayering {
+				err := &core.ConfigError{Err: fmt.Errorf("--env-file and --no-env-layering cannot be used together: the files passed with -e are loaded as they are, without layering")}
+				core.PrintError("Env", err)
+				core.ExitWithError(err)
+			}
+
+			core.SetConfigEnvironment(blEnv)
+			core.SetEnvLayering(!noEnvLayering)
+			core.LoadCommandSecrets(commandSecrets)
+			core.ReadSecrets(folder, envFiles)
+			if folder != "" {
+				core.ReadSecrets("", envFiles)
+			}
+			core.ReadConfigToml(folder, false)
+
+			envs := core.ResolveEnvs()
+			for _, env := range envs {
+				if err := core.ValidateEnvNames([]core.Env{{Name: env.Name}}); err != nil {
+					core.PrintWarning(fmt.Sprintf("%s, 'bl deploy' would fail", err))
+				}
+			}
+			printResolvedEnvs(maskResolvedEnvs(envs))
+		},
+	}
+
+	cmd.Flags().StringSliceVarP(&envFiles, "env-file", "e", []string{".env"}, "Environment file to load")
+	cmd.Flags().StringSliceVarP(&commandSecrets, "secrets", "s", []string{}, "Secrets to deploy")
+	cmd.Flags().StringVar(&blEnv, "bl-env", "", "Environment whose [env.<name>] blaxel.toml sections are merged over the base config (e.g. production)")
+	cmd.Flags().BoolVar(&noEnvLayering, "no-env-layering", false, "Load only .env, not .env.<bl-env> and .env.local over it")
+	return cmd
+}
+
+// maskResolvedEnvs hides the values coming from secrets
+func maskResolvedEnvs(envs []core.ResolvedEnv) []core.ResolvedEnv {
+	masked := make([]core.ResolvedEnv, 0, len(envs))
+	for _, env := range envs {
+		if env.IsSecret() {
+			env.Value = "***"
+		} else {
+			env.Value = core.RedactSecrets(env.Value)
+		}
+		masked = append(masked, env)
+	}
+	return masked
+}
+
+func printResolvedEnvs(envs []core.ResolvedEnv) {
+	switch core.GetOutputFormat() {
+	case "json":
+		data, _ := json.MarshalIndent(envs, "", "  ")
+		fmt.Println(string(data))
+		return
+	case "yaml":
+		data, _ := yaml.Marshal(envs)
+		fmt.Print(string(data))
+		return
+	}
+
+	if len(envs) == 0 {
+		core.PrintInfo("No environment variable would be set")
+		return
+	}
+	nameWidth, valueWidth := len("NAME"), len("VALUE")
+	for _, env := range envs {
+		nameWidth = max(nameWidth, len(env.Name))
+		valueWidth = max(valueWidth, len(env.Value))
+	}
+	fmt.Printf("%-*s  %-*s  %s\n", nameWidth, "NAME", valueWidth, "VALUE", "SOURCE")
+	for _, env := range envs {
+		source := env.Source
+		if len(env.Overridden) > 0 {
+			source += fmt.Sprintf(" (overrides %s)", strings.Join(env.Overridden, ", "))
+		}
+		fmt.Printf("%-*s  %-*s  %s\n", nameWidth, env.Name, valueWidth, env.Value, source)
+	}
+}
@@ -0,0 +1,29 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/blaxel-ai/toolkit/cli/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMaskResolvedEnvs(t *testing.T) {
+	core.ResetSecrets()
+	core.LoadCommandSecrets([]string{"OPENAI_API_KEY=sk-live-abc123"})
+	t.Cleanup(func() {
+		core.ResetSecrets()
+		core.SetCommandSecrets(nil)
+	})
+
+	envs := maskResolvedEnvs([]core.ResolvedEnv{
+		{Name: "OPENAI_API_KEY", Value: "sk-live-abc123", Source: "-s flag"},
+		{Name: "PIN", Value: "42", Source: ".env"},
+		{Name: "LLM_KEY", Value: "sk-live-abc123", Source: "[env] in blaxel.toml"},
+		{Name: "REGION", Value: "eu-west-1", Source: "[env] in blaxel.toml"},
+	})
+
+	assert.Equal(t, "***", envs[0].Value)
+	assert.Equal(t, "***", envs[1].Value, "short secret values are masked too")
+	assert.Equal(t, "***", envs[2].Value, "[env] values resolved from a secret are masked")
+	assert.Equal(t, "eu-west-1", envs[3].Value)
+}
@@ -56,10 +56,35 @@ Use -o flag to control output format:
 - json: Machine-readable JSON (for scripting)
 - yaml: YAML format
 - table: Tabular format with columns
+- wide: Table with extra columns such as memory, full image names and
+  creation/update times
+- name: Resource names only, one per line, for piping to other commands
 
 Watch Mode:
 Use --watch to continuously monitor a resource and see updates in real-time.
-Useful for tracking deployment status or watching for changes.
+Useful for tracking deployment status or watching for changes. Works for a
+single resource or a whole list. In a terminal, tables are refreshed in place:
+only changed rows are updated, and status transitions such as
+DEPLOYING → DEPLOYED are highlighted. Use --interval to change how often
+resources are polled (default 2s) and q or Ctrl+C to stop.
+With --until-ready, the watch exits once every watched resource reaches a
+terminal status, and exits with code 5 if any of them failed, like a failed
+deploy.
+Combined with -o json, --watch emits one JSON object per line for each
+observed change, with a type (ADDED, MODIFIED or DELETED) and the resource.
+
+Label Selectors:
+Use -l/--selector to only list resources whose metadata.labels match.
+Supported forms are key=value, key!=value and key (the label exists).
+Several selectors, repeated or comma-separated, must all match. Labels
+are filtered client-side, so every page is fetched.
+
+All Workspaces:
+Use --all-workspaces to query every workspace configured locally (see
+'bl workspaces') at once. Results get a WORKSPACE column, and workspaces
+whose credentials are invalid or that cannot be reached are listed
+separately. Each workspace is given --workspace-timeout (30s by default)
+to answer. Pass a name to find which workspace a resource lives in.
 
 The command can list all resources of a type or get details for a specific one.`,
 		Example: `  # List all agents
@@ -74,9 +99,42 @@ The command can list all resources of a type or get details for a specific one.`
   # Watch agent status in real-time
   bl get agent my-agent --watch
 
+  # Watch all jobs, polling every 5 seconds
+  bl get jobs --watch --interval 5s
+
+  # Wait until every agent is deployed
+  bl get agents --watch --until-ready
+
+  # Stream sandbox changes as JSON events (one per line)
+  bl get sandboxes --watch -o json | jq -c 'select(.type == "MODIFIED")'
+
   # List all resources with table output
   bl get agents -o table
 
+  # List jobs with extra columns (image, memory, timestamps)
+  bl get jobs -o wide
+
+  # List agents owned by the payments team
+  bl get agents -l team=payments
+
+  # Delete every agent labeled env=dev
+  bl get agents -l env=dev -o name | xargs -n1 bl delete agent
+
+  # Combine selectors: production jobs that are not deprecated
+  bl get jobs -l env=prod,deprecated!=true
+
+  # List sandboxes that have an owner label
+  bl get sandboxes -l owner
+
+  # List sandboxes across all your workspaces
+  bl get sandboxes --all-workspaces
+
+  # Find which workspace an agent lives in
+  bl get agent my-agent --all-workspaces
+
+  # Give slow workspaces more time to answer
+  bl get sandboxes --all-workspaces --workspace-timeout 2m
+
   # Get MCP servers (also called functions)
   bl get functions
   bl get mcp
@@ -144,6 +202,8 @@ The command can list all resources of a type or get details for a specific one.`
   bl get agents -o json | jq 'group_by(.status) | map({status: .[0].status, count: length})'`,
 	}
 	var watch bool
+	var watchInterval time.Duration
+	var untilReady bool
 	resources := core.GetResources()
 	for _, resource := range resources {
 		aliases := []string{resource.Singular, resource.Short}
@@ -166,6 +226,9 @@ The command can list all resources of a type or get details for a specific one.`
 		var pageLimit int
 		var pageCursor string
 		var fetchAll bool
+		var allWorkspaces bool
+		var workspaceTimeout time.Duration
+		var selectors []string
 
 		subcmd := &cobra.Command{
 			Use:               resource.Plural,
@@ -173,6 +236,12 @@ The command can list all resources of a type or get details for a specific one.`
 			Short:             fmt.Sprintf("List all %s or get details of a specific one", resource.Plural),
 			ValidArgsFunction: GetResourceValidArgsFunction(resourceKind),
 			Run: func(cmd *cobra.Command, args []string) {
+				selector, err := core.ParseLabelSelector(selectors)
+				if err != nil {
+					core.PrintError("Get", err)
+					core.ExitWithError(err)
+				}
+
 				// Check if this is a nested resource request
 				isNestedResource := false
 				var nestedResourceFn func()
@@ -205,9 +274,58 @@ The command can list all resources of a type or get details for a specific one.`
 					}
 				}
 
+				if !selector.Empty() && (isNestedResource || (len(args) > 0 && !allWorkspaces)) {
+					err := fmt.Errorf("--selector can only be used when listing %s", resource.Plural)
+					core.PrintError("Get", err)
+					core.ExitWithError(err)
+				}
+
+				if allWorkspaces {
+					if watch || isNestedResource || len(args) > 1 {
+						err := fmt.Errorf("--all-workspaces can only be used to list %s or get one by name", resource.Plural)
+						core.PrintError("Get", err)
+						core.ExitWithError(err)
+					}
+					name := ""
+					if len(args) == 1 {
+						name = args[0]
+					}
+					if workspaceTimeout <= 0 {
+						err := fmt.Errorf("--workspace-timeout must be greater than 0")
+						core.PrintError("Get", err)
+						core.ExitWithError(err)
+					}
+					listAllWorkspaces(resource, name, selector, workspaceTimeout)
+					return
+				}
+
 				if watch {
-					seconds := 2
-					duration := time.Duration(seconds) * time.Second
+					if watchInterval <= 0 {
+						err := fmt.Errorf("--interval must be greater than 0")
+						core.PrintError("Get", err)
+						core.ExitWithError(err)
+					}
+					duration := watchInterval
+					seconds := max(int(duration.Seconds()), 1)
+					outputFormat := core.GetOutputFormat()
+
+					// With -o json, emit one JSON event per change instead of re-rendering
+					if outputFormat == "json" && !isNestedResource {
+						runWatchJSONEvents(resource, args, selector, duration, untilReady)
+						return
+					}
+
+					// Tables are refreshed in place, only updating the rows that changed
+					if !isNestedResource && isWatchTableFormat(outputFormat) && term.IsTerminal(int(os.Stdout.Fd())) {
+						runWatchTUI(resource, args, selector, duration, untilReady)
+						return
+					}
+
+					if untilReady {
+						err := fmt.Errorf("--until-ready requires a terminal or -o json")
+						core.PrintError("Get", err)
+						core.ExitWithError(err)
+					}
 
 					// Create a ticker to periodically fetch updates
 					ticker := time.NewTicker(duration)
@@ -226,7 +344,7 @@ The command can list all resources of a type or get details for a specific one.`
 					if isNestedResource && nestedResourceFn != nil {
 						executeNestedResourceWatch(nestedResourceFn, seconds)
 					} else {
-						executeAndDisplayWatch(args, *resource, seconds)
+						executeAndDisplayWatch(args, *resource, selector, seconds)
 					}
 
 					for {
@@ -235,7 +353,7 @@ The command can list all resources of a type or get details for a specific one.`
 							if isNestedResource && nestedResourceFn != nil {
 								executeNestedResourceWatch(nestedResourceFn, seconds)
 							} else {
-								executeAndDisplayWatch(args, *resource, seconds)
+								executeAndDisplayWatch(args, *resource, selector, seconds)
 							}
 						case <-sigChan:
 							fmt.Println("\nStopped watching.")
@@ -252,6 +370,10 @@ The command can list all resources of a type or get details for a specific one.`
 						return
 					}
 
+					if len(args) == 0 && !selector.Empty() {
+						ListFnWithSelector(resource, selector)
+						return
+					}
 					if len(args) == 0 {
 						ListFnPaginated(resource, pageLimit, pageCursor, fetchAll)
 						return
@@ -267,8 +389,12 @@ The command can list all resources of a type or get details for a specific one.`
 			subcmd.Flags().IntVar(&pageLimit, "limit", core.DefaultPageLimit, "Maximum number of items to return (auto-paginates when above 200)")
 			subcmd.Flags().StringVar(&pageCursor, "cursor", "", "Cursor from a previous page to fetch the next page of results")
 			subcmd.Flags().BoolVar(&fetchAll, "all", false, "Fetch all pages (may be slow for large collections)")
+			subcmd.Flags().BoolVar(&allWorkspaces, "all-workspaces", false, "Query every workspace you are logged in to and show which one each resource belongs to")
+			subcmd.Flags().DurationVar(&workspaceTimeout, "workspace-timeout", defaultAllWorkspacesTimeout, "Maximum time to wait for each workspace with --all-workspaces")
 		}
 
+		subcmd.Flags().StringSliceVarP(&selectors, "selector", "l", nil, "Only list resources whose labels match (key=value, key!=value or key). Repeat or separate with commas to require all of them.")
+
 		cmd.AddCommand(subcmd)
 	}
 
@@ -280,6 +406,8 @@ The command can list all resources of a type or get details for a specific one.`
 	cmd.AddCommand(getMCPHubCmd())
 
 	cmd.PersistentFlags().BoolVarP(&watch, "watch", "", false, "After listing/getting the requested object, watch for changes.")
+	cmd.PersistentFlags().DurationVar(&watchInterval, "interval", 2*time.Second, "Poll interval used with --watch")
+	cmd.PersistentFlags().BoolVar(&untilReady, "until-ready", false, "With --watch, exit once every watched resource reaches a terminal status (code 5 if any failed)")
 	return cmd
 }
 
@@ -396,7 +524,7 @@ Output formats:
 
 func printSandboxHubTable(images []blaxel.SandboxGetHubResponse) {
 	tw := table.NewWriter()
-	tw.SetStyle(table.StyleLight)
+	tw.SetStyle(core.TableStyle())
 	tw.AppendHeader(table.Row{"NAME", "IMAGE", "MEMORY (MB)", "DESCRIPTION"})
 	for _, img := range images {
 		name := img.DisplayName
@@ -504,7 +632,7 @@ Output formats:
 
 func printMCPHubTable(definitions []mcpHubDefinition) {
 	tw := table.NewWriter()
-	tw.SetStyle(table.StyleLight)
+	tw.SetStyle(core.TableStyle())
 	tw.AppendHeader(table.Row{"NAME", "INTEGRATION", "DESCRIPTION"})
 	for _, d := range definitions {
 		name := d.DisplayName
@@ -521,7 +649,6 @@ func printMCPHubTable(definitions []mcpHubDefinition) {
 }
 
 func GetFn(resource *core.Resource, name string) {
-	ctx := context.Background()
 	formattedError := fmt.Sprintf("Resource %s:%s error: ", resource.Kind, name)
 
 	if resource.Get == nil {
@@ -531,12 +658,33 @@ func GetFn(resource *core.Resource, name string) {
 		core.ExitWithError(err)
 	}
 
+	res, err := GetExec(resource, name)
+	if err != nil {
+		fmt.Println(err)
+		core.ExitWithError(err)
+	}
+	if res == nil {
+		return
+	}
+
+	core.Output(*resource, []interface{}{res}, core.GetOutputFormat())
+}
+
+// GetExec fetches a single resource by name and returns it as a generic
+// JSON-compatible value. It returns (nil, nil) when the SDK method has no result value.
+func GetExec(resource *core.Resource, name string) (interface{}, error) {
+	ctx := context.Background()
+	formattedError := fmt.Sprintf("Resource %s:%s error: ", resource.Kind, name)
+
+	if resource.Get == nil {
+		hint := nestedResourceHint(resource, "get")
+		return nil, fmt.Errorf("%s'bl get %s <name>' is not supported directly.%s", formattedError, resource.Singular, hint)
+	}
+
 	// Use reflect to call the function
 	funcValue := reflect.ValueOf(resource.Get)
 	if funcValue.Kind() != reflect.Func {
-		err := fmt.Errorf("%s%s", formattedError, "fn is not a valid function")
-		core.PrintError("Get", err)
-		core.ExitWithError(err)
+		return nil, fmt.Errorf("%s%s", formattedError, "fn is not a valid function")
 	}
 
 	// Build arguments: (ctx, name, ...opts)
@@ -565,37 +713,60 @@ func GetFn(resource *core.Resource, name string) {
 
 	// Handle the results based on your needs
 	if len(results) <= 1 {
-		return
+		return nil, nil
 	}
 
 	if err, ok := results[1].Interface().(error); ok && err != nil {
-		fmt.Printf("%s%v\n", formattedError, err)
-		core.ExitWithError(err)
+		return nil, fmt.Errorf("%s%w", formattedError, err)
 	}
 
 	// The new SDK returns typed responses, not *http.Response
 	// Convert result to interface{} for output
 	result := results[0].Interface()
 	if result == nil {
-		err := fmt.Errorf("%s%s", formattedError, "no result returned")
-		fmt.Println(err)
-		core.ExitWithError(err)
+		return nil, fmt.Errorf("%s%s", formattedError, "no result returned")
 	}
 
 	// Convert to JSON and back to interface{} for consistent handling
 	jsonData, err := json.Marshal(result)
 	if err != nil {
-		fmt.Printf("%s%v\n", formattedError, err)
-		core.ExitWithError(err)
+		return nil, fmt.Errorf("%s%v", formattedError, err)
 	}
 
 	var res interface{}
 	if err := json.Unmarshal(jsonData, &res); err != nil {
-		fmt.Printf("%s%v\n", formattedError, err)
+		return nil, fmt.Errorf("%s%v", formattedError, err)
+	}
+
+	return res, nil
+}
+
+// ListFnWithSelector lists every resource whose labels match selector. Labels
+// are filtered client-side, so all pages are fetched.
+func ListFnWithSelector(resource *core.Resource, selector core.LabelSelector) {
+	items, err := ListSelectedExec(resource, selector)
+	if err != nil {
+		fmt.Println(err)
 		core.ExitWithError(err)
 	}
+	core.Output(*resource, items, core.GetOutputFormat())
+}
 
-	core.Output(*resource, []interface{}{res}, core.GetOutputFormat())
+// ListSelectedExec fetches every resource, across all pages, and keeps the ones matching selector
+func ListSelectedExec(resource *core.Resource, selector core.LabelSelector) ([]interface{}, error) {
+	if !resource.Paginated || resource.APIPath == "" {
+		items, err := ListExec(resource)
+		if err != nil {
+			return nil, err
+		}
+		return selector.Filter(items), nil
+	}
+	formattedError := fmt.Sprintf("Resource %s error: ", resource.Kind)
+	items, err := core.ListAllWithClient(context.Background(), core.GetClient(), resource)
+	if err != nil {
+		return nil, fmt.Errorf("%s%v", formattedError, err)
+	}
+	return selector.Filter(items), nil
 }
 
 func ListFn(resource *core.Resource) {
@@ -806,7 +977,7 @@ func executeNestedResourceWatch(fn func(), seconds int) {
 }
 
 // Helper function to execute and display results
-func executeAndDisplayWatch(args []string, resource core.Resource, seconds int) {
+func executeAndDisplayWatch(args []string, resource core.Resource, selector core.LabelSelector, seconds int) {
 	// Create a pipe to capture output
 	r, w, _ := os.Pipe()
 	// Save the original stdout
@@ -815,7 +986,9 @@ func executeAndDisplayWatch(args []string, resource core.Resource, seconds int)
 	os.Stdout = w
 
 	// Execute the resource function
-	if len(args) == 0 {
+	if len(args) == 0 && !selector.Empty() {
+		ListFnWithSelector(&resource, selector)
+	} else if len(args) == 0 {
 		ListFn(&resource)
 	} else if len(args) == 1 {
 		GetFn(&resource, args[0])
@@ -0,0 +1,161 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	blaxel "github.com/blaxel-ai/sdk-go"
+	"github.com/blaxel-ai/sdk-go/option"
+	"github.com/blaxel-ai/toolkit/cli/core"
+)
+
+// defaultAllWorkspacesTimeout bounds how long each workspace is queried with --all-workspaces
+const defaultAllWorkspacesTimeout = 30 * time.Second
+
+// workspaceListResult holds the items listed in one workspace, or why it was skipped
+type workspaceListResult struct {
+	workspace string
+	items     []interface{}
+	skipped   string
+}
+
+// listAllWorkspaces lists resource in every workspace of the local config,
+// keeping only items named name when it is not empty and matching selector.
+// Each workspace is given timeout to answer.
+func listAllWorkspaces(resource *core.Resource, name string, selector core.LabelSelector, timeout time.Duration) {
+	if !resource.Paginated || resource.APIPath == "" {
+		err := fmt.Errorf("'bl get %s' does not support --all-workspaces", resource.Plural)
+		core.PrintError("Get", err)
+		core.ExitWithError(err)
+	}
+
+	cfg, err := blaxel.LoadConfig()
+	if err != nil {
+		core.PrintError("Get", fmt.Errorf("failed to load config: %w", err))
+		core.ExitWithError(err)
+	}
+	workspaces := []string{}
+	for _, ws := range cfg.Workspaces {
+		if ws.Name != "" {
+			workspaces = append(workspaces, ws.Name)
+		}
+	}
+	if len(workspaces) == 0 {
+		err := fmt.Errorf("no workspace configured. Please run 'bl login' first to authenticate")
+		core.PrintError("Get", err)
+		core.ExitWithError(err)
+	}
+
+	results := queryWorkspaces(resource, workspaces, timeout)
+
+	items := []interface{}{}
+	skipped := []workspaceListResult{}
+	for _, result := range results {
+		if result.skipped != "" {
+			skipped = append(skipped, result)
+			continue
+		}
+		for _, item := range result.items {
+			if (name == "" || watchResourceName(item) == name) && selector.Matches(item) {
+				items = append(items, annotateWorkspace(item, result.workspace))
+			}
+		}
+	}
+
+	core.Output(withWorkspaceField(*resource), items, core.GetOutputFormat())
+
+	if len(skipped) > 0 {
+		fmt.Fprintf(os.Stderr, "\nSkipped %d workspace(s):\n", len(skipped))
+		for _, result := range skipped {
+			fmt.Fprintf(os.Stderr, "  - %s: %s\n", result.workspace, result.skipped)
+		}
+	}
+	if name != "" && len(items) == 0 {
+		err := fmt.Errorf("%s %s not found in any workspace", resource.Singular, name)
+		core.PrintError("Get", err)
+		core.ExitWithError(err)
+	}
+}
+
+// queryWorkspaces lists resource concurrently in each workspace. Results keep the order of workspaces.
+func queryWorkspaces(resource *core.Resource, workspaces []string, timeout time.Duration) []workspaceListResult {
+	results := make([]workspaceListResult, len(workspaces))
+	clients := make([]*blaxel.Client, len(workspaces))
+
+	// Clients are created one at a time: creating one initializes the global
+	// SDK environment (dev or prod URLs) for its workspace
+	for i, ws := range workspaces {
+		results[i].workspace = ws
+		credentials, err := blaxel.LoadCredentials(ws)
+		if err != nil || !credentials.IsValid() {
+			results[i].skipped = fmt.Sprintf("invalid credentials, run 'bl login %s'", ws)
+			continue
+		}
+		c, err := blaxel.NewClientFromConfig(ws, append([]option.RequestOption{option.WithWorkspace(ws)}, core.RequestLogOptions()...)...)
+		if err != nil {
+			results[i].skipped = fmt.Sprintf("failed to create client: %v", err)
+			continue
+		}
+		clients[i] = c
+	}
+	blaxel.InitializeEnvironment(core.GetWorkspace())
+
+	var wg sync.WaitGroup
+	for i, c := range clients {
+		if c == nil {
+			continue
+		}
+		wg.Add(1)
+		go func(i int, c *blaxel.Client) {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(context.Background(), timeout)
+			defer cancel()
+			items, err := core.ListAllWithClient(ctx, c, resource)
+			if err != nil {
+				results[i].skipped = workspaceSkipReason(results[i].workspace, err, timeout)
+				return
+			}
+			results[i].items = items
+		}(i, c)
+	}
+	wg.Wait()
+	return results
+}
+
+// workspaceSkipReason describes why listing a workspace failed
+func workspaceSkipReason(workspace string, err error, timeout time.Duration) string {
+	var apiErr *blaxel.Error
+	if errors.As(err, &apiErr) && (apiErr.StatusCode == http.StatusUnauthorized || apiErr.StatusCode == http.StatusForbidden) {
+		return fmt.Sprintf("invalid credentials, run 'bl login %s'", workspace)
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return fmt.Sprintf("timed out after %s", timeout)
+	}
+	return err.Error()
+}
+
+// withWorkspaceField returns resource with a leading WORKSPACE column
+func withWorkspaceField(resource core.Resource) core.Resource {
+	resource.Fields = append([]core.Field{{Key: "WORKSPACE", Value: "metadata.workspace"}}, resource.Fields...)
+	return resource
+}
+
+// annotateWorkspace sets metadata.workspace on item so every output format shows where it comes from
+func annotateWorkspace(item interface{}, workspace string) interface{} {
+	itemMap, ok := item.(map[string]interface{})
+	if !ok {
+		return item
+	}
+	metadata, ok := itemMap["metadata"].(map[string]interface{})
+	if !ok {
+		metadata = map[string]interface{}{}
+		itemMap["metadata"] = metadata
+	}
+	metadata["workspace"] = workspace
+	return itemMap
+}
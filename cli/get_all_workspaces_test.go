@@ -0,0 +1,48 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	blaxel "github.com/blaxel-ai/sdk-go"
+	"github.com/blaxel-ai/toolkit/cli/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnnotateWorkspace(t *testing.T) {
+	item := annotateWorkspace(watchItem("my-agent", "DEPLOYED"), "prod")
+	metadata := item.(map[string]interface{})["metadata"].(map[string]interface{})
+	assert.Equal(t, "prod", metadata["workspace"])
+	assert.Equal(t, "my-agent", metadata["name"])
+
+	// Items without metadata get one
+	item = annotateWorkspace(map[string]interface{}{"status": "DEPLOYED"}, "dev")
+	metadata = item.(map[string]interface{})["metadata"].(map[string]interface{})
+	assert.Equal(t, "dev", metadata["workspace"])
+
+	assert.Equal(t, "raw", annotateWorkspace("raw", "dev"))
+}
+
+func TestWithWorkspaceField(t *testing.T) {
+	resource := core.Resource{Kind: "Agent", Fields: []core.Field{{Key: "NAME", Value: "metadata.name"}}}
+	wide := withWorkspaceField(resource)
+	require.Len(t, wide.Fields, 2)
+	assert.Equal(t, "WORKSPACE", wide.Fields[0].Key)
+	assert.Equal(t, "metadata.workspace", wide.Fields[0].Value)
+	// The original resource is left untouched
+	assert.Len(t, resource.Fields, 1)
+}
+
+func TestWorkspaceSkipReason(t *testing.T) {
+	unauthorized := fmt.Errorf("paginated list agents: %w", &blaxel.Error{StatusCode: http.StatusUnauthorized})
+	assert.Contains(t, workspaceSkipReason("prod", unauthorized, time.Minute), "bl login prod")
+
+	timeout := fmt.Errorf("paginated list agents: %w", context.DeadlineExceeded)
+	assert.Contains(t, workspaceSkipReason("prod", timeout, time.Minute), "timed out after 1m0s")
+
+	assert.Equal(t, "boom", workspaceSkipReason("prod", fmt.Errorf("boom"), time.Minute))
+}
@@ -166,7 +166,7 @@ func getSandboxProcess(sandboxName, processName string) {
 	core.Output(resource, []interface{}{processMap}, outputFormat)
 }
 
-func getSandboxProcessLogs(sandboxName, processName string) {
+func getSandboxProcessLogs(sandboxName, processName, prefix string, tail int) {
 	ctx := context.Background()
 	client := core.GetClient()
 
@@ -221,14 +221,14 @@ func getSandboxProcessLogs(sandboxName, processName string) {
 	} else {
 		// For pretty/default output, just print the logs directly
 		if logs.Logs != "" {
-			fmt.Print(logs.Logs)
+			fmt.Print(prefixLines(prefix, tailLines(logs.Logs, tail)))
 		} else {
 			// Fallback to stdout/stderr if logs field is empty
 			if logs.Stdout != "" {
-				fmt.Print(logs.Stdout)
+				fmt.Print(prefixLines(prefix, tailLines(logs.Stdout, tail)))
 			}
 			if logs.Stderr != "" {
-				fmt.Fprint(os.Stderr, logs.Stderr)
+				fmt.Fprint(os.Stderr, prefixLines(prefix, tailLines(logs.Stderr, tail)))
 			}
 		}
 	}
@@ -264,7 +264,7 @@ func outputProcessData(data interface{}, format string) {
 }
 
 // streamSandboxProcessLogs streams process logs in real-time using SDK's StreamLogs
-func streamSandboxProcessLogs(sandboxName, processName string) {
+func streamSandboxProcessLogs(sandboxName, processName, prefix string) {
 	ctx := context.Background()
 	client := core.GetClient()
 
@@ -282,10 +282,10 @@ func streamSandboxProcessLogs(sandboxName, processName string) {
 	// Start streaming logs using SDK's StreamLogs
 	streamControl := sandboxInstance.Process.StreamLogs(ctx, processName, blaxel.ProcessStreamOptions{
 		OnStdout: func(stdout string) {
-			printWithNewline(stdout)
+			printWithNewline(prefixLines(prefix, stdout))
 		},
 		OnStderr: func(stderr string) {
-			printWithNewlineStderr(stderr)
+			printWithNewlineStderr(prefixLines(prefix, stderr))
 		},
 		OnError: func(err error) {
 			core.PrintError("Stream", err)
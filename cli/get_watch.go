@@ -0,0 +1,227 @@
+package cli
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	blaxel "github.com/blaxel-ai/sdk-go"
+	"github.com/blaxel-ai/toolkit/cli/core"
+)
+
+// Watch event types emitted by `bl get <kind> --watch -o json`
+const (
+	watchEventAdded    = "ADDED"
+	watchEventModified = "MODIFIED"
+	watchEventDeleted  = "DELETED"
+)
+
+// watchMaxBackoff caps the delay between polls after consecutive fetch failures
+const watchMaxBackoff = 30 * time.Second
+
+// watchEvent is a single observed state change, written as one JSON line
+type watchEvent struct {
+	Type     string      `json:"type"`
+	Resource interface{} `json:"resource"`
+}
+
+// watchState remembers the last observed version of each resource, keyed by name
+type watchState map[string]watchEntry
+
+type watchEntry struct {
+	raw      string
+	resource interface{}
+}
+
+// diffWatchState compares the previously observed resources with the current
+// ones and returns the events describing the transition, together with the
+// new state. Events are ordered by resource name for stable output.
+func diffWatchState(prev watchState, items []interface{}) ([]watchEvent, watchState) {
+	next := watchState{}
+	for _, item := range items {
+		name := watchResourceName(item)
+		data, err := json.Marshal(item)
+		if err != nil {
+			continue
+		}
+		next[name] = watchEntry{raw: string(data), resource: item}
+	}
+
+	names := make([]string, 0, len(prev)+len(next))
+	seen := map[string]bool{}
+	for name := range prev {
+		names = append(names, name)
+		seen[name] = true
+	}
+	for name := range next {
+		if !seen[name] {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	events := []watchEvent{}
+	for _, name := range names {
+		before, existed := prev[name]
+		after, exists := next[name]
+		switch {
+		case !existed && exists:
+			events = append(events, watchEvent{Type: watchEventAdded, Resource: after.resource})
+		case existed && !exists:
+			events = append(events, watchEvent{Type: watchEventDeleted, Resource: before.resource})
+		case before.raw != after.raw:
+			events = append(events, watchEvent{Type: watchEventModified, Resource: after.resource})
+		}
+	}
+	return events, next
+}
+
+// watchResourceName returns the name identifying a generic resource across
+// polls: metadata.name, else its id. Items with neither are identified by a
+// hash of their content, so they do not all collapse into one entry; a change
+// to such an item shows as DELETED then ADDED.
+func watchResourceName(item interface{}) string {
+	m, ok := item.(map[string]interface{})
+	if ok {
+		if metadata, ok := m["metadata"].(map[string]interface{}); ok {
+			if name, _ := metadata["name"].(string); name != "" {
+				return name
+			}
+			if id, _ := metadata["id"].(string); id != "" {
+				return id
+			}
+		}
+		if id, _ := m["id"].(string); id != "" {
+			return id
+		}
+	}
+	data, err := json.Marshal(item)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:6])
+}
+
+// fetchWatchItems lists the resources matching selector (or gets the named one) for a watch poll.
+// A missing named resource is reported as an empty set so it yields a DELETED event.
+func fetchWatchItems(resource *core.Resource, args []string, selector core.LabelSelector) ([]interface{}, error) {
+	if len(args) == 0 {
+		if !selector.Empty() {
+			return ListSelectedExec(resource, selector)
+		}
+		return ListExec(resource)
+	}
+	res, err := GetExec(resource, args[0])
+	if err != nil {
+		var apiErr *blaxel.Error
+		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+			return []interface{}{}, nil
+		}
+		return nil, err
+	}
+	if res == nil {
+		return []interface{}{}, nil
+	}
+	return []interface{}{res}, nil
+}
+
+// writeWatchEvents writes one JSON object per line. Each event is written as
+// soon as it is encoded, so consumers reading the stream see every change as
+// soon as it is observed.
+func writeWatchEvents(w io.Writer, events []watchEvent) error {
+	encoder := json.NewEncoder(w)
+	for _, event := range events {
+		if err := encoder.Encode(event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isWatchTableFormat reports whether outputFormat renders resources as a table
+func isWatchTableFormat(outputFormat string) bool {
+	return outputFormat == "" || outputFormat == "table" || outputFormat == "wide"
+}
+
+// watchItemsSettled reports whether items is not empty and all of them reached a terminal status
+func watchItemsSettled(items []interface{}) bool {
+	if len(items) == 0 {
+		return false
+	}
+	for _, item := range items {
+		if !isTerminalWatchStatus(watchItemStatus(item)) {
+			return false
+		}
+	}
+	return true
+}
+
+// watchFailedItems returns the names of the items whose status is a failure
+func watchFailedItems(items []interface{}) []string {
+	names := []string{}
+	for _, item := range items {
+		if containsStatus(watchFailedStatuses, watchItemStatus(item)) {
+			names = append(names, watchResourceName(item))
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// runWatchJSONEvents polls the resource and emits a JSON event per change until
+// interrupted, or with untilReady until every resource reaches a terminal status,
+// exiting with the API error code when one of them failed.
+// Fetch failures are reported on stderr and retried with backoff, keeping the
+// last known state so a reconnect does not replay ADDED events.
+func runWatchJSONEvents(resource *core.Resource, args []string, selector core.LabelSelector, interval time.Duration, untilReady bool) {
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(sigChan)
+
+	state := watchState{}
+	delay := time.Duration(0)
+	failures := 0
+	for {
+		select {
+		case <-sigChan:
+			return
+		case <-time.After(delay):
+		}
+
+		items, err := fetchWatchItems(resource, args, selector)
+		if err != nil {
+			failures++
+			delay = min(interval*time.Duration(1<<min(failures, 4)), watchMaxBackoff)
+			core.PrintWarning(fmt.Sprintf("Watch error (retrying in %s): %v", delay, err))
+			continue
+		}
+		failures = 0
+		delay = interval
+
+		var events []watchEvent
+		events, state = diffWatchState(state, items)
+		if err := writeWatchEvents(os.Stdout, events); err != nil {
+			// The reader went away (e.g. closed pipe), nothing left to do
+			return
+		}
+		if untilReady && watchItemsSettled(items) {
+			if failed := watchFailedItems(items); len(failed) > 0 {
+				err := &core.BuildError{Err: fmt.Errorf("%s failed: %s", resource.Plural, strings.Join(failed, ", "))}
+				core.PrintError("Watch", err)
+				core.ExitWithError(err)
+			}
+			return
+		}
+	}
+}
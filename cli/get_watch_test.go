@@ -0,0 +1,169 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/blaxel-ai/toolkit/cli/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func watchItem(name string, status string) interface{} {
+	return map[string]interface{}{
+		"metadata": map[string]interface{}{"name": name},
+		"status":   status,
+	}
+}
+
+func TestDiffWatchState(t *testing.T) {
+	events, state := diffWatchState(watchState{}, []interface{}{
+		watchItem("b", "DEPLOYING"),
+		watchItem("a", "DEPLOYED"),
+	})
+	require.Len(t, events, 2)
+	assert.Equal(t, watchEventAdded, events[0].Type)
+	assert.Equal(t, "a", watchResourceName(events[0].Resource))
+	assert.Equal(t, watchEventAdded, events[1].Type)
+
+	// No change means no events
+	events, state = diffWatchState(state, []interface{}{
+		watchItem("a", "DEPLOYED"),
+		watchItem("b", "DEPLOYING"),
+	})
+	assert.Empty(t, events)
+
+	events, _ = diffWatchState(state, []interface{}{
+		watchItem("b", "DEPLOYED"),
+		watchItem("c", "DEPLOYING"),
+	})
+	require.Len(t, events, 3)
+	assert.Equal(t, watchEventDeleted, events[0].Type)
+	assert.Equal(t, "a", watchResourceName(events[0].Resource))
+	assert.Equal(t, watchEventModified, events[1].Type)
+	assert.Equal(t, "b", watchResourceName(events[1].Resource))
+	assert.Equal(t, watchEventAdded, events[2].Type)
+	assert.Equal(t, "c", watchResourceName(events[2].Resource))
+}
+
+func TestWatchResourceNameFallbacks(t *testing.T) {
+	assert.Equal(t, "exec-1", watchResourceName(map[string]interface{}{"metadata": map[string]interface{}{"id": "exec-1"}}))
+	assert.Equal(t, "token-1", watchResourceName(map[string]interface{}{"id": "token-1"}))
+
+	first := map[string]interface{}{"status": "DEPLOYED", "spec": map[string]interface{}{"port": float64(80)}}
+	second := map[string]interface{}{"status": "DEPLOYED", "spec": map[string]interface{}{"port": float64(81)}}
+	assert.NotEmpty(t, watchResourceName(first))
+	assert.Equal(t, watchResourceName(first), watchResourceName(map[string]interface{}{"spec": map[string]interface{}{"port": float64(80)}, "status": "DEPLOYED"}), "stable across polls")
+
+	// Unnamed items are kept apart instead of collapsing into one
+	events, _ := diffWatchState(watchState{}, []interface{}{first, second})
+	assert.Len(t, events, 2)
+}
+
+func TestWriteWatchEvents(t *testing.T) {
+	var buf bytes.Buffer
+	err := writeWatchEvents(&buf, []watchEvent{
+		{Type: watchEventAdded, Resource: watchItem("a", "DEPLOYING")},
+		{Type: watchEventModified, Resource: watchItem("a", "DEPLOYED")},
+	})
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 2)
+	var event map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &event))
+	assert.Equal(t, "MODIFIED", event["type"])
+	assert.Equal(t, "DEPLOYED", event["resource"].(map[string]interface{})["status"])
+}
+
+func TestFetchWatchItemsNotFound(t *testing.T) {
+	handlers := map[string]http.HandlerFunc{}
+	server := setupTestServer(t, handlers)
+	defer server.Close()
+	setupTestClient(t, server.URL)
+
+	client := core.GetClient()
+	resource := &core.Resource{Kind: "Agent", Singular: "agent", Get: client.Agents.Get}
+
+	items, err := fetchWatchItems(resource, []string{"missing"}, nil)
+	require.NoError(t, err)
+	assert.Empty(t, items)
+}
+
+func newTestWatchModel(untilReady bool) *watchModel {
+	resource := &core.Resource{
+		Kind:   "Agent",
+		Plural: "agents",
+		Fields: []core.Field{
+			{Key: "NAME", Value: "metadata.name"},
+			{Key: "STATUS", Value: "status"},
+		},
+	}
+	return newWatchModel(resource, nil, nil, time.Second, untilReady)
+}
+
+func TestWatchModelStatusTransitions(t *testing.T) {
+	m := newTestWatchModel(false)
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	m.now = func() time.Time { return now }
+
+	m.apply([]interface{}{watchItem("a", "DEPLOYING"), watchItem("b", "DEPLOYED")})
+	require.Len(t, m.rows, 2)
+	assert.Equal(t, "DEPLOYING", m.rows["a"].status)
+	assert.Empty(t, m.rows["a"].prev)
+
+	now = now.Add(2 * time.Second)
+	m.apply([]interface{}{watchItem("a", "DEPLOYED"), watchItem("b", "DEPLOYED")})
+	assert.Equal(t, "DEPLOYED", m.rows["a"].status)
+	assert.Equal(t, "DEPLOYING", m.rows["a"].prev)
+	assert.Contains(t, m.View(), "DEPLOYING → DEPLOYED")
+
+	// The transition is no longer shown once the highlight expires
+	now = now.Add(watchHighlightDuration)
+	m.apply([]interface{}{watchItem("a", "DEPLOYED"), watchItem("b", "DEPLOYED")})
+	assert.NotContains(t, m.View(), "→")
+
+	// Deleted resources disappear from the table
+	m.apply([]interface{}{watchItem("a", "DEPLOYED")})
+	assert.Len(t, m.rows, 1)
+}
+
+func TestWatchModelUntilReady(t *testing.T) {
+	m := newTestWatchModel(true)
+
+	_, cmd := m.Update(watchItemsMsg{items: []interface{}{watchItem("a", "DEPLOYING"), watchItem("b", "DEPLOYED")}})
+	assert.False(t, m.done)
+	assert.NotNil(t, cmd)
+
+	_, _ = m.Update(watchItemsMsg{items: []interface{}{watchItem("a", "FAILED"), watchItem("b", "DEPLOYED")}})
+	assert.True(t, m.done)
+	assert.Equal(t, []string{"a"}, m.failed())
+}
+
+func TestWatchModelFetchError(t *testing.T) {
+	m := newTestWatchModel(false)
+	_, cmd := m.Update(watchItemsMsg{err: errors.New("connection refused")})
+	assert.NotNil(t, cmd)
+	assert.Equal(t, 1, m.failures)
+	assert.Contains(t, m.View(), "connection refused")
+
+	_, _ = m.Update(watchItemsMsg{items: []interface{}{}})
+	assert.Equal(t, 0, m.failures)
+	assert.Contains(t, m.View(), "No agents found")
+}
+
+func TestWatchItemsSettled(t *testing.T) {
+	assert.False(t, watchItemsSettled(nil))
+	assert.False(t, watchItemsSettled([]interface{}{watchItem("a", "DEPLOYED"), watchItem("b", "BUILDING")}))
+	assert.True(t, watchItemsSettled([]interface{}{watchItem("a", "DEPLOYED"), watchItem("b", "FAILED"), watchItem("c", "DEACTIVATED")}))
+}
+
+func TestWatchFailedItems(t *testing.T) {
+	assert.Empty(t, watchFailedItems([]interface{}{watchItem("a", "DEPLOYED"), watchItem("b", "DEACTIVATED")}))
+	assert.Equal(t, []string{"a", "c"}, watchFailedItems([]interface{}{watchItem("c", "FAILED"), watchItem("b", "DEPLOYED"), watchItem("a", "FAILED")}))
+}
@@ -0,0 +1,332 @@
+package cli
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/blaxel-ai/toolkit/cli/core"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// watchHighlightDuration is how long a changed row and its status transition stay highlighted
+const watchHighlightDuration = 10 * time.Second
+
+// Statuses after which a resource is not expected to change on its own
+var (
+	watchReadyStatuses  = []string{"DEPLOYED", "READY"}
+	watchFailedStatuses = []string{"FAILED", "TERMINATED"}
+	watchIdleStatuses   = []string{"DEACTIVATED"}
+)
+
+var (
+	watchHeaderStyle  = lipgloss.NewStyle().Bold(true)
+	watchTitleStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
+	watchChangedStyle = lipgloss.NewStyle().Bold(true)
+	watchReadyStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("10"))
+	watchFailedStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("9"))
+	watchPendingStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("214"))
+)
+
+// watchRow is one watched resource as displayed in the table
+type watchRow struct {
+	name      string
+	cells     []string
+	status    string
+	prev      string
+	changedAt time.Time
+}
+
+// watchItemsMsg carries the result of a poll
+type watchItemsMsg struct {
+	items []interface{}
+	err   error
+}
+
+// watchModel is the bubbletea model behind `bl get <kind> --watch`. It keeps
+// one row per resource and only updates the rows whose content changed.
+type watchModel struct {
+	resource     *core.Resource
+	args         []string
+	outputFormat string
+	interval     time.Duration
+	untilReady   bool
+	fetch        func() ([]interface{}, error)
+
+	header     []string
+	state      watchState
+	rows       map[string]*watchRow
+	lastUpdate time.Time
+	err        error
+	failures   int
+	done       bool
+	now        func() time.Time
+}
+
+func newWatchModel(resource *core.Resource, args []string, selector core.LabelSelector, interval time.Duration, untilReady bool) *watchModel {
+	outputFormat := core.GetOutputFormat()
+	return &watchModel{
+		resource:     resource,
+		args:         args,
+		outputFormat: outputFormat,
+		interval:     interval,
+		untilReady:   untilReady,
+		fetch:        func() ([]interface{}, error) { return fetchWatchItems(resource, args, selector) },
+		header:       core.TableHeader(*resource, outputFormat),
+		state:        watchState{},
+		rows:         map[string]*watchRow{},
+		now:          time.Now,
+	}
+}
+
+func (m *watchModel) Init() tea.Cmd {
+	return m.poll(0)
+}
+
+// poll fetches the resources after delay
+func (m *watchModel) poll(delay time.Duration) tea.Cmd {
+	fetch := func() tea.Msg {
+		items, err := m.fetch()
+		return watchItemsMsg{items: items, err: err}
+	}
+	if delay <= 0 {
+		return fetch
+	}
+	return tea.Tick(delay, func(time.Time) tea.Msg { return fetch() })
+}
+
+func (m *watchModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "q", "ctrl+c", "esc":
+			return m, tea.Quit
+		}
+	case watchItemsMsg:
+		if msg.err != nil {
+			m.err = msg.err
+			m.failures++
+			return m, m.poll(min(m.interval*time.Duration(1<<min(m.failures, 4)), watchMaxBackoff))
+		}
+		m.err = nil
+		m.failures = 0
+		m.apply(msg.items)
+		if m.untilReady && m.allSettled() {
+			m.done = true
+			return m, tea.Quit
+		}
+		return m, m.poll(m.interval)
+	}
+	return m, nil
+}
+
+// apply updates the rows from a poll result, recording status transitions
+func (m *watchModel) apply(items []interface{}) {
+	now := m.now()
+	m.lastUpdate = now
+
+	var events []watchEvent
+	events, m.state = diffWatchState(m.state, items)
+	for _, event := range events {
+		name := watchResourceName(event.Resource)
+		switch event.Type {
+		case watchEventDeleted:
+			delete(m.rows, name)
+		case watchEventAdded:
+			m.rows[name] = &watchRow{
+				name:      name,
+				cells:     core.TableRow(*m.resource, event.Resource, m.outputFormat),
+				status:    watchItemStatus(event.Resource),
+				changedAt: now,
+			}
+		case watchEventModified:
+			row := m.rows[name]
+			status := watchItemStatus(event.Resource)
+			if status != row.status {
+				row.prev = row.status
+				row.status = status
+			}
+			row.cells = core.TableRow(*m.resource, event.Resource, m.outputFormat)
+			row.changedAt = now
+		}
+	}
+}
+
+// allSettled reports whether every watched resource reached a terminal status
+func (m *watchModel) allSettled() bool {
+	if len(m.rows) == 0 {
+		return false
+	}
+	for _, row := range m.rows {
+		if !isTerminalWatchStatus(row.status) {
+			return false
+		}
+	}
+	return true
+}
+
+// failed returns the names of the watched resources that ended in a failed status
+func (m *watchModel) failed() []string {
+	names := []string{}
+	for _, row := range m.sortedRows() {
+		if containsStatus(watchFailedStatuses, row.status) {
+			names = append(names, row.name)
+		}
+	}
+	return names
+}
+
+func (m *watchModel) sortedRows() []*watchRow {
+	rows := make([]*watchRow, 0, len(m.rows))
+	for _, row := range m.rows {
+		rows = append(rows, row)
+	}
+	sort.Slice(rows, func(i, j int) bool { return rows[i].name < rows[j].name })
+	return rows
+}
+
+func (m *watchModel) View() string {
+	var b strings.Builder
+	title := fmt.Sprintf("Every %s: %s", m.interval, m.resource.Plural)
+	if !m.lastUpdate.IsZero() {
+		title += fmt.Sprintf(" (updated %s)", m.lastUpdate.Format("15:04:05"))
+	}
+	b.WriteString(watchTitleStyle.Render(title) + "\n\n")
+
+	if m.lastUpdate.IsZero() && m.err == nil {
+		b.WriteString("Loading...\n")
+	} else if len(m.rows) == 0 {
+		b.WriteString(fmt.Sprintf("No %s found\n", m.resource.Plural))
+	} else {
+		b.WriteString(m.renderTable())
+	}
+
+	if m.err != nil {
+		b.WriteString("\n" + watchFailedStyle.Render(fmt.Sprintf("Watch error (retrying): %v", m.err)) + "\n")
+	}
+	if !m.done {
+		hint := "Press q or Ctrl+C to stop watching"
+		if m.untilReady {
+			hint = "Waiting for all " + m.resource.Plural + " to be ready. " + hint
+		}
+		b.WriteString("\n" + watchTitleStyle.Render(hint) + "\n")
+	}
+	return b.String()
+}
+
+// renderTable lays out the rows in aligned columns, styling the status cell
+// and highlighting rows that changed recently
+func (m *watchModel) renderTable() string {
+	rows := m.sortedRows()
+	statusCol := -1
+	for i, key := range m.header {
+		if key == "STATUS" {
+			statusCol = i
+		}
+	}
+
+	cells := make([][]string, len(rows))
+	widths := make([]int, len(m.header))
+	for i, key := range m.header {
+		widths[i] = len(key)
+	}
+	now := m.now()
+	for r, row := range rows {
+		cells[r] = make([]string, len(m.header))
+		copy(cells[r], row.cells)
+		if statusCol >= 0 && row.prev != "" && now.Sub(row.changedAt) < watchHighlightDuration {
+			cells[r][statusCol] = row.prev + " → " + row.status
+		}
+		for i, cell := range cells[r] {
+			widths[i] = max(widths[i], lipgloss.Width(cell))
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString(watchHeaderStyle.Render(joinWatchCells(m.header, widths)) + "\n")
+	for r, row := range rows {
+		recent := now.Sub(row.changedAt) < watchHighlightDuration
+		padded := make([]string, len(cells[r]))
+		for i, cell := range cells[r] {
+			cell += strings.Repeat(" ", widths[i]-lipgloss.Width(cell))
+			if recent {
+				cell = watchChangedStyle.Render(cell)
+			}
+			if i == statusCol {
+				cell = watchStatusStyle(row.status).Render(cell)
+			}
+			padded[i] = cell
+		}
+		b.WriteString(strings.TrimRight(strings.Join(padded, "   "), " ") + "\n")
+	}
+	return b.String()
+}
+
+func joinWatchCells(cells []string, widths []int) string {
+	padded := make([]string, len(cells))
+	for i, cell := range cells {
+		padded[i] = cell + strings.Repeat(" ", widths[i]-lipgloss.Width(cell))
+	}
+	return strings.TrimRight(strings.Join(padded, "   "), " ")
+}
+
+func watchStatusStyle(status string) lipgloss.Style {
+	switch {
+	case containsStatus(watchReadyStatuses, status):
+		return watchReadyStyle
+	case containsStatus(watchFailedStatuses, status):
+		return watchFailedStyle
+	case containsStatus(watchIdleStatuses, status):
+		return lipgloss.NewStyle()
+	default:
+		return watchPendingStyle
+	}
+}
+
+// isTerminalWatchStatus reports whether a resource with status is done changing
+func isTerminalWatchStatus(status string) bool {
+	return containsStatus(watchReadyStatuses, status) ||
+		containsStatus(watchFailedStatuses, status) ||
+		containsStatus(watchIdleStatuses, status)
+}
+
+func containsStatus(statuses []string, status string) bool {
+	for _, s := range statuses {
+		if strings.EqualFold(s, status) {
+			return true
+		}
+	}
+	return false
+}
+
+// watchItemStatus returns the top-level status of a generic resource
+func watchItemStatus(item interface{}) string {
+	m, ok := item.(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	status, _ := m["status"].(string)
+	return status
+}
+
+// runWatchTUI watches the resource with an in-place refreshing table until the
+// user quits or, with untilReady, every resource reaches a terminal status
+func runWatchTUI(resource *core.Resource, args []string, selector core.LabelSelector, interval time.Duration, untilReady bool) {
+	model := newWatchModel(resource, args, selector, interval, untilReady)
+	if _, err := tea.NewProgram(model).Run(); err != nil {
+		core.PrintError("Watch", err)
+		core.ExitWithError(err)
+	}
+	if !model.done {
+		fmt.Println("Stopped watching.")
+		return
+	}
+	if failed := model.failed(); len(failed) > 0 {
+		err := &core.BuildError{Err: fmt.Errorf("%s failed: %s", resource.Plural, strings.Join(failed, ", "))}
+		core.PrintError("Watch", err)
+		core.ExitWithError(err)
+	}
+	core.PrintSuccess(fmt.Sprintf("All %s are ready", resource.Plural))
+}
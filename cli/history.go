@@ -0,0 +1,154 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	blaxel "github.com/blaxel-ai/sdk-go"
+	"github.com/blaxel-ai/toolkit/cli/core"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+func init() {
+	core.RegisterCommand("history", func() *cobra.Command {
+		return HistoryCmd()
+	})
+}
+
+// HistoryEntry is an image tag built for a resource. Only the tag the
+// resource currently runs has a status and a deployer.
+type HistoryEntry struct {
+	Tag        string `json:"tag" yaml:"tag"`
+	CreatedAt  string `json:"createdAt" yaml:"createdAt"`
+	Size       int64  `json:"size" yaml:"size"`
+	Current    bool   `json:"current" yaml:"current"`
+	Status     string `json:"status,omitempty" yaml:"status,omitempty"`
+	DeployedBy string `json:"deployedBy,omitempty" yaml:"deployedBy,omitempty"`
+}
+
+func HistoryCmd() *cobra.Command {
+	var limit int
+	cmd := &cobra.Command{
+		Use:   "history resourceType name",
+		Short: "Show the images a resource was deployed with",
+		Args:  cobra.ExactArgs(2),
+		Long: `Show the deploy history of an agent, function, job, sandbox or application,
+built from the tags of its image, the most recent first.
+
+Every build pushes a new tag, so the list tells what was deployed and when.
+The tag the resource currently runs is marked with its status and the user
+who last updated the resource; older tags only show when they were built.
+After a rollback, the current tag is not the most recent one.
+
+Any tag of the list can be passed to 'bl rollback --to'.`,
+		Example: `  # Show the last 10 deploys of an agent
+  bl history agent my-agent
+
+  # Show every tag, as JSON
+  bl history agent my-agent --limit 0 -o json`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if limit < 0 {
+				err := &core.ConfigError{Err: fmt.Errorf("--limit must be positive or 0, got %d", limit)}
+				core.PrintError("History", err)
+				core.ExitWithError(err)
+			}
+			entries, err := resourceHistory(args[0], args[1])
+			if err != nil {
+				core.PrintError("History", err)
+				core.ExitWithError(err)
+			}
+			if limit > 0 && len(entries) > limit {
+				entries = entries[:limit]
+			}
+			printHistory(entries)
+		},
+	}
+	cmd.Flags().IntVar(&limit, "limit", 10, "Maximum number of tags to show, 0 shows them all")
+	return cmd
+}
+
+// resourceHistory returns the history of resourceType/name from the tags of
+// the image it runs
+func resourceHistory(resourceType, name string) ([]HistoryEntry, error) {
+	kind, err := imageResourceKind(resourceType)
+	if err != nil {
+		return nil, err
+	}
+	live, err := getResource(resourceType, name)
+	if err != nil {
+		return nil, err
+	}
+	current := liveImageRef(kind, live)
+	imageType, imageName, currentTag, err := parseImageRef(current)
+	if err != nil {
+		return nil, &core.ConfigError{Err: fmt.Errorf("%s %s does not run an image built by Blaxel (image '%s'), it has no history", resourceType, name, current)}
+	}
+
+	image, err := core.GetClient().Images.Get(context.Background(), imageName, blaxel.ImageGetParams{ResourceType: imageType})
+	if err != nil {
+		return nil, fmt.Errorf("error getting image %s/%s: %w", imageType, imageName, err)
+	}
+	status, _ := live["status"].(string)
+	metadata, _ := live["metadata"].(map[string]interface{})
+	updatedBy, _ := metadata["updatedBy"].(string)
+	return imageHistory(image.Spec.Tags, currentTag, status, updatedBy), nil
+}
+
+// imageHistory returns tags as history entries, the most recent first, with
+// the status and deployer of the resource on the current tag
+func imageHistory(tags []blaxel.ImageSpecTag, currentTag, status, updatedBy string) []HistoryEntry {
+	entries := make([]HistoryEntry, 0, len(tags))
+	for _, tag := range sortedImageTags(tags) {
+		entry := HistoryEntry{Tag: tag.Name, CreatedAt: tag.CreatedAt, Size: tag.Size}
+		if tag.Name == currentTag {
+			entry.Current = true
+			entry.Status = status
+			entry.DeployedBy = updatedBy
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+func printHistory(entries []HistoryEntry) {
+	switch core.GetOutputFormat() {
+	case "json":
+		data, _ := json.MarshalIndent(entries, "", "  ")
+		fmt.Println(string(data))
+		return
+	case "yaml":
+		data, _ := yaml.Marshal(entries)
+		fmt.Print(string(data))
+		return
+	}
+
+	if len(entries) == 0 {
+		core.PrintInfo("No image tag found")
+		return
+	}
+	rows := [][]string{{"TAG", "CREATED", "SIZE", "STATUS", "DEPLOYED BY"}}
+	for _, entry := range entries {
+		status, deployedBy := "-", "-"
+		if entry.Current {
+			status = "current"
+			if entry.Status != "" {
+				status = entry.Status + " (current)"
+			}
+			if entry.DeployedBy != "" {
+				deployedBy = entry.DeployedBy
+			}
+		}
+		rows = append(rows, []string{entry.Tag, entry.CreatedAt, formatBytes(entry.Size), status, deployedBy})
+	}
+	widths := make([]int, len(rows[0]))
+	for _, row := range rows {
+		for i, cell := range row {
+			widths[i] = max(widths[i], len(cell))
+		}
+	}
+	for _, row := range rows {
+		fmt.Printf("%-*s  %-*s  %-*s  %-*s  %s\n", widths[0], row[0], widths[1], row[1], widths[2], row[2], widths[3], row[3], row[4])
+	}
+}
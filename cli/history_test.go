@@ -0,0 +1,23 @@
+package cli
+
+import (
+	"testing"
+
+	blaxel "github.com/blaxel-ai/sdk-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImageHistory(t *testing.T) {
+	tags := []blaxel.ImageSpecTag{
+		{Name: "v1", CreatedAt: "2025-01-01T00:00:00Z", Size: 10},
+		{Name: "v3", CreatedAt: "2025-01-03T00:00:00Z", Size: 30},
+		{Name: "v2", CreatedAt: "2025-01-02T00:00:00Z", Size: 20},
+	}
+	assert.Equal(t, []HistoryEntry{
+		{Tag: "v3", CreatedAt: "2025-01-03T00:00:00Z", Size: 30},
+		{Tag: "v2", CreatedAt: "2025-01-02T00:00:00Z", Size: 20, Current: true, Status: "DEPLOYED", DeployedBy: "jane@example.com"},
+		{Tag: "v1", CreatedAt: "2025-01-01T00:00:00Z", Size: 10},
+	}, imageHistory(tags, "v2", "DEPLOYED", "jane@example.com"))
+
+	assert.Empty(t, imageHistory(nil, "v1", "DEPLOYED", ""))
+}
@@ -257,7 +257,7 @@ func getImage(resourceType, imageName, tag string) {
 
 	// Check output format - if table, display tags in a table
 	outputFormat := core.GetOutputFormat()
-	if outputFormat == "table" || outputFormat == "" {
+	if outputFormat == "table" || outputFormat == "wide" || outputFormat == "" {
 		displayImageWithTags(image, resourceType, imageName)
 	} else {
 		// For other formats (json, yaml, pretty), use standard output
@@ -0,0 +1,160 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	blaxel "github.com/blaxel-ai/sdk-go"
+	"github.com/blaxel-ai/toolkit/cli/core"
+	"github.com/spf13/cobra"
+)
+
+func ImagesPromoteCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "promote srcType/srcName:tag dstType/dstName",
+		Short:             "Deploy an existing image tag to another resource",
+		Args:              cobra.ExactArgs(2),
+		ValidArgsFunction: GetImageValidArgsFunction(),
+		Long: `Point a resource at an image tag that was already built for another resource,
+then apply it, without rebuilding anything.
+
+The destination resource must exist: only its runtime image changes, the rest
+of its spec is applied as is. Since the very same image is reused, what runs
+in the destination is bit-identical to what was tested with the source, which
+makes it the way to move a build from staging to production.
+
+The source tag must exist, check with 'bl get image srcType/srcName'. The
+promotion fails if the destination was changed while it was being applied.`,
+		Example: `  # Promote the image tested on the staging agent to the prod agent
+  bl images promote agent/my-agent-staging:a1b2c3 agent/my-agent-prod
+
+  # Find the latest tag to promote
+  bl get image agent/my-agent-staging --latest`,
+		Run: func(cmd *cobra.Command, args []string) {
+			srcType, srcName, srcTag, err := parseImageRef(args[0])
+			if err == nil && srcTag == "" {
+				err = fmt.Errorf("the source image needs a tag, e.g. %s/%s:latest", srcType, srcName)
+			}
+			var dstType, dstName, dstTag string
+			if err == nil {
+				dstType, dstName, dstTag, err = parseImageRef(args[1])
+			}
+			if err == nil && dstTag != "" {
+				err = fmt.Errorf("the destination is a resource, remove ':%s' from it", dstTag)
+			}
+			if err != nil {
+				err = &core.ConfigError{Err: err}
+				core.PrintError("Promote", err)
+				core.ExitWithError(err)
+			}
+
+			if err := promoteImage(srcType, srcName, srcTag, dstType, dstName); err != nil {
+				core.PrintError("Promote", err)
+				core.ExitWithError(err)
+			}
+		},
+	}
+	return cmd
+}
+
+// promoteImage points the runtime image of dstType/dstName at the srcTag tag
+// of srcType/srcName and applies the resource without building it
+func promoteImage(srcType, srcName, srcTag, dstType, dstName string) error {
+	client := core.GetClient()
+	image, err := client.Images.Get(context.Background(), srcName, blaxel.ImageGetParams{ResourceType: srcType})
+	if err != nil {
+		return fmt.Errorf("error getting image %s/%s: %w", srcType, srcName, err)
+	}
+	if !imageHasTag(image.Spec.Tags, srcTag) {
+		return &core.ConfigError{Err: fmt.Errorf("tag '%s' not found for image %s/%s", srcTag, srcType, srcName)}
+	}
+
+	kind, err := imageResourceKind(dstType)
+	if err != nil {
+		return err
+	}
+	live, err := getResource(dstType, dstName)
+	if err != nil {
+		return err
+	}
+	imageRef := fmt.Sprintf("%s/%s:%s", srcType, srcName, srcTag)
+	result, err := promotedResource(kind, live, imageRef)
+	if err != nil {
+		return err
+	}
+
+	results, err := ApplyResources([]core.Result{result})
+	if err != nil {
+		return err
+	}
+	if hasFailedApplyResult(results) {
+		return fmt.Errorf("failed to apply %s %s", dstType, dstName)
+	}
+	core.PrintSuccess(fmt.Sprintf("Promoted %s to %s/%s", imageRef, dstType, dstName))
+	return nil
+}
+
+// imageHasTag reports whether tags contains a tag named name
+func imageHasTag(tags []blaxel.ImageSpecTag, name string) bool {
+	for _, tag := range tags {
+		if tag.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// liveResourceManifest returns the manifest applying live as is, with its
+// name and spec, "" and nil when live has none. The metadata keeps updatedAt so
+// that a concurrent change of the resource is reported as a conflict.
+func liveResourceManifest(kind string, live map[string]interface{}) (core.Result, string, map[string]interface{}) {
+	liveMetadata, _ := live["metadata"].(map[string]interface{})
+	name, _ := liveMetadata["name"].(string)
+	spec, _ := live["spec"].(map[string]interface{})
+	if name == "" || spec == nil {
+		return core.Result{}, "", nil
+	}
+
+	metadata := map[string]interface{}{"name": name}
+	for _, key := range []string{"displayName", "labels", "updatedAt"} {
+		if value, ok := liveMetadata[key]; ok {
+			metadata[key] = value
+		}
+	}
+	return core.Result{
+		ApiVersion: "blaxel.ai/v1alpha1",
+		Kind:       kind,
+		Metadata:   metadata,
+		Spec:       spec,
+	}, name, spec
+}
+
+// promotedResource returns the manifest applying live with its runtime image
+// replaced by imageRef and the build skipped
+func promotedResource(kind string, live map[string]interface{}, imageRef string) (core.Result, error) {
+	result, name, spec := liveResourceManifest(kind, live)
+	if spec == nil {
+		return core.Result{}, fmt.Errorf("cannot promote to a %s without name or spec", strings.ToLower(kind))
+	}
+
+	if kind == "Application" {
+		var revision map[string]interface{}
+		if revisions, _ := spec["revisions"].([]interface{}); len(revisions) > 0 {
+			revision, _ = revisions[0].(map[string]interface{})
+		}
+		if revision == nil {
+			return core.Result{}, fmt.Errorf("application %s has no revision to promote to", name)
+		}
+		revision["image"] = imageRef
+	} else {
+		runtime, _ := spec["runtime"].(map[string]interface{})
+		if runtime == nil {
+			runtime = map[string]interface{}{}
+			spec["runtime"] = runtime
+		}
+		runtime["image"] = imageRef
+		runtime["skipBuild"] = "true"
+	}
+	return result, nil
+}
@@ -0,0 +1,73 @@
+package cli
+
+import (
+	"testing"
+
+	blaxel "github.com/blaxel-ai/sdk-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImageHasTag(t *testing.T) {
+	tags := []blaxel.ImageSpecTag{{Name: "v1"}, {Name: "v2"}}
+	assert.True(t, imageHasTag(tags, "v2"))
+	assert.False(t, imageHasTag(tags, "v3"))
+	assert.False(t, imageHasTag(nil, "v1"))
+}
+
+func TestPromotedResource(t *testing.T) {
+	t.Run("agent", func(t *testing.T) {
+		live := map[string]interface{}{
+			"metadata": map[string]interface{}{
+				"name":      "prod",
+				"labels":    map[string]interface{}{"env": "prod"},
+				"updatedAt": "2025-01-01T00:00:00Z",
+				"createdBy": "someone",
+			},
+			"spec": map[string]interface{}{
+				"runtime": map[string]interface{}{"image": "agent/prod:old", "memory": 4096.0},
+			},
+			"status": "DEPLOYED",
+		}
+		result, err := promotedResource("Agent", live, "agent/staging:new")
+		require.NoError(t, err)
+
+		assert.Equal(t, "Agent", result.Kind)
+		assert.Equal(t, map[string]interface{}{
+			"name":      "prod",
+			"labels":    map[string]interface{}{"env": "prod"},
+			"updatedAt": "2025-01-01T00:00:00Z",
+		}, result.Metadata)
+		runtime := result.Spec.(map[string]interface{})["runtime"].(map[string]interface{})
+		assert.Equal(t, "agent/staging:new", runtime["image"])
+		assert.Equal(t, "true", runtime["skipBuild"])
+		assert.Equal(t, 4096.0, runtime["memory"])
+	})
+
+	t.Run("application", func(t *testing.T) {
+		live := map[string]interface{}{
+			"metadata": map[string]interface{}{"name": "app"},
+			"spec": map[string]interface{}{
+				"revisions": []interface{}{map[string]interface{}{"image": "application/app:old"}},
+			},
+		}
+		result, err := promotedResource("Application", live, "application/app-staging:new")
+		require.NoError(t, err)
+		revision := result.Spec.(map[string]interface{})["revisions"].([]interface{})[0].(map[string]interface{})
+		assert.Equal(t, "application/app-staging:new", revision["image"])
+	})
+
+	t.Run("application without revision", func(t *testing.T) {
+		live := map[string]interface{}{
+			"metadata": map[string]interface{}{"name": "app"},
+			"spec":     map[string]interface{}{},
+		}
+		_, err := promotedResource("Application", live, "application/app:new")
+		assert.Error(t, err)
+	})
+
+	t.Run("missing spec", func(t *testing.T) {
+		_, err := promotedResource("Agent", map[string]interface{}{"metadata": map[string]interface{}{"name": "a"}}, "agent/a:1")
+		assert.Error(t, err)
+	})
+}
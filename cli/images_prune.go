@@ -0,0 +1,243 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	blaxel "github.com/blaxel-ai/sdk-go"
+	"github.com/blaxel-ai/toolkit/cli/core"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	core.RegisterCommand("images", func() *cobra.Command {
+		return ImagesCmd()
+	})
+}
+
+func ImagesCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "images",
+		Aliases: []string{"image", "img"},
+		Short:   "Manage container images",
+		Long: `Manage the container images of your workspace.
+
+To list, inspect or delete images, use 'bl get image' and 'bl delete image'.`,
+		Example: `  # Preview which tags of an image would be removed
+  bl images prune agent/my-agent --keep 5
+
+  # Deploy the image of a staging agent to the prod agent
+  bl images promote agent/my-agent-staging:a1b2c3 agent/my-agent-prod`,
+	}
+
+	cmd.AddCommand(ImagesPruneCmd())
+	cmd.AddCommand(ImagesPromoteCmd())
+	return cmd
+}
+
+func ImagesPruneCmd() *cobra.Command {
+	var keep int
+	var olderThan string
+	var confirm bool
+	cmd := &cobra.Command{
+		Use:               "prune resourceType/imageName",
+		Short:             "Delete old tags of an image",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: GetImageValidArgsFunction(),
+		Long: `Delete the old tags of an image, following one of two policies:
+
+  --keep N            Keep the N most recently created tags
+  --older-than 30d    Delete the tags created more than 30 days ago
+
+Tags currently deployed by a resource of the image type (its runtime image)
+are always kept, whatever the policy.
+
+Nothing is deleted unless --confirm is passed: by default the tags that
+would be removed are only listed.
+
+The --older-than duration accepts s, m, h, d and w suffixes (e.g., 12h, 30d, 2w).`,
+		Example: `  # List the tags that would be removed, keeping the 5 newest
+  bl images prune agent/my-agent --keep 5
+
+  # Delete them
+  bl images prune agent/my-agent --keep 5 --confirm
+
+  # Delete tags created more than 30 days ago
+  bl images prune sandbox/my-template --older-than 30d --confirm`,
+		Run: func(cmd *cobra.Command, args []string) {
+			resourceType, imageName, tag, err := parseImageRef(args[0])
+			if err == nil && tag != "" {
+				err = fmt.Errorf("prune applies to all the tags of an image, remove ':%s' from the reference", tag)
+			}
+			if err == nil {
+				err = validatePrunePolicy(cmd.Flags().Changed("keep"), keep, olderThan)
+			}
+			if err != nil {
+				err = &core.ConfigError{Err: err}
+				core.PrintError("Prune", err)
+				core.ExitWithError(err)
+			}
+
+			var maxAge time.Duration
+			if olderThan != "" {
+				seconds, err := core.ParseDurationToSeconds(olderThan)
+				if err == nil && seconds == 0 {
+					err = fmt.Errorf("duration must be greater than zero")
+				}
+				if err != nil {
+					err = &core.ConfigError{Err: fmt.Errorf("invalid --older-than: %w", err)}
+					core.PrintError("Prune", err)
+					core.ExitWithError(err)
+				}
+				maxAge = time.Duration(seconds) * time.Second
+			}
+
+			if err := pruneImage(resourceType, imageName, keep, maxAge, confirm); err != nil {
+				core.PrintError("Prune", err)
+				core.ExitWithError(err)
+			}
+		},
+	}
+	cmd.Flags().IntVar(&keep, "keep", 0, "Number of most recent tags to keep")
+	cmd.Flags().StringVar(&olderThan, "older-than", "", "Delete tags created longer ago than this duration (e.g., 30d)")
+	cmd.Flags().BoolVar(&confirm, "confirm", false, "Delete the tags instead of only listing them")
+	return cmd
+}
+
+// validatePrunePolicy checks that exactly one of --keep and --older-than is set
+func validatePrunePolicy(keepSet bool, keep int, olderThan string) error {
+	switch {
+	case keepSet && olderThan != "":
+		return fmt.Errorf("--keep and --older-than cannot be used together")
+	case !keepSet && olderThan == "":
+		return fmt.Errorf("a policy is required, use --keep N or --older-than DURATION")
+	case keepSet && keep < 0:
+		return fmt.Errorf("--keep must be zero or more, got %d", keep)
+	}
+	return nil
+}
+
+// pruneImage deletes the tags of resourceType/imageName the policy does not
+// keep when confirm is set, and only lists them otherwise
+func pruneImage(resourceType, imageName string, keep int, maxAge time.Duration, confirm bool) error {
+	client := core.GetClient()
+	image, err := client.Images.Get(context.Background(), imageName, blaxel.ImageGetParams{ResourceType: resourceType})
+	if err != nil {
+		return fmt.Errorf("error getting image %s/%s: %w", resourceType, imageName, err)
+	}
+
+	deployed, err := deployedImageTags(resourceType, imageName)
+	if err != nil {
+		return err
+	}
+
+	_, pruned := selectPrunableTags(image.Spec.Tags, keep, maxAge, deployed, time.Now())
+	if len(pruned) == 0 {
+		fmt.Printf("No tag to prune for image %s/%s\n", resourceType, imageName)
+		return nil
+	}
+
+	if !confirm {
+		for _, tag := range pruned {
+			fmt.Printf("Image tag %s/%s:%s would be deleted (created %s, %s)\n", resourceType, imageName, tag.Name, tag.CreatedAt, formatBytes(tag.Size))
+		}
+		fmt.Printf("%d tag(s) would be deleted, run again with --confirm to delete them\n", len(pruned))
+		return nil
+	}
+
+	failed := 0
+	for _, tag := range pruned {
+		if err := deleteImage(resourceType, imageName, tag.Name); err != nil {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d tag deletions failed", failed, len(pruned))
+	}
+	return nil
+}
+
+// deployedImageTags returns the tags of resourceType/imageName used as the
+// runtime image of a resource of that type
+func deployedImageTags(resourceType, imageName string) (map[string]bool, error) {
+	deployed := map[string]bool{}
+	var resource *core.Resource
+	for _, r := range core.GetResources() {
+		if strings.EqualFold(r.Singular, resourceType) {
+			resource = r
+			break
+		}
+	}
+	if resource == nil {
+		return deployed, nil
+	}
+
+	items, err := listAll(resource)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list %s: %w", resource.Plural, err)
+	}
+	for _, item := range items {
+		itemMap, _ := item.(map[string]interface{})
+		spec, _ := itemMap["spec"].(map[string]interface{})
+		runtime, _ := spec["runtime"].(map[string]interface{})
+		ref, _ := runtime["image"].(string)
+		if tag, ok := imageRefTag(ref, resourceType, imageName); ok {
+			deployed[tag] = true
+		}
+	}
+	return deployed, nil
+}
+
+// imageRefTag returns the tag of ref when it points to resourceType/imageName,
+// with or without a registry in front. Registry references may leave out the
+// resource type (registry.blaxel.ai/workspace/imageName:tag).
+func imageRefTag(ref, resourceType, imageName string) (string, bool) {
+	i := strings.LastIndex(ref, ":")
+	if i < 0 || strings.Contains(ref[i:], "/") {
+		return "", false
+	}
+	name, tag := ref[:i], ref[i+1:]
+	want := resourceType + "/" + imageName
+	if name != want && !strings.HasSuffix(name, "/"+want) && !strings.HasSuffix(name, "/"+imageName) {
+		return "", false
+	}
+	return tag, true
+}
+
+// selectPrunableTags sorts tags from the newest to the oldest and splits them
+// between those to keep and those to prune. With maxAge set, the tags created
+// before now minus maxAge are pruned, otherwise all but the keep newest.
+// Deployed tags are always kept.
+func selectPrunableTags(tags []blaxel.ImageSpecTag, keep int, maxAge time.Duration, deployed map[string]bool, now time.Time) (kept, pruned []blaxel.ImageSpecTag) {
+	sorted := append([]blaxel.ImageSpecTag(nil), tags...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return tagCreatedAt(sorted[i]).After(tagCreatedAt(sorted[j]))
+	})
+
+	for i, tag := range sorted {
+		prune := i >= keep
+		if maxAge > 0 {
+			created := tagCreatedAt(tag)
+			prune = !created.IsZero() && now.Sub(created) > maxAge
+		}
+		if prune && !deployed[tag.Name] {
+			pruned = append(pruned, tag)
+		} else {
+			kept = append(kept, tag)
+		}
+	}
+	return kept, pruned
+}
+
+// tagCreatedAt parses the creation date of a tag, zero when it is missing or
+// malformed
+func tagCreatedAt(tag blaxel.ImageSpecTag) time.Time {
+	created, err := time.Parse(time.RFC3339, tag.CreatedAt)
+	if err != nil {
+		return time.Time{}
+	}
+	return created
+}
@@ -0,0 +1,80 @@
+package cli
+
+import (
+	"testing"
+	"time"
+
+	blaxel "github.com/blaxel-ai/sdk-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func tagNames(tags []blaxel.ImageSpecTag) []string {
+	names := []string{}
+	for _, tag := range tags {
+		names = append(names, tag.Name)
+	}
+	return names
+}
+
+func TestSelectPrunableTags(t *testing.T) {
+	now := time.Date(2025, 6, 30, 0, 0, 0, 0, time.UTC)
+	tags := []blaxel.ImageSpecTag{
+		{Name: "v2", CreatedAt: "2025-06-20T00:00:00Z"},
+		{Name: "v4", CreatedAt: "2025-06-29T00:00:00Z"},
+		{Name: "v1", CreatedAt: "2025-04-01T00:00:00Z"},
+		{Name: "v3", CreatedAt: "2025-06-25T00:00:00Z"},
+	}
+
+	t.Run("keep newest", func(t *testing.T) {
+		kept, pruned := selectPrunableTags(tags, 2, 0, nil, now)
+		assert.Equal(t, []string{"v4", "v3"}, tagNames(kept))
+		assert.Equal(t, []string{"v2", "v1"}, tagNames(pruned))
+	})
+
+	t.Run("keep deployed tag", func(t *testing.T) {
+		kept, pruned := selectPrunableTags(tags, 1, 0, map[string]bool{"v1": true}, now)
+		assert.Equal(t, []string{"v4", "v1"}, tagNames(kept))
+		assert.Equal(t, []string{"v3", "v2"}, tagNames(pruned))
+	})
+
+	t.Run("older than", func(t *testing.T) {
+		kept, pruned := selectPrunableTags(tags, 0, 7*24*time.Hour, nil, now)
+		assert.Equal(t, []string{"v4", "v3"}, tagNames(kept))
+		assert.Equal(t, []string{"v2", "v1"}, tagNames(pruned))
+	})
+
+	t.Run("older than keeps undated tags", func(t *testing.T) {
+		kept, pruned := selectPrunableTags([]blaxel.ImageSpecTag{{Name: "x"}}, 0, time.Hour, nil, now)
+		assert.Equal(t, []string{"x"}, tagNames(kept))
+		assert.Empty(t, pruned)
+	})
+}
+
+func TestImageRefTag(t *testing.T) {
+	tests := []struct {
+		ref    string
+		tag    string
+		wantOK bool
+	}{
+		{"agent/my-agent:v1", "v1", true},
+		{"registry.example.com/ws/agent/my-agent:v2", "v2", true},
+		{"registry.blaxel.ai/ws/my-agent:v3", "v3", true},
+		{"agent/other:v1", "", false},
+		{"agent/not-my-agent:v1", "", false},
+		{"agent/my-agent", "", false},
+		{"", "", false},
+	}
+	for _, tt := range tests {
+		tag, ok := imageRefTag(tt.ref, "agent", "my-agent")
+		assert.Equal(t, tt.wantOK, ok, tt.ref)
+		assert.Equal(t, tt.tag, tag, tt.ref)
+	}
+}
+
+func TestValidatePrunePolicy(t *testing.T) {
+	assert.NoError(t, validatePrunePolicy(true, 3, ""))
+	assert.NoError(t, validatePrunePolicy(false, 0, "30d"))
+	assert.Error(t, validatePrunePolicy(true, 3, "30d"))
+	assert.Error(t, validatePrunePolicy(false, 0, ""))
+	assert.Error(t, validatePrunePolicy(true, -1, ""))
+}
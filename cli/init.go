@@ -0,0 +1,207 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/blaxel-ai/toolkit/cli/core"
+	"github.com/blaxel-ai/toolkit/cli/server"
+	"github.com/charmbracelet/huh"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	core.RegisterCommand("init", func() *cobra.Command {
+		return InitCmd()
+	})
+}
+
+// initOptions describes the blaxel.toml written by `bl init`
+type initOptions struct {
+	Name     string
+	Type     newType
+	Language string
+}
+
+func InitCmd() *cobra.Command {
+	var resourceType string
+	var name string
+	var noTTY bool
+	var force bool
+
+	cmd := &cobra.Command{
+		Use:   "init [directory]",
+		Args:  cobra.MaximumNArgs(1),
+		Short: "Add a blaxel.toml to an existing project",
+		Long: `Add a blaxel.toml to an existing project.
+
+Unlike 'bl new', which scaffolds a full project from a template, this command
+only writes the configuration needed to serve and deploy code you already have.
+It detects the project language, asks for the resource type and writes a
+minimal blaxel.toml along with a .blaxelignore.
+
+An existing .blaxelignore is left untouched. An existing blaxel.toml is only
+replaced when --force is set.`,
+		Example: `  # Initialize the current directory interactively
+  bl init
+
+  # Initialize with defaults, without prompting
+  bl init --yes
+
+  # Initialize another directory as an MCP server
+  bl init ./my-server --type mcp --yes`,
+		Run: func(cmd *cobra.Command, args []string) {
+			dir := "."
+			if len(args) == 1 {
+				dir = args[0]
+			}
+
+			configPath := filepath.Join(dir, "blaxel.toml")
+			if _, err := os.Stat(configPath); err == nil && !force {
+				err := fmt.Errorf("%s already exists, use --force to overwrite it or 'bl config validate --fix' to correct it", configPath)
+				core.PrintError("Init", err)
+				core.ExitWithError(err)
+			}
+
+			t := newTypeAgent
+			if resourceType != "" {
+				t = parseInitType(resourceType)
+				if t == "" {
+					err := fmt.Errorf("unknown type '%s'. Allowed: agent | mcp | job | sandbox", resourceType)
+					core.PrintError("Init", err)
+					core.ExitWithError(err)
+				}
+			} else if !noTTY {
+				var selected string
+				form := huh.NewForm(
+					huh.NewGroup(
+						huh.NewSelect[string]().
+							Title("What kind of project is this?").
+							Options(
+								huh.NewOption("Agent app", string(newTypeAgent)),
+								huh.NewOption("MCP server", string(newTypeMCP)),
+								huh.NewOption("Job", string(newTypeJob)),
+								huh.NewOption("Sandbox", string(newTypeSandbox)),
+							).
+							Value(&selected),
+					),
+				)
+				form.WithTheme(core.GetHuhTheme())
+				if err := form.Run(); err != nil {
+					return
+				}
+				t = parseInitType(selected)
+			}
+
+			opts, err := buildInitOptions(dir, t, name)
+			if err != nil {
+				core.PrintError("Init", err)
+				core.ExitWithError(err)
+			}
+
+			if err := os.WriteFile(configPath, []byte(renderInitConfig(opts)), 0644); err != nil {
+				err = fmt.Errorf("failed to write %s: %w", configPath, err)
+				core.PrintError("Init", err)
+				core.ExitWithError(err)
+			}
+			core.PrintSuccess(fmt.Sprintf("Created %s", configPath))
+
+			ignorePath := filepath.Join(dir, ".blaxelignore")
+			if _, err := os.Stat(ignorePath); os.IsNotExist(err) {
+				content := strings.Join(defaultIgnoredPaths, "\n") + "\n"
+				if err := os.WriteFile(ignorePath, []byte(content), 0644); err != nil {
+					err = fmt.Errorf("failed to write %s: %w", ignorePath, err)
+					core.PrintError("Init", err)
+					core.ExitWithError(err)
+				}
+				core.PrintSuccess(fmt.Sprintf("Created %s", ignorePath))
+			}
+
+			if opts.Language == "" && t != newTypeSandbox {
+				core.PrintWarning("Could not detect the project language, set [entrypoint] in blaxel.toml before deploying")
+			}
+			fmt.Println()
+			core.PrintInfo("Next steps:")
+			if t == newTypeJob {
+				fmt.Printf("  bl run job %s --local --file batches/sample-batch.json\n", opts.Name)
+			} else if t != newTypeSandbox {
+				fmt.Println("  bl serve --hotreload")
+			}
+			fmt.Println("  bl deploy")
+		},
+	}
+
+	cmd.Flags().StringVarP(&resourceType, "type", "t", "", "Resource type (agent, mcp, job, sandbox)")
+	cmd.Flags().StringVarP(&name, "name", "n", "", "Resource name (defaults to the directory name)")
+	cmd.Flags().BoolVarP(&noTTY, "yes", "y", false, "Skip interactive prompts and use defaults")
+	cmd.Flags().BoolVarP(&force, "force", "f", false, "Overwrite an existing blaxel.toml")
+	return cmd
+}
+
+// parseInitType maps user input to the resource types supported by `bl init`
+func parseInitType(s string) newType {
+	switch t := parseNewType(s); t {
+	case newTypeAgent, newTypeMCP, newTypeJob, newTypeSandbox:
+		return t
+	}
+	return ""
+}
+
+// buildInitOptions inspects dir to derive the name and language of the project
+func buildInitOptions(dir string, t newType, name string) (initOptions, error) {
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return initOptions{}, fmt.Errorf("failed to resolve directory: %w", err)
+	}
+	info, err := os.Stat(absDir)
+	if err != nil || !info.IsDir() {
+		return initOptions{}, fmt.Errorf("directory %s does not exist", dir)
+	}
+	if name == "" {
+		name = filepath.Base(absDir)
+	}
+	return initOptions{
+		Name:     core.Slugify(name),
+		Type:     t,
+		Language: detectInitLanguage(absDir),
+	}, nil
+}
+
+// detectInitLanguage returns the language of the code in dir, or "" when no
+// manifest or entry file is recognized
+func detectInitLanguage(dir string) string {
+	if language := core.ModuleLanguage(dir); language != "" {
+		return language
+	}
+	if server.FindPythonEntryFile(dir) != "" {
+		return "python"
+	}
+	if core.HasTypeScriptEntryFile(dir) {
+		return "typescript"
+	}
+	if core.HasGoEntryFile(dir) {
+		return "go"
+	}
+	return ""
+}
+
+// renderInitConfig returns the content of a minimal blaxel.toml
+func renderInitConfig(opts initOptions) string {
+	resourceType := string(opts.Type)
+	if opts.Type == newTypeMCP {
+		resourceType = "function"
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "name = %q\n", opts.Name)
+	fmt.Fprintf(&b, "type = %q\n", resourceType)
+	if opts.Language == "" && opts.Type != newTypeSandbox {
+		b.WriteString("\n# The project language could not be detected, set the commands used to start it\n")
+		b.WriteString("# [entrypoint]\n")
+		b.WriteString("# prod = \"\"\n")
+		b.WriteString("# dev = \"\"\n")
+	}
+	return b.String()
+}
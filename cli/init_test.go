@@ -0,0 +1,56 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/BurntSushi/toml"
+	"github.com/blaxel-ai/toolkit/cli/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseInitType(t *testing.T) {
+	assert.Equal(t, newTypeAgent, parseInitType("agent"))
+	assert.Equal(t, newTypeMCP, parseInitType("MCP"))
+	assert.Equal(t, newTypeJob, parseInitType("jb"))
+	assert.Equal(t, newTypeSandbox, parseInitType("sbx"))
+	assert.Equal(t, newType(""), parseInitType("app"))
+	assert.Equal(t, newType(""), parseInitType("volumetemplate"))
+}
+
+func TestBuildInitOptions(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "My Agent")
+	require.NoError(t, os.Mkdir(dir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "main.py"), []byte("print('hi')\n"), 0644))
+
+	opts, err := buildInitOptions(dir, newTypeAgent, "")
+	require.NoError(t, err)
+	assert.Equal(t, "my-agent", opts.Name)
+	assert.Equal(t, "python", opts.Language)
+
+	opts, err = buildInitOptions(dir, newTypeJob, "nightly")
+	require.NoError(t, err)
+	assert.Equal(t, "nightly", opts.Name)
+
+	_, err = buildInitOptions(filepath.Join(dir, "missing"), newTypeAgent, "")
+	assert.Error(t, err)
+}
+
+func TestRenderInitConfig(t *testing.T) {
+	content := renderInitConfig(initOptions{Name: "my-mcp", Type: newTypeMCP, Language: "typescript"})
+
+	var config core.Config
+	_, err := toml.Decode(content, &config)
+	require.NoError(t, err)
+	assert.Equal(t, "my-mcp", config.Name)
+	assert.Equal(t, "function", config.Type)
+	assert.NotContains(t, content, "port")
+	assert.NotContains(t, content, "entrypoint")
+
+	content = renderInitConfig(initOptions{Name: "my-job", Type: newTypeJob})
+	_, err = toml.Decode(content, &config)
+	require.NoError(t, err)
+	assert.Contains(t, content, "# [entrypoint]")
+}
@@ -16,31 +16,89 @@ func init() {
 	})
 }
 
-// ClearCredentials clears credentials for a workspace
-func clearCredentials(workspaceName string) error {
-	config, err := blaxel.LoadConfig()
-	if err != nil {
-		return err
+// logoutResult describes what a logout removed from ~/.blaxel/config.yaml
+type logoutResult struct {
+	Removed []string
+	// Context is the current workspace after logout, "" when none is left
+	Context        string
+	ContextChanged bool
+}
+
+// removeWorkspaceCredentials removes the named workspaces from cfg, or every
+// workspace when all is set. When the current workspace is removed, the context
+// switches to the first remaining workspace, or is cleared when none is left.
+func removeWorkspaceCredentials(cfg blaxel.Config, names []string, all bool) (blaxel.Config, logoutResult, error) {
+	known := map[string]bool{}
+	for _, ws := range cfg.Workspaces {
+		known[ws.Name] = true
+	}
+	remove := map[string]bool{}
+	for _, name := range names {
+		if !known[name] {
+			return cfg, logoutResult{}, fmt.Errorf("no credentials stored for workspace %s", name)
+		}
+		remove[name] = true
+	}
+
+	result := logoutResult{}
+	kept := []blaxel.WorkspaceConfig{}
+	for _, ws := range cfg.Workspaces {
+		if all || remove[ws.Name] {
+			result.Removed = append(result.Removed, ws.Name)
+			continue
+		}
+		kept = append(kept, ws)
 	}
+	cfg.Workspaces = kept
 
-	// Find and remove the workspace
-	for i, ws := range config.Workspaces {
-		if ws.Name == workspaceName {
-			config.Workspaces = append(config.Workspaces[:i], config.Workspaces[i+1:]...)
-			break
+	if all || remove[cfg.Context.Workspace] {
+		next := ""
+		if len(kept) > 0 {
+			next = kept[0].Name
 		}
+		result.ContextChanged = cfg.Context.Workspace != next
+		cfg.Context.Workspace = next
 	}
+	result.Context = cfg.Context.Workspace
+	return cfg, result, nil
+}
 
-	// Clear current workspace if it was the one being removed
-	if config.Context.Workspace == workspaceName {
-		config.Context.Workspace = ""
+// clearCredentials removes the credentials of the named workspaces, or of every
+// workspace when all is set, from ~/.blaxel/config.yaml
+func clearCredentials(names []string, all bool) (logoutResult, error) {
+	config, err := blaxel.LoadConfig()
+	if err != nil {
+		return logoutResult{}, err
+	}
+	config, result, err := removeWorkspaceCredentials(config, names, all)
+	if err != nil {
+		return logoutResult{}, err
 	}
+	return result, blaxel.WriteConfig(config)
+}
 
-	return blaxel.WriteConfig(config)
+// printLogoutResult tells which credentials were removed and what the current workspace is now
+func printLogoutResult(result logoutResult) {
+	if len(result.Removed) == 0 {
+		core.PrintInfo("No stored credentials to remove")
+		return
+	}
+	for _, name := range result.Removed {
+		core.PrintSuccess(fmt.Sprintf("Logged out from workspace %s, its credentials were removed", name))
+	}
+	if !result.ContextChanged {
+		return
+	}
+	if result.Context != "" {
+		core.PrintInfo(fmt.Sprintf("Current workspace is now %s", result.Context))
+	} else {
+		core.PrintInfo("No workspace is selected anymore, run 'bl login WORKSPACE' to log in again")
+	}
 }
 
 func LogoutCmd() *cobra.Command {
-	return &cobra.Command{
+	var all bool
+	cmd := &cobra.Command{
 		Use:   "logout [workspace]",
 		Args:  cobra.MaximumNArgs(1),
 		Short: "Logout from Blaxel",
@@ -53,6 +111,11 @@ continue running and are not affected by logout.
 If you have multiple workspaces authenticated, you can logout from:
 - A specific workspace by providing its name
 - Any workspace interactively by running 'bl logout' without arguments
+- Every workspace at once with --all, for example on a shared machine
+
+The credentials are removed from ~/.blaxel/config.yaml. When you log out from
+the current workspace, another authenticated workspace becomes the current
+one; the current workspace is cleared when none is left.
 
 After logging out, you'll need to run 'bl login WORKSPACE' again to
 authenticate before using other commands for that workspace.
@@ -70,9 +133,26 @@ Examples:
   # Logout from specific workspace
   bl logout my-workspace
 
+  # Remove every stored credential, e.g. after a token leak
+  bl logout --all
+
   # Login again after logout
   bl login my-workspace`,
 		Run: func(cmd *cobra.Command, args []string) {
+			if all {
+				if len(args) > 0 {
+					err := fmt.Errorf("--all cannot be combined with a workspace name")
+					core.PrintError("Logout", err)
+					core.ExitWithError(err)
+				}
+				result, err := clearCredentials(nil, true)
+				if err != nil {
+					core.PrintError("Logout", err)
+					core.ExitWithError(err)
+				}
+				printLogoutResult(result)
+				return
+			}
 			if len(args) == 0 {
 				cfg, _ := blaxel.LoadConfig()
 				workspaces := make([]string, 0, len(cfg.Workspaces))
@@ -116,18 +196,22 @@ Examples:
 					selectedWorkspace = workspaces[0]
 				}
 
-				if err := clearCredentials(selectedWorkspace); err != nil {
+				result, err := clearCredentials([]string{selectedWorkspace}, false)
+				if err != nil {
 					core.PrintError("Logout", err)
 					core.ExitWithError(err)
 				}
-				core.PrintSuccess(fmt.Sprintf("Logged out from workspace %s", selectedWorkspace))
+				printLogoutResult(result)
 			} else {
-				if err := clearCredentials(args[0]); err != nil {
+				result, err := clearCredentials(args, false)
+				if err != nil {
 					core.PrintError("Logout", err)
 					core.ExitWithError(err)
 				}
-				core.PrintSuccess(fmt.Sprintf("Logged out from workspace %s", args[0]))
+				printLogoutResult(result)
 			}
 		},
 	}
+	cmd.Flags().BoolVar(&all, "all", false, "Remove the credentials of every workspace")
+	return cmd
 }
@@ -0,0 +1,97 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	blaxel "github.com/blaxel-ai/sdk-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func logoutTestConfig(current string, names ...string) blaxel.Config {
+	cfg := blaxel.Config{Context: blaxel.ContextConfig{Workspace: current}}
+	for _, name := range names {
+		cfg.Workspaces = append(cfg.Workspaces, blaxel.WorkspaceConfig{
+			Name:        name,
+			Credentials: blaxel.Credentials{APIKey: "key-" + name},
+		})
+	}
+	return cfg
+}
+
+func workspaceNames(cfg blaxel.Config) []string {
+	names := []string{}
+	for _, ws := range cfg.Workspaces {
+		names = append(names, ws.Name)
+	}
+	return names
+}
+
+func TestRemoveWorkspaceCredentials(t *testing.T) {
+	t.Run("other workspace keeps the context", func(t *testing.T) {
+		cfg, result, err := removeWorkspaceCredentials(logoutTestConfig("prod", "dev", "prod"), []string{"dev"}, false)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"prod"}, workspaceNames(cfg))
+		assert.Equal(t, []string{"dev"}, result.Removed)
+		assert.False(t, result.ContextChanged)
+		assert.Equal(t, "prod", cfg.Context.Workspace)
+	})
+
+	t.Run("current workspace switches to another one", func(t *testing.T) {
+		cfg, result, err := removeWorkspaceCredentials(logoutTestConfig("prod", "prod", "dev", "staging"), []string{"prod"}, false)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"dev", "staging"}, workspaceNames(cfg))
+		assert.True(t, result.ContextChanged)
+		assert.Equal(t, "dev", result.Context)
+		assert.Equal(t, "dev", cfg.Context.Workspace)
+	})
+
+	t.Run("last workspace clears the context", func(t *testing.T) {
+		cfg, result, err := removeWorkspaceCredentials(logoutTestConfig("prod", "prod"), []string{"prod"}, false)
+		require.NoError(t, err)
+		assert.Empty(t, cfg.Workspaces)
+		assert.True(t, result.ContextChanged)
+		assert.Equal(t, "", cfg.Context.Workspace)
+	})
+
+	t.Run("all removes every workspace", func(t *testing.T) {
+		cfg, result, err := removeWorkspaceCredentials(logoutTestConfig("dev", "prod", "dev"), nil, true)
+		require.NoError(t, err)
+		assert.Empty(t, cfg.Workspaces)
+		assert.Equal(t, []string{"prod", "dev"}, result.Removed)
+		assert.Equal(t, "", cfg.Context.Workspace)
+	})
+
+	t.Run("unknown workspace", func(t *testing.T) {
+		_, _, err := removeWorkspaceCredentials(logoutTestConfig("prod", "prod"), []string{"missing"}, false)
+		assert.EqualError(t, err, "no credentials stored for workspace missing")
+	})
+}
+
+func TestClearCredentialsWritesConfig(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	require.NoError(t, blaxel.WriteConfig(logoutTestConfig("prod", "prod", "dev")))
+
+	result, err := clearCredentials([]string{"prod"}, false)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"prod"}, result.Removed)
+
+	cfg, err := blaxel.LoadConfig()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"dev"}, workspaceNames(cfg))
+	assert.Equal(t, "dev", cfg.Context.Workspace)
+
+	content, err := os.ReadFile(filepath.Join(home, ".blaxel", "config.yaml"))
+	require.NoError(t, err)
+	assert.NotContains(t, string(content), "key-prod")
+}
+
+func TestLogoutCmdAllFlag(t *testing.T) {
+	cmd := LogoutCmd()
+	flag := cmd.Flags().Lookup("all")
+	require.NotNil(t, flag)
+	assert.Equal(t, "false", flag.DefValue)
+}
@@ -1,16 +1,20 @@
 package cli
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"os/signal"
+	"sort"
 	"strings"
+	"sync"
 	"syscall"
 	"time"
 
 	blaxel "github.com/blaxel-ai/sdk-go"
 	"github.com/blaxel-ai/toolkit/cli/core"
 	"github.com/blaxel-ai/toolkit/cli/monitor"
+	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 )
 
@@ -106,8 +110,17 @@ func LogsCmd() *cobra.Command {
 		endTimeStr   string
 		noTimestamps bool
 		utc          bool
+		timestamps   string
 		severity     string
 		search       string
+		prefix       string
+		noPrefix     bool
+		since        string
+		tail         int
+		level        string
+		pretty       bool
+		grep         string
+		grepV        string
 	)
 
 	cmd := &cobra.Command{
@@ -134,15 +147,33 @@ For jobs, you can filter logs by execution ID and task ID:
   bl logs job my-job my-execution-id
   bl logs job my-job my-execution-id my-task-id
 
+When an execution has several tasks and no task ID is given, each line is
+prefixed with its task ID, e.g. [task3], in a color per task. Prefixes are
+padded to the same width. Use --no-prefix to disable it. The logs of each
+task are requested separately, so an execution with more than 8 tasks is
+shown as a single stream without task prefixes.
+
 Time Filtering:
 By default, logs from the last 1 hour are displayed.
 In follow mode (--follow), the last 15 minutes are shown as context, then new logs
 are continuously streamed in real-time.
+When following a job execution, logs from all of its tasks are streamed and
+the command exits once the execution reaches a terminal status (succeeded,
+failed, cancelled or timeout). Ctrl+C stops following without affecting the job.
 You can customize this by:
 - Using duration format (e.g., 3d, 1h, 10m, 24h) with --period flag
 - Using explicit start/end times with --start and --end flags
+- Using a relative duration with --since (e.g., 10m), which also accepts
+  plain seconds and weeks (w)
 - Maximum time range is 3 days
 
+Use --tail N to only show the N most recent lines. When combined with
+--since or --period, the window is bounded first and --tail caps the number
+of lines within it. With --grep, --grep-v or --level, the whole window is
+fetched and --tail keeps the N most recent lines left by them. In follow
+mode, --tail limits the initial context, except for sandbox processes whose
+stream is not bounded.
+
 Duration units:
 - d: days
 - h: hours
@@ -150,17 +181,51 @@ Duration units:
 - s: seconds
 
 Timestamps:
-By default, logs are prefixed with their timestamp in local timezone.
-Use --no-timestamps to hide them, or --utc to display timestamps in UTC.
+By default, logs are prefixed with their timestamp in local timezone, to the
+millisecond, which keeps the lines of several job tasks in order. Use
+--timestamps utc to display them in UTC, to line them up with the logs of
+other services, or --timestamps none to hide them. --utc and --no-timestamps
+are shorthands for these.
 
 Severity Filtering:
 By default, all severity levels are shown. Use --severity to filter by specific levels.
 Available severities: FATAL, ERROR, WARNING, INFO, DEBUG, TRACE, UNKNOWN
 Use comma-separated values: --severity ERROR,FATAL
 
+Structured Logs:
+Lines that are JSON objects are read as structured logs: their level comes
+from a level, lvl, severity or levelname field and their message from msg,
+message or event. --level keeps JSON lines at that level or more severe
+(fatal, error, warn, info, debug, trace), for example --level warn shows
+warnings, errors and fatal lines. --pretty prints JSON lines as a colored
+level, the message and the other fields as key=value. Lines that are not
+JSON, or have no level, are always shown as-is. Unlike --severity, which
+uses the severity recorded by the platform, these flags read the content of
+the line.
+
 Search:
 Use --search to filter logs by text content. Only logs containing the search term will be displayed.
 
+Use --grep to only show the lines matching a regular expression (Go RE2
+syntax, e.g. "(?i)timeout" to ignore case) and --grep-v to hide the lines
+matching one; both can be combined with each other and with --search. They
+are applied by the CLI as lines arrive, including with --follow, so the
+prefixes and colors are kept. Matches of --grep are highlighted when colors
+are enabled, except in the lines reformatted by --pretty. An invalid
+expression is reported before any log is fetched.
+
+Prefix:
+Log lines are not prefixed by default, except for the tasks of a job
+execution. Use --prefix to prefix each line with a custom template, or
+--no-prefix to remove it. Supported placeholders:
+
+` + "```" + `
+{kind}     resource type
+{name}     resource name
+{process}  sandbox process name
+{task}     job execution task ID
+` + "```" + `
+
 Examples:
   # View logs for a specific sandbox (last 1 hour - default)
   bl logs sandbox my-sandbox
@@ -180,7 +245,7 @@ Examples:
   # View logs for a specific task within an execution
   bl logs job my-job exec-abc123 task-456
 
-  # Follow job execution logs in real-time
+  # Follow job execution logs until the execution finishes
   bl logs job my-job exec-abc123 --follow
 
   # Follow logs in real-time (shows last 15 minutes, then streams new logs)
@@ -192,6 +257,12 @@ Examples:
   # View logs from last 3 days
   bl logs job my-job --period 3d
 
+  # View the last 50 lines from the past 10 minutes
+  bl logs agent my-agent --since 10m --tail 50
+
+  # View the last 100 lines of a sandbox process
+  bl logs sandbox my-sandbox my-process --tail 100
+
   # View logs for a specific time range
   bl logs agent my-agent --start 2024-01-01T00:00:00Z --end 2024-01-01T23:59:59Z
 
@@ -199,14 +270,26 @@ Examples:
   bl logs agent my-agent --no-timestamps
 
   # Show timestamps in UTC
-  bl logs agent my-agent --utc
+  bl logs agent my-agent --timestamps utc
 
   # Filter by severity
   bl logs agent my-agent --severity ERROR,FATAL
 
+  # Show warnings and errors of an app logging JSON, in a readable form
+  bl logs agent my-agent --level warn --pretty
+
   # Search for specific text in logs
   bl logs agent my-agent --search "error"
 
+  # Follow the lines matching a regular expression, without health checks
+  bl logs agent my-agent -f --grep "(?i)error|timeout" --grep-v "GET /health"
+
+  # Prefix each line with a custom template
+  bl logs sandbox my-sandbox my-process --prefix '{name}:{process} |'
+
+  # Remove the task prefix (e.g. when piping to other tools)
+  bl logs job my-job my-execution-id --no-prefix
+
   # Using aliases
   bl logs sbx my-sandbox --follow
   bl logs j my-job --period 1h
@@ -241,12 +324,27 @@ Examples:
 				}
 			}
 
+			if tail < 0 {
+				err := fmt.Errorf("--tail must be a positive number of lines, or 0 to show all")
+				core.PrintError("logs", err)
+				core.ExitWithError(err)
+			}
+
+			// A single source is shown unprefixed unless a template is requested
+			linePrefix := renderLogPrefix(strings.ReplaceAll(resolveLogPrefixTemplate(prefix, noPrefix), "{task}", taskID), canonicalType, resourceName, processName)
+
 			// Handle sandbox process logs
 			if canonicalType == "sandbox" && processName != "" {
+				if since != "" {
+					core.PrintWarning("--since is ignored for sandbox process logs, which have no timestamps")
+				}
 				if follow {
-					streamSandboxProcessLogs(resourceName, processName)
+					if tail > 0 {
+						core.PrintWarning("--tail is ignored when following sandbox process logs")
+					}
+					streamSandboxProcessLogs(resourceName, processName, linePrefix)
 				} else {
-					getSandboxProcessLogs(resourceName, processName)
+					getSandboxProcessLogs(resourceName, processName, linePrefix, tail)
 				}
 				return
 			}
@@ -279,6 +377,17 @@ Examples:
 
 				endTime = time.Now().UTC()
 				startTime = endTime.Add(-duration)
+			} else if since != "" {
+				// Use a relative window (e.g., "10m", "2h", "600")
+				seconds, err := core.ParseDurationToSeconds(since)
+				if err != nil {
+					err = fmt.Errorf("invalid --since value: %w", err)
+					core.PrintError("logs", err)
+					core.ExitWithError(err)
+				}
+
+				endTime = time.Now().UTC()
+				startTime = endTime.Add(-time.Duration(seconds) * time.Second)
 			} else if startTimeStr != "" {
 				// Only start time provided
 				startTime, err = parseTimeFlag(startTimeStr)
@@ -323,16 +432,54 @@ Examples:
 				core.ExitWithError(err)
 			}
 
+			if cmd.Flags().Changed("timestamps") {
+				noTimestamps, utc, err = parseLogTimestamps(timestamps)
+				if err != nil {
+					core.PrintError("logs", err)
+					core.ExitWithError(err)
+				}
+			}
+
+			level, err := parseStructuredLogLevel(level)
+			if err != nil {
+				core.PrintError("logs", err)
+				core.ExitWithError(err)
+			}
+			grepRe, err := parseLogGrep("grep", grep)
+			if err != nil {
+				core.PrintError("logs", err)
+				core.ExitWithError(err)
+			}
+			grepVRe, err := parseLogGrep("grep-v", grepV)
+			if err != nil {
+				core.PrintError("logs", err)
+				core.ExitWithError(err)
+			}
+			structured := structuredLogOptions{level: level, pretty: pretty, grep: grepRe, grepV: grepVRe}
+
+			// The tasks of an execution are fetched separately so each line can be prefixed with its task
+			sources := []logSource{{taskID: taskID, prefix: linePrefix}}
+			if canonicalType == "job" && executionID != "" && taskID == "" && !noPrefix {
+				taskIDs := jobExecutionTaskIDs(resourceName, executionID)
+				switch {
+				case len(taskIDs) > maxTaskLogSources:
+					core.PrintWarning(fmt.Sprintf("Execution %s has %d tasks, their logs are shown without task prefixes; pass a task ID to see the logs of one", executionID, len(taskIDs)))
+				case len(taskIDs) > 1:
+					sources = taskLogSources(prefix, canonicalType, resourceName, taskIDs)
+				}
+			}
+
 			if follow {
 				// Follow logs mode - show some context if period was specified
-				if period == "" && startTimeStr == "" {
+				fixedStart := period != "" || since != "" || startTimeStr != ""
+				if !fixedStart {
 					// No period specified, show last 15 minutes of context
 					startTime = endTime.Add(-15 * time.Minute)
 				}
-				followLogs(workspace, canonicalType, resourceName, startTime, noTimestamps, utc, severity, search, taskID, executionID)
+				followLogs(workspace, canonicalType, resourceName, startTime, fixedStart, noTimestamps, utc, severity, search, executionID, sources, tail, structured)
 			} else {
 				// Fetch logs once
-				fetchLogs(workspace, canonicalType, resourceName, startTime, endTime, noTimestamps, utc, severity, search, taskID, executionID)
+				fetchLogs(workspace, canonicalType, resourceName, startTime, endTime, noTimestamps, utc, severity, search, executionID, sources, tail, structured)
 			}
 		},
 	}
@@ -343,12 +490,43 @@ Examples:
 	cmd.Flags().StringVar(&endTimeStr, "end", "", "End time for logs (RFC3339 format or YYYY-MM-DD)")
 	cmd.Flags().BoolVar(&noTimestamps, "no-timestamps", false, "Hide timestamps in log output")
 	cmd.Flags().BoolVar(&utc, "utc", false, "Display timestamps in UTC instead of local timezone")
+	cmd.Flags().StringVar(&timestamps, "timestamps", "local", "Timestamps of the log lines: local, utc or none")
 	cmd.Flags().StringVar(&severity, "severity", "", "Filter by severity levels (comma-separated): FATAL,ERROR,WARNING,INFO,DEBUG,TRACE,UNKNOWN")
 	cmd.Flags().StringVar(&search, "search", "", "Search for logs containing specific text")
+	cmd.Flags().StringVar(&grep, "grep", "", "Only show log lines matching this regular expression, highlighting the matches")
+	cmd.Flags().StringVar(&grepV, "grep-v", "", "Hide log lines matching this regular expression")
+	cmd.Flags().StringVar(&prefix, "prefix", "", "Prefix template for each log line, supports {kind}, {name}, {process} and {task}")
+	cmd.Flags().BoolVar(&noPrefix, "no-prefix", false, "Do not prefix log lines with their source")
+	cmd.Flags().StringVar(&since, "since", "", "Only show logs newer than a relative duration (e.g., 30s, 10m, 2h)")
+	cmd.Flags().IntVar(&tail, "tail", 0, "Number of most recent log lines to show (0 shows all)")
+	cmd.Flags().StringVar(&level, "level", "", "Only show JSON log lines at this level or more severe: fatal, error, warn, info, debug, trace")
+	cmd.Flags().BoolVar(&pretty, "pretty", false, "Reformat JSON log lines as level, message and key=value fields")
+	cmd.MarkFlagsMutuallyExclusive("prefix", "no-prefix")
+	cmd.MarkFlagsMutuallyExclusive("timestamps", "utc")
+	cmd.MarkFlagsMutuallyExclusive("timestamps", "no-timestamps")
+	cmd.MarkFlagsMutuallyExclusive("since", "period")
+	cmd.MarkFlagsMutuallyExclusive("since", "start")
 
 	return cmd
 }
 
+// logTimestampModes are the values accepted by --timestamps
+var logTimestampModes = []string{"local", "utc", "none"}
+
+// parseLogTimestamps returns the --no-timestamps and --utc values matching a
+// --timestamps value
+func parseLogTimestamps(mode string) (noTimestamps bool, utc bool, err error) {
+	switch strings.ToLower(strings.TrimSpace(mode)) {
+	case "local":
+		return false, false, nil
+	case "utc":
+		return false, true, nil
+	case "none":
+		return true, false, nil
+	}
+	return false, false, fmt.Errorf("invalid --timestamps %q: expected one of %s", mode, strings.Join(logTimestampModes, ", "))
+}
+
 // formatLogOutput formats a log entry with optional timestamp
 func formatLogOutput(logEntry monitor.LogEntry, noTimestamps bool, utc bool) string {
 	if noTimestamps {
@@ -371,14 +549,164 @@ func formatLogOutput(logEntry monitor.LogEntry, noTimestamps bool, utc bool) str
 	return fmt.Sprintf("[%s] %s", t.Format("2006-01-02 15:04:05.000"), logEntry.Message)
 }
 
-// fetchLogs fetches logs for a given time range
-func fetchLogs(workspace, resourceType, resourceName string, startTime, endTime time.Time, noTimestamps bool, utc bool, severity, search, taskID, executionID string) {
+// defaultTaskLogPrefixTemplate is used when the tasks of a job execution are multiplexed
+const defaultTaskLogPrefixTemplate = "[{task}]"
+
+// resolveLogPrefixTemplate picks the prefix template from the --prefix and
+// --no-prefix flags. Without either flag, a single source is not prefixed.
+func resolveLogPrefixTemplate(tmpl string, noPrefix bool) string {
+	if noPrefix {
+		return ""
+	}
+	return tmpl
+}
+
+// renderLogPrefix expands the {kind}, {name} and {process} placeholders of a
+// prefix template. A non-empty prefix is followed by a single space.
+func renderLogPrefix(tmpl, kind, name, process string) string {
+	if tmpl == "" {
+		return ""
+	}
+	rendered := strings.NewReplacer(
+		"{kind}", kind,
+		"{name}", name,
+		"{process}", process,
+	).Replace(tmpl)
+	return rendered + " "
+}
+
+// prefixLines prepends prefix to every line of s
+func prefixLines(prefix, s string) string {
+	if prefix == "" || s == "" {
+		return s
+	}
+	trailingNewline := strings.HasSuffix(s, "\n")
+	lines := strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = prefix + line
+	}
+	out := strings.Join(lines, "\n")
+	if trailingNewline {
+		out += "\n"
+	}
+	return out
+}
+
+// tailLogEntries keeps the last n entries of logs, or all of them when n is 0
+func tailLogEntries[T any](logs []T, n int) []T {
+	if n <= 0 || len(logs) <= n {
+		return logs
+	}
+	return logs[len(logs)-n:]
+}
+
+// tailLines keeps the last n lines of s, or all of it when n is 0
+func tailLines(s string, n int) string {
+	if n <= 0 || s == "" {
+		return s
+	}
+	trailingNewline := strings.HasSuffix(s, "\n")
+	lines := strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+	if len(lines) <= n {
+		return s
+	}
+	out := strings.Join(lines[len(lines)-n:], "\n")
+	if trailingNewline {
+		out += "\n"
+	}
+	return out
+}
+
+// logSource is a stream of logs shown by the logs command, with the prefix of its lines
+type logSource struct {
+	taskID string
+	prefix string
+}
+
+// prefixedLogEntry is a log entry along with the prefix of the source it came from
+type prefixedLogEntry struct {
+	monitor.LogEntry
+	prefix string
+}
+
+// maxTaskLogSources is the largest number of tasks whose logs are requested
+// separately, each one being polled on its own while following
+const maxTaskLogSources = 8
+
+// taskLogPrefixColors are cycled through to tell the tasks of an execution apart
+var taskLogPrefixColors = []color.Attribute{color.FgCyan, color.FgGreen, color.FgYellow, color.FgMagenta, color.FgBlue, color.FgRed}
+
+// jobExecutionTaskIDs returns the task IDs of a job execution, or nil when they cannot be fetched
+func jobExecutionTaskIDs(jobName, executionID string) []string {
 	client := core.GetClient()
-	fetcher := monitor.NewLogFetcher(client, workspace, resourceType, resourceName, startTime, endTime, severity, search, taskID, executionID)
-	logs, err := fetcher.FetchLogs()
-	if err != nil {
-		core.PrintError("logs", err)
-		core.ExitWithError(err)
+	execution, err := client.Jobs.Executions.Get(context.Background(), executionID, blaxel.JobExecutionGetParams{JobID: jobName})
+	if err != nil || execution == nil {
+		return nil
+	}
+
+	taskIDs := make([]string, 0, len(execution.Tasks))
+	for i, task := range execution.Tasks {
+		// Task ID is metadata.name or "task{index}" if name is empty
+		taskID := task.Metadata.Name
+		if taskID == "" {
+			taskID = fmt.Sprintf("task%d", i)
+		}
+		taskIDs = append(taskIDs, taskID)
+	}
+	return taskIDs
+}
+
+// taskLogSources builds one log source per task of an execution. Prefixes are
+// padded to the same width so messages line up, and colored per task.
+func taskLogSources(tmpl, kind, name string, taskIDs []string) []logSource {
+	if tmpl == "" {
+		tmpl = defaultTaskLogPrefixTemplate
+	}
+
+	prefixes := make([]string, len(taskIDs))
+	width := 0
+	for i, taskID := range taskIDs {
+		prefixes[i] = strings.TrimSuffix(renderLogPrefix(strings.ReplaceAll(tmpl, "{task}", taskID), kind, name, ""), " ")
+		width = max(width, len(prefixes[i]))
+	}
+
+	sources := make([]logSource, len(taskIDs))
+	for i, taskID := range taskIDs {
+		padded := fmt.Sprintf("%-*s", width, prefixes[i])
+		sources[i] = logSource{
+			taskID: taskID,
+			prefix: color.New(taskLogPrefixColors[i%len(taskLogPrefixColors)]).Sprint(padded) + " ",
+		}
+	}
+	return sources
+}
+
+// fetchLogs fetches logs for a given time range. Logs of several sources are
+// merged in chronological order. With --grep, --grep-v or --level the whole
+// range is fetched, so --tail keeps the last lines left by the filters.
+func fetchLogs(workspace, resourceType, resourceName string, startTime, endTime time.Time, noTimestamps bool, utc bool, severity, search, executionID string, sources []logSource, tail int, structured structuredLogOptions) {
+	client := core.GetClient()
+	var logs []prefixedLogEntry
+	for _, source := range sources {
+		fetcher := monitor.NewLogFetcher(client, workspace, resourceType, resourceName, startTime, endTime, severity, search, source.taskID, executionID)
+		var entries []monitor.LogEntry
+		var err error
+		if structured.filters() {
+			// Lines dropped by the filters must not count against --tail
+			entries, err = fetcher.FetchAllLogs()
+		} else {
+			fetcher.SetLimit(tail)
+			entries, err = fetcher.FetchLogs()
+		}
+		if err != nil {
+			core.PrintError("logs", err)
+			core.ExitWithError(err)
+		}
+		for _, entry := range entries {
+			if structured.keep(entry) {
+				logs = append(logs, prefixedLogEntry{LogEntry: entry, prefix: source.prefix})
+			}
+		}
 	}
 
 	// Check if no logs were retrieved
@@ -387,35 +715,134 @@ func fetchLogs(workspace, resourceType, resourceName string, startTime, endTime
 		return
 	}
 
+	if len(sources) > 1 {
+		sortLogEntries(logs)
+	}
+
 	// Print logs with timestamps
-	for _, log := range logs {
-		fmt.Println(formatLogOutput(log, noTimestamps, utc))
+	for _, log := range tailLogEntries(logs, tail) {
+		fmt.Println(prefixLines(log.prefix, structured.format(log.LogEntry, noTimestamps, utc)))
 	}
 }
 
-// followLogs follows logs in real-time
-func followLogs(workspace, resourceType, resourceName string, startTime time.Time, noTimestamps bool, utc bool, severity, search, taskID, executionID string) {
+// sortLogEntries sorts logs chronologically, keeping the order of entries with the same timestamp
+func sortLogEntries(logs []prefixedLogEntry) {
+	sort.SliceStable(logs, func(i, j int) bool {
+		ti, errI := time.Parse(time.RFC3339Nano, logs[i].Timestamp)
+		tj, errJ := time.Parse(time.RFC3339Nano, logs[j].Timestamp)
+		if errI != nil || errJ != nil {
+			return logs[i].Timestamp < logs[j].Timestamp
+		}
+		return ti.Before(tj)
+	})
+}
+
+// followLogs follows logs in real-time, with one follower per source. For a
+// job execution it returns the status the execution finished with, or "" when
+// following was interrupted.
+func followLogs(workspace, resourceType, resourceName string, startTime time.Time, fixedStart bool, noTimestamps bool, utc bool, severity, search, executionID string, sources []logSource, tail int, structured structuredLogOptions) string {
 	// Handle Ctrl+C gracefully
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
 
 	client := core.GetClient()
-	follower := monitor.NewLogFollower(client, workspace, resourceType, resourceName, startTime, severity, search, taskID, executionID,
-		func(logEntry monitor.LogEntry) {
-			fmt.Println(formatLogOutput(logEntry, noTimestamps, utc))
-		},
-		func(err error) {
-			core.PrintWarning(fmt.Sprintf("Warning: %v\n", err))
-		},
-		func(info string) {
-			core.PrintInfo(info)
-		},
-	)
+	var printMu sync.Mutex
+	followers := make([]*monitor.LogFollower, 0, len(sources))
+	for _, source := range sources {
+		prefix := source.prefix
+		follower := monitor.NewLogFollower(client, workspace, resourceType, resourceName, startTime, severity, search, source.taskID, executionID,
+			func(logEntry monitor.LogEntry) {
+				if !structured.keep(logEntry) {
+					return
+				}
+				printMu.Lock()
+				defer printMu.Unlock()
+				fmt.Println(prefixLines(prefix, structured.format(logEntry, noTimestamps, utc)))
+			},
+			func(err error) {
+				core.PrintWarning(fmt.Sprintf("Warning: %v\n", err))
+			},
+			func(info string) {
+				core.PrintInfo(info)
+			},
+		)
+		follower.SetLimit(tail)
+		follower.SetFixedStart(fixedStart)
+		follower.Start()
+		followers = append(followers, follower)
+	}
+	stopFollowers := func() {
+		for _, follower := range followers {
+			follower.Stop()
+		}
+	}
+	flushFollowers := func() {
+		for _, follower := range followers {
+			follower.Flush()
+		}
+	}
+
+	// A job execution ends on its own, so stop following once it is done
+	var done <-chan string
+	if resourceType == "job" && executionID != "" {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		done = watchJobExecutionStatus(ctx, client, resourceName, executionID)
+	}
+
+	// Wait for interrupt signal or the end of the execution
+	select {
+	case <-sigChan:
+		stopFollowers()
+		fmt.Println("\nStopped following logs.")
+		return ""
+	case status := <-done:
+		// Logs take a few seconds to reach the observability system
+		select {
+		case <-sigChan:
+		case <-time.After(jobLogsFlushDelay):
+			flushFollowers()
+		}
+		stopFollowers()
+		core.PrintInfo(fmt.Sprintf("Execution %s finished with status %s", executionID, strings.ToUpper(status)))
+		return status
+	}
+}
 
-	follower.Start()
+// jobLogsFlushDelay is how long to wait for late logs once an execution has finished
+const jobLogsFlushDelay = 10 * time.Second
+
+// isTerminalJobExecutionStatus reports whether an execution will not produce new logs
+func isTerminalJobExecutionStatus(status string) bool {
+	switch blaxel.JobExecutionStatus(strings.ToLower(status)) {
+	case blaxel.JobExecutionStatusSucceeded,
+		blaxel.JobExecutionStatusFailed,
+		blaxel.JobExecutionStatusCancelled,
+		blaxel.JobExecutionStatusTimeout:
+		return true
+	}
+	return false
+}
 
-	// Wait for interrupt signal
-	<-sigChan
-	follower.Stop()
-	fmt.Println("\nStopped following logs.")
+// watchJobExecutionStatus polls a job execution and sends its status once it is terminal.
+// Polling errors are ignored so a transient failure does not stop the log stream.
+func watchJobExecutionStatus(ctx context.Context, client *blaxel.Client, jobName, executionID string) <-chan string {
+	done := make(chan string, 1)
+	go func() {
+		ticker := time.NewTicker(5 * time.Second)
+		defer ticker.Stop()
+		for {
+			execution, err := client.Jobs.Executions.Get(ctx, executionID, blaxel.JobExecutionGetParams{JobID: jobName})
+			if err == nil && execution != nil && isTerminalJobExecutionStatus(string(execution.Status)) {
+				done <- string(execution.Status)
+				return
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return done
 }
@@ -0,0 +1,240 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/blaxel-ai/toolkit/cli/monitor"
+	"github.com/fatih/color"
+)
+
+// structuredLogLevels ranks the --level values, most severe first
+var structuredLogLevels = []string{"fatal", "error", "warn", "info", "debug", "trace"}
+
+// structuredLogKeys are the JSON keys read for the level, message and time of a line
+var (
+	structuredLogLevelKeys   = []string{"level", "lvl", "severity", "levelname", "log.level"}
+	structuredLogMessageKeys = []string{"msg", "message", "event"}
+	structuredLogTimeKeys    = []string{"time", "timestamp", "ts", "@timestamp", "asctime"}
+)
+
+// structuredLogOptions holds the --level, --pretty, --grep and --grep-v flags
+// of bl logs
+type structuredLogOptions struct {
+	level  string
+	pretty bool
+	grep   *regexp.Regexp
+	grepV  *regexp.Regexp
+}
+
+// structuredLogLine is a log message that was a JSON object
+type structuredLogLine struct {
+	level   string
+	message string
+	fields  map[string]interface{}
+}
+
+// parseStructuredLogLevel validates a --level value
+func parseStructuredLogLevel(level string) (string, error) {
+	if level == "" {
+		return "", nil
+	}
+	normalized := normalizeLogLevel(level)
+	for _, known := range structuredLogLevels {
+		if normalized == known {
+			return normalized, nil
+		}
+	}
+	return "", fmt.Errorf("invalid --level %q: expected one of %s", level, strings.Join(structuredLogLevels, ", "))
+}
+
+// normalizeLogLevel maps the level names and numbers used by common loggers
+// (pino, bunyan, logrus, zap, Python logging) to the --level names
+func normalizeLogLevel(level string) string {
+	level = strings.ToLower(strings.TrimSpace(level))
+	if n, err := strconv.Atoi(level); err == nil {
+		switch {
+		case n >= 60:
+			return "fatal"
+		case n >= 50:
+			return "error"
+		case n >= 40:
+			return "warn"
+		case n >= 30:
+			return "info"
+		case n >= 20:
+			return "debug"
+		default:
+			return "trace"
+		}
+	}
+	switch level {
+	case "critical", "crit", "panic", "dpanic", "emergency", "alert":
+		return "fatal"
+	case "err":
+		return "error"
+	case "warning":
+		return "warn"
+	case "information", "notice":
+		return "info"
+	}
+	return level
+}
+
+// logLevelRank returns the position of level in structuredLogLevels, or -1
+func logLevelRank(level string) int {
+	for i, known := range structuredLogLevels {
+		if level == known {
+			return i
+		}
+	}
+	return -1
+}
+
+// parseStructuredLogLine parses message when it is a JSON object
+func parseStructuredLogLine(message string) (structuredLogLine, bool) {
+	trimmed := strings.TrimSpace(message)
+	if !strings.HasPrefix(trimmed, "{") {
+		return structuredLogLine{}, false
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal([]byte(trimmed), &fields); err != nil {
+		return structuredLogLine{}, false
+	}
+	line := structuredLogLine{fields: fields}
+	if value, ok := popLogField(fields, structuredLogLevelKeys); ok {
+		line.level = normalizeLogLevel(value)
+	}
+	line.message, _ = popLogField(fields, structuredLogMessageKeys)
+	// The entry already carries its timestamp
+	_, _ = popLogField(fields, structuredLogTimeKeys)
+	return line, true
+}
+
+// popLogField removes the first of keys found in fields and returns its value as text
+func popLogField(fields map[string]interface{}, keys []string) (string, bool) {
+	for _, key := range keys {
+		if value, ok := fields[key]; ok {
+			delete(fields, key)
+			return logFieldText(value), true
+		}
+	}
+	return "", false
+}
+
+// logFieldText renders a JSON value for key=value output
+func logFieldText(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case nil:
+		return "null"
+	}
+	data, _ := json.Marshal(value)
+	return string(data)
+}
+
+// parseLogGrep compiles the regular expression of the grep flag, nil when
+// pattern is empty
+func parseLogGrep(flag, pattern string) (*regexp.Regexp, error) {
+	if pattern == "" {
+		return nil, nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --%s %q: %w", flag, pattern, err)
+	}
+	return re, nil
+}
+
+// filters reports whether keep can drop entries
+func (o structuredLogOptions) filters() bool {
+	return o.grep != nil || o.grepV != nil || o.level != ""
+}
+
+// keep reports whether entry passes the --grep, --grep-v and --level filters.
+// Lines that are not JSON, or have no level, are always kept by --level.
+func (o structuredLogOptions) keep(entry monitor.LogEntry) bool {
+	if o.grep != nil && !o.grep.MatchString(entry.Message) {
+		return false
+	}
+	if o.grepV != nil && o.grepV.MatchString(entry.Message) {
+		return false
+	}
+	if o.level == "" {
+		return true
+	}
+	line, ok := parseStructuredLogLine(entry.Message)
+	if !ok {
+		return true
+	}
+	rank := logLevelRank(line.level)
+	return rank < 0 || rank <= logLevelRank(o.level)
+}
+
+// format renders entry like formatLogOutput, reformatting JSON lines with
+// --pretty and highlighting the --grep matches of the other lines
+func (o structuredLogOptions) format(entry monitor.LogEntry, noTimestamps bool, utc bool) string {
+	if o.pretty {
+		if line, ok := parseStructuredLogLine(entry.Message); ok {
+			entry.Message = line.pretty()
+			return formatLogOutput(entry, noTimestamps, utc)
+		}
+	}
+	if o.grep != nil {
+		entry.Message = highlightLogMatches(o.grep, entry.Message)
+	}
+	return formatLogOutput(entry, noTimestamps, utc)
+}
+
+// highlightLogMatches colors the matches of re in message, like grep --color
+func highlightLogMatches(re *regexp.Regexp, message string) string {
+	if color.NoColor {
+		return message
+	}
+	match := color.New(color.FgRed, color.Bold)
+	return re.ReplaceAllStringFunc(message, func(s string) string {
+		if s == "" {
+			return s
+		}
+		return match.Sprint(s)
+	})
+}
+
+// pretty renders the line as "LEVEL message key=value ..." with a colored level
+func (l structuredLogLine) pretty() string {
+	parts := []string{}
+	if l.level != "" {
+		parts = append(parts, logLevelColor(l.level).Sprintf("%-5s", strings.ToUpper(l.level)))
+	}
+	if l.message != "" {
+		parts = append(parts, l.message)
+	}
+	keys := make([]string, 0, len(l.fields))
+	for key := range l.fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		parts = append(parts, color.New(color.Faint).Sprint(key+"=")+logFieldText(l.fields[key]))
+	}
+	return strings.Join(parts, " ")
+}
+
+func logLevelColor(level string) *color.Color {
+	switch level {
+	case "fatal", "error":
+		return color.New(color.FgRed, color.Bold)
+	case "warn":
+		return color.New(color.FgYellow, color.Bold)
+	case "info":
+		return color.New(color.FgBlue)
+	}
+	return color.New(color.Faint)
+}
@@ -0,0 +1,106 @@
+package cli
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/blaxel-ai/toolkit/cli/monitor"
+	"github.com/fatih/color"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseStructuredLogLevel(t *testing.T) {
+	for input, want := range map[string]string{"": "", "error": "error", "WARN": "warn", "warning": "warn", "Info": "info"} {
+		got, err := parseStructuredLogLevel(input)
+		require.NoError(t, err, input)
+		assert.Equal(t, want, got)
+	}
+	_, err := parseStructuredLogLevel("loud")
+	assert.ErrorContains(t, err, "expected one of fatal, error, warn, info, debug, trace")
+}
+
+func TestNormalizeLogLevel(t *testing.T) {
+	for input, want := range map[string]string{
+		"ERROR":    "error",
+		"warning":  "warn",
+		"critical": "fatal",
+		"50":       "error",
+		"30":       "info",
+		"10":       "trace",
+		"custom":   "custom",
+	} {
+		assert.Equal(t, want, normalizeLogLevel(input), input)
+	}
+}
+
+func TestParseStructuredLogLine(t *testing.T) {
+	line, ok := parseStructuredLogLine(`{"level":"warning","msg":"slow request","time":"2024-01-01T00:00:00Z","duration":1.5,"path":"/run"}`)
+	require.True(t, ok)
+	assert.Equal(t, "warn", line.level)
+	assert.Equal(t, "slow request", line.message)
+	assert.Equal(t, map[string]interface{}{"duration": 1.5, "path": "/run"}, line.fields)
+
+	for _, raw := range []string{"plain text", "[1, 2]", "{not json"} {
+		_, ok := parseStructuredLogLine(raw)
+		assert.False(t, ok, raw)
+	}
+}
+
+func TestStructuredLogOptionsKeep(t *testing.T) {
+	opts := structuredLogOptions{level: "warn"}
+	entry := func(message string) monitor.LogEntry { return monitor.LogEntry{Message: message} }
+
+	assert.True(t, opts.keep(entry(`{"level":"error","msg":"boom"}`)))
+	assert.True(t, opts.keep(entry(`{"level":"warn","msg":"careful"}`)))
+	assert.False(t, opts.keep(entry(`{"level":"info","msg":"hello"}`)))
+	assert.False(t, opts.keep(entry(`{"level":30,"msg":"pino info"}`)))
+	assert.True(t, opts.keep(entry("not json")), "non-JSON lines pass through")
+	assert.True(t, opts.keep(entry(`{"msg":"no level"}`)), "lines without a level pass through")
+	assert.True(t, structuredLogOptions{}.keep(entry(`{"level":"debug"}`)))
+}
+
+func TestStructuredLogOptionsFormat(t *testing.T) {
+	defer func(noColor bool) { color.NoColor = noColor }(color.NoColor)
+	color.NoColor = true
+
+	opts := structuredLogOptions{pretty: true}
+	got := opts.format(monitor.LogEntry{Message: `{"level":"error","msg":"boom","user":{"id":1},"code":500}`}, true, false)
+	assert.Equal(t, `ERROR boom code=500 user={"id":1}`, got)
+
+	assert.Equal(t, "plain text", opts.format(monitor.LogEntry{Message: "plain text"}, true, false))
+
+	raw := `{"level":"info","msg":"hi"}`
+	assert.Equal(t, raw, structuredLogOptions{}.format(monitor.LogEntry{Message: raw}, true, false))
+}
+
+func TestStructuredLogOptionsGrep(t *testing.T) {
+	grep, err := parseLogGrep("grep", "(?i)error|timeout")
+	require.NoError(t, err)
+	grepV, err := parseLogGrep("grep-v", "/health")
+	require.NoError(t, err)
+	opts := structuredLogOptions{grep: grep, grepV: grepV}
+	entry := func(message string) monitor.LogEntry { return monitor.LogEntry{Message: message} }
+
+	assert.True(t, opts.keep(entry("ERROR: boom")))
+	assert.True(t, opts.keep(entry("request timeout")))
+	assert.False(t, opts.keep(entry("all good")))
+	assert.False(t, opts.keep(entry("GET /health error")), "--grep-v wins")
+
+	re, err := parseLogGrep("grep", "")
+	require.NoError(t, err)
+	assert.Nil(t, re)
+	_, err = parseLogGrep("grep-v", "(unclosed")
+	assert.ErrorContains(t, err, `invalid --grep-v "(unclosed"`)
+}
+
+func TestHighlightLogMatches(t *testing.T) {
+	defer func(noColor bool) { color.NoColor = noColor }(color.NoColor)
+	re := regexp.MustCompile("err|x*")
+
+	color.NoColor = true
+	assert.Equal(t, "an error", highlightLogMatches(re, "an error"))
+
+	color.NoColor = false
+	assert.Equal(t, "an "+color.New(color.FgRed, color.Bold).Sprint("err")+"or", highlightLogMatches(re, "an error"))
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/blaxel-ai/toolkit/cli/core"
 	"github.com/blaxel-ai/toolkit/cli/monitor"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNormalizeResourceType(t *testing.T) {
@@ -280,6 +281,19 @@ func TestFormatLogOutput(t *testing.T) {
 	}
 }
 
+func TestParseLogTimestamps(t *testing.T) {
+	for mode, want := range map[string][2]bool{"local": {false, false}, "UTC": {false, true}, "none": {true, false}} {
+		noTimestamps, utc, err := parseLogTimestamps(mode)
+		require.NoError(t, err, mode)
+		assert.Equal(t, want, [2]bool{noTimestamps, utc}, mode)
+	}
+	_, _, err := parseLogTimestamps("iso")
+	assert.EqualError(t, err, `invalid --timestamps "iso": expected one of local, utc, none`)
+
+	entry := monitor.LogEntry{Timestamp: "2024-03-01T10:20:30.123456789Z", Message: "hello"}
+	assert.Equal(t, "[2024-03-01 10:20:30.123] hello", formatLogOutput(entry, false, true))
+}
+
 func TestLogsCmd(t *testing.T) {
 	cmd := LogsCmd()
 
@@ -314,6 +328,9 @@ func TestLogsCmd(t *testing.T) {
 	searchFlag := cmd.Flags().Lookup("search")
 	assert.NotNil(t, searchFlag)
 
+	assert.NotNil(t, cmd.Flags().Lookup("since"))
+	assert.NotNil(t, cmd.Flags().Lookup("tail"))
+
 	// task-id and execution-id are now positional args (NESTED_ARGS), not flags
 }
 
@@ -446,3 +463,73 @@ func TestFormatLogOutputVariants(t *testing.T) {
 		})
 	}
 }
+
+func TestResolveLogPrefixTemplate(t *testing.T) {
+	assert.Equal(t, "", resolveLogPrefixTemplate("", false))
+	assert.Equal(t, "{name}:", resolveLogPrefixTemplate("{name}:", false))
+	assert.Equal(t, "", resolveLogPrefixTemplate("{name}:", true))
+}
+
+func TestRenderLogPrefix(t *testing.T) {
+	assert.Equal(t, "", renderLogPrefix("", "sandbox", "my-sandbox", "proc"))
+	assert.Equal(t, "[sandbox/my-sandbox] ", renderLogPrefix("[{kind}/{name}]", "sandbox", "my-sandbox", ""))
+	assert.Equal(t, "my-sandbox:proc | ", renderLogPrefix("{name}:{process} |", "sandbox", "my-sandbox", "proc"))
+}
+
+func TestPrefixLines(t *testing.T) {
+	assert.Equal(t, "line", prefixLines("", "line"))
+	assert.Equal(t, "", prefixLines("p ", ""))
+	assert.Equal(t, "p a\np b", prefixLines("p ", "a\nb"))
+	assert.Equal(t, "p a\np b\n", prefixLines("p ", "a\nb\n"))
+}
+
+func TestTailLines(t *testing.T) {
+	assert.Equal(t, "a\nb\nc\n", tailLines("a\nb\nc\n", 0))
+	assert.Equal(t, "b\nc\n", tailLines("a\nb\nc\n", 2))
+	assert.Equal(t, "c", tailLines("a\nb\nc", 1))
+	assert.Equal(t, "a\nb", tailLines("a\nb", 5))
+	assert.Equal(t, "", tailLines("", 3))
+}
+
+func TestTailLogEntries(t *testing.T) {
+	logs := []monitor.LogEntry{{Message: "a"}, {Message: "b"}, {Message: "c"}}
+	assert.Len(t, tailLogEntries(logs, 0), 3)
+	assert.Len(t, tailLogEntries(logs, 5), 3)
+	tailed := tailLogEntries(logs, 2)
+	assert.Equal(t, "b", tailed[0].Message)
+	assert.Equal(t, "c", tailed[1].Message)
+}
+
+func TestIsTerminalJobExecutionStatus(t *testing.T) {
+	for _, status := range []string{"succeeded", "FAILED", "cancelled", "timeout"} {
+		assert.True(t, isTerminalJobExecutionStatus(status), status)
+	}
+	for _, status := range []string{"queued", "pending", "RUNNING", "cancelling", ""} {
+		assert.False(t, isTerminalJobExecutionStatus(status), status)
+	}
+}
+
+func TestTaskLogSources(t *testing.T) {
+	sources := taskLogSources("", "job", "my-job", []string{"task0", "task10"})
+	require.Len(t, sources, 2)
+	assert.Equal(t, "task0", sources[0].taskID)
+	assert.Equal(t, "task10", sources[1].taskID)
+	// Prefixes are padded to the same width so messages line up
+	assert.Contains(t, sources[0].prefix, "[task0] ")
+	assert.Contains(t, sources[1].prefix, "[task10]")
+
+	custom := taskLogSources("{name}/{task}:", "job", "my-job", []string{"a"})
+	assert.Contains(t, custom[0].prefix, "my-job/a:")
+}
+
+func TestSortLogEntries(t *testing.T) {
+	logs := []prefixedLogEntry{
+		{LogEntry: monitor.LogEntry{Timestamp: "2024-01-01T00:00:02Z", Message: "c"}},
+		{LogEntry: monitor.LogEntry{Timestamp: "2024-01-01T00:00:01.5Z", Message: "b"}},
+		{LogEntry: monitor.LogEntry{Timestamp: "2024-01-01T00:00:01Z", Message: "a"}},
+	}
+	sortLogEntries(logs)
+	assert.Equal(t, "a", logs[0].Message)
+	assert.Equal(t, "b", logs[1].Message)
+	assert.Equal(t, "c", logs[2].Message)
+}
@@ -256,6 +256,9 @@ func (w *BuildLogWatcher) fetchBuildLogs(offset int) ([]bufferedLogEntry, error)
 	return entries, nil
 }
 
+// defaultLogsPageSize is the number of log entries requested per API call
+const defaultLogsPageSize = 1000
+
 // LogEntry represents a single log entry with timestamp
 type LogEntry struct {
 	Timestamp string
@@ -274,6 +277,7 @@ type LogFetcher struct {
 	search       string
 	taskID       string
 	executionID  string
+	limit        int
 }
 
 // NewLogFetcher creates a new log fetcher
@@ -292,11 +296,42 @@ func NewLogFetcher(client *blaxel.Client, workspace, resourceType, resourceName
 	}
 }
 
+// SetLimit caps the number of entries returned to the most recent limit logs.
+// A limit of 0 keeps the default page size.
+func (lf *LogFetcher) SetLimit(limit int) {
+	lf.limit = limit
+}
+
 // FetchLogs fetches logs for the configured time range
 func (lf *LogFetcher) FetchLogs() ([]LogEntry, error) {
 	return lf.fetchLogsFromAPI(context.Background(), 0)
 }
 
+// maxLogsPages bounds the pages FetchAllLogs requests
+const maxLogsPages = 100
+
+// FetchAllLogs fetches every log of the time range, page after page until
+// one comes back short, up to maxLogsPages pages. The limit is ignored.
+func (lf *LogFetcher) FetchAllLogs() ([]LogEntry, error) {
+	limit := lf.limit
+	lf.limit = 0
+	defer func() { lf.limit = limit }()
+
+	var all []LogEntry
+	for page := 0; page < maxLogsPages; page++ {
+		entries, err := lf.fetchLogsFromAPI(context.Background(), page*defaultLogsPageSize)
+		if err != nil {
+			return nil, err
+		}
+		// Pages go back in time, each one chronological
+		all = append(entries, all...)
+		if len(entries) < defaultLogsPageSize {
+			break
+		}
+	}
+	return all, nil
+}
+
 // fetchLogsFromAPI fetches logs from the Blaxel API
 func (lf *LogFetcher) fetchLogsFromAPI(ctx context.Context, offset int) ([]LogEntry, error) {
 	// Format times in UTC (matching the curl format)
@@ -309,6 +344,11 @@ func (lf *LogFetcher) fetchLogsFromAPI(ctx context.Context, offset int) ([]LogEn
 		severityFilter = "FATAL,ERROR,WARNING,INFO,DEBUG,TRACE,UNKNOWN"
 	}
 
+	limit := defaultLogsPageSize
+	if lf.limit > 0 && lf.limit < limit {
+		limit = lf.limit
+	}
+
 	// Build query options
 	queryOpts := []option.RequestOption{
 		option.WithQuery("start", start),
@@ -317,7 +357,7 @@ func (lf *LogFetcher) fetchLogsFromAPI(ctx context.Context, offset int) ([]LogEn
 		option.WithQuery("workloadIds", lf.resourceName),
 		option.WithQuery("type", "all"),
 		option.WithQuery("traceId", ""),
-		option.WithQuery("limit", "1000"),
+		option.WithQuery("limit", fmt.Sprintf("%d", limit)),
 		option.WithQuery("offset", fmt.Sprintf("%d", offset)),
 		option.WithQuery("severity", severityFilter),
 		option.WithQuery("search", lf.search),
@@ -375,12 +415,15 @@ type LogFollower struct {
 	search        string
 	taskID        string
 	executionID   string
+	limit         int
+	fixedStart    bool
 	onLog         func(LogEntry)
 	onError       func(error)
 	onInfo        func(string)
 	ctx           context.Context
 	cancel        context.CancelFunc
 	seenLogs      map[string]bool
+	lastFetch     time.Time // start of the next poll, zero until the initial fetch is done
 	mu            sync.Mutex
 	errorReported bool // Track if we've already reported an error to avoid spam
 }
@@ -407,6 +450,18 @@ func NewLogFollower(client *blaxel.Client, workspace, resourceType, resourceName
 	}
 }
 
+// SetLimit caps the number of past logs shown before following new ones.
+// A limit of 0 shows all logs found by the initial fetch.
+func (lf *LogFollower) SetLimit(limit int) {
+	lf.limit = limit
+}
+
+// SetFixedStart keeps the initial fetch from looking further back than the
+// start time when no logs are found there, e.g. when --since bounds the window.
+func (lf *LogFollower) SetFixedStart(fixed bool) {
+	lf.fixedStart = fixed
+}
+
 // Start begins following logs
 func (lf *LogFollower) Start() {
 	go lf.followLogs()
@@ -443,6 +498,7 @@ func (lf *LogFollower) followLogs() {
 		}
 
 		fetcher := NewLogFetcher(lf.client, lf.workspace, lf.resourceType, lf.resourceName, currentStartTime, futureTime, lf.severity, lf.search, lf.taskID, lf.executionID)
+		fetcher.SetLimit(lf.limit)
 		logs, err := fetcher.FetchLogs()
 		if err != nil {
 			// Report error on initial fetch
@@ -453,6 +509,13 @@ func (lf *LogFollower) followLogs() {
 			break
 		}
 
+		if len(logs) == 0 && lf.fixedStart {
+			if lf.onInfo != nil {
+				lf.onInfo("No logs found in the requested window. Waiting for new logs...")
+			}
+			break
+		}
+
 		if len(logs) > 0 {
 			foundLogs = true
 			lf.mu.Lock()
@@ -477,6 +540,7 @@ func (lf *LogFollower) followLogs() {
 	// Set start time for subsequent fetches to current time minus a buffer
 	// We need a large buffer (30s) because logs have a delay in appearing in the observability system
 	lastFetchTime := time.Now().UTC().Add(-60 * time.Second)
+	lf.setLastFetch(lastFetchTime)
 
 	for {
 		select {
@@ -517,6 +581,50 @@ func (lf *LogFollower) followLogs() {
 			// Update last fetch time - keep a 30 second overlap to catch logs at boundaries
 			// This large overlap accounts for delays in logs appearing in the observability system
 			lastFetchTime = currentTime.Add(-30 * time.Second)
+			lf.setLastFetch(lastFetchTime)
+		}
+	}
+}
+
+func (lf *LogFollower) setLastFetch(t time.Time) {
+	lf.mu.Lock()
+	lf.lastFetch = t
+	lf.mu.Unlock()
+}
+
+// Flush fetches any logs not yet reported since the last poll, with the same
+// overlap as the polls and without the limit of the initial fetch, which would
+// only return lines already shown. Before the initial fetch is done it fetches
+// from the follower's start time like that fetch. It is used to drain
+// late-arriving logs after the followed workload finished.
+func (lf *LogFollower) Flush() {
+	lf.mu.Lock()
+	start := lf.lastFetch
+	lf.mu.Unlock()
+
+	futureTime := time.Now().UTC().Add(24 * time.Hour)
+	limit := 0
+	if start.IsZero() {
+		start = lf.startTime
+		limit = lf.limit
+	}
+	fetcher := NewLogFetcher(lf.client, lf.workspace, lf.resourceType, lf.resourceName, start, futureTime, lf.severity, lf.search, lf.taskID, lf.executionID)
+	fetcher.SetLimit(limit)
+	logs, err := fetcher.FetchLogs()
+	if err != nil {
+		if lf.onError != nil {
+			lf.onError(fmt.Errorf("failed to fetch logs: %w", err))
+		}
+		return
+	}
+
+	lf.mu.Lock()
+	defer lf.mu.Unlock()
+	for _, log := range logs {
+		key := fmt.Sprintf("%s:%s", log.Timestamp, log.Message)
+		if !lf.seenLogs[key] {
+			lf.onLog(log)
+			lf.seenLogs[key] = true
 		}
 	}
 }
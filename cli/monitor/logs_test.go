@@ -1,13 +1,21 @@
 package monitor
 
 import (
+	"encoding/json"
+	"fmt"
 	"io"
 	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
+	blaxel "github.com/blaxel-ai/sdk-go"
+	"github.com/blaxel-ai/sdk-go/option"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestPluralizeResourceType(t *testing.T) {
@@ -136,6 +144,146 @@ func TestLogFollowerStop(t *testing.T) {
 	follower.Stop()
 }
 
+func TestLogFollowerFlushWithLimit(t *testing.T) {
+	var limits []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		limits = append(limits, r.URL.Query().Get("limit"))
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"my-job": map[string]interface{}{
+				"logs": []map[string]interface{}{
+					{"timestamp": "2026-01-01T00:00:02Z", "message": "second"},
+					{"timestamp": "2026-01-01T00:00:01Z", "message": "first"},
+				},
+			},
+		})
+	}))
+	defer server.Close()
+
+	t.Setenv("BL_API_KEY", "test-api-key")
+	client, err := blaxel.NewDefaultClient(option.WithBaseURL(server.URL), option.WithWorkspace("test"))
+	require.NoError(t, err)
+
+	var messages []string
+	follower := NewLogFollower(&client, "test", "job", "my-job", time.Now(), "", "", "", "", func(e LogEntry) {
+		messages = append(messages, e.Message)
+	}, nil, nil)
+	follower.SetLimit(2)
+	follower.seenLogs["2026-01-01T00:00:01Z:first"] = true
+
+	follower.Flush()
+
+	assert.Equal(t, []string{"2"}, limits)
+	assert.Equal(t, []string{"second"}, messages)
+}
+
+func TestLogFollowerFlushAfterPoll(t *testing.T) {
+	var queries []url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.Query())
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"my-job": map[string]interface{}{
+				"logs": []map[string]interface{}{
+					{"timestamp": "2026-01-01T00:10:01Z", "message": "late"},
+				},
+			},
+		})
+	}))
+	defer server.Close()
+
+	t.Setenv("BL_API_KEY", "test-api-key")
+	client, err := blaxel.NewDefaultClient(option.WithBaseURL(server.URL), option.WithWorkspace("test"))
+	require.NoError(t, err)
+
+	var messages []string
+	startTime := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	follower := NewLogFollower(&client, "test", "job", "my-job", startTime, "", "", "", "", func(e LogEntry) {
+		messages = append(messages, e.Message)
+	}, nil, nil)
+	follower.SetLimit(2)
+	follower.setLastFetch(startTime.Add(10 * time.Minute))
+
+	follower.Flush()
+
+	require.Len(t, queries, 1)
+	assert.Equal(t, "2026-01-01T00:10:00", queries[0].Get("start"))
+	assert.Equal(t, fmt.Sprintf("%d", defaultLogsPageSize), queries[0].Get("limit"))
+	assert.Equal(t, []string{"late"}, messages)
+}
+
+func TestLogFetcherFetchAllLogs(t *testing.T) {
+	var offsets []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		offsets = append(offsets, r.URL.Query().Get("offset"))
+		// Newest first: a full page of recent lines, then two older ones
+		logs := []map[string]interface{}{}
+		if r.URL.Query().Get("offset") == "0" {
+			for i := defaultLogsPageSize; i > 0; i-- {
+				logs = append(logs, map[string]interface{}{"timestamp": fmt.Sprintf("2026-01-01T01:00:%04dZ", i), "message": fmt.Sprintf("recent %d", i)})
+			}
+		} else {
+			logs = append(logs,
+				map[string]interface{}{"timestamp": "2026-01-01T00:00:02Z", "message": "old 2"},
+				map[string]interface{}{"timestamp": "2026-01-01T00:00:01Z", "message": "old 1"},
+			)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"my-agent": map[string]interface{}{"logs": logs}})
+	}))
+	defer server.Close()
+
+	t.Setenv("BL_API_KEY", "test-api-key")
+	client, err := blaxel.NewDefaultClient(option.WithBaseURL(server.URL), option.WithWorkspace("test"))
+	require.NoError(t, err)
+
+	fetcher := NewLogFetcher(&client, "test", "agent", "my-agent", time.Now().Add(-time.Hour), time.Now(), "", "", "", "")
+	fetcher.SetLimit(5)
+	entries, err := fetcher.FetchAllLogs()
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"0", "1000"}, offsets)
+	require.Len(t, entries, defaultLogsPageSize+2)
+	assert.Equal(t, "old 1", entries[0].Message)
+	assert.Equal(t, "old 2", entries[1].Message)
+	assert.Equal(t, "recent 1", entries[2].Message)
+	assert.Equal(t, fmt.Sprintf("recent %d", defaultLogsPageSize), entries[len(entries)-1].Message)
+}
+
+func TestLogFollowerFixedStart(t *testing.T) {
+	var mu sync.Mutex
+	var starts []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		starts = append(starts, r.URL.Query().Get("start"))
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"my-agent": map[string]interface{}{"logs": []interface{}{}}})
+	}))
+	defer server.Close()
+
+	t.Setenv("BL_API_KEY", "test-api-key")
+	client, err := blaxel.NewDefaultClient(option.WithBaseURL(server.URL), option.WithWorkspace("test"))
+	require.NoError(t, err)
+
+	info := make(chan string, 1)
+	follower := NewLogFollower(&client, "test", "agent", "my-agent", time.Now().Add(-10*time.Minute), "", "", "", "",
+		func(LogEntry) {}, func(error) {}, func(msg string) { info <- msg })
+	follower.SetFixedStart(true)
+	follower.Start()
+	defer follower.Stop()
+
+	select {
+	case msg := <-info:
+		assert.Contains(t, msg, "requested window")
+	case <-time.After(5 * time.Second):
+		t.Fatal("follower did not give up on the initial fetch")
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Len(t, starts, 1, "the initial fetch must not look back past the start time")
+}
+
 func TestStreamBuildLogs(t *testing.T) {
 	t.Run("streams regular lines", func(t *testing.T) {
 		body := io.NopCloser(strings.NewReader("line1\nline2\nline3\n"))
@@ -42,6 +42,8 @@ func NewCmd() *cobra.Command {
 	var templateName string
 	var noTTY bool
 	var listTemplates bool
+	var fromGit string
+	var branch string
 
 	cmd := &cobra.Command{
 		Use:               "new [type] [directory]",
@@ -79,12 +81,21 @@ Combine with a type argument to filter: 'bl new agent --list'
 Interactive Mode (Recommended):
 When called without arguments, the CLI guides you through:
 1. Choosing a resource type
-2. Selecting a template (language/framework)
+2. Selecting a template (language/framework), with its description; type to
+   filter the list
 3. Naming your project directory
 4. Setting up initial configuration
 
 Non-Interactive Mode:
-Use --template and --yes flags for automation and CI/CD workflows.
+Use --template and --yes flags for automation and CI/CD workflows. With --yes
+and no --template, the default template for the type is used and its name is
+printed.
+
+Custom Templates:
+Use --from-git with a repository URL to scaffold from your own template
+instead of the built-in ones. The repository is shallow cloned (optionally at
+--branch), its .git directory is removed and it goes through the same setup
+as built-in templates: dependency installation and blaxel.toml updates.
 
 After Creation:
 1. cd into your new directory
@@ -106,6 +117,20 @@ After Creation:
 				return
 			}
 
+			if branch != "" && fromGit == "" {
+				err := fmt.Errorf("--branch requires --from-git")
+				core.PrintError("New", err)
+				core.ExitWithError(err)
+			}
+			if fromGit != "" {
+				if templateName != "" {
+					err := fmt.Errorf("--from-git and --template cannot be used together")
+					core.PrintError("New", err)
+					core.ExitWithError(err)
+				}
+				core.SetGitTemplate(&core.GitTemplate{URL: fromGit, Branch: branch})
+			}
+
 			var t newType
 			dirArg := ""
 
@@ -171,6 +196,8 @@ After Creation:
 	cmd.Flags().StringVarP(&templateName, "template", "t", "", "Template to use (skips interactive prompt)")
 	cmd.Flags().BoolVarP(&noTTY, "yes", "y", false, "Skip interactive prompts and use defaults")
 	cmd.Flags().BoolVarP(&listTemplates, "list", "l", false, "List available templates with descriptions")
+	cmd.Flags().StringVar(&fromGit, "from-git", "", "Scaffold from a git repository URL instead of the built-in templates")
+	cmd.Flags().StringVar(&branch, "branch", "", "Branch or tag to clone with --from-git (defaults to the repository default branch)")
 
 	cmd.Example = `  # Interactive creation (recommended for beginners)
   bl new
@@ -194,6 +221,12 @@ After Creation:
   # Create job with specific template
   bl new job my-batch-job -t jobs-py
 
+  # Create an agent from your own template repository
+  bl new agent my-agent --from-git https://github.com/my-org/my-template
+
+  # Use a specific branch of the template repository
+  bl new agent my-agent --from-git https://github.com/my-org/my-template --branch v2
+
   # List all available templates
   bl new --list
 
@@ -307,7 +340,7 @@ func listAvailableTemplates(filterType string, outputFormat string) {
 
 func printTemplateTable(templates []templateInfo) {
 	t := table.NewWriter()
-	t.SetStyle(table.StyleLight)
+	t.SetStyle(core.TableStyle())
 	t.AppendHeader(table.Row{"NAME", "TYPE", "LANGUAGE", "DESCRIPTION"})
 	for _, tmpl := range templates {
 		desc := tmpl.Description
@@ -291,7 +291,10 @@ For private registries, supply credentials via --registry-cred or --docker-confi
 				config.Type = resourceType
 				validationWarning := ValidateBuildConfig(cwd, folder, config)
 				if validationWarning != "" {
-					handleConfigWarning(validationWarning, noTTY)
+					if err := handleConfigWarning(validationWarning, noTTY); err != nil {
+						core.PrintError("Push", err)
+						core.ExitWithError(&core.ConfigError{Err: err})
+					}
 				}
 
 				// Standard flow: package source code and upload
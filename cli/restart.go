@@ -0,0 +1,206 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/blaxel-ai/toolkit/cli/core"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	core.RegisterCommand("restart", func() *cobra.Command {
+		return RestartCmd()
+	})
+}
+
+// restartedAtEnv is the runtime environment variable bl restart sets to the
+// Unix time of the restart. Labels are metadata and do not roll a resource
+// out, a changed environment always deploys a new revision.
+const restartedAtEnv = "BL_RESTARTED_AT"
+
+// restartTypes are the resource types bl restart supports
+var restartTypes = []string{"agent", "function", "job", "sandbox"}
+
+func RestartCmd() *cobra.Command {
+	var wait bool
+	var timeout time.Duration
+	cmd := &cobra.Command{
+		Use:   "restart resourceType name [name...]",
+		Short: "Roll out a resource again with the image it runs",
+		Args:  cobra.MinimumNArgs(2),
+		Long: `Restart agents, functions, jobs or sandboxes by rolling them out again with
+the image they run, for example to pick up a changed secret or start from a
+clean state.
+
+The API has no restart operation, so the current resource is applied again
+without building it, with the ` + restartedAtEnv + ` environment variable
+set to the time of the restart so that a new revision is deployed. Nothing
+else in the resource changes. The variable stays in the resource until the
+next bl deploy replaces its environment.
+
+Several resources of the same type can be restarted at once. With --wait, the
+command returns only once every restarted resource is DEPLOYED again, and
+fails with a timeout error when one is not after --timeout.`,
+		Example: `  # Restart an agent
+  bl restart agent my-agent
+
+  # Restart two functions and wait until they are deployed again
+  bl restart function search fetch --wait --timeout 5m`,
+		Run: func(cmd *cobra.Command, args []string) {
+			resourceType := strings.ToLower(args[0])
+			err := validateRestartType(resourceType)
+			if err == nil && timeout <= 0 {
+				err = fmt.Errorf("--timeout must be positive, got %s", timeout)
+			}
+			if err == nil && cmd.Flags().Changed("timeout") && !wait {
+				err = fmt.Errorf("--timeout only applies with --wait")
+			}
+			if err != nil {
+				err = &core.ConfigError{Err: err}
+				core.PrintError("Restart", err)
+				core.ExitWithError(err)
+			}
+
+			var firstErr error
+			restarted := []string{}
+			for _, name := range args[1:] {
+				if err := restartResource(resourceType, name, time.Now()); err != nil {
+					core.PrintError("Restart", err)
+					if firstErr == nil {
+						firstErr = err
+					}
+					continue
+				}
+				restarted = append(restarted, name)
+			}
+			if wait && len(restarted) > 0 {
+				if err := waitForRestarts(resourceType, restarted, timeout); err != nil && firstErr == nil {
+					firstErr = err
+				}
+			}
+			if firstErr != nil {
+				core.ExitWithError(firstErr)
+			}
+		},
+	}
+	cmd.Flags().BoolVar(&wait, "wait", false, "Wait until every restarted resource is DEPLOYED again")
+	cmd.Flags().DurationVar(&timeout, "timeout", 10*time.Minute, "Maximum time to wait with --wait")
+	return cmd
+}
+
+// validateRestartType returns an error when resources of resourceType cannot
+// be restarted
+func validateRestartType(resourceType string) error {
+	for _, known := range restartTypes {
+		if resourceType == known {
+			return nil
+		}
+	}
+	return fmt.Errorf("bl restart supports %s, not %s", strings.Join(restartTypes, ", "), resourceType)
+}
+
+// restartResource applies resourceType/name again as it runs, without building it
+func restartResource(resourceType, name string, now time.Time) error {
+	kind, err := imageResourceKind(resourceType)
+	if err != nil {
+		return err
+	}
+	live, err := getResource(resourceType, name)
+	if err != nil {
+		return err
+	}
+	result, err := restartedResource(kind, live, now)
+	if err != nil {
+		return err
+	}
+	results, err := ApplyResources([]core.Result{result})
+	if err != nil {
+		return err
+	}
+	if hasFailedApplyResult(results) {
+		return fmt.Errorf("failed to apply %s %s", resourceType, name)
+	}
+	core.PrintSuccess(fmt.Sprintf("Restarting %s %s", resourceType, name))
+	return nil
+}
+
+// restartedResource returns the manifest applying live with its image, the
+// build skipped and restartedAtEnv set to now
+func restartedResource(kind string, live map[string]interface{}, now time.Time) (core.Result, error) {
+	result, name, spec := liveResourceManifest(kind, live)
+	if spec == nil {
+		return core.Result{}, fmt.Errorf("cannot restart a %s without name or spec", strings.ToLower(kind))
+	}
+	runtime, _ := spec["runtime"].(map[string]interface{})
+	if image, _ := runtime["image"].(string); image == "" {
+		return core.Result{}, fmt.Errorf("%s %s has no image to restart with, deploy it first", strings.ToLower(kind), name)
+	}
+	runtime["skipBuild"] = "true"
+
+	envs := []interface{}{}
+	if liveEnvs, ok := runtime["envs"].([]interface{}); ok {
+		for _, env := range liveEnvs {
+			if entry, ok := env.(map[string]interface{}); ok && entry["name"] == restartedAtEnv {
+				continue
+			}
+			envs = append(envs, env)
+		}
+	}
+	runtime["envs"] = append(envs, map[string]interface{}{
+		"name":  restartedAtEnv,
+		"value": strconv.FormatInt(now.Unix(), 10),
+	})
+	return result, nil
+}
+
+// waitForRestarts waits for every restarted resource to be DEPLOYED, all
+// within timeout. Resources that are not are reported and the first error is
+// returned, a *core.TimeoutError when the timeout expired.
+func waitForRestarts(resourceType string, names []string, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	var firstErr error
+	for _, name := range names {
+		_, err := core.WaitForStatus(ctx, resourceType, name, restartWaitOptions(resourceType, name))
+		var statusErr *core.StatusError
+		switch {
+		case errors.Is(err, context.DeadlineExceeded) || errors.Is(err, core.ErrWaitTimeout):
+			err = &core.TimeoutError{Err: fmt.Errorf("%s %s is not deployed after %s, raise --timeout to wait longer", resourceType, name, timeout)}
+		case errors.As(err, &statusErr):
+			err = fmt.Errorf("restart of %s %s ended with status %s", resourceType, name, statusErr.Status)
+		}
+		if err != nil {
+			core.PrintError("Restart", err)
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		core.PrintSuccess(fmt.Sprintf("%s %s is deployed", resourceType, name))
+	}
+	return firstErr
+}
+
+// restartWaitOptions returns how waitForRestarts waits for resourceType/name.
+// The DEPLOYED status of the revision being replaced is not taken for the
+// restart until the new revision was seen rolling out or the settle grace
+// has passed.
+func restartWaitOptions(resourceType, name string) core.WaitOptions {
+	return core.WaitOptions{
+		Done:   []string{"DEPLOYED"},
+		Failed: deployFailedStatuses,
+		Settle: deployStaleStatusGrace,
+		Accept: rolloutStarted(deployStaleStatusGrace),
+		OnChange: func(status string) {
+			if !core.IsQuiet() {
+				core.PrintDiagnostic(fmt.Sprintf("%s %s: status changed to %s", resourceType, name, status))
+			}
+		},
+	}
+}
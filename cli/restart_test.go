@@ -0,0 +1,101 @@
+package cli
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/blaxel-ai/toolkit/cli/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRestartedResource(t *testing.T) {
+	live := map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"name":      "prod",
+			"labels":    map[string]interface{}{"env": "prod"},
+			"updatedAt": "2025-01-01T00:00:00Z",
+		},
+		"spec": map[string]interface{}{
+			"runtime": map[string]interface{}{
+				"image":  "agent/prod:v2",
+				"memory": 4096.0,
+				"envs": []interface{}{
+					map[string]interface{}{"name": "LOG_LEVEL", "value": "debug"},
+					map[string]interface{}{"name": restartedAtEnv, "value": "1600000000"},
+				},
+			},
+		},
+		"status": "DEPLOYED",
+	}
+	result, err := restartedResource("Agent", live, time.Unix(1700000000, 0))
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]interface{}{
+		"name":      "prod",
+		"labels":    map[string]interface{}{"env": "prod"},
+		"updatedAt": "2025-01-01T00:00:00Z",
+	}, result.Metadata)
+	runtime := result.Spec.(map[string]interface{})["runtime"].(map[string]interface{})
+	assert.Equal(t, "agent/prod:v2", runtime["image"])
+	assert.Equal(t, "true", runtime["skipBuild"])
+	assert.Equal(t, 4096.0, runtime["memory"])
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"name": "LOG_LEVEL", "value": "debug"},
+		map[string]interface{}{"name": restartedAtEnv, "value": "1700000000"},
+	}, runtime["envs"])
+
+	live = map[string]interface{}{
+		"metadata": map[string]interface{}{"name": "new"},
+		"spec":     map[string]interface{}{},
+	}
+	_, err = restartedResource("Function", live, time.Now())
+	assert.EqualError(t, err, "function new has no image to restart with, deploy it first")
+}
+
+func TestValidateRestartType(t *testing.T) {
+	assert.NoError(t, validateRestartType("agent"))
+	assert.EqualError(t, validateRestartType("volume"), "bl restart supports agent, function, job, sandbox, not volume")
+}
+
+func TestRestartWaitOptionsIgnoresStaleDeployed(t *testing.T) {
+	statuses := func(sequence ...string) func(context.Context) (string, error) {
+		return func(context.Context) (string, error) {
+			status := sequence[0]
+			if len(sequence) > 1 {
+				sequence = sequence[1:]
+			}
+			return status, nil
+		}
+	}
+
+	// The previous revision stays DEPLOYED, the restart never rolled out
+	opts := restartWaitOptions("agent", "prod")
+	opts.Interval = time.Millisecond
+	opts.Timeout = 20 * time.Millisecond
+	opts.Fetch = statuses("DEPLOYED")
+	_, err := core.WaitForStatus(context.Background(), "agent", "prod", opts)
+	assert.ErrorIs(t, err, core.ErrWaitTimeout)
+
+	opts = restartWaitOptions("agent", "prod")
+	opts.Interval = time.Millisecond
+	opts.Timeout = time.Second
+	opts.Fetch = statuses("DEPLOYED", "DEPLOYING", "DEPLOYED")
+	status, err := core.WaitForStatus(context.Background(), "agent", "prod", opts)
+	require.NoError(t, err)
+	assert.Equal(t, "DEPLOYED", status)
+}
+
+func TestRolloutStartedAfterSettle(t *testing.T) {
+	accept := rolloutStarted(20 * time.Millisecond)
+	assert.False(t, accept("DEPLOYED"))
+	// A rollout done between two polls only ever shows DEPLOYED
+	time.Sleep(20 * time.Millisecond)
+	assert.True(t, accept("DEPLOYED"))
+
+	accept = rolloutStarted(time.Hour)
+	assert.False(t, accept("DEPLOYED"))
+	assert.True(t, accept("DEPLOYING"))
+	assert.True(t, accept("DEPLOYED"))
+}
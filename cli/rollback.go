@@ -0,0 +1,216 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	blaxel "github.com/blaxel-ai/sdk-go"
+	"github.com/blaxel-ai/toolkit/cli/core"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	core.RegisterCommand("rollback", func() *cobra.Command {
+		return RollbackCmd()
+	})
+}
+
+// rollbackListedTags is how many recent tags rollback prints
+const rollbackListedTags = 5
+
+func RollbackCmd() *cobra.Command {
+	var to string
+	var timeout time.Duration
+	cmd := &cobra.Command{
+		Use:   "rollback resourceType name",
+		Short: "Redeploy a resource with a previous image",
+		Args:  cobra.ExactArgs(2),
+		Long: `Redeploy an agent, function, job, sandbox or application with an image tag
+it ran before, without rebuilding anything.
+
+The tags of the image of the resource are listed from the most recent. By
+default the resource goes back to the tag built right before the one it runs;
+pass --to to pick another tag, as listed by 'bl history'. The tag must
+exist, it is checked before anything is applied.
+
+Only the runtime image of the resource changes, the rest of its spec is
+applied as is. The command then waits until the resource is DEPLOYED and
+exits with a timeout error when it is not after --timeout. The rollback fails
+if the resource was changed while it was being applied.`,
+		Example: `  # Go back to the image built before the current one
+  bl rollback agent my-agent
+
+  # Go back to a given tag
+  bl rollback agent my-agent --to a1b2c3
+
+  # List the tags to pick from
+  bl history agent my-agent`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if timeout <= 0 {
+				err := &core.ConfigError{Err: fmt.Errorf("--timeout must be positive, got %s", timeout)}
+				core.PrintError("Rollback", err)
+				core.ExitWithError(err)
+			}
+			if err := rollback(args[0], args[1], to, timeout); err != nil {
+				core.PrintError("Rollback", err)
+				core.ExitWithError(err)
+			}
+		},
+	}
+	cmd.Flags().StringVar(&to, "to", "", "Image tag to roll back to, the tag before the current one by default")
+	cmd.Flags().DurationVar(&timeout, "timeout", 10*time.Minute, "Maximum time to wait for the resource to be DEPLOYED")
+	return cmd
+}
+
+// rollback applies resourceType/name with the to tag of its image, or the tag
+// before its current one, and waits until it is deployed
+func rollback(resourceType, name, to string, timeout time.Duration) error {
+	kind, err := imageResourceKind(resourceType)
+	if err != nil {
+		return err
+	}
+	live, err := getResource(resourceType, name)
+	if err != nil {
+		return err
+	}
+	current := liveImageRef(kind, live)
+	imageType, imageName, currentTag, err := parseImageRef(current)
+	if err != nil || currentTag == "" {
+		return &core.ConfigError{Err: fmt.Errorf("%s %s does not run an image built by Blaxel (image '%s'), it cannot be rolled back", resourceType, name, current)}
+	}
+
+	image, err := core.GetClient().Images.Get(context.Background(), imageName, blaxel.ImageGetParams{ResourceType: imageType})
+	if err != nil {
+		return fmt.Errorf("error getting image %s/%s: %w", imageType, imageName, err)
+	}
+	tags := sortedImageTags(image.Spec.Tags)
+	target, err := rollbackTarget(tags, currentTag, to)
+	if err != nil {
+		return &core.ConfigError{Err: fmt.Errorf("image %s/%s: %w", imageType, imageName, err)}
+	}
+	printRollbackTags(tags, currentTag, target)
+
+	imageRef := fmt.Sprintf("%s/%s:%s", imageType, imageName, target)
+	result, err := promotedResource(kind, live, imageRef)
+	if err != nil {
+		return err
+	}
+	results, err := ApplyResources([]core.Result{result})
+	if err != nil {
+		return err
+	}
+	if hasFailedApplyResult(results) {
+		return fmt.Errorf("failed to apply %s %s", resourceType, name)
+	}
+
+	_, err = core.WaitForStatus(context.Background(), resourceType, name, core.WaitOptions{
+		Timeout: timeout,
+		Done:    []string{"DEPLOYED"},
+		Failed:  deployFailedStatuses,
+		Settle:  deployStaleStatusGrace,
+		Accept:  rolloutStarted(deployStaleStatusGrace),
+		OnChange: func(status string) {
+			if !core.IsQuiet() {
+				core.PrintDiagnostic(fmt.Sprintf("Status changed to: %s", status))
+			}
+		},
+	})
+	var statusErr *core.StatusError
+	switch {
+	case errors.Is(err, core.ErrWaitTimeout):
+		return &core.TimeoutError{Err: fmt.Errorf("%s %s is not deployed after %s", resourceType, name, timeout)}
+	case errors.As(err, &statusErr):
+		return fmt.Errorf("rollback of %s %s to %s ended with status %s", resourceType, name, imageRef, statusErr.Status)
+	case err != nil:
+		return err
+	}
+	core.PrintSuccess(fmt.Sprintf("Rolled back %s/%s from %s to %s", resourceType, name, currentTag, target))
+	return nil
+}
+
+// imageResourceKind returns the kind of the resources of resourceType
+func imageResourceKind(resourceType string) (string, error) {
+	for _, r := range core.GetResources() {
+		if strings.EqualFold(r.Singular, resourceType) {
+			return r.Kind, nil
+		}
+	}
+	return "", &core.ConfigError{Err: fmt.Errorf("unknown resource type: %s", resourceType)}
+}
+
+// liveImageRef returns the image a resource of kind runs, "" when it has none
+func liveImageRef(kind string, live map[string]interface{}) string {
+	spec, _ := live["spec"].(map[string]interface{})
+	if kind == "Application" {
+		revisions, _ := spec["revisions"].([]interface{})
+		if len(revisions) == 0 {
+			return ""
+		}
+		revision, _ := revisions[0].(map[string]interface{})
+		image, _ := revision["image"].(string)
+		return image
+	}
+	runtime, _ := spec["runtime"].(map[string]interface{})
+	image, _ := runtime["image"].(string)
+	return image
+}
+
+// sortedImageTags returns a copy of tags, the most recent first
+func sortedImageTags(tags []blaxel.ImageSpecTag) []blaxel.ImageSpecTag {
+	sorted := append([]blaxel.ImageSpecTag(nil), tags...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].CreatedAt > sorted[j].CreatedAt
+	})
+	return sorted
+}
+
+// rollbackTarget returns the tag to roll back to among tags, sorted from the
+// most recent: to when set, the tag before current otherwise
+func rollbackTarget(tags []blaxel.ImageSpecTag, current, to string) (string, error) {
+	if to != "" {
+		if !imageHasTag(tags, to) {
+			return "", fmt.Errorf("tag '%s' not found", to)
+		}
+		if to == current {
+			return "", fmt.Errorf("tag '%s' is already deployed", to)
+		}
+		return to, nil
+	}
+	for i, tag := range tags {
+		if tag.Name != current {
+			continue
+		}
+		if i == len(tags)-1 {
+			return "", fmt.Errorf("no tag older than the deployed '%s'", current)
+		}
+		return tags[i+1].Name, nil
+	}
+	return "", fmt.Errorf("the deployed tag '%s' is not listed anymore, pick one with --to", current)
+}
+
+// printRollbackTags prints the most recent tags, marking the current and
+// target ones
+func printRollbackTags(tags []blaxel.ImageSpecTag, current, target string) {
+	if core.IsQuiet() {
+		return
+	}
+	core.PrintInfo("Recent tags:")
+	for i, tag := range tags {
+		if i == rollbackListedTags {
+			core.PrintInfo(fmt.Sprintf("  ... %d more", len(tags)-i))
+			break
+		}
+		line := fmt.Sprintf("  %s  %s", tag.Name, tag.CreatedAt)
+		switch tag.Name {
+		case current:
+			line += "  (deployed)"
+		case target:
+			line += "  (rollback target)"
+		}
+		core.PrintInfo(line)
+	}
+}
@@ -0,0 +1,50 @@
+package cli
+
+import (
+	"testing"
+
+	blaxel "github.com/blaxel-ai/sdk-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRollbackTarget(t *testing.T) {
+	tags := sortedImageTags([]blaxel.ImageSpecTag{
+		{Name: "v1", CreatedAt: "2025-01-01T00:00:00Z"},
+		{Name: "v3", CreatedAt: "2025-01-03T00:00:00Z"},
+		{Name: "v2", CreatedAt: "2025-01-02T00:00:00Z"},
+	})
+	assert.Equal(t, []string{"v3", "v2", "v1"}, []string{tags[0].Name, tags[1].Name, tags[2].Name})
+
+	target, err := rollbackTarget(tags, "v3", "")
+	require.NoError(t, err)
+	assert.Equal(t, "v2", target)
+
+	target, err = rollbackTarget(tags, "v3", "v1")
+	require.NoError(t, err)
+	assert.Equal(t, "v1", target)
+
+	_, err = rollbackTarget(tags, "v1", "")
+	assert.EqualError(t, err, "no tag older than the deployed 'v1'")
+	_, err = rollbackTarget(tags, "gone", "")
+	assert.EqualError(t, err, "the deployed tag 'gone' is not listed anymore, pick one with --to")
+	_, err = rollbackTarget(tags, "v3", "v9")
+	assert.EqualError(t, err, "tag 'v9' not found")
+	_, err = rollbackTarget(tags, "v3", "v3")
+	assert.EqualError(t, err, "tag 'v3' is already deployed")
+}
+
+func TestLiveImageRef(t *testing.T) {
+	agent := map[string]interface{}{
+		"spec": map[string]interface{}{"runtime": map[string]interface{}{"image": "agent/prod:v2"}},
+	}
+	assert.Equal(t, "agent/prod:v2", liveImageRef("Agent", agent))
+
+	app := map[string]interface{}{
+		"spec": map[string]interface{}{"revisions": []interface{}{map[string]interface{}{"image": "application/app:v1"}}},
+	}
+	assert.Equal(t, "application/app:v1", liveImageRef("Application", app))
+
+	assert.Empty(t, liveImageRef("Agent", map[string]interface{}{}))
+	assert.Empty(t, liveImageRef("Application", map[string]interface{}{"spec": map[string]interface{}{}}))
+}
@@ -4,10 +4,12 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
+	"slices"
 	"strings"
 	"sync"
 	"time"
@@ -48,7 +50,12 @@ func RunCmd() *cobra.Command {
 	var folder string
 	var concurrent int
 	var stream bool
-	var timeout int
+	var rawFrames bool
+	var schemaPath string
+	var repeat int
+	var timeoutStr string
+	var waitForExecution bool
+	var followExecutionLogs bool
 	cmd := &cobra.Command{
 		Use:               "run resource-type resource-name",
 		Args:              cobra.ExactArgs(2),
@@ -79,12 +86,47 @@ Local vs Remote:
 
 Input Formats:
 - Inline JSON with --data json-object
-- From file with --file path/to/input.json
+- From a file with --data @path/to/input.json, sent as is like with curl
+- From stdin with --data -, for example piped from another command
+- From file with --file path/to/input.json, YAML files are converted to JSON
+
+Data read with @ or - is checked to be valid JSON, with the line and column of
+the first error, unless a --header sets a Content-Type that is not JSON.
 
 Streaming:
 When agents respond via SSE (Server-Sent Events), the CLI automatically detects
 and parses the stream. Use --stream to explicitly request streaming mode and
-print chunks in real-time as they arrive.
+print chunks in real-time as they arrive. It also streams chunked responses that
+have no Content-Type. When the response is not streamed, for example JSON, it is
+printed once complete, as without --stream. Add --raw to print the response
+exactly as received, SSE frames included, to debug an agent's stream.
+
+Job Batches:
+A job runs the tasks of a batch, an object with a tasks array of objects. The
+batch given with --file is checked before it is sent, and mistakes are
+reported with their line. Pass --schema with a JSON schema to also check every
+task against it. The type, enum, required, properties, additionalProperties,
+items, minimum and maximum keywords are supported, others are ignored.
+
+Add --wait to follow the execution a job run starts until it finishes, printing
+its status changes, or --logs to print its logs instead. The command then
+exits with an error if the execution failed, was cancelled or timed out. Run
+'bl logs job my-job <Tab>' to complete the latest execution ID later on.
+
+Timeouts:
+The request, including reading a streamed response, is bounded by --timeout,
+10 minutes by default. It takes a number of seconds or a duration like 90s,
+5m or 1h, and 0 waits forever. When it expires the command says so, as
+opposed to an error returned by the resource, and exits with code 7 so
+scripts can retry.
+
+Load Testing:
+Pass --repeat N to send the same request N times, up to --concurrent at a
+time, and print the success and error counts, the total duration and the
+p50, p90 and p99 latencies of the successful requests instead of the
+responses. Each request has its own --timeout. Use -o json for a summary to
+track in CI. The command exits with a non-zero code if any request failed.
+Jobs are not supported, since every request would start an execution.
 
 Advanced Usage:
 Use --path, --method, and --params for custom HTTP requests to your resources.
@@ -95,15 +137,31 @@ This is useful for testing specific endpoints or non-standard API calls.`,
   # Run agent with file input
   bl run agent my-agent --file request.json
 
+  # Read the data from a file or from stdin, like curl
+  bl run agent my-agent -d @payload.json
+  jq -n '{inputs: "hello"}' | bl run agent my-agent -d -
+
   # Run agent with real-time streaming output
   bl run agent my-agent --data '{"inputs": "hello"}' --stream
 
+  # Print the raw SSE frames of the stream
+  bl run agent my-agent --data '{"inputs": "hello"}' --stream --raw
+
   # Run agent with timeout
   bl run agent my-agent --data '{"inputs": "hello"}' --timeout 120
 
+  # Smoke test an agent with 100 requests, 10 at a time
+  bl run agent my-agent -d '{"inputs": "hello"}' --repeat 100 --concurrent 10
+
   # Run job with batch file
   bl run job my-job --file batches/process-users.json
 
+  # Check each task of the batch against a JSON schema before running the job
+  bl run job my-job --file batch.json --schema task.schema.json
+
+  # Run job and print its logs until the execution finishes
+  bl run job my-job --file batch.json --logs
+
   # Run job locally for testing (requires 'bl serve' in another terminal)
   bl run job my-job --local --file batch.json
 
@@ -162,6 +220,10 @@ This is useful for testing specific endpoints or non-standard API calls.`,
 			headers := make(map[string]string)
 			outputFormat := core.GetOutputFormat()
 			dataFromInlineFlag := data != ""
+			// rawJSON is the content of a JSON --file, to locate errors in it
+			var rawJSON []byte
+			// dataSource names where -d @file or -d - read the data from
+			dataSource := ""
 
 			// Parse header flags into map
 			for _, header := range headerFlags {
@@ -176,6 +238,17 @@ This is useful for testing specific endpoints or non-standard API calls.`,
 				headers[key] = value
 			}
 
+			if isDataReference(data) {
+				var err error
+				data, dataSource, err = readRunData(data, os.Stdin)
+				if err != nil {
+					core.PrintError("Run", err)
+					core.ExitWithError(err)
+				}
+				rawJSON = []byte(data)
+				dataFromInlineFlag = false
+			}
+
 			if filePath != "" {
 				fileContent, err := os.ReadFile(filePath)
 				if err != nil {
@@ -198,6 +271,7 @@ This is useful for testing specific endpoints or non-standard API calls.`,
 					data = string(jsonBytes)
 				} else {
 					data = string(fileContent)
+					rawJSON = fileContent
 				}
 				dataFromInlineFlag = false
 			}
@@ -220,6 +294,43 @@ This is useful for testing specific endpoints or non-standard API calls.`,
 			isJob := resourceType == "job" || resourceType == "jobs"
 			isRawOutput := outputFormat == "json" || outputFormat == "yaml"
 
+			waitForExecution = waitForExecution || followExecutionLogs
+			if waitForExecution && !isJob {
+				core.StrictWarning("Run", "--wait and --logs only apply to jobs, ignoring them")
+				waitForExecution = false
+			}
+			if schemaPath != "" && !isJob {
+				core.StrictWarning("Run", "--schema only applies to jobs, ignoring it")
+			}
+			if dataSource != "" && !isJob && expectsJSONBody(headers) {
+				if err := validateRunDataJSON(data, dataSource); err != nil {
+					core.PrintError("Run", err)
+					core.ExitWithError(err)
+				}
+			}
+			if isJob && (filePath != "" || dataSource != "" || schemaPath != "") {
+				var schema map[string]interface{}
+				if schemaPath != "" {
+					var err error
+					schema, err = readJobSchema(schemaPath)
+					if err != nil {
+						core.PrintError("Run", err)
+						core.ExitWithError(err)
+					}
+				}
+				if err := validateJobBatch(data, rawJSON, schema); err != nil {
+					source := "--data"
+					if filePath != "" {
+						source = filePath
+					} else if dataSource != "" {
+						source = dataSource
+					}
+					err = fmt.Errorf("invalid job batch in %s: %w", source, err)
+					core.PrintError("Run", err)
+					core.ExitWithError(err)
+				}
+			}
+
 			if isJob && local {
 				runJobLocally(data, folder, core.GetConfig(), concurrent)
 				os.Exit(0)
@@ -254,12 +365,44 @@ This is useful for testing specific endpoints or non-standard API calls.`,
 			}
 
 			// Add streaming headers when --stream flag is set
-			if stream {
+			if stream || rawFrames {
 				headers["Accept"] = "text/event-stream"
 				headers["Cache-Control"] = "no-cache"
 			}
 
 			// Set up context with optional timeout
+			timeout, err := core.ParseDurationToSeconds(timeoutStr)
+			if err != nil {
+				err = fmt.Errorf("invalid --timeout: %w", err)
+				core.PrintError("Run", err)
+				core.ExitWithError(&core.ConfigError{Err: err})
+			}
+			if repeat > 1 || cmd.Flags().Changed("repeat") {
+				if repeat < 1 || concurrent < 1 {
+					err := fmt.Errorf("--repeat and --concurrent must be at least 1")
+					core.PrintError("Run", err)
+					core.ExitWithError(&core.ConfigError{Err: err})
+				}
+				if isJob {
+					err := fmt.Errorf("--repeat is not supported for jobs, every request would start an execution")
+					core.PrintError("Run", err)
+					core.ExitWithError(&core.ConfigError{Err: err})
+				}
+				if debug || rawFrames {
+					core.StrictWarning("Run", "--debug and --raw are ignored with --repeat, responses are not printed")
+				}
+				if !isRawOutput {
+					core.PrintInfo(fmt.Sprintf("Sending %d requests to %s %s, %d at a time...", repeat, resourceType, resourceName, concurrent))
+				}
+				send := benchRequest(core.GetWorkspace(), resourceType, resourceName, method, path, headers, params, data, local, port)
+				summary := runBenchmark(repeat, concurrent, time.Duration(timeout)*time.Second, send)
+				printBenchSummary(summary, outputFormat)
+				if summary.Failed > 0 {
+					core.ExitWithError(fmt.Errorf("%d of %d requests failed", summary.Failed, summary.Requests))
+				}
+				return
+			}
+
 			ctx := context.Background()
 			if timeout > 0 {
 				var cancel context.CancelFunc
@@ -284,7 +427,7 @@ This is useful for testing specific endpoints or non-standard API calls.`,
 			)
 			if err != nil {
 				if ctx.Err() == context.DeadlineExceeded {
-					err = fmt.Errorf("request timed out after %ds", timeout)
+					err = runTimeoutError(resourceType, resourceName, timeout)
 				} else {
 					err = fmt.Errorf("error making request: %w", err)
 				}
@@ -310,9 +453,20 @@ This is useful for testing specific endpoints or non-standard API calls.`,
 
 			// Detect streaming response
 			contentType := res.Header.Get("Content-Type")
-			isSSE := core.IsStreamingResponse(contentType)
+			isSSE := isStreamedResponse(res, stream)
 
-			if isSSE || stream {
+			if rawFrames {
+				// Copy the body as it arrives, without parsing the frames
+				if _, err := io.Copy(os.Stdout, res.Body); err != nil {
+					if ctx.Err() == context.DeadlineExceeded {
+						err = runTimeoutError(resourceType, resourceName, timeout)
+						core.PrintError("Run", err)
+						core.ExitWithError(err)
+					}
+					core.PrintError("Run", fmt.Errorf("error reading stream: %w", err))
+					core.ExitWithError(err)
+				}
+			} else if isSSE {
 				// Handle streaming response
 				var accumulated strings.Builder
 				err := core.ReadSSEStream(res.Body, func(chunk string) {
@@ -325,7 +479,9 @@ This is useful for testing specific endpoints or non-standard API calls.`,
 				})
 				if err != nil {
 					if ctx.Err() == context.DeadlineExceeded {
-						err = fmt.Errorf("request timed out after %ds", timeout)
+						err = runTimeoutError(resourceType, resourceName, timeout)
+						core.PrintError("Run", err)
+						core.ExitWithError(err)
 					}
 					core.PrintError("Run", fmt.Errorf("error reading stream: %w", err))
 					core.ExitWithError(err)
@@ -346,9 +502,17 @@ This is useful for testing specific endpoints or non-standard API calls.`,
 					fmt.Print(string(yamlData))
 				}
 			} else {
+				if stream {
+					core.PrintDiagnostic(fmt.Sprintf("The response is not streamed (Content-Type: %s), printing it once complete", contentType))
+				}
 				// Non-streaming: read full body
 				body, err := io.ReadAll(res.Body)
 				if err != nil {
+					if ctx.Err() == context.DeadlineExceeded {
+						err = runTimeoutError(resourceType, resourceName, timeout)
+						core.PrintError("Run", err)
+						core.ExitWithError(err)
+					}
 					err = fmt.Errorf("error reading response: %w", err)
 					core.PrintError("Run", err)
 					core.ExitWithError(err)
@@ -356,18 +520,7 @@ This is useful for testing specific endpoints or non-standard API calls.`,
 
 				// Handle job-specific success output (skip if raw output format)
 				if isJob && res.StatusCode < 400 && !isRawOutput {
-					var responseData map[string]interface{}
-					executionID := ""
-					if err := json.Unmarshal(body, &responseData); err == nil {
-						if id, ok := responseData["execution_id"].(string); ok {
-							executionID = id
-						} else if id, ok := responseData["executionId"].(string); ok {
-							executionID = id
-						} else if id, ok := responseData["id"].(string); ok {
-							executionID = id
-						}
-					}
-
+					executionID := jobExecutionID(body)
 					if executionID != "" {
 						shortID := executionID
 						if len(shortID) > 8 {
@@ -405,12 +558,25 @@ This is useful for testing specific endpoints or non-standard API calls.`,
 						core.Print(string(body))
 					}
 				}
+
+				if isJob && waitForExecution && res.StatusCode < 400 {
+					executionID := jobExecutionID(body)
+					if executionID == "" {
+						err := fmt.Errorf("the response has no execution ID to wait for")
+						core.PrintError("Run", err)
+						core.ExitWithError(err)
+					}
+					if err := waitForJobExecution(core.GetWorkspace(), resourceName, executionID, followExecutionLogs); err != nil {
+						core.PrintError("Run", err)
+						core.ExitWithError(err)
+					}
+				}
 			}
 		},
 	}
 
 	cmd.Flags().StringVarP(&filePath, "file", "f", "", "Input from a file")
-	cmd.Flags().StringVarP(&data, "data", "d", "", "JSON body data for the inference request")
+	cmd.Flags().StringVarP(&data, "data", "d", "", "JSON body data for the inference request, @file to read it from a file or - from stdin")
 	cmd.Flags().StringVar(&path, "path", "", "path for the inference request")
 	cmd.Flags().StringVar(&method, "method", "POST", "HTTP method for the inference request")
 	cmd.Flags().StringSliceVar(&params, "params", []string{}, "Query params sent to the inference request")
@@ -422,12 +588,27 @@ This is useful for testing specific endpoints or non-standard API calls.`,
 	cmd.Flags().StringSliceVarP(&envFiles, "env-file", "e", []string{".env"}, "Environment file to load")
 	cmd.Flags().StringSliceVarP(&commandSecrets, "secrets", "s", []string{}, "Secrets to pass to the execution")
 	cmd.Flags().StringVar(&folder, "directory", "", "Directory to run the command from")
-	cmd.Flags().IntVarP(&concurrent, "concurrent", "c", 1, "Number of concurrent workers for local job execution")
+	cmd.Flags().IntVarP(&concurrent, "concurrent", "c", 1, "Number of concurrent workers for local job execution, or of requests in flight with --repeat")
 	cmd.Flags().BoolVar(&stream, "stream", false, "Stream SSE responses in real-time")
-	cmd.Flags().IntVar(&timeout, "timeout", 0, "Request timeout in seconds (default: no timeout)")
+	cmd.Flags().IntVar(&repeat, "repeat", 1, "Send the request this many times and print latency statistics instead of the responses")
+	cmd.Flags().StringVar(&schemaPath, "schema", "", "JSON schema to check each task of a job batch against before running it")
+	cmd.Flags().BoolVar(&rawFrames, "raw", false, "Print the response as received, SSE frames included, without extracting the text")
+	cmd.Flags().BoolVar(&waitForExecution, "wait", false, "For jobs, wait for the execution to finish and exit with an error if it did not succeed")
+	cmd.Flags().BoolVar(&followExecutionLogs, "logs", false, "For jobs, print the logs of the execution until it finishes, implies --wait")
+	cmd.Flags().StringVar(&timeoutStr, "timeout", "10m", "Request timeout, in seconds or as a duration like 90s, 5m or 1h, 0 for none")
 	return cmd
 }
 
+// isStreamedResponse reports whether res is read as a stream: SSE, NDJSON or
+// text, or with --stream a chunked response without Content-Type
+func isStreamedResponse(res *http.Response, stream bool) bool {
+	contentType := res.Header.Get("Content-Type")
+	if core.IsStreamingResponse(contentType) {
+		return true
+	}
+	return stream && contentType == "" && slices.Contains(res.TransferEncoding, "chunked")
+}
+
 func isSandboxResource(resourceType string) bool {
 	return resourceType == "sandbox" || resourceType == "sandboxes"
 }
@@ -439,6 +620,63 @@ func normalizeRequestPath(path string) string {
 	return "/" + path
 }
 
+// runTimeoutError tells that the request gave up waiting, which is not an
+// error of the resource
+func runTimeoutError(resourceType, resourceName string, timeout int) error {
+	after := (time.Duration(timeout) * time.Second).String()
+	return &core.TimeoutError{Err: fmt.Errorf("timed out after %s waiting for %s %s to respond, the request may still be running; raise --timeout to wait longer", after, resourceType, resourceName)}
+}
+
+// isDataReference reports whether --data names a file (@path) or stdin (-)
+// to read the data from instead of holding it
+func isDataReference(data string) bool {
+	return data == "-" || strings.HasPrefix(data, "@")
+}
+
+// readRunData reads the data --data refers to, and returns it with the name
+// of its source for error messages
+func readRunData(data string, stdin io.Reader) (string, string, error) {
+	if data == "-" || data == "@-" {
+		content, err := io.ReadAll(stdin)
+		if err != nil {
+			return "", "", fmt.Errorf("error reading data from stdin: %w", err)
+		}
+		return string(content), "stdin", nil
+	}
+	path := strings.TrimPrefix(data, "@")
+	if path == "" {
+		return "", "", errors.New("--data @ needs a file name, like --data @payload.json")
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", "", fmt.Errorf("error reading data file: %w", err)
+	}
+	return string(content), path, nil
+}
+
+// expectsJSONBody reports whether the body is sent as JSON, which it is
+// unless a --header sets another Content-Type
+func expectsJSONBody(headers map[string]string) bool {
+	for key, value := range headers {
+		if strings.EqualFold(key, "Content-Type") {
+			return strings.Contains(strings.ToLower(value), "json")
+		}
+	}
+	return true
+}
+
+// validateRunDataJSON checks that data read from source is JSON
+func validateRunDataJSON(data, source string) error {
+	if strings.TrimSpace(data) == "" {
+		return fmt.Errorf("no data in %s", source)
+	}
+	var raw json.RawMessage
+	if err := json.Unmarshal([]byte(data), &raw); err != nil {
+		return fmt.Errorf("invalid JSON in %s%s: %w", source, jsonErrorPosition([]byte(data), err), err)
+	}
+	return nil
+}
+
 func validateInlineRunDataJSON(data, resourceType, path string) error {
 	if !isSandboxResource(resourceType) || normalizeRequestPath(path) != "/process" {
 		return nil
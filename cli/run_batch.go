@@ -0,0 +1,253 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// validateJobBatch checks a batch file before it is submitted: it must be a
+// JSON object with a tasks array of objects. Each task is also checked against
+// schema when it is not nil. When raw is the JSON of the file, issues give
+// the line of the task.
+func validateJobBatch(data string, raw []byte, schema map[string]interface{}) error {
+	var batch interface{}
+	if err := json.Unmarshal([]byte(data), &batch); err != nil {
+		return fmt.Errorf("invalid JSON%s: %w", jsonErrorPosition(raw, err), err)
+	}
+
+	object, ok := batch.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("a job batch must be an object with a tasks array, got %s", jsonTypeName(batch))
+	}
+	value, ok := object["tasks"]
+	if !ok {
+		return errors.New(`missing "tasks": a job batch must be an object with a tasks array, like {"tasks": [{...}]}`)
+	}
+	tasks, ok := value.([]interface{})
+	if !ok {
+		return fmt.Errorf("tasks: must be an array, got %s", jsonTypeName(value))
+	}
+
+	lines := taskLines(raw)
+	issues := []string{}
+	for i, task := range tasks {
+		prefix := ""
+		if i < len(lines) {
+			prefix = fmt.Sprintf("line %d: ", lines[i])
+		}
+		path := fmt.Sprintf("tasks[%d]", i)
+		if _, ok := task.(map[string]interface{}); !ok {
+			issues = append(issues, fmt.Sprintf("%s%s: must be an object, got %s", prefix, path, jsonTypeName(task)))
+			continue
+		}
+		for _, issue := range validateSchema(task, schema, path) {
+			issues = append(issues, prefix+issue)
+		}
+	}
+	if len(issues) > 0 {
+		return fmt.Errorf("%d invalid task(s):\n  %s", len(issues), strings.Join(issues, "\n  "))
+	}
+	return nil
+}
+
+// readJobSchema reads the JSON schema of --schema
+func readJobSchema(path string) (map[string]interface{}, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading schema: %w", err)
+	}
+	var schema map[string]interface{}
+	if err := json.Unmarshal(content, &schema); err != nil {
+		return nil, fmt.Errorf("error parsing schema %s: %w", path, err)
+	}
+	return schema, nil
+}
+
+// validateSchema checks value against the subset of JSON Schema used for job
+// tasks: type, enum, required, properties, additionalProperties, items,
+// minimum and maximum. Other keywords are ignored.
+func validateSchema(value interface{}, schema map[string]interface{}, path string) []string {
+	if schema == nil {
+		return nil
+	}
+	if types := schemaTypes(schema["type"]); len(types) > 0 && !slices.ContainsFunc(types, func(t string) bool { return matchesJSONType(value, t) }) {
+		return []string{fmt.Sprintf("%s: must be %s, got %s", path, strings.Join(types, " or "), jsonTypeName(value))}
+	}
+	issues := []string{}
+	if enum, ok := schema["enum"].([]interface{}); ok && !slices.ContainsFunc(enum, func(e interface{}) bool { return reflect.DeepEqual(e, value) }) {
+		allowed := make([]string, len(enum))
+		for i, e := range enum {
+			data, _ := json.Marshal(e)
+			allowed[i] = string(data)
+		}
+		issues = append(issues, fmt.Sprintf("%s: must be one of %s", path, strings.Join(allowed, ", ")))
+	}
+	if number, ok := value.(float64); ok {
+		if minimum, ok := schema["minimum"].(float64); ok && number < minimum {
+			issues = append(issues, fmt.Sprintf("%s: must be at least %v", path, minimum))
+		}
+		if maximum, ok := schema["maximum"].(float64); ok && number > maximum {
+			issues = append(issues, fmt.Sprintf("%s: must be at most %v", path, maximum))
+		}
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if required, ok := schema["required"].([]interface{}); ok {
+			for _, name := range required {
+				if key, ok := name.(string); ok {
+					if _, present := v[key]; !present {
+						issues = append(issues, fmt.Sprintf("%s.%s: required", path, key))
+					}
+				}
+			}
+		}
+		properties, _ := schema["properties"].(map[string]interface{})
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			if propertySchema, ok := properties[key].(map[string]interface{}); ok {
+				issues = append(issues, validateSchema(v[key], propertySchema, path+"."+key)...)
+				continue
+			}
+			switch additional := schema["additionalProperties"].(type) {
+			case bool:
+				if !additional {
+					issues = append(issues, fmt.Sprintf("%s.%s: unknown field", path, key))
+				}
+			case map[string]interface{}:
+				issues = append(issues, validateSchema(v[key], additional, path+"."+key)...)
+			}
+		}
+	case []interface{}:
+		if items, ok := schema["items"].(map[string]interface{}); ok {
+			for i, item := range v {
+				issues = append(issues, validateSchema(item, items, fmt.Sprintf("%s[%d]", path, i))...)
+			}
+		}
+	}
+	return issues
+}
+
+// schemaTypes returns the types allowed by the type keyword of a schema
+func schemaTypes(value interface{}) []string {
+	switch t := value.(type) {
+	case string:
+		return []string{t}
+	case []interface{}:
+		types := []string{}
+		for _, item := range t {
+			if s, ok := item.(string); ok {
+				types = append(types, s)
+			}
+		}
+		return types
+	}
+	return nil
+}
+
+func matchesJSONType(value interface{}, schemaType string) bool {
+	if schemaType == "integer" {
+		number, ok := value.(float64)
+		return ok && number == math.Trunc(number)
+	}
+	return jsonTypeName(value) == schemaType
+}
+
+// jsonTypeName returns the JSON Schema type of a value decoded from JSON
+func jsonTypeName(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	}
+	return fmt.Sprintf("%T", value)
+}
+
+// taskLines returns the line on which each element of the top-level tasks
+// array of raw starts, or nil when it cannot be found
+func taskLines(raw []byte) []int {
+	if raw == nil {
+		return nil
+	}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	if token, err := dec.Token(); err != nil || token != json.Delim('{') {
+		return nil
+	}
+	for dec.More() {
+		key, err := dec.Token()
+		if err != nil {
+			return nil
+		}
+		if key != "tasks" {
+			var skip json.RawMessage
+			if err := dec.Decode(&skip); err != nil {
+				return nil
+			}
+			continue
+		}
+		if token, err := dec.Token(); err != nil || token != json.Delim('[') {
+			return nil
+		}
+		lines := []int{}
+		for dec.More() {
+			// InputOffset is the end of the previous token, skip the spaces
+			// and the comma before the element
+			offset := int(dec.InputOffset())
+			for offset < len(raw) && strings.ContainsRune(" \t\r\n,", rune(raw[offset])) {
+				offset++
+			}
+			line, _ := lineAndColumn(raw, int64(offset))
+			lines = append(lines, line)
+			var skip json.RawMessage
+			if err := dec.Decode(&skip); err != nil {
+				return nil
+			}
+		}
+		return lines
+	}
+	return nil
+}
+
+// jsonErrorPosition returns " at line L, column C" for a syntax error of
+// decoding data, or "" when the position is unknown
+func jsonErrorPosition(data []byte, err error) string {
+	var syntaxErr *json.SyntaxError
+	if data == nil || !errors.As(err, &syntaxErr) {
+		return ""
+	}
+	// Offset is just after the byte that could not be parsed
+	line, col := lineAndColumn(data, max(syntaxErr.Offset-1, 0))
+	return fmt.Sprintf(" at line %d, column %d", line, col)
+}
+
+// lineAndColumn converts a byte offset of data to a 1-based line and column
+func lineAndColumn(data []byte, offset int64) (int, int) {
+	if offset > int64(len(data)) {
+		offset = int64(len(data))
+	}
+	before := data[:offset]
+	line := bytes.Count(before, []byte("\n")) + 1
+	column := int(offset) - bytes.LastIndexByte(before, '\n')
+	return line, column
+}
@@ -0,0 +1,73 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateJobBatch(t *testing.T) {
+	valid := `{"tasks": [{"name": "a"}, {"name": "b"}]}`
+	assert.NoError(t, validateJobBatch(valid, []byte(valid), nil))
+	assert.NoError(t, validateJobBatch(`{"tasks": []}`, nil, nil))
+
+	tests := []struct {
+		name string
+		data string
+		want string
+	}{
+		{"syntax", "{\n  \"tasks\": [\n    {\"name\": \"a\"},\n  ]\n}", "invalid JSON at line 4, column 3"},
+		{"not an object", `[{"name": "a"}]`, "got array"},
+		{"missing tasks", `{"task": [{"name": "a"}]}`, `missing "tasks"`},
+		{"tasks not an array", `{"tasks": {"name": "a"}}`, "tasks: must be an array, got object"},
+		{"task not an object", "{\n  \"tasks\": [\n    {\"name\": \"a\"},\n    \"b\"\n  ]\n}", "line 4: tasks[1]: must be an object, got string"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.ErrorContains(t, validateJobBatch(tt.data, []byte(tt.data), nil), tt.want)
+		})
+	}
+}
+
+func TestValidateJobBatchWithSchema(t *testing.T) {
+	schemaPath := filepath.Join(t.TempDir(), "task.schema.json")
+	require.NoError(t, os.WriteFile(schemaPath, []byte(`{
+		"type": "object",
+		"required": ["user_id"],
+		"additionalProperties": false,
+		"properties": {
+			"user_id": {"type": "integer", "minimum": 1},
+			"mode": {"enum": ["full", "delta"]},
+			"tags": {"type": "array", "items": {"type": "string"}}
+		}
+	}`), 0644))
+	schema, err := readJobSchema(schemaPath)
+	require.NoError(t, err)
+
+	valid := `{"tasks": [{"user_id": 1, "mode": "full", "tags": ["a"]}]}`
+	assert.NoError(t, validateJobBatch(valid, []byte(valid), schema))
+
+	data := "{\"tasks\": [\n  {\"user_id\": 1},\n  {\"user_id\": 0.5, \"mode\": \"all\", \"tags\": [1], \"userId\": 2},\n  {\"mode\": \"delta\"}\n]}"
+	err = validateJobBatch(data, []byte(data), schema)
+	require.Error(t, err)
+	assert.Equal(t, `5 invalid task(s):
+  line 3: tasks[1].mode: must be one of "full", "delta"
+  line 3: tasks[1].tags[0]: must be string, got number
+  line 3: tasks[1].userId: unknown field
+  line 3: tasks[1].user_id: must be integer, got number
+  line 4: tasks[2].user_id: required`, err.Error())
+
+	// Without the raw JSON, for YAML files, issues have no line
+	err = validateJobBatch(`{"tasks": [{}]}`, nil, schema)
+	assert.EqualError(t, err, "1 invalid task(s):\n  tasks[0].user_id: required")
+}
+
+func TestReadJobSchemaInvalid(t *testing.T) {
+	schemaPath := filepath.Join(t.TempDir(), "task.schema.json")
+	require.NoError(t, os.WriteFile(schemaPath, []byte(`{"type": `), 0644))
+	_, err := readJobSchema(schemaPath)
+	assert.ErrorContains(t, err, "error parsing schema")
+}
@@ -0,0 +1,172 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// benchSummary is the result of bl run --repeat
+type benchSummary struct {
+	Requests    int            `json:"requests" yaml:"requests"`
+	Concurrency int            `json:"concurrency" yaml:"concurrency"`
+	Succeeded   int            `json:"succeeded" yaml:"succeeded"`
+	Failed      int            `json:"failed" yaml:"failed"`
+	DurationMs  int64          `json:"durationMs" yaml:"durationMs"`
+	Throughput  float64        `json:"requestsPerSecond" yaml:"requestsPerSecond"`
+	Latency     *benchLatency  `json:"latencyMs,omitempty" yaml:"latencyMs,omitempty"`
+	Errors      map[string]int `json:"errors,omitempty" yaml:"errors,omitempty"`
+}
+
+// benchLatency holds the latency percentiles of the successful requests, in
+// milliseconds
+type benchLatency struct {
+	Min int64 `json:"min" yaml:"min"`
+	P50 int64 `json:"p50" yaml:"p50"`
+	P90 int64 `json:"p90" yaml:"p90"`
+	P99 int64 `json:"p99" yaml:"p99"`
+	Max int64 `json:"max" yaml:"max"`
+}
+
+// runBenchmark calls send repeat times with up to concurrency calls in
+// flight, each with its own timeout, and summarizes the results. send returns
+// the HTTP status of the response once its body has been read.
+func runBenchmark(repeat, concurrency int, timeout time.Duration, send func(ctx context.Context) (int, string, error)) benchSummary {
+	type outcome struct {
+		latency time.Duration
+		failure string
+	}
+	outcomes := make([]outcome, repeat)
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+
+	start := time.Now()
+	for w := 0; w < min(concurrency, repeat); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				ctx := context.Background()
+				cancel := context.CancelFunc(func() {})
+				if timeout > 0 {
+					ctx, cancel = context.WithTimeout(ctx, timeout)
+				}
+				sent := time.Now()
+				status, statusText, err := send(ctx)
+				outcomes[i].latency = time.Since(sent)
+				switch {
+				case err != nil && ctx.Err() == context.DeadlineExceeded:
+					outcomes[i].failure = fmt.Sprintf("timed out after %s", timeout)
+				case err != nil:
+					outcomes[i].failure = err.Error()
+				case status >= 400:
+					outcomes[i].failure = statusText
+				}
+				cancel()
+			}
+		}()
+	}
+	for i := 0; i < repeat; i++ {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+	elapsed := time.Since(start)
+
+	summary := benchSummary{
+		Requests:    repeat,
+		Concurrency: concurrency,
+		DurationMs:  elapsed.Milliseconds(),
+		Throughput:  float64(repeat) / elapsed.Seconds(),
+	}
+	latencies := []time.Duration{}
+	for _, o := range outcomes {
+		if o.failure != "" {
+			summary.Failed++
+			if summary.Errors == nil {
+				summary.Errors = map[string]int{}
+			}
+			summary.Errors[o.failure]++
+			continue
+		}
+		summary.Succeeded++
+		latencies = append(latencies, o.latency)
+	}
+	if len(latencies) > 0 {
+		sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+		summary.Latency = &benchLatency{
+			Min: latencies[0].Milliseconds(),
+			P50: percentile(latencies, 50).Milliseconds(),
+			P90: percentile(latencies, 90).Milliseconds(),
+			P99: percentile(latencies, 99).Milliseconds(),
+			Max: latencies[len(latencies)-1].Milliseconds(),
+		}
+	}
+	return summary
+}
+
+// percentile returns the nearest-rank percentile p of sorted
+func percentile(sorted []time.Duration, p int) time.Duration {
+	rank := (p*len(sorted) + 99) / 100
+	return sorted[max(rank, 1)-1]
+}
+
+// benchRequest returns the send function of runBenchmark for a run request
+func benchRequest(workspace, resourceType, resourceName, method, path string, headers map[string]string, params []string, data string, local bool, port int) func(ctx context.Context) (int, string, error) {
+	return func(ctx context.Context) (int, string, error) {
+		res, err := runRequest(ctx, workspace, resourceType, resourceName, method, path, headers, params, data, false, local, port)
+		if err != nil {
+			return 0, "", err
+		}
+		defer func() { _ = res.Body.Close() }()
+		// The latency includes reading the whole response, streamed or not
+		if _, err := io.Copy(io.Discard, res.Body); err != nil {
+			return 0, "", err
+		}
+		return res.StatusCode, res.Status, nil
+	}
+}
+
+// printBenchSummary prints the summary as a table, or as JSON or YAML
+func printBenchSummary(summary benchSummary, outputFormat string) {
+	switch outputFormat {
+	case "json":
+		data, _ := json.MarshalIndent(summary, "", "  ")
+		fmt.Println(string(data))
+		return
+	case "yaml":
+		data, _ := yaml.Marshal(summary)
+		fmt.Print(string(data))
+		return
+	}
+
+	fmt.Printf("Requests:    %d (%d succeeded, %d failed), %d in parallel\n", summary.Requests, summary.Succeeded, summary.Failed, summary.Concurrency)
+	fmt.Printf("Duration:    %s (%.1f req/s)\n", time.Duration(summary.DurationMs)*time.Millisecond, summary.Throughput)
+	if summary.Latency != nil {
+		l := summary.Latency
+		fmt.Printf("Latency:     p50 %dms  p90 %dms  p99 %dms  (min %dms, max %dms)\n", l.P50, l.P90, l.P99, l.Min, l.Max)
+	}
+	if len(summary.Errors) > 0 {
+		messages := make([]string, 0, len(summary.Errors))
+		for message := range summary.Errors {
+			messages = append(messages, message)
+		}
+		sort.Slice(messages, func(i, j int) bool {
+			if summary.Errors[messages[i]] != summary.Errors[messages[j]] {
+				return summary.Errors[messages[i]] > summary.Errors[messages[j]]
+			}
+			return messages[i] < messages[j]
+		})
+		fmt.Println("Errors:")
+		for _, message := range messages {
+			fmt.Printf("  %4d × %s\n", summary.Errors[message], strings.TrimSpace(message))
+		}
+	}
+}
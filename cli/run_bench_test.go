@@ -0,0 +1,73 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunBenchmark(t *testing.T) {
+	var calls, inFlight, maxInFlight atomic.Int32
+	send := func(ctx context.Context) (int, string, error) {
+		n := calls.Add(1)
+		current := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			seen := maxInFlight.Load()
+			if current <= seen || maxInFlight.CompareAndSwap(seen, current) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond)
+		switch {
+		case n%10 == 0:
+			return 500, "500 Internal Server Error", nil
+		case n%7 == 0:
+			return 0, "", errors.New("connection refused")
+		}
+		return 200, "200 OK", nil
+	}
+
+	summary := runBenchmark(30, 4, 0, send)
+	assert.Equal(t, int32(30), calls.Load())
+	assert.LessOrEqual(t, maxInFlight.Load(), int32(4))
+	assert.Equal(t, 30, summary.Requests)
+	assert.Equal(t, 4, summary.Concurrency)
+	assert.Equal(t, 3+4, summary.Failed)
+	assert.Equal(t, 23, summary.Succeeded)
+	assert.Equal(t, map[string]int{"500 Internal Server Error": 3, "connection refused": 4}, summary.Errors)
+	require.NotNil(t, summary.Latency)
+	assert.LessOrEqual(t, summary.Latency.Min, summary.Latency.P50)
+	assert.LessOrEqual(t, summary.Latency.P50, summary.Latency.P99)
+	assert.LessOrEqual(t, summary.Latency.P99, summary.Latency.Max)
+}
+
+func TestRunBenchmarkTimeout(t *testing.T) {
+	send := func(ctx context.Context) (int, string, error) {
+		<-ctx.Done()
+		return 0, "", ctx.Err()
+	}
+	summary := runBenchmark(2, 2, 10*time.Millisecond, send)
+	assert.Equal(t, 2, summary.Failed)
+	assert.Equal(t, map[string]int{"timed out after 10ms": 2}, summary.Errors)
+	assert.Nil(t, summary.Latency, "no latency without successful requests")
+}
+
+func TestPercentile(t *testing.T) {
+	latencies := make([]time.Duration, 100)
+	for i := range latencies {
+		latencies[i] = time.Duration(i+1) * time.Millisecond
+	}
+	assert.Equal(t, 50*time.Millisecond, percentile(latencies, 50))
+	assert.Equal(t, 90*time.Millisecond, percentile(latencies, 90))
+	assert.Equal(t, 99*time.Millisecond, percentile(latencies, 99))
+
+	single := []time.Duration{7 * time.Millisecond}
+	assert.Equal(t, 7*time.Millisecond, percentile(single, 50))
+	assert.Equal(t, 7*time.Millisecond, percentile(single, 99))
+}
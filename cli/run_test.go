@@ -2,10 +2,13 @@ package cli
 
 import (
 	"encoding/json"
+	"net/http"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/blaxel-ai/toolkit/cli/core"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -71,7 +74,39 @@ func TestRunCmd(t *testing.T) {
 
 	timeoutFlag := cmd.Flags().Lookup("timeout")
 	assert.NotNil(t, timeoutFlag)
-	assert.Equal(t, "0", timeoutFlag.DefValue)
+	assert.Equal(t, "10m", timeoutFlag.DefValue)
+
+	rawFlag := cmd.Flags().Lookup("raw")
+	assert.NotNil(t, rawFlag)
+	assert.Equal(t, "false", rawFlag.DefValue)
+
+	assert.NotNil(t, cmd.Flags().Lookup("wait"))
+	assert.NotNil(t, cmd.Flags().Lookup("logs"))
+}
+
+func TestJobExecutionID(t *testing.T) {
+	assert.Equal(t, "exec-1", jobExecutionID([]byte(`{"execution_id": "exec-1"}`)))
+	assert.Equal(t, "exec-2", jobExecutionID([]byte(`{"executionId": "exec-2", "id": "other"}`)))
+	assert.Equal(t, "exec-3", jobExecutionID([]byte(`{"id": "exec-3"}`)))
+	assert.Equal(t, "", jobExecutionID([]byte(`{"status": "queued"}`)))
+	assert.Equal(t, "", jobExecutionID([]byte(`not json`)))
+}
+
+func TestIsStreamedResponse(t *testing.T) {
+	response := func(contentType string, transferEncoding ...string) *http.Response {
+		res := &http.Response{Header: http.Header{}, TransferEncoding: transferEncoding}
+		if contentType != "" {
+			res.Header.Set("Content-Type", contentType)
+		}
+		return res
+	}
+
+	assert.True(t, isStreamedResponse(response("text/event-stream; charset=utf-8"), false))
+	assert.True(t, isStreamedResponse(response("application/x-ndjson"), true))
+	assert.False(t, isStreamedResponse(response("application/json"), true), "JSON falls back to buffered output")
+	assert.True(t, isStreamedResponse(response("", "chunked"), true))
+	assert.False(t, isStreamedResponse(response("", "chunked"), false), "chunked without type only with --stream")
+	assert.False(t, isStreamedResponse(response(""), true))
 }
 
 func TestBatchStruct(t *testing.T) {
@@ -237,3 +272,47 @@ func TestBatchTaskWithMultipleFields(t *testing.T) {
 	tags := task["tags"].([]string)
 	assert.Contains(t, tags, "important")
 }
+
+func TestReadRunData(t *testing.T) {
+	payloadPath := filepath.Join(t.TempDir(), "payload.json")
+	require.NoError(t, os.WriteFile(payloadPath, []byte(`{"inputs": "hello"}`), 0644))
+
+	assert.True(t, isDataReference("@"+payloadPath))
+	assert.True(t, isDataReference("-"))
+	assert.False(t, isDataReference(`{"inputs": "hello"}`))
+
+	data, source, err := readRunData("@"+payloadPath, nil)
+	require.NoError(t, err)
+	assert.Equal(t, `{"inputs": "hello"}`, data)
+	assert.Equal(t, payloadPath, source)
+
+	for _, ref := range []string{"-", "@-"} {
+		data, source, err = readRunData(ref, strings.NewReader(`{"inputs": "piped"}`))
+		require.NoError(t, err)
+		assert.Equal(t, `{"inputs": "piped"}`, data)
+		assert.Equal(t, "stdin", source)
+	}
+
+	_, _, err = readRunData("@", nil)
+	assert.ErrorContains(t, err, "needs a file name")
+	_, _, err = readRunData("@/non/existent.json", nil)
+	assert.ErrorContains(t, err, "error reading data file")
+}
+
+func TestValidateRunDataJSON(t *testing.T) {
+	assert.NoError(t, validateRunDataJSON(`{"inputs": "hello"}`, "stdin"))
+	assert.ErrorContains(t, validateRunDataJSON("{\n  \"inputs\": 'hello'\n}", "payload.json"), "invalid JSON in payload.json at line 2, column 13")
+	assert.ErrorContains(t, validateRunDataJSON("", "stdin"), "no data in stdin")
+}
+
+func TestExpectsJSONBody(t *testing.T) {
+	assert.True(t, expectsJSONBody(map[string]string{}))
+	assert.True(t, expectsJSONBody(map[string]string{"content-type": "application/json; charset=utf-8"}))
+	assert.False(t, expectsJSONBody(map[string]string{"Content-Type": "text/plain"}))
+}
+
+func TestRunTimeoutError(t *testing.T) {
+	err := runTimeoutError("agents", "my-agent", 90)
+	assert.EqualError(t, err, "timed out after 1m30s waiting for agents my-agent to respond, the request may still be running; raise --timeout to wait longer")
+	assert.Equal(t, core.ExitCodeTimeout, core.ExitCode(err))
+}
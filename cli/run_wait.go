@@ -0,0 +1,87 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	blaxel "github.com/blaxel-ai/sdk-go"
+	"github.com/blaxel-ai/toolkit/cli/core"
+)
+
+// jobExecutionWaitInterval is the time between two polls of bl run job --wait
+const jobExecutionWaitInterval = 5 * time.Second
+
+// jobExecutionFailedStatuses end bl run job --wait with an error
+var jobExecutionFailedStatuses = []string{
+	string(blaxel.JobExecutionStatusFailed),
+	string(blaxel.JobExecutionStatusCancelled),
+	string(blaxel.JobExecutionStatusTimeout),
+}
+
+// jobExecutionID returns the ID of the execution created by a job run, from
+// the body of the response, or "" when there is none
+func jobExecutionID(body []byte) string {
+	var responseData map[string]interface{}
+	if err := json.Unmarshal(body, &responseData); err != nil {
+		return ""
+	}
+	for _, key := range []string{"execution_id", "executionId", "id"} {
+		if id, ok := responseData[key].(string); ok && id != "" {
+			return id
+		}
+	}
+	return ""
+}
+
+// waitForJobExecution follows a job execution until it is done, printing its
+// status changes or, with logs, its logs. It returns a *core.StatusError when
+// the execution did not succeed.
+func waitForJobExecution(workspace, jobName, executionID string, logs bool) error {
+	kind := "job execution"
+	if logs {
+		if workspace == "" {
+			ctx, _ := blaxel.CurrentContext()
+			workspace = ctx.Workspace
+		}
+		sources := []logSource{{}}
+		if taskIDs := jobExecutionTaskIDs(jobName, executionID); len(taskIDs) > 1 {
+			sources = taskLogSources("", "job", jobName, taskIDs)
+		}
+		// Leave some room for the clock of the logs, the execution just started
+		startTime := time.Now().UTC().Add(-time.Minute)
+		status := followLogs(workspace, "job", jobName, startTime, false, false, false, "", "", executionID, sources, 0, structuredLogOptions{})
+		if status == "" {
+			core.PrintInfoWithCommand("Execution still running, follow it with:", fmt.Sprintf("bl logs job %s %s -f", jobName, executionID))
+			return nil
+		}
+		if !strings.EqualFold(status, string(blaxel.JobExecutionStatusSucceeded)) {
+			return &core.StatusError{Kind: kind, Name: executionID, Status: strings.ToLower(status)}
+		}
+		return nil
+	}
+
+	client := core.GetClient()
+	status, err := core.WaitForStatus(context.Background(), kind, executionID, core.WaitOptions{
+		Interval: jobExecutionWaitInterval,
+		Done:     []string{string(blaxel.JobExecutionStatusSucceeded)},
+		Failed:   jobExecutionFailedStatuses,
+		Fetch: func(ctx context.Context) (string, error) {
+			execution, err := client.Jobs.Executions.Get(ctx, executionID, blaxel.JobExecutionGetParams{JobID: jobName})
+			if err != nil {
+				return "", err
+			}
+			return strings.ToLower(string(execution.Status)), nil
+		},
+		OnChange: func(status string) {
+			core.PrintInfo(fmt.Sprintf("Execution %s is %s", executionID, strings.ToUpper(status)))
+		},
+	})
+	if err != nil {
+		return err
+	}
+	core.PrintSuccess(fmt.Sprintf("Execution %s finished with status %s", executionID, strings.ToUpper(status)))
+	return nil
+}
@@ -0,0 +1,200 @@
+package cli
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/blaxel-ai/toolkit/cli/core"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	core.RegisterCommand("scale", func() *cobra.Command {
+		return ScaleCmd()
+	})
+}
+
+// scaleFields lists the runtime fields bl scale can change per resource type
+var scaleFields = map[string][]string{
+	"agent":    {"minScale", "maxScale", "memory"},
+	"function": {"minScale", "maxScale", "memory"},
+	"job":      {"memory"},
+	"sandbox":  {"memory"},
+}
+
+// scaleChange is a runtime field set by a bl scale flag
+type scaleChange struct {
+	field string
+	value int64
+}
+
+// scaleDiff is the value of a runtime field before and after bl scale
+type scaleDiff struct {
+	field  string
+	before string
+	after  string
+}
+
+func ScaleCmd() *cobra.Command {
+	var minScale, maxScale, memory int64
+	cmd := &cobra.Command{
+		Use:   "scale resourceType name",
+		Short: "Change the replicas or memory of a deployed resource",
+		Args:  cobra.ExactArgs(2),
+		Long: `Change the scaling of a deployed resource without rebuilding it or going
+through blaxel.toml.
+
+--min and --max set the minimum and maximum number of replicas of an agent or
+function, --memory the memory in MB of an agent, function, job or sandbox.
+The current resource is fetched, only these runtime fields change and it is
+applied again with the image it runs, so no build happens. The values before
+and after are printed. --min cannot be above --max, the current value being
+used for the one that is not passed.
+
+The change is lost at the next 'bl deploy' unless blaxel.toml sets the same
+values. The command fails if the resource was changed while it was being
+applied.`,
+		Example: `  # Allow up to 5 replicas and keep one warm
+  bl scale agent my-agent --min 1 --max 5
+
+  # Give a job more memory
+  bl scale job my-job --memory 8192`,
+		Run: func(cmd *cobra.Command, args []string) {
+			changes := []scaleChange{}
+			for _, change := range []scaleChange{{"minScale", minScale}, {"maxScale", maxScale}, {"memory", memory}} {
+				if cmd.Flags().Changed(scaleFlag(change.field)) {
+					changes = append(changes, change)
+				}
+			}
+			if err := scaleResource(args[0], args[1], changes); err != nil {
+				core.PrintError("Scale", err)
+				core.ExitWithError(err)
+			}
+		},
+	}
+	cmd.Flags().Int64Var(&minScale, "min", 0, "Minimum number of replicas of an agent or function")
+	cmd.Flags().Int64Var(&maxScale, "max", 0, "Maximum number of replicas of an agent or function")
+	cmd.Flags().Int64Var(&memory, "memory", 0, "Memory in MB")
+	return cmd
+}
+
+// scaleFlag returns the flag of a runtime field
+func scaleFlag(field string) string {
+	switch field {
+	case "minScale":
+		return "min"
+	case "maxScale":
+		return "max"
+	}
+	return field
+}
+
+// scaleResource applies resourceType/name with changes to its runtime, without
+// building it
+func scaleResource(resourceType, name string, changes []scaleChange) error {
+	if len(changes) == 0 {
+		return &core.ConfigError{Err: fmt.Errorf("nothing to change, pass --min, --max or --memory")}
+	}
+	resourceType = strings.ToLower(resourceType)
+	kind, err := imageResourceKind(resourceType)
+	if err != nil {
+		return err
+	}
+	live, err := getResource(resourceType, name)
+	if err != nil {
+		return err
+	}
+	result, _, spec := liveResourceManifest(kind, live)
+	if spec == nil {
+		return fmt.Errorf("cannot scale a %s without name or spec", resourceType)
+	}
+	runtime, _ := spec["runtime"].(map[string]interface{})
+	if runtime == nil {
+		runtime = map[string]interface{}{}
+		spec["runtime"] = runtime
+	}
+
+	diffs, err := scaleRuntime(resourceType, runtime, changes)
+	if err != nil {
+		return &core.ConfigError{Err: err}
+	}
+	changed := false
+	for _, diff := range diffs {
+		changed = changed || diff.before != diff.after
+	}
+	if !changed {
+		core.PrintInfo(fmt.Sprintf("%s %s already has these values, nothing to apply", resourceType, name))
+		return nil
+	}
+	runtime["skipBuild"] = "true"
+
+	results, err := ApplyResources([]core.Result{result})
+	if err != nil {
+		return err
+	}
+	if hasFailedApplyResult(results) {
+		return fmt.Errorf("failed to apply %s %s", resourceType, name)
+	}
+	if !core.IsQuiet() {
+		for _, diff := range diffs {
+			core.PrintInfo(fmt.Sprintf("  %s: %s -> %s", diff.field, diff.before, diff.after))
+		}
+	}
+	core.PrintSuccess(fmt.Sprintf("Scaled %s/%s", resourceType, name))
+	return nil
+}
+
+// scaleRuntime sets changes in runtime, the runtime of a resource of
+// resourceType, and returns the values of the changed fields before and after
+func scaleRuntime(resourceType string, runtime map[string]interface{}, changes []scaleChange) ([]scaleDiff, error) {
+	fields, ok := scaleFields[resourceType]
+	if !ok {
+		return nil, fmt.Errorf("bl scale supports agents, functions, jobs and sandboxes, not %s", resourceType)
+	}
+	for _, change := range changes {
+		if !slices.Contains(fields, change.field) {
+			return nil, fmt.Errorf("--%s does not apply to a %s", scaleFlag(change.field), resourceType)
+		}
+		if change.value < 0 || (change.value == 0 && change.field != "minScale") {
+			return nil, fmt.Errorf("--%s must be positive, got %d", scaleFlag(change.field), change.value)
+		}
+	}
+
+	diffs := make([]scaleDiff, 0, len(changes))
+	for _, change := range changes {
+		diffs = append(diffs, scaleDiff{
+			field:  change.field,
+			before: scaleValueText(runtime[change.field]),
+			after:  fmt.Sprint(change.value),
+		})
+		runtime[change.field] = change.value
+	}
+	minValue, hasMin := scaleValue(runtime["minScale"])
+	maxValue, hasMax := scaleValue(runtime["maxScale"])
+	if hasMin && hasMax && minValue > maxValue {
+		return nil, fmt.Errorf("minScale %d would be above maxScale %d", minValue, maxValue)
+	}
+	return diffs, nil
+}
+
+// scaleValue returns a numeric runtime value, false when it is not set
+func scaleValue(value interface{}) (int64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return int64(v), true
+	case int64:
+		return v, true
+	case int:
+		return int64(v), true
+	}
+	return 0, false
+}
+
+// scaleValueText renders a runtime value, "unset" when there is none
+func scaleValueText(value interface{}) string {
+	if v, ok := scaleValue(value); ok {
+		return fmt.Sprint(v)
+	}
+	return "unset"
+}
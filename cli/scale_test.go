@@ -0,0 +1,41 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScaleRuntime(t *testing.T) {
+	t.Run("sets the fields and reports the values before", func(t *testing.T) {
+		runtime := map[string]interface{}{"image": "agent/a:v1", "memory": 4096.0, "maxScale": 10.0}
+		diffs, err := scaleRuntime("agent", runtime, []scaleChange{{"minScale", 1}, {"maxScale", 5}, {"memory", 8192}})
+		require.NoError(t, err)
+		assert.Equal(t, []scaleDiff{
+			{field: "minScale", before: "unset", after: "1"},
+			{field: "maxScale", before: "10", after: "5"},
+			{field: "memory", before: "4096", after: "8192"},
+		}, diffs)
+		assert.Equal(t, map[string]interface{}{"image": "agent/a:v1", "memory": int64(8192), "minScale": int64(1), "maxScale": int64(5)}, runtime)
+	})
+
+	t.Run("checks min against the current max", func(t *testing.T) {
+		_, err := scaleRuntime("function", map[string]interface{}{"maxScale": 3.0}, []scaleChange{{"minScale", 4}})
+		assert.EqualError(t, err, "minScale 4 would be above maxScale 3")
+	})
+
+	t.Run("rejects fields the type does not have", func(t *testing.T) {
+		_, err := scaleRuntime("job", map[string]interface{}{}, []scaleChange{{"maxScale", 3}})
+		assert.EqualError(t, err, "--max does not apply to a job")
+		_, err = scaleRuntime("application", map[string]interface{}{}, []scaleChange{{"memory", 2048}})
+		assert.ErrorContains(t, err, "not application")
+	})
+
+	t.Run("rejects values out of range", func(t *testing.T) {
+		_, err := scaleRuntime("agent", map[string]interface{}{}, []scaleChange{{"maxScale", 0}})
+		assert.EqualError(t, err, "--max must be positive, got 0")
+		_, err = scaleRuntime("agent", map[string]interface{}{}, []scaleChange{{"minScale", 0}})
+		assert.NoError(t, err, "no warm replica is allowed")
+	})
+}
@@ -47,6 +47,19 @@ Enable --hotreload to automatically restart your server when code changes
 are detected. This dramatically speeds up development by eliminating manual
 restarts.
 
+Port:
+The server listens on port 1338 by default. When --port is omitted and 1338
+is already taken, or with --port 0, a free port is picked and printed. The
+port stays reserved by bl until the server starts.
+
+Monorepo Packages:
+With -r (the default), every package declared in blaxel.toml is served along
+the root project. A package without a port, or whose port is already taken by
+another package, gets the next free port from 1339 and the choice is saved to
+blaxel.toml so later runs keep it. A package whose port is the one of the root
+server gets a free port for this run only, as the root port may come from a
+one-off --port.
+
 Testing Locally:
 While your server is running, test it with:
 - bl chat agent-name --local   (for agents)
@@ -63,6 +76,9 @@ Workflow:
   # Serve on custom port
   bl serve --port 8080
 
+  # Serve on any free port, the URL is printed
+  bl serve --port 0
+
   # Serve specific subdirectory in monorepo
   bl serve -d packages/my-agent
 
@@ -147,6 +163,23 @@ Workflow:
 				core.ExitWithError(err)
 			}
 
+			// The port is bound now and held until the servers start, so the
+			// server, the packages and their BL_*_URL variables all agree on it.
+			// --port 0, or the default port when it is taken, picks a free one.
+			listener, boundPort, err := core.ReservePort(port)
+			if err != nil && port != 0 && !cmd.Flags().Changed("port") {
+				listener, boundPort, err = core.ReservePort(0)
+			}
+			if err != nil {
+				core.PrintError("Serve", err)
+				core.ExitWithError(err)
+			}
+			if boundPort != port {
+				core.PrintInfo(fmt.Sprintf("Serving on http://%s:%d", host, boundPort))
+			}
+			port = boundPort
+			server.HoldPort(listener)
+
 			// If it's a package, we need to handle it
 			if recursive {
 				if server.StartPackageServer(port, host, hotreload, config, envFiles, core.GetSecrets()) {
@@ -242,7 +275,7 @@ Workflow:
 		},
 	}
 
-	cmd.Flags().IntVarP(&port, "port", "p", 1338, "Bind socket to this port")
+	cmd.Flags().IntVarP(&port, "port", "p", 1338, "Bind socket to this port, 0 picks a free port")
 	cmd.Flags().StringVarP(&host, "host", "H", "0.0.0.0", "Bind socket to this host. If 0.0.0.0, listens on all interfaces")
 	cmd.Flags().BoolVarP(&hotreload, "hotreload", "", false, "Watch for changes in the project")
 	cmd.Flags().BoolVarP(&recursive, "recursive", "r", true, "Serve the project recursively")
@@ -137,6 +137,7 @@ func StartEntrypoint(port int, host string, hotreload bool, folder string, confi
 	envs := GetServerEnvironment(port, host, hotreload, config)
 	cmd.Env = envs.ToEnv()
 
+	releasePort()
 	err := cmd.Start()
 	if err != nil {
 		err = fmt.Errorf("failed to start entrypoint: %w", err)
@@ -61,6 +61,7 @@ func StartGoServer(port int, host string, hotreload bool, folder string, config
 	envs := GetServerEnvironment(port, host, hotreload, config)
 	golang.Env = envs.ToEnv()
 
+	releasePort()
 	err = golang.Start()
 	if err != nil {
 		err = fmt.Errorf("failed to start Go server: %w", err)
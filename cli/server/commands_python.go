@@ -93,6 +93,7 @@ func StartPythonServer(port int, host string, hotreload bool, folder string, con
 	envs := GetServerEnvironment(port, host, hotreload, config)
 	python.Env = envs.ToEnv()
 
+	releasePort()
 	err = python.Start()
 	if err != nil {
 		err = fmt.Errorf("failed to start Python server: %w", err)
@@ -356,29 +356,19 @@ func TestGetServerEnvironmentWithSecrets(t *testing.T) {
 }
 
 func TestGetServeCommandsPortCollision(t *testing.T) {
-	// Test that duplicate ports are detected
 	config := core.Config{
+		SkipRoot: true,
 		Function: map[string]core.Package{
 			"func1": {Path: "./func1", Port: 8001},
 			"func2": {Path: "./func2", Port: 8001}, // Duplicate port
 		},
 	}
 
-	// Save the current directory
-	originalDir, err := os.Getwd()
-	require.NoError(t, err)
-
-	// Create temp directory and change to it
-	tempDir, err := os.MkdirTemp("", "port_test")
-	require.NoError(t, err)
-	defer func() { _ = os.RemoveAll(tempDir) }()
-	require.NoError(t, os.Chdir(tempDir))
-	defer func() { _ = os.Chdir(originalDir) }()
-
-	// This should fail due to port collision
-	// Note: The function exits on error, so we can't easily test this
-	// Just verify it doesn't panic
-	_ = config
+	_, err := getServeCommands(8080, "localhost", false, config, nil, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "port 8001 is set for both function func")
+	assert.Contains(t, err.Error(), "func1")
+	assert.Contains(t, err.Error(), "func2")
 }
 
 func TestPackageCommandEnvs(t *testing.T) {
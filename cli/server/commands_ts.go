@@ -82,6 +82,7 @@ func StartTypescriptServer(port int, host string, hotreload bool, folder string,
 	envs := GetServerEnvironment(port, host, hotreload, config)
 	ts.Env = envs.ToEnv()
 
+	releasePort()
 	err = ts.Start()
 	if err != nil {
 		err = fmt.Errorf("failed to start TypeScript server: %w", err)
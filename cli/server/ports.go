@@ -0,0 +1,122 @@
+package server
+
+import (
+	"fmt"
+	"maps"
+	"net"
+	"sort"
+
+	"github.com/blaxel-ai/toolkit/cli/core"
+)
+
+// heldPort keeps the port of bl serve bound until its first server process starts
+var heldPort net.Listener
+
+// HoldPort keeps listener open until a server process is about to start, so no
+// other program takes its port in the meantime
+func HoldPort(listener net.Listener) {
+	heldPort = listener
+}
+
+// releasePort closes the listener given to HoldPort, if any, so the server
+// process about to start can bind the port
+func releasePort() {
+	if heldPort != nil {
+		_ = heldPort.Close()
+		heldPort = nil
+	}
+}
+
+// portAssignment records a package port chosen by bl serve
+type portAssignment struct {
+	Name     string
+	Type     string
+	Previous int
+	Port     int
+	Reason   string
+	// Temporary is set when the package port only collides with the root
+	// server, whose port may come from a one-off --port: the assigned port is
+	// used for this run and not saved to blaxel.toml
+	Temporary bool
+}
+
+// assignPackagePorts gives a free port to every served package that has none or
+// whose port collides with the root server or another package. Other packages
+// keep their port. Packages are visited in name order so the same package keeps
+// a contested port on every run. It returns the updated config and the changes made.
+func assignPackagePorts(rootPort int, config core.Config) (core.Config, []portAssignment) {
+	packages := GetAllPackages(config)
+	names := make([]string, 0, len(packages))
+	for name := range packages {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	owners := map[int]string{}
+	used := map[int]bool{}
+	if !config.SkipRoot {
+		owners[rootPort] = "the root server"
+		used[rootPort] = true
+	}
+
+	assignments := []portAssignment{}
+	for _, name := range names {
+		pkg := packages[name]
+		if pkg.Type == "job" {
+			continue
+		}
+		if pkg.Port != 0 && !used[pkg.Port] {
+			owners[pkg.Port] = pkg.Type + " " + name
+			used[pkg.Port] = true
+			continue
+		}
+
+		reason := "port is not set"
+		temporary := false
+		if pkg.Port != 0 {
+			reason = fmt.Sprintf("port %d is already used by %s", pkg.Port, owners[pkg.Port])
+			temporary = !config.SkipRoot && pkg.Port == rootPort
+		}
+		port := core.NextAvailablePort(used)
+		owners[port] = pkg.Type + " " + name
+		used[port] = true
+		assignments = append(assignments, portAssignment{Name: name, Type: pkg.Type, Previous: pkg.Port, Port: port, Reason: reason, Temporary: temporary})
+		config = withPackagePort(config, pkg.Type, name, port)
+	}
+	return config, assignments
+}
+
+// withPackagePort returns config with the port of the package name of type pkgType set,
+// leaving the maps of the original config untouched
+func withPackagePort(config core.Config, pkgType string, name string, port int) core.Config {
+	var packages *map[string]core.Package
+	switch pkgType {
+	case "function":
+		packages = &config.Function
+	case "agent":
+		packages = &config.Agent
+	default:
+		return config
+	}
+	*packages = maps.Clone(*packages)
+	pkg := (*packages)[name]
+	pkg.Port = port
+	(*packages)[name] = pkg
+	return config
+}
+
+// savePortAssignments writes the assigned ports that are not temporary to
+// blaxel.toml so the next runs reuse them, and reports whether any was written
+func savePortAssignments(assignments []portAssignment) (bool, error) {
+	sets := []string{}
+	for _, a := range assignments {
+		if !a.Temporary {
+			sets = append(sets, fmt.Sprintf("%s.%s.port=%d", a.Type, a.Name, a.Port))
+		}
+	}
+	if len(sets) == 0 {
+		return false, nil
+	}
+	_, err := core.SaveConfigSets("", sets)
+	return err == nil, err
+}
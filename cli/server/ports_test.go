@@ -0,0 +1,103 @@
+package server
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/blaxel-ai/toolkit/cli/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAssignPackagePorts(t *testing.T) {
+	config := core.Config{
+		Function: map[string]core.Package{
+			"func1": {Path: "./func1", Port: 8001},
+			"func2": {Path: "./func2", Port: 8001},
+			"func3": {Path: "./func3"},
+		},
+		Agent: map[string]core.Package{
+			"agent1": {Path: "./agent1", Port: 8080},
+		},
+		Job: map[string]core.Package{
+			"job1": {Path: "./job1"},
+		},
+	}
+
+	updated, assignments := assignPackagePorts(8080, config)
+
+	require.Len(t, assignments, 3)
+	assert.Equal(t, "agent1", assignments[0].Name)
+	assert.Equal(t, "port 8080 is already used by the root server", assignments[0].Reason)
+	assert.True(t, assignments[0].Temporary, "a collision with the root port is not saved")
+	assert.Equal(t, "func2", assignments[1].Name)
+	assert.Equal(t, "port 8001 is already used by function func1", assignments[1].Reason)
+	assert.False(t, assignments[1].Temporary)
+	assert.Equal(t, "func3", assignments[2].Name)
+	assert.Equal(t, "port is not set", assignments[2].Reason)
+	assert.False(t, assignments[2].Temporary)
+
+	ports := map[int]bool{8080: true, 8001: true}
+	for _, a := range assignments {
+		assert.False(t, ports[a.Port], "port %d assigned twice", a.Port)
+		ports[a.Port] = true
+	}
+	assert.Equal(t, assignments[0].Port, updated.Agent["agent1"].Port)
+	assert.Equal(t, assignments[1].Port, updated.Function["func2"].Port)
+	assert.Equal(t, 8001, updated.Function["func1"].Port)
+
+	// The original config is left untouched
+	assert.Equal(t, 8001, config.Function["func2"].Port)
+	assert.Equal(t, 0, config.Function["func3"].Port)
+
+	_, err := getServeCommands(8080, "localhost", false, updated, nil, nil)
+	assert.NoError(t, err)
+}
+
+func TestSavePortAssignments(t *testing.T) {
+	tempDir := t.TempDir()
+	content := "name = \"root\"\n\n[function.func2]\npath = \"./func2\"\nport = 8001\n\n[function.func3]\npath = \"./func3\"\n"
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "blaxel.toml"), []byte(content), 0644))
+
+	originalDir, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(tempDir))
+	defer func() { _ = os.Chdir(originalDir) }()
+
+	saved, err := savePortAssignments([]portAssignment{
+		{Name: "func2", Type: "function", Previous: 8001, Port: 1340},
+		{Name: "func3", Type: "function", Port: 1341},
+	})
+	require.NoError(t, err)
+	assert.True(t, saved)
+
+	updated, err := os.ReadFile(filepath.Join(tempDir, "blaxel.toml"))
+	require.NoError(t, err)
+	assert.Equal(t, "name = \"root\"\n\n[function.func2]\npath = \"./func2\"\nport = 1340\n\n[function.func3]\npath = \"./func3\"\nport = 1341\n", string(updated))
+
+	// A port only reassigned to avoid the root server is kept out of blaxel.toml
+	saved, err = savePortAssignments([]portAssignment{
+		{Name: "func2", Type: "function", Previous: 1340, Port: 1342, Temporary: true},
+	})
+	require.NoError(t, err)
+	assert.False(t, saved)
+	unchanged, err := os.ReadFile(filepath.Join(tempDir, "blaxel.toml"))
+	require.NoError(t, err)
+	assert.Equal(t, string(updated), string(unchanged))
+}
+
+func TestHoldPortReleasedBeforeStart(t *testing.T) {
+	listener, port, err := core.ReservePort(0)
+	require.NoError(t, err)
+
+	HoldPort(listener)
+	assert.False(t, core.IsPortAvailable(port))
+
+	releasePort()
+	assert.True(t, core.IsPortAvailable(port))
+	assert.Nil(t, heldPort)
+
+	// Releasing again is a no-op
+	releasePort()
+}
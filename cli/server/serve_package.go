@@ -9,7 +9,9 @@ import (
 	"os/signal"
 	"path/filepath"
 	"strings"
+	"sync"
 	"syscall"
+	"time"
 
 	"github.com/blaxel-ai/toolkit/cli/core"
 	"github.com/fatih/color"
@@ -25,6 +27,22 @@ type PackageCommand struct {
 }
 
 func StartPackageServer(port int, host string, hotreload bool, config core.Config, envFiles []string, secrets []core.Env) bool {
+	config, assignments := assignPackagePorts(port, config)
+	if len(assignments) > 0 {
+		for _, a := range assignments {
+			if a.Temporary {
+				fmt.Printf("Using port %d for %s %s during this run (%s)\n", a.Port, a.Type, a.Name, a.Reason)
+			} else {
+				fmt.Printf("Using port %d for %s %s (%s)\n", a.Port, a.Type, a.Name, a.Reason)
+			}
+		}
+		if saved, err := savePortAssignments(assignments); err != nil {
+			core.PrintWarning(fmt.Sprintf("Could not save the assigned ports to blaxel.toml: %v", err))
+		} else if saved {
+			fmt.Println("Saved the assigned ports to blaxel.toml")
+		}
+	}
+
 	commands, err := getServeCommands(port, host, hotreload, config, envFiles, secrets)
 	if err != nil {
 		err = fmt.Errorf("failed to get package commands: %w", err)
@@ -54,13 +72,14 @@ func RunCommands(commands []PackageCommand, oneByOne bool) {
 		stdoutPipe, _ := cmd.StdoutPipe()
 		stderrPipe, _ := cmd.StderrPipe()
 
+		releasePort()
 		if err := cmd.Start(); err != nil {
 			core.PrintError("Serve", fmt.Errorf("failed to start command '%s': %w", cmdInfo.Name, err))
 			continue
 		}
 
-		go prefixOutput(stdoutPipe, cmdInfo.Name, cmdInfo.Color)
-		go prefixOutput(stderrPipe, cmdInfo.Name, cmdInfo.Color)
+		go prefixOutput(os.Stdout, stdoutPipe, cmdInfo.Name, cmdInfo.Color)
+		go prefixOutput(os.Stdout, stderrPipe, cmdInfo.Name, cmdInfo.Color)
 
 		if oneByOne {
 			err := cmd.Wait() // Wait for the command to finish before starting the next one
@@ -82,7 +101,73 @@ func RunCommands(commands []PackageCommand, oneByOne bool) {
 	}
 }
 
-func prefixOutput(pipe io.ReadCloser, prefix string, color string) {
+// PackageColors are the colors of the package prefixes, assigned in turn
+var PackageColors = []string{"red", "green", "blue", "yellow", "purple", "cyan", "white"}
+
+// CommandResult is how a command run by RunCommandsWithResults ended
+type CommandResult struct {
+	Name     string
+	Duration time.Duration
+	// Err is set when the command could not start or exited with an error
+	Err error
+}
+
+// RunCommandsWithResults runs commands one after the other, each line of
+// their output prefixed with their colored name, and returns how each one
+// ended
+func RunCommandsWithResults(commands []PackageCommand) []CommandResult {
+	return runCommandsWithResults(os.Stdout, commands)
+}
+
+func runCommandsWithResults(w io.Writer, commands []PackageCommand) []CommandResult {
+	results := make([]CommandResult, 0, len(commands))
+	for _, cmdInfo := range commands {
+		start := time.Now()
+		err := runPrefixed(w, cmdInfo)
+		results = append(results, CommandResult{Name: cmdInfo.Name, Duration: time.Since(start), Err: err})
+	}
+	return results
+}
+
+// runPrefixed runs a command until it exits, its output prefixed with its name
+func runPrefixed(w io.Writer, cmdInfo PackageCommand) error {
+	cmd := exec.Command(cmdInfo.Command, cmdInfo.Args...)
+	cmd.Dir = cmdInfo.Cwd
+	cmd.Env = append(os.Environ(), cmdInfo.Envs.ToEnv()...)
+	stdoutPipe, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	stderrPipe, err := cmd.StderrPipe()
+	if err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to start command '%s': %w", cmdInfo.Name, err)
+	}
+
+	// The pipes must be read completely before waiting for the command
+	var wg sync.WaitGroup
+	for _, pipe := range []io.ReadCloser{stdoutPipe, stderrPipe} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			prefixOutput(w, pipe, cmdInfo.Name, cmdInfo.Color)
+		}()
+	}
+	wg.Wait()
+	return cmd.Wait()
+}
+
+// outputMu keeps the lines written by prefixOutput whole when several
+// commands, or the stdout and stderr of one, write at the same time
+var outputMu sync.Mutex
+
+// prefixOutputMaxLine is the longest line prefixOutput reads at once, longer
+// lines are split
+const prefixOutputMaxLine = 1024 * 1024
+
+func prefixOutput(w io.Writer, pipe io.ReadCloser, prefix string, color string) {
 
 	// Ensure the prefix is exactly 20 characters long
 	if len(prefix) < 20 {
@@ -94,9 +179,30 @@ func prefixOutput(pipe io.ReadCloser, prefix string, color string) {
 	// we colorize the prefix
 	prefix = colorize(prefix, color)
 
-	scanner := bufio.NewScanner(pipe)
-	for scanner.Scan() {
-		fmt.Printf("%s %s\n", prefix, scanner.Text())
+	reader := bufio.NewReaderSize(pipe, 64*1024)
+	for {
+		line, err := readLine(reader)
+		if len(line) > 0 || err == nil {
+			outputMu.Lock()
+			fmt.Fprintf(w, "%s %s\n", prefix, line)
+			outputMu.Unlock()
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+// readLine reads a line without its line ending, at most prefixOutputMaxLine
+// bytes of it
+func readLine(reader *bufio.Reader) (string, error) {
+	var line []byte
+	for {
+		chunk, isPrefix, err := reader.ReadLine()
+		line = append(line, chunk...)
+		if err != nil || !isPrefix || len(line) >= prefixOutputMaxLine {
+			return string(line), err
+		}
 	}
 }
 
@@ -143,12 +249,12 @@ func GetAllPackages(config core.Config) map[string]core.Package {
 
 func getServeCommands(port int, host string, hotreload bool, config core.Config, envFiles []string, secrets []core.Env) ([]PackageCommand, error) {
 	packages := GetAllPackages(config)
-	usedPorts := make(map[int]bool)
+	usedPorts := make(map[int]string)
 	pwd, err := os.Getwd()
 	if err != nil {
 		return nil, fmt.Errorf("error getting current directory: %v", err)
 	}
-	colors := []string{"red", "green", "blue", "yellow", "purple", "cyan", "white"}
+	colors := PackageColors
 	command := PackageCommand{
 		Name:    "root",
 		Cwd:     pwd,
@@ -171,15 +277,11 @@ func getServeCommands(port int, host string, hotreload bool, config core.Config,
 		}
 		if pkg.Port == 0 {
 			return nil, fmt.Errorf("port is not set for %s", name)
-		} else {
-			if !usedPorts[pkg.Port] {
-				usedPorts[pkg.Port] = true
-			} else {
-				err := fmt.Errorf("port %d is already in use, please choose another one", pkg.Port)
-				fmt.Println(err)
-				core.ExitWithError(err)
-			}
 		}
+		if other, ok := usedPorts[pkg.Port]; ok {
+			return nil, fmt.Errorf("port %d is set for both %s and %s %s, please choose another one", pkg.Port, other, pkg.Type, name)
+		}
+		usedPorts[pkg.Port] = pkg.Type + " " + name
 		command := PackageCommand{
 			Name:    name,
 			Cwd:     filepath.Join(pwd, pkg.Path),
@@ -1,10 +1,16 @@
 package server
 
 import (
+	"bytes"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
 	"testing"
 
 	"github.com/blaxel-ai/toolkit/cli/core"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestGetAllPackages(t *testing.T) {
@@ -124,3 +130,42 @@ func TestPackageCommand(t *testing.T) {
 		assert.Equal(t, "value", cmd.Envs["KEY"])
 	})
 }
+
+func TestRunCommandsWithResults(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh is not available")
+	}
+	var out bytes.Buffer
+	results := runCommandsWithResults(&out, []PackageCommand{
+		{Name: "ok", Command: "sh", Args: []string{"-c", "printf 'line 1\\nline 2\\n'; echo oops >&2; printf 'no newline'"}},
+		{Name: "broken", Command: "sh", Args: []string{"-c", "echo failing; exit 3"}},
+		{Name: "missing", Command: "does-not-exist-bl-test"},
+	})
+
+	require.Len(t, results, 3)
+	assert.Equal(t, "ok", results[0].Name)
+	assert.NoError(t, results[0].Err)
+	assert.Error(t, results[1].Err)
+	assert.Error(t, results[2].Err)
+	assert.Contains(t, results[2].Err.Error(), "failed to start command 'missing'")
+
+	lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	assert.ElementsMatch(t, []string{
+		fmt.Sprintf("%-20s line 1", "ok"),
+		fmt.Sprintf("%-20s line 2", "ok"),
+		fmt.Sprintf("%-20s oops", "ok"),
+		fmt.Sprintf("%-20s no newline", "ok"),
+		fmt.Sprintf("%-20s failing", "broken"),
+	}, lines)
+}
+
+func TestPrefixOutputLongLine(t *testing.T) {
+	var out bytes.Buffer
+	long := strings.Repeat("x", 200*1024)
+	prefixOutput(&out, io.NopCloser(strings.NewReader(long+"\nshort\n")), "pkg", "")
+
+	lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	require.Len(t, lines, 2)
+	assert.Equal(t, fmt.Sprintf("%-20s %s", "pkg", long), lines[0])
+	assert.Equal(t, fmt.Sprintf("%-20s short", "pkg"), lines[1])
+}
@@ -2,8 +2,6 @@ package cli
 
 import (
 	"context"
-	"encoding/base64"
-	"encoding/json"
 	"fmt"
 	"strings"
 	"time"
@@ -74,7 +72,7 @@ export TOKEN=$(bl token)
 			}
 
 			// Get workspace to check if access is allowed + it refreshes the token if needed.
-			client, err := blaxel.NewClientFromConfig(workspace)
+			client, err := blaxel.NewClientFromConfig(workspace, core.RequestLogOptions()...)
 			if err != nil {
 				err := fmt.Errorf("failed to create client for workspace '%s': %w", workspace, err)
 				core.PrintError("token", err)
@@ -144,20 +142,10 @@ func bearerTokenFromHeaders(headers map[string]string) string {
 }
 
 func jwtExpired(token string, now time.Time) (bool, bool) {
-	parts := strings.Split(token, ".")
-	if len(parts) != 3 {
-		return false, false
-	}
-
-	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
-	if err != nil {
-		return false, false
-	}
-
 	var claims struct {
 		Exp float64 `json:"exp"`
 	}
-	if err := json.Unmarshal(payload, &claims); err != nil || claims.Exp == 0 {
+	if !core.DecodeTokenClaims(token, &claims) || claims.Exp == 0 {
 		return false, false
 	}
 
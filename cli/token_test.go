@@ -73,14 +73,19 @@ func TestBearerTokenFromHeaders(t *testing.T) {
 func testJWT(t *testing.T, issuedAt time.Time, expiresAt time.Time) string {
 	t.Helper()
 
+	return testJWTWithClaims(t, map[string]any{
+		"iat": issuedAt.Unix(),
+		"exp": expiresAt.Unix(),
+	})
+}
+
+func testJWTWithClaims(t *testing.T, claims map[string]any) string {
+	t.Helper()
+
 	header := map[string]string{
 		"alg": "none",
 		"typ": "JWT",
 	}
-	claims := map[string]int64{
-		"iat": issuedAt.Unix(),
-		"exp": expiresAt.Unix(),
-	}
 
 	return fmt.Sprintf("%s.%s.",
 		testJWTPart(t, header),
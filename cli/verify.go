@@ -0,0 +1,261 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	blaxel "github.com/blaxel-ai/sdk-go"
+	"github.com/blaxel-ai/toolkit/cli/core"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+func init() {
+	core.RegisterCommand("verify", func() *cobra.Command {
+		return VerifyCmd()
+	})
+}
+
+// verifyProbe is the request sent by bl verify to check a resource is healthy
+type verifyProbe struct {
+	Kind         string
+	ResourceType string
+	Path         string
+	Data         string
+	Contains     []string
+	// Process is set when the response is a sandbox process, whose exit code
+	// and stdout are checked instead of the raw body
+	Process bool
+}
+
+// verifyResult is the outcome of a probe, printed with -o json/yaml
+type verifyResult struct {
+	Kind     string `json:"kind" yaml:"kind"`
+	Name     string `json:"name" yaml:"name"`
+	Status   int    `json:"status" yaml:"status"`
+	Duration string `json:"duration" yaml:"duration"`
+	Healthy  bool   `json:"healthy" yaml:"healthy"`
+	Error    string `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// verifyMarker is echoed by the sandbox probe and looked for in its output
+const verifyMarker = "blaxel-verify"
+
+// verifyProbeFor returns the default probe for a resource kind
+func verifyProbeFor(kind string) (verifyProbe, error) {
+	switch strings.ToLower(kind) {
+	case "agent", "agents", "ag":
+		return verifyProbe{Kind: "agent", ResourceType: "agents", Data: `{"inputs":"Hello"}`}, nil
+	case "function", "functions", "fn", "mcp", "mcps":
+		return verifyProbe{Kind: "function", ResourceType: "functions", Data: `{"inputs":"Hello"}`}, nil
+	case "sandbox", "sandboxes", "sbx", "sb":
+		return verifyProbe{
+			Kind:         "sandbox",
+			ResourceType: "sandbox",
+			Path:         "/process",
+			Data:         fmt.Sprintf(`{"command":"echo %s","waitForCompletion":true}`, verifyMarker),
+			Contains:     []string{verifyMarker},
+			Process:      true,
+		}, nil
+	case "job", "jobs", "jb":
+		return verifyProbe{Kind: "job", ResourceType: "jobs", Path: "/executions", Data: `{"tasks":[{}]}`}, nil
+	}
+	return verifyProbe{}, fmt.Errorf("cannot verify a resource of kind %q, expected one of: agent, function, sandbox, job", kind)
+}
+
+// checkVerifyResponse checks a probe response against the expected status and
+// substrings. An expected status of 0 accepts any status below 400.
+func checkVerifyResponse(status int, body string, expectStatus int, contains []string) error {
+	if expectStatus != 0 && status != expectStatus {
+		return fmt.Errorf("expected status %d, got %d", expectStatus, status)
+	}
+	if expectStatus == 0 && status >= 400 {
+		return fmt.Errorf("unexpected status %d: %s", status, truncateVerifyBody(body))
+	}
+	for _, expected := range contains {
+		if !strings.Contains(body, expected) {
+			return fmt.Errorf("response does not contain %q: %s", expected, truncateVerifyBody(body))
+		}
+	}
+	return nil
+}
+
+// checkVerifyProcess checks a sandbox process API response: the process must
+// have exited with code 0 and its stdout contain each of contains
+func checkVerifyProcess(body string, contains []string) error {
+	var process struct {
+		ExitCode int64  `json:"exitCode"`
+		Stdout   string `json:"stdout"`
+		Stderr   string `json:"stderr"`
+	}
+	if err := json.Unmarshal([]byte(body), &process); err != nil {
+		return fmt.Errorf("unexpected process response: %s", truncateVerifyBody(body))
+	}
+	if process.ExitCode != 0 {
+		return fmt.Errorf("process exited with code %d: %s", process.ExitCode, truncateVerifyBody(process.Stderr))
+	}
+	for _, expected := range contains {
+		if !strings.Contains(process.Stdout, expected) {
+			return fmt.Errorf("process output does not contain %q: %s", expected, truncateVerifyBody(process.Stdout))
+		}
+	}
+	return nil
+}
+
+func truncateVerifyBody(body string) string {
+	body = strings.TrimSpace(body)
+	if len(body) > 200 {
+		return body[:200] + "..."
+	}
+	return body
+}
+
+func VerifyCmd() *cobra.Command {
+	var data string
+	var path string
+	var expectStatus int
+	var contains []string
+	var timeout time.Duration
+
+	cmd := &cobra.Command{
+		Use:               "verify resource-type resource-name",
+		Args:              cobra.ExactArgs(2),
+		Short:             "Check that a deployed resource responds",
+		ValidArgsFunction: GetVerifyValidArgsFunction(),
+		Long: `Send a health probe to a deployed resource and exit with a non-zero code if it fails.
+
+The probe depends on the resource type:
+
+- agent, function/mcp: Invoke the resource with a sample payload
+                       (default: {"inputs":"Hello"})
+
+- sandbox (sbx): Run "echo blaxel-verify" through the process API and check
+                 that it exits with code 0 and prints the marker
+
+- job: Start an execution with a single empty task
+
+The probe passes when the response status is below 400. Use --expect-status to
+require an exact status and --contains to require text in the response body.
+Use --data and --path to send a different payload or call another endpoint.
+When no response comes within --timeout, the command exits with code 7 like
+'bl run --timeout'.
+
+This is meant for smoke tests after 'bl deploy', for example in CI.`,
+		Example: `  # Check that an agent answers
+  bl verify agent my-agent
+
+  # Invoke a function with a custom payload and check the response
+  bl verify function my-mcp --data '{"inputs": "ping"}' --contains pong
+
+  # Check that a sandbox can run commands
+  bl verify sandbox my-sandbox
+
+  # Check that a job accepts executions, requiring a 200 response
+  bl verify job my-job --expect-status 200
+
+  # Give a slow agent more time to answer
+  bl verify agent my-agent --timeout 5m`,
+		Run: func(cmd *cobra.Command, args []string) {
+			kind, name := args[0], args[1]
+			probe, err := verifyProbeFor(kind)
+			if err != nil {
+				core.PrintError("Verify", err)
+				core.ExitWithError(err)
+			}
+			if cmd.Flags().Changed("data") {
+				probe.Data = data
+				probe.Contains = nil
+			}
+			if cmd.Flags().Changed("path") {
+				probe.Path = path
+				probe.Process = false
+			}
+			if len(contains) > 0 {
+				probe.Contains = contains
+			}
+
+			ctx := context.Background()
+			if timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, timeout)
+				defer cancel()
+			}
+
+			start := time.Now()
+			result := verifyResult{Kind: probe.Kind, Name: name}
+			status, body, err := sendVerifyProbe(ctx, probe, name)
+			result.Duration = time.Since(start).Round(time.Millisecond).String()
+			result.Status = status
+			if err != nil && ctx.Err() == context.DeadlineExceeded {
+				err = &core.TimeoutError{Err: fmt.Errorf("no response after %s", timeout)}
+			}
+			if err == nil && probe.Process {
+				err = checkVerifyResponse(status, body, expectStatus, nil)
+				if err == nil {
+					err = checkVerifyProcess(body, probe.Contains)
+				}
+			} else if err == nil {
+				err = checkVerifyResponse(status, body, expectStatus, probe.Contains)
+			}
+			result.Healthy = err == nil
+			if err != nil {
+				result.Error = err.Error()
+			}
+
+			printVerifyResult(result)
+			if err != nil {
+				core.ExitWithError(err)
+			}
+		},
+	}
+
+	cmd.Flags().StringVarP(&data, "data", "d", "", "JSON payload sent by the probe (default depends on the resource type)")
+	cmd.Flags().StringVar(&path, "path", "", "Path called by the probe (default depends on the resource type)")
+	cmd.Flags().IntVar(&expectStatus, "expect-status", 0, "Exact response status to expect (default: any status below 400)")
+	cmd.Flags().StringSliceVar(&contains, "contains", []string{}, "Text the response body must contain (can be repeated)")
+	cmd.Flags().DurationVar(&timeout, "timeout", 2*time.Minute, "Maximum time to wait for the response")
+	return cmd
+}
+
+// sendVerifyProbe sends probe to the resource name and returns the response status and body
+func sendVerifyProbe(ctx context.Context, probe verifyProbe, name string) (int, string, error) {
+	headers := map[string]string{"Content-Type": "application/json"}
+	res, err := runRequest(ctx, core.GetWorkspace(), probe.ResourceType, name, http.MethodPost, probe.Path, headers, nil, probe.Data, false, false, 0)
+	var apiErr *blaxel.Error
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode, apiErr.RawJSON(), nil
+	}
+	if err != nil {
+		return 0, "", err
+	}
+	defer func() { _ = res.Body.Close() }()
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return res.StatusCode, "", fmt.Errorf("error reading response: %w", err)
+	}
+	return res.StatusCode, string(body), nil
+}
+
+func printVerifyResult(result verifyResult) {
+	switch core.GetOutputFormat() {
+	case "json":
+		out, _ := json.MarshalIndent(result, "", "  ")
+		fmt.Println(string(out))
+		return
+	case "yaml":
+		out, _ := yaml.Marshal(result)
+		fmt.Print(string(out))
+		return
+	}
+	if result.Healthy {
+		core.PrintSuccess(fmt.Sprintf("%s %s is healthy (status %d in %s)", result.Kind, result.Name, result.Status, result.Duration))
+		return
+	}
+	core.PrintError("Verify", fmt.Errorf("%s %s is unhealthy: %s", result.Kind, result.Name, result.Error))
+}
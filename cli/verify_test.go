@@ -0,0 +1,106 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyCmd(t *testing.T) {
+	cmd := VerifyCmd()
+
+	assert.Equal(t, "verify resource-type resource-name", cmd.Use)
+	assert.NotEmpty(t, cmd.Short)
+	assert.NotNil(t, cmd.ValidArgsFunction)
+	for _, name := range []string{"data", "path", "expect-status", "contains", "timeout"} {
+		assert.NotNil(t, cmd.Flags().Lookup(name), name)
+	}
+	assert.Equal(t, "2m0s", cmd.Flags().Lookup("timeout").DefValue)
+}
+
+func TestVerifyProbeFor(t *testing.T) {
+	tests := []struct {
+		kind         string
+		wantKind     string
+		resourceType string
+		path         string
+		contains     []string
+	}{
+		{"agent", "agent", "agents", "", nil},
+		{"ag", "agent", "agents", "", nil},
+		{"mcp", "function", "functions", "", nil},
+		{"functions", "function", "functions", "", nil},
+		{"sbx", "sandbox", "sandbox", "/process", []string{verifyMarker}},
+		{"Sandbox", "sandbox", "sandbox", "/process", []string{verifyMarker}},
+		{"job", "job", "jobs", "/executions", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.kind, func(t *testing.T) {
+			probe, err := verifyProbeFor(tt.kind)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantKind, probe.Kind)
+			assert.Equal(t, tt.resourceType, probe.ResourceType)
+			assert.Equal(t, tt.path, probe.Path)
+			assert.Equal(t, tt.contains, probe.Contains)
+			assert.NotEmpty(t, probe.Data)
+		})
+	}
+
+	_, err := verifyProbeFor("model")
+	assert.ErrorContains(t, err, "cannot verify a resource of kind \"model\"")
+}
+
+func TestCheckVerifyResponse(t *testing.T) {
+	tests := []struct {
+		name         string
+		status       int
+		body         string
+		expectStatus int
+		contains     []string
+		wantErr      string
+	}{
+		{name: "success", status: 200, body: "hi"},
+		{name: "redirect accepted", status: 302},
+		{name: "server error", status: 500, body: "boom", wantErr: "unexpected status 500: boom"},
+		{name: "exact status", status: 404, expectStatus: 404},
+		{name: "wrong status", status: 200, expectStatus: 201, wantErr: "expected status 201, got 200"},
+		{name: "contains", status: 200, body: `{"stdout":"blaxel-verify\n"}`, contains: []string{"blaxel-verify"}},
+		{name: "missing text", status: 200, body: "hello", contains: []string{"hello", "pong"}, wantErr: `response does not contain "pong": hello`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkVerifyResponse(tt.status, tt.body, tt.expectStatus, tt.contains)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCheckVerifyProcess(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		contains []string
+		wantErr  string
+	}{
+		{name: "success", body: `{"exitCode":0,"stdout":"blaxel-verify\n"}`, contains: []string{verifyMarker}},
+		{name: "failed command", body: `{"exitCode":127,"stdout":"","stderr":"echo: not found"}`, contains: []string{verifyMarker}, wantErr: "process exited with code 127: echo: not found"},
+		{name: "marker only in the command", body: `{"exitCode":0,"command":"echo blaxel-verify","stdout":""}`, contains: []string{verifyMarker}, wantErr: `process output does not contain "blaxel-verify": `},
+		{name: "not a process", body: `<html>`, wantErr: "unexpected process response: <html>"},
+		{name: "custom command", body: `{"exitCode":0,"stdout":"anything"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkVerifyProcess(tt.body, tt.contains)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,162 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	blaxel "github.com/blaxel-ai/sdk-go"
+	"github.com/blaxel-ai/toolkit/cli/core"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+func init() {
+	core.RegisterCommand("whoami", func() *cobra.Command {
+		return WhoamiCmd()
+	})
+}
+
+// whoamiInfo describes the workspace, environment and credentials the CLI uses
+type whoamiInfo struct {
+	Workspace      string `json:"workspace" yaml:"workspace"`
+	Environment    string `json:"environment" yaml:"environment"`
+	BaseURL        string `json:"baseUrl" yaml:"baseUrl"`
+	AuthMethod     string `json:"authMethod" yaml:"authMethod"`
+	AuthOrigin     string `json:"authOrigin,omitempty" yaml:"authOrigin,omitempty"`
+	User           string `json:"user,omitempty" yaml:"user,omitempty"`
+	TokenExpiresAt string `json:"tokenExpiresAt,omitempty" yaml:"tokenExpiresAt,omitempty"`
+	TokenExpired   bool   `json:"tokenExpired,omitempty" yaml:"tokenExpired,omitempty"`
+	Refreshable    bool   `json:"refreshable,omitempty" yaml:"refreshable,omitempty"`
+}
+
+// whoamiAuthMethod returns the credential kind used for workspace, named after
+// its key in ~/.blaxel/config.yaml. Stored credentials win over environment
+// variables, as in core.ResolveAuthSource.
+func whoamiAuthMethod(creds blaxel.Credentials) string {
+	switch {
+	case creds.APIKey != "":
+		return "apiKey"
+	case creds.AccessToken != "" || creds.RefreshToken != "":
+		return "access_token"
+	case creds.ClientCredentials != "":
+		return "client_credentials"
+	case os.Getenv("BL_API_KEY") != "":
+		return "apiKey"
+	case os.Getenv("BL_CLIENT_CREDENTIALS") != "":
+		return "client_credentials"
+	}
+	return "none"
+}
+
+// buildWhoamiInfo describes workspace authenticated with creds at time now
+func buildWhoamiInfo(workspace string, creds blaxel.Credentials, now time.Time) whoamiInfo {
+	info := whoamiInfo{
+		Workspace:   workspace,
+		Environment: string(blaxel.GetEnvironment()),
+		BaseURL:     blaxel.GetBaseURL(),
+		AuthMethod:  whoamiAuthMethod(creds),
+		AuthOrigin:  core.ResolveAuthSource(workspace).Origin,
+	}
+	if info.AuthMethod != "access_token" {
+		return info
+	}
+	info.User = core.TokenIdentity(creds.AccessToken)
+	info.Refreshable = creds.RefreshToken != ""
+	if expiresAt, ok := core.TokenExpiresAt(creds); ok {
+		info.TokenExpiresAt = expiresAt.UTC().Format(time.RFC3339)
+		info.TokenExpired = !expiresAt.After(now)
+	}
+	return info
+}
+
+func WhoamiCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "whoami",
+		Args:  cobra.NoArgs,
+		Short: "Show the current workspace, environment and credentials",
+		Long: `Show which workspace and environment the CLI talks to and how it is
+authenticated, without calling the API.
+
+The output includes:
+- The workspace used by commands (--workspace, BL_WORKSPACE or the current context)
+- The environment (prod or dev) and its API base URL
+- The authentication method: apiKey, access_token or client_credentials, and
+  whether it comes from ~/.blaxel/config.yaml or an environment variable
+- For access tokens from 'bl login', the user and when the token expires
+
+Run it before 'bl deploy' to check you are not about to deploy to the wrong
+workspace or environment. Use -o json or -o yaml in scripts.`,
+		Example: `  # Show the current identity
+  bl whoami
+
+  # Check another workspace
+  bl whoami --workspace staging
+
+  # Fail a script unless the CLI points to dev
+  test "$(bl whoami -o json | jq -r .environment)" = dev`,
+		Run: func(cmd *cobra.Command, args []string) {
+			workspace := core.GetWorkspace()
+			if workspace == "" {
+				err := fmt.Errorf("no workspace selected. Run 'bl login' to authenticate")
+				core.PrintError("Whoami", err)
+				core.ExitWithError(err)
+			}
+
+			// The environment depends on the workspace, which may come from --workspace
+			blaxel.InitializeEnvironment(workspace)
+			creds, err := blaxel.LoadCredentials(workspace)
+			if err != nil {
+				err = fmt.Errorf("failed to load credentials for workspace '%s': %w", workspace, err)
+				core.PrintError("Whoami", err)
+				core.ExitWithError(err)
+			}
+
+			printWhoami(buildWhoamiInfo(workspace, creds, time.Now()), time.Now())
+		},
+	}
+	return cmd
+}
+
+func printWhoami(info whoamiInfo, now time.Time) {
+	switch core.GetOutputFormat() {
+	case "json":
+		out, _ := json.MarshalIndent(info, "", "  ")
+		fmt.Println(string(out))
+		return
+	case "yaml":
+		out, _ := yaml.Marshal(info)
+		fmt.Print(string(out))
+		return
+	}
+
+	fmt.Printf("Workspace:    %s\n", info.Workspace)
+	fmt.Printf("Environment:  %s (%s)\n", info.Environment, info.BaseURL)
+	method := info.AuthMethod
+	if info.AuthOrigin != "" {
+		method = fmt.Sprintf("%s from %s", method, info.AuthOrigin)
+	}
+	fmt.Printf("Auth method:  %s\n", method)
+	if info.User != "" {
+		fmt.Printf("User:         %s\n", info.User)
+	}
+	if info.TokenExpiresAt != "" {
+		fmt.Printf("Token:        %s\n", describeTokenExpiry(info, now))
+	}
+}
+
+// describeTokenExpiry renders the token expiry relative to now
+func describeTokenExpiry(info whoamiInfo, now time.Time) string {
+	expiresAt, err := time.Parse(time.RFC3339, info.TokenExpiresAt)
+	if err != nil {
+		return info.TokenExpiresAt
+	}
+	if !info.TokenExpired {
+		return fmt.Sprintf("expires %s (in %s)", info.TokenExpiresAt, expiresAt.Sub(now).Round(time.Minute))
+	}
+	if info.Refreshable {
+		return fmt.Sprintf("expired %s, it will be refreshed on the next request", info.TokenExpiresAt)
+	}
+	return fmt.Sprintf("expired %s, run 'bl login %s'", info.TokenExpiresAt, info.Workspace)
+}
@@ -0,0 +1,56 @@
+package cli
+
+import (
+	"testing"
+	"time"
+
+	blaxel "github.com/blaxel-ai/sdk-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildWhoamiInfo(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("BL_API_KEY", "")
+	t.Setenv("BL_CLIENT_CREDENTIALS", "")
+	now := time.Unix(1_700_000_000, 0)
+
+	t.Run("api key", func(t *testing.T) {
+		info := buildWhoamiInfo("ws", blaxel.Credentials{APIKey: "key"}, now)
+		assert.Equal(t, "ws", info.Workspace)
+		assert.Equal(t, "apiKey", info.AuthMethod)
+		assert.Empty(t, info.TokenExpiresAt)
+		assert.NotEmpty(t, info.BaseURL)
+	})
+
+	t.Run("access token", func(t *testing.T) {
+		token := testJWTWithClaims(t, map[string]any{"email": "me@example.com", "exp": now.Add(-time.Hour).Unix()})
+		info := buildWhoamiInfo("ws", blaxel.Credentials{AccessToken: token, RefreshToken: "r"}, now)
+		assert.Equal(t, "access_token", info.AuthMethod)
+		assert.Equal(t, "me@example.com", info.User)
+		assert.Equal(t, now.Add(-time.Hour).UTC().Format(time.RFC3339), info.TokenExpiresAt)
+		assert.True(t, info.TokenExpired)
+		assert.True(t, info.Refreshable)
+		assert.Contains(t, describeTokenExpiry(info, now), "refreshed on the next request")
+	})
+
+	t.Run("client credentials from the environment", func(t *testing.T) {
+		t.Setenv("BL_CLIENT_CREDENTIALS", "id:secret")
+		info := buildWhoamiInfo("ws", blaxel.Credentials{}, now)
+		assert.Equal(t, "client_credentials", info.AuthMethod)
+		assert.Equal(t, "environment variable BL_CLIENT_CREDENTIALS", info.AuthOrigin)
+	})
+
+	t.Run("no credentials", func(t *testing.T) {
+		info := buildWhoamiInfo("ws", blaxel.Credentials{}, now)
+		assert.Equal(t, "none", info.AuthMethod)
+	})
+}
+
+func TestDescribeTokenExpiry(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	info := whoamiInfo{Workspace: "ws", TokenExpiresAt: now.Add(90 * time.Minute).UTC().Format(time.RFC3339)}
+	assert.Contains(t, describeTokenExpiry(info, now), "(in 1h30m0s)")
+
+	info.TokenExpired = true
+	assert.Contains(t, describeTokenExpiry(info, now), "run 'bl login ws'")
+}
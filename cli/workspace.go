@@ -2,12 +2,16 @@ package cli
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"os"
+	"time"
 
 	blaxel "github.com/blaxel-ai/sdk-go"
 	"github.com/blaxel-ai/sdk-go/option"
 	"github.com/blaxel-ai/toolkit/cli/core"
 	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
 )
 
 func init() {
@@ -16,6 +20,44 @@ func init() {
 	})
 }
 
+// workspaceInfo describes a locally configured workspace for structured output
+type workspaceInfo struct {
+	Name             string `json:"name" yaml:"name"`
+	Env              string `json:"env" yaml:"env"`
+	Current          bool   `json:"current" yaml:"current"`
+	CredentialsValid bool   `json:"credentialsValid" yaml:"credentialsValid"`
+}
+
+// workspaceInfos returns the workspaces of cfg, flagging currentWorkspace
+func workspaceInfos(cfg blaxel.Config, currentWorkspace string) []workspaceInfo {
+	infos := make([]workspaceInfo, 0, len(cfg.Workspaces))
+	for _, ws := range cfg.Workspaces {
+		env := ws.Env
+		if env == "" {
+			env = string(blaxel.EnvProduction)
+		}
+		infos = append(infos, workspaceInfo{
+			Name:             ws.Name,
+			Env:              env,
+			Current:          ws.Name == currentWorkspace,
+			CredentialsValid: ws.Credentials.IsValid(),
+		})
+	}
+	return infos
+}
+
+// printWorkspacesStructured prints data as JSON or YAML
+func printWorkspacesStructured(data interface{}, outputFormat string) {
+	switch outputFormat {
+	case "json":
+		out, _ := json.MarshalIndent(data, "", "  ")
+		fmt.Println(string(out))
+	case "yaml":
+		out, _ := yaml.Marshal(data)
+		fmt.Print(string(out))
+	}
+}
+
 func ListOrSetWorkspacesCmd() *cobra.Command {
 	var current bool
 
@@ -39,19 +81,33 @@ All commands like 'bl deploy', 'bl get', 'bl run' use the current workspace
 unless you override with the --workspace flag.
 
 To switch workspaces, provide the workspace name as an argument.
-To list all authenticated workspaces, run without arguments.`,
+To list all authenticated workspaces, run without arguments.
+
+With -o json or -o yaml, each workspace is printed with its environment
+(prod or dev), whether it is the current one and whether its stored
+credentials are valid. Scripts should rely on this instead of parsing
+the table.`,
 		Example: `  # List all authenticated workspaces
   bl workspaces
 
   # Switch to different workspace
   bl workspaces production
 
+  # Switch and show the environment (prod or dev) it points to
+  bl workspace switch staging
+
   # Use specific workspace for one command (doesn't switch current)
   bl get agents --workspace staging
 
   # Get only the current workspace name
   bl workspaces --current
 
+  # List workspaces as JSON (env, current flag, credential validity)
+  bl workspaces -o json
+
+  # Get the name of the current workspace with jq
+  bl workspaces -o json | jq -r '.[] | select(.current) | .name'
+
   # Common multi-workspace workflow
   bl workspaces dev        # Switch to dev
   bl deploy                # Deploy to dev
@@ -60,9 +116,23 @@ To list all authenticated workspaces, run without arguments.`,
 		Run: func(cmd *cobra.Command, args []string) {
 			ctx, _ := blaxel.CurrentContext()
 			currentWorkspace := ctx.Workspace
+			outputFormat := core.GetOutputFormat()
+			structured := outputFormat == "json" || outputFormat == "yaml"
 
 			// If --current flag is set, only print the current workspace name
 			if current {
+				if structured {
+					cfg, _ := blaxel.LoadConfig()
+					for _, info := range workspaceInfos(cfg, currentWorkspace) {
+						if info.Current {
+							printWorkspacesStructured(info, outputFormat)
+							return
+						}
+					}
+					err := fmt.Errorf("no current workspace")
+					core.PrintError("Workspace", err)
+					core.ExitWithError(err)
+				}
 				fmt.Println(currentWorkspace)
 				return
 			}
@@ -80,6 +150,10 @@ To list all authenticated workspaces, run without arguments.`,
 
 			// Otherwise, list all workspaces
 			cfg, _ := blaxel.LoadConfig()
+			if structured {
+				printWorkspacesStructured(workspaceInfos(cfg, currentWorkspace), outputFormat)
+				return
+			}
 			workspaces := make([]string, 0, len(cfg.Workspaces))
 			for _, ws := range cfg.Workspaces {
 				workspaces = append(workspaces, ws.Name)
@@ -100,10 +174,76 @@ To list all authenticated workspaces, run without arguments.`,
 	}
 
 	cmd.Flags().BoolVar(&current, "current", false, "Display only the current workspace name")
+	cmd.AddCommand(workspaceSwitchCmd())
 
 	return cmd
 }
 
+func workspaceSwitchCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:               "switch <workspace>",
+		Args:              cobra.ExactArgs(1),
+		Short:             "Switch the current workspace and show its environment",
+		ValidArgsFunction: GetWorkspaceValidArgsFunction(),
+		Long: `Switch the current workspace, then print the environment (prod or dev) and
+the API base URL it resolves to, so you know right away where the next
+commands go.
+
+The workspace must have been added with 'bl login'. A warning is printed
+when its credentials are missing or its access token has expired.
+
+With -o json or -o yaml, the workspace is described as in 'bl whoami'.`,
+		Example: `  # Switch to the staging workspace
+  bl workspace switch staging
+
+  # Switch and check the environment in a script
+  bl workspace switch staging -o json | jq -r .environment`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := switchWorkspace(args[0], time.Now()); err != nil {
+				core.PrintError("Workspace", err)
+				core.ExitWithError(err)
+			}
+		},
+	}
+}
+
+// switchWorkspace makes name the current workspace and prints the environment
+// it resolves to. The workspace must be in ~/.blaxel/config.yaml.
+func switchWorkspace(name string, now time.Time) error {
+	cfg, _ := blaxel.LoadConfig()
+	var creds *blaxel.Credentials
+	for i, ws := range cfg.Workspaces {
+		if ws.Name == name {
+			creds = &cfg.Workspaces[i].Credentials
+			break
+		}
+	}
+	if creds == nil {
+		return &core.ConfigError{Err: fmt.Errorf("workspace '%s' not found, run 'bl login %s' to add it", name, name)}
+	}
+	if err := blaxel.SetCurrentWorkspace(name); err != nil {
+		return fmt.Errorf("failed to set workspace: %w", err)
+	}
+
+	blaxel.InitializeEnvironment(name)
+	info := buildWhoamiInfo(name, *creds, now)
+	switch outputFormat := core.GetOutputFormat(); outputFormat {
+	case "json", "yaml":
+		printWorkspacesStructured(info, outputFormat)
+	default:
+		fmt.Printf("Current workspace set to %s.\n", name)
+		fmt.Printf("Environment:  %s (%s)\n", info.Environment, info.BaseURL)
+	}
+
+	switch {
+	case !creds.IsValid() && os.Getenv("BL_API_KEY") == "" && os.Getenv("BL_CLIENT_CREDENTIALS") == "":
+		core.PrintWarning(fmt.Sprintf("No credentials for workspace '%s', run 'bl login %s'", name, name))
+	case info.TokenExpired:
+		core.PrintWarning(fmt.Sprintf("Access token %s", describeTokenExpiry(info, now)))
+	}
+	return nil
+}
+
 func CheckWorkspaceAccess(workspaceName string, credentials blaxel.Credentials) (blaxel.Workspace, error) {
 	// Build client options based on credentials
 	opts := []option.RequestOption{
@@ -122,6 +262,7 @@ func CheckWorkspaceAccess(workspaceName string, credentials blaxel.Credentials)
 		opts = append(opts, option.WithClientCredentials(credentials.ClientCredentials))
 	}
 
+	opts = append(opts, core.RequestLogOptions()...)
 	c := blaxel.NewClient(opts...)
 	workspace, err := c.Workspaces.Get(context.Background(), workspaceName, blaxel.WorkspaceGetParams{})
 	if err != nil {
@@ -1,9 +1,14 @@
 package cli
 
 import (
+	"encoding/json"
 	"testing"
+	"time"
 
+	blaxel "github.com/blaxel-ai/sdk-go"
+	"github.com/blaxel-ai/toolkit/cli/core"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestListOrSetWorkspacesCmd(t *testing.T) {
@@ -90,3 +95,52 @@ func TestTokenCmdArguments(t *testing.T) {
 	// Token cmd takes optional workspace argument
 	assert.NotNil(t, cmd.Args)
 }
+
+func TestWorkspaceInfos(t *testing.T) {
+	cfg := blaxel.Config{
+		Workspaces: []blaxel.WorkspaceConfig{
+			{Name: "prod-ws", Credentials: blaxel.Credentials{APIKey: "key"}},
+			{Name: "dev-ws", Env: "dev"},
+		},
+	}
+
+	infos := workspaceInfos(cfg, "dev-ws")
+	assert.Equal(t, []workspaceInfo{
+		{Name: "prod-ws", Env: "prod", Current: false, CredentialsValid: true},
+		{Name: "dev-ws", Env: "dev", Current: true, CredentialsValid: false},
+	}, infos)
+
+	data, err := json.Marshal(infos[1])
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"name":"dev-ws","env":"dev","current":true,"credentialsValid":false}`, string(data))
+}
+
+func TestSwitchWorkspace(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("BL_ENV", "")
+	t.Setenv("BL_API_URL", "")
+	t.Setenv("BL_API_KEY", "")
+	t.Setenv("BL_CLIENT_CREDENTIALS", "")
+	require.NoError(t, blaxel.WriteConfig(blaxel.Config{
+		Context: blaxel.ContextConfig{Workspace: "prod-ws"},
+		Workspaces: []blaxel.WorkspaceConfig{
+			{Name: "prod-ws", Credentials: blaxel.Credentials{APIKey: "key"}},
+			{Name: "dev-ws", Env: "dev"},
+		},
+	}))
+	t.Cleanup(func() { blaxel.InitializeEnvironment("") })
+
+	err := switchWorkspace("missing", time.Now())
+	var configErr *core.ConfigError
+	assert.ErrorAs(t, err, &configErr)
+	ctx, _ := blaxel.CurrentContext()
+	assert.Equal(t, "prod-ws", ctx.Workspace, "unknown workspaces are not switched to")
+
+	stderr := captureStderr(t, func() {
+		require.NoError(t, switchWorkspace("dev-ws", time.Now()))
+	})
+	ctx, _ = blaxel.CurrentContext()
+	assert.Equal(t, "dev-ws", ctx.Workspace)
+	assert.Equal(t, blaxel.EnvDevelopment, blaxel.GetEnvironment())
+	assert.Contains(t, stderr, "No credentials for workspace 'dev-ws'")
+}
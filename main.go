@@ -1,7 +1,6 @@
 package main
 
 import (
-	"fmt"
 	"os"
 	"time"
 
@@ -38,7 +37,7 @@ func main() {
 
 	err := cli.Execute(version, commit, date)
 	if err != nil {
-		fmt.Fprintln(os.Stderr, "Error", err)
+		core.PrintCommandError(err)
 		core.ExitWithError(err)
 	}
 }
@@ -1,6 +1,9 @@
 package integration
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
@@ -9,6 +12,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/blaxel-ai/toolkit/cli/core"
 	"github.com/joho/godotenv"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -22,7 +26,7 @@ const (
 	CleanupTimeout       = 60 * time.Second
 	AgentTestTimeout     = 60 * time.Second
 	PreCleanupDelay      = 15 * time.Second
-	PostDeleteDelay      = 15 * time.Second
+	PostDeleteTimeout    = 2 * time.Minute
 	DeploymentSettleTime = 10 * time.Second
 
 	// Polling configuration
@@ -389,9 +393,22 @@ func performLogin(t *testing.T, env *RealCLITestEnvironment) {
 // checkWorkspaces verifies that the workspace is accessible
 func checkWorkspaces(t *testing.T, env *RealCLITestEnvironment) {
 	t.Logf("🔍 Checking workspaces...")
-	workspaceResult := env.ExecuteCLI("workspace")
+	workspaceResult := env.ExecuteCLI("workspace", "-o", "json")
 	AssertCLISuccess(t, workspaceResult)
-	assert.Contains(t, workspaceResult.Stdout, env.Workspace, "Workspace should be listed")
+
+	var workspaces []struct {
+		Name             string `json:"name"`
+		CredentialsValid bool   `json:"credentialsValid"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(workspaceResult.Stdout), &workspaces), "Workspace output should be valid JSON")
+	found := false
+	for _, ws := range workspaces {
+		if ws.Name == env.Workspace {
+			found = true
+			assert.True(t, ws.CredentialsValid, "Workspace credentials should be valid")
+		}
+	}
+	assert.True(t, found, "Workspace should be listed")
 }
 
 // runParallelDeployments executes all deployment workflows in parallel and returns the total number of successful operations
@@ -632,13 +649,9 @@ func deployManifests(t *testing.T, env *RealCLITestEnvironment, proj ManifestCon
 
 	// Delete existing manifests first
 	t.Logf("🗑️ Deleting existing manifests recursively...")
-	deleteResult := env.ExecuteCLI("delete", "-R", "-f", manifestsDir)
+	deleteResult := env.ExecuteCLI("delete", "-R", "-f", manifestsDir, "--wait", "--timeout", PostDeleteTimeout.String())
 	logCommandResult(t, "Delete "+proj.Name, deleteResult)
 
-	// Wait before applying
-	t.Logf("⏰ Waiting %v after deletion before applying manifests...", PostDeleteDelay)
-	time.Sleep(PostDeleteDelay)
-
 	// Apply manifests
 	t.Logf("📄 Applying manifests recursively...")
 	applyResult := env.ExecuteCLI("apply", "-R", "-f", manifestsDir, "-e", envFile)
@@ -1078,121 +1091,71 @@ func (env *RealCLITestEnvironment) ExecuteCLIInDirectory(dir string, args ...str
 	return result
 }
 
-// WaitForDeployment waits for a deployment to complete by watching the status
+// WaitForDeployment waits for a deployment to complete by polling the status
 func (env *RealCLITestEnvironment) WaitForDeployment(t *testing.T, projectType, projectName string, maxWaitTime time.Duration) error {
-	var watchCmd []string
+	var getCmd []string
 
 	switch projectType {
 	case "mcp":
-		watchCmd = []string{"get", "functions", projectName, "--watch"}
+		getCmd = []string{"get", "functions", projectName}
 	case "agent":
-		watchCmd = []string{"get", "agents", projectName, "--watch"}
+		getCmd = []string{"get", "agents", projectName}
 	case "job":
-		watchCmd = []string{"get", "job", projectName, "--watch"}
+		getCmd = []string{"get", "job", projectName}
 	default:
 		return fmt.Errorf("unknown project type: %s", projectType)
 	}
 
-	t.Logf("👀 Checking deployment status for %s (%s) using: %v", projectName, projectType, watchCmd[:len(watchCmd)-1])
-
-	// First try a simple get command to check if the resource exists
-	getCmd := watchCmd[:len(watchCmd)-1] // Remove --watch flag
-	result := env.ExecuteCLIWithTimeout(30*time.Second, getCmd...)
-
-	if result.ExitCode == 0 {
-		t.Logf("📋 Initial status check output:\n%s", result.Stdout)
-
-		// Check if output indicates successful deployment
-		output := strings.ToLower(result.Stdout)
-		if strings.Contains(output, "deployed") ||
-			strings.Contains(output, "ready") ||
-			strings.Contains(output, "running") ||
-			strings.Contains(output, "active") {
-			t.Logf("✅ %s is already deployed successfully", projectName)
-			return nil
-		}
-
-		if strings.Contains(output, "failed") ||
-			strings.Contains(output, "error") {
-			t.Logf("❌ %s deployment failed based on initial check", projectName)
-			return fmt.Errorf("deployment failed: %s", result.Stdout)
-		}
-
-		t.Logf("⏳ %s deployment status is pending, starting watch...", projectName)
-	} else {
-		t.Logf("⚠️ Initial status check failed (exit code %d), proceeding to watch: %s", result.ExitCode, result.Stderr)
-	}
-
-	// Poll periodically instead of using a single long-running watch command
-	t.Logf("👁️ Starting periodic deployment check for %s (polling every 20s)", projectName)
-	t.Logf("⏲️ Waiting for deployment completion (timeout: %v)...", maxWaitTime)
+	t.Logf("👀 Checking deployment status for %s (%s) using: %v", projectName, projectType, getCmd)
+	t.Logf("⏲️ Waiting for deployment completion (polling every %v, timeout: %v)...", DeploymentPollInterval, maxWaitTime)
 
 	startTime := time.Now()
-	ticker := time.NewTicker(20 * time.Second)
-	defer ticker.Stop()
-
-	// Check immediately first
-	checkCmd := getCmd // Remove --watch flag for polling
-	result = env.ExecuteCLIWithTimeout(30*time.Second, checkCmd...)
-	t.Logf("🔍 [0s] Initial deployment status check for %s: exit_code=%d", projectName, result.ExitCode)
-	if result.ExitCode == 0 {
-		t.Logf("📋 [0s] Status output:\n%s", strings.TrimSpace(result.Stdout))
-
-		output := strings.ToLower(result.Stdout)
-		if strings.Contains(output, "deployed") ||
-			strings.Contains(output, "ready") ||
-			strings.Contains(output, "running") ||
-			strings.Contains(output, "active") {
-			t.Logf("✅ %s deployment completed successfully", projectName)
-			return nil
-		}
-
-		if strings.Contains(output, "failed") ||
-			strings.Contains(output, "error") {
-			t.Logf("❌ %s deployment failed", projectName)
-			return fmt.Errorf("deployment failed: %s", result.Stdout)
-		}
-	} else {
-		t.Logf("⚠️ [0s] Status check failed: %s", result.Stderr)
-	}
-
-	// Poll every 20 seconds
-	for {
-		select {
-		case <-ticker.C:
-			elapsed := time.Since(startTime)
-			result = env.ExecuteCLIWithTimeout(30*time.Second, checkCmd...)
-			t.Logf("🔍 [%v] Deployment status check for %s: exit_code=%d", elapsed.Round(time.Second), projectName, result.ExitCode)
-
-			if result.ExitCode == 0 {
-				t.Logf("📋 [%v] Status output:\n%s", elapsed.Round(time.Second), strings.TrimSpace(result.Stdout))
-
-				output := strings.ToLower(result.Stdout)
-				if strings.Contains(output, "deployed") ||
-					strings.Contains(output, "ready") ||
-					strings.Contains(output, "running") ||
-					strings.Contains(output, "active") {
-					t.Logf("✅ %s deployment completed successfully after %v", projectName, elapsed.Round(time.Second))
-					return nil
-				}
-
-				if strings.Contains(output, "failed") ||
-					strings.Contains(output, "error") {
-					t.Logf("❌ %s deployment failed after %v", projectName, elapsed.Round(time.Second))
-					return fmt.Errorf("deployment failed: %s", result.Stdout)
-				}
-
-				t.Logf("⏳ [%v] %s deployment still in progress...", elapsed.Round(time.Second), projectName)
-			} else {
-				t.Logf("⚠️ [%v] Status check failed for %s: %s", elapsed.Round(time.Second), projectName, result.Stderr)
+	lastOutput := ""
+	status, err := core.WaitForStatus(context.Background(), projectType, projectName, core.WaitOptions{
+		Interval: DeploymentPollInterval,
+		Timeout:  maxWaitTime,
+		Done:     []string{"DEPLOYED"},
+		Failed:   []string{"FAILED"},
+		Fetch: func(ctx context.Context) (string, error) {
+			elapsed := time.Since(startTime).Round(time.Second)
+			result := env.ExecuteCLIWithTimeout(30*time.Second, getCmd...)
+			t.Logf("🔍 [%v] Deployment status check for %s: exit_code=%d", elapsed, projectName, result.ExitCode)
+			if result.ExitCode != 0 {
+				t.Logf("⚠️ [%v] Status check failed for %s: %s", elapsed, projectName, result.Stderr)
+				return "", fmt.Errorf("status check failed: %s", result.Stderr)
 			}
+			lastOutput = result.Stdout
+			t.Logf("📋 [%v] Status output:\n%s", elapsed, strings.TrimSpace(result.Stdout))
+			return deploymentStatusFromOutput(result.Stdout), nil
+		},
+	})
+	elapsed := time.Since(startTime).Round(time.Second)
+	switch {
+	case errors.Is(err, core.ErrWaitTimeout):
+		t.Logf("⏰ Deployment watch timeout for %s after %v", projectName, elapsed)
+		return fmt.Errorf("deployment timeout after %v", maxWaitTime)
+	case err != nil:
+		t.Logf("❌ %s deployment failed after %v", projectName, elapsed)
+		return fmt.Errorf("deployment failed: %s", lastOutput)
+	}
+	t.Logf("✅ %s deployment completed with status %s after %v", projectName, status, elapsed)
+	return nil
+}
 
-		case <-time.After(maxWaitTime):
-			elapsed := time.Since(startTime)
-			t.Logf("⏰ Deployment watch timeout for %s after %v", projectName, elapsed.Round(time.Second))
-			return fmt.Errorf("deployment timeout after %v", maxWaitTime)
-		}
+// deploymentStatusFromOutput maps the output of bl get to the statuses used by WaitForDeployment
+func deploymentStatusFromOutput(output string) string {
+	output = strings.ToLower(output)
+	switch {
+	case strings.Contains(output, "deployed"),
+		strings.Contains(output, "ready"),
+		strings.Contains(output, "running"),
+		strings.Contains(output, "active"):
+		return "DEPLOYED"
+	case strings.Contains(output, "failed"),
+		strings.Contains(output, "error"):
+		return "FAILED"
 	}
+	return "PENDING"
 }
 
 // executeCommandWithTimeout executes a command with a timeout